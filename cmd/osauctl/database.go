@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/internal/app"
+	"github.com/BeRebornBng/OsauAmsApi/internal/seed"
+	"github.com/BeRebornBng/OsauAmsApi/migrations"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
+)
+
+func migrate(ctx context.Context, ctl *ctl, args []string) error {
+	applied, err := postgres.Migrate(ctx, ctl.db, migrations.FS)
+	for _, migration := range applied {
+		fmt.Println("applied", migration.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(applied) == 0 {
+		fmt.Println("the database is up to date")
+	}
+	return nil
+}
+
+// seedDictionaries adds the dictionary entries missing by name, running it twice changes nothing
+func seedDictionaries(ctx context.Context, ctl *ctl, args []string) error {
+	added, err := seed.Dictionaries(ctx, ctl.repos)
+	for _, entry := range added {
+		fmt.Println(entry)
+	}
+	return err
+}
+
+// demo fills the database with the demo dataset of a seed
+func demo(ctx context.Context, ctl *ctl, args []string) error {
+	set := flag.NewFlagSet("demo", flag.ExitOnError)
+	var opts seed.Options
+	start := set.String("start", "2024-09-02", "first day of the semester, YYYY-MM-DD")
+	set.Int64Var(&opts.Seed, "seed", 1, "seed of the random generator")
+	set.IntVar(&opts.Faculties, "faculties", 0, "number of faculties, at most 3")
+	set.IntVar(&opts.GroupsPerProfile, "groups", 0, "groups per profile")
+	set.IntVar(&opts.StudentsPerGroup, "students", 0, "students per group")
+	set.IntVar(&opts.TeachersPerDepartament, "teachers", 0, "teachers per departament")
+	set.IntVar(&opts.Weeks, "weeks", 0, "weeks of attendance")
+	set.StringVar(&opts.Password, "password", "", "password of every demo account")
+	if err := parse(set, args); err != nil {
+		return err
+	}
+
+	var err error
+	if opts.Start, err = time.Parse(time.DateOnly, *start); err != nil {
+		return fmt.Errorf("invalid -start: %w", err)
+	}
+
+	result, err := seed.Generate(ctx, ctl.repos, app.NewHasher(ctl.cfg), opts)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("faculties %d, departaments %d, groups %d, teachers %d, students %d, lessons %d, attendance marks %d\n",
+		result.Faculties, result.Departaments, result.Groups, result.Teachers, result.Students, result.Schedules, result.Attendance)
+	fmt.Printf("sign in as %s with the password %s\n", result.Admin, result.Password)
+	return nil
+}
@@ -0,0 +1,136 @@
+// Command osauctl bootstraps and maintains an Osau AMS installation: it creates admins,
+// resets passwords, lists users, revokes sessions, applies migrations, seeds dictionaries and
+// generates demo data
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/BeRebornBng/OsauAmsApi/internal/app"
+	"github.com/BeRebornBng/OsauAmsApi/internal/config"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/auth"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/mailer"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const usage = `Usage: osauctl [-config dir] <command> [flags]
+
+Commands:
+  create-admin     -username name [-password pass]   create a user with the admin role
+  reset-password   -username name [-password pass]   set a new password and sign the user out
+  list-users       -role role                         list the users of a role (Админ, Преподаватель, Староста, Студент)
+  revoke-sessions  -username name | -all              sign users out everywhere
+  migrate                                             apply the embedded migrations
+  seed                                                add the missing education levels, education types and discipline types
+  demo             [-seed n] [-start date] [...]      generate the demo dataset of a seed, see osauctl demo -h
+
+The password is read from stdin when -password is omitted.
+`
+
+// minPasswordLength is the shortest password osauctl sets
+const minPasswordLength = 8
+
+type command func(ctx context.Context, ctl *ctl, args []string) error
+
+var commands = map[string]command{
+	"create-admin":    createAdmin,
+	"reset-password":  resetPassword,
+	"list-users":      listUsers,
+	"revoke-sessions": revokeSessions,
+	"migrate":         migrate,
+	"seed":            seedDictionaries,
+	"demo":            demo,
+}
+
+// ctl holds the connections the commands share
+type ctl struct {
+	cfg      *config.Config
+	db       *pgxpool.Pool
+	repos    *repository.Repositories
+	services *service.Services
+}
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	configPath := flag.String("config", "configs", "directory of config.*")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	run, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", flag.Arg(0))
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := execute(*configPath, run, flag.Args()[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "osauctl:", err)
+		os.Exit(1)
+	}
+}
+
+func execute(configPath string, run command, args []string) error {
+	cfg, err := config.Init(configPath)
+	if err != nil {
+		return err
+	}
+
+	db, err := postgres.New(cfg.Postgres.Url)
+	if err != nil {
+		return fmt.Errorf("unable to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	repos := repository.NewRepositories(db)
+	services := service.NewServices(service.Support{
+		Repos:          repos,
+		Hasher:         app.NewHasher(cfg),
+		TokenManager:   auth.NewManager(cfg.Jwt.SecretKey),
+		Mailer:         mailer.NewLogSender(slog.Default()),
+		AccessTokenTTL: cfg.Jwt.AccessTokenTTL,
+		ResetTokenTTL:  cfg.Jwt.ResetTokenTTL,
+	})
+
+	return run(context.Background(), &ctl{cfg: cfg, db: db, repos: repos, services: services}, args)
+}
+
+// readPassword returns the flag value or the first line of stdin
+func readPassword(password string) (string, error) {
+	if password == "" {
+		fmt.Fprint(os.Stderr, "password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("failed to read the password: %w", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+	if len(password) < minPasswordLength {
+		return "", fmt.Errorf("the password must be at least %d characters long", minPasswordLength)
+	}
+	return password, nil
+}
+
+func parse(set *flag.FlagSet, args []string, required ...string) error {
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+	for _, name := range required {
+		if set.Lookup(name).Value.String() == "" {
+			return errors.New("-" + name + " is required")
+		}
+	}
+	return nil
+}
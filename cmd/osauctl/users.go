@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/jackc/pgx/v5"
+)
+
+const adminRole = "Админ"
+
+func createAdmin(ctx context.Context, ctl *ctl, args []string) error {
+	set := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	username := set.String("username", "", "username of the admin")
+	password := set.String("password", "", "password of the admin")
+	if err := parse(set, args, "username"); err != nil {
+		return err
+	}
+
+	secret, err := readPassword(*password)
+	if err != nil {
+		return err
+	}
+
+	err = ctl.services.UserService.Create(ctx, domain.User{Username: *username, Password: secret, Role: adminRole})
+	if errors.Is(err, service.ErrUserNameExists) {
+		return fmt.Errorf("user %q already exists, use reset-password to change the password", *username)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("admin %q created\n", *username)
+	return nil
+}
+
+func resetPassword(ctx context.Context, ctl *ctl, args []string) error {
+	set := flag.NewFlagSet("reset-password", flag.ContinueOnError)
+	username := set.String("username", "", "username of the user")
+	password := set.String("password", "", "new password")
+	if err := parse(set, args, "username"); err != nil {
+		return err
+	}
+
+	secret, err := readPassword(*password)
+	if err != nil {
+		return err
+	}
+
+	err = ctl.services.UserService.ResetPassword(ctx, *username, secret)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("user %q not found", *username)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("password of %q reset, the user is signed out\n", *username)
+	return nil
+}
+
+func listUsers(ctx context.Context, ctl *ctl, args []string) error {
+	set := flag.NewFlagSet("list-users", flag.ContinueOnError)
+	role := set.String("role", "", "role of the users")
+	if err := parse(set, args, "role"); err != nil {
+		return err
+	}
+
+	users, err := ctl.services.UserService.GetAllByRole(ctx, *role)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USER ID\tUSERNAME\tROLE\tNAME")
+	for _, user := range users {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", user.User.UserID, user.User.Username, user.User.Role, fullName(user.UserSub))
+	}
+	return w.Flush()
+}
+
+func fullName(sub domain.UserSub) string {
+	switch {
+	case sub.StudentFullName != nil:
+		return sub.StudentFullName.LastName + " " + sub.StudentFullName.FirstName + " " + sub.StudentFullName.MiddleName
+	case sub.TeacherFullName != nil:
+		return sub.TeacherFullName.LastName + " " + sub.TeacherFullName.FirstName + " " + sub.TeacherFullName.MiddleName
+	default:
+		return ""
+	}
+}
+
+func revokeSessions(ctx context.Context, ctl *ctl, args []string) error {
+	set := flag.NewFlagSet("revoke-sessions", flag.ContinueOnError)
+	username := set.String("username", "", "username of the user")
+	all := set.Bool("all", false, "sign out every user")
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+	if (*username == "") == !*all {
+		return errors.New("either -username or -all is required")
+	}
+
+	var users []domain.UserInfo
+	if *all {
+		var err error
+		if users, err = ctl.services.UserService.GetAll(ctx); err != nil {
+			return err
+		}
+	} else {
+		user, err := ctl.services.UserService.GetByName(ctx, *username)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("user %q not found", *username)
+		}
+		if err != nil {
+			return err
+		}
+		users = append(users, user)
+	}
+
+	for _, user := range users {
+		if err := ctl.services.UserService.RevokeSessions(ctx, user.User.UserID); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("sessions of %d users revoked\n", len(users))
+	return nil
+}
@@ -579,49 +579,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admins/universities": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Get a list of all universities",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Universities"
-                ],
-                "summary": "Get all universities",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.University"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    }
-                }
-            },
+        "/admins/calendar": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update an existing university",
+                "description": "Update an existing period of the academic calendar",
                 "consumes": [
                     "application/json"
                 ],
@@ -629,17 +594,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Universities"
+                    "Calendar"
                 ],
-                "summary": "Update a university",
+                "summary": "Update a period of the academic calendar",
                 "parameters": [
                     {
-                        "description": "University info",
-                        "name": "university",
+                        "description": "Calendar period info",
+                        "name": "period",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PutUniversityRequest"
+                            "$ref": "#/definitions/handler.PutCalendarPeriodRequest"
                         }
                     }
                 ],
@@ -670,7 +635,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new university",
+                "description": "Add a semester, a holiday, an exam session or a practice period to the calendar of a university",
                 "consumes": [
                     "application/json"
                 ],
@@ -678,17 +643,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Universities"
+                    "Calendar"
                 ],
-                "summary": "Create a university",
+                "summary": "Create a period of the academic calendar",
                 "parameters": [
                     {
-                        "description": "University info",
-                        "name": "university",
+                        "description": "Calendar period info",
+                        "name": "period",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateUniversityRequest"
+                            "$ref": "#/definitions/handler.CalendarPeriodRequest"
                         }
                     }
                 ],
@@ -696,7 +661,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/domain.CalendarPeriod"
                         }
                     },
                     "400": {
@@ -712,40 +677,52 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/admins/calendar/university/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Partially update an existing university",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the periods of the academic calendar that intersect the range, the whole calendar without a range",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Universities"
+                    "Calendar"
                 ],
-                "summary": "Partially update a university",
+                "summary": "Get the academic calendar of a university",
                 "parameters": [
                     {
-                        "description": "University info",
-                        "name": "university",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.PatchUniversityRequest"
-                        }
+                        "type": "integer",
+                        "description": "University ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range (2006-01-02)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range (2006-01-02)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.CalendarPeriod"
+                            }
                         }
                     },
                     "400": {
@@ -763,29 +740,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/admins/universities/name/{name}": {
+        "/admins/calendar/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get a university by its name",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get a period of the academic calendar by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Universities"
+                    "Calendar"
                 ],
-                "summary": "Get a university by name",
+                "summary": "Get a period of the academic calendar",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "University Name",
-                        "name": "name",
+                        "type": "integer",
+                        "description": "Calendar period ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -794,7 +768,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.University"
+                            "$ref": "#/definitions/domain.CalendarPeriod"
                         }
                     },
                     "400": {
@@ -803,6 +777,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -810,30 +790,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admins/universities/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get a university by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delete a period of the academic calendar by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Universities"
+                    "Calendar"
                 ],
-                "summary": "Get a university by ID",
+                "summary": "Delete a period of the academic calendar",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "University ID",
+                        "description": "Calendar period ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -843,7 +818,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.University"
+                            "$ref": "#/definitions/handler.SuccessResponse"
                         }
                     },
                     "400": {
@@ -859,38 +834,79 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admins/classrooms/free": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete an existing university",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the classrooms not used by any actual schedule in the slot, filtered by capacity and features",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Universities"
+                    "Classrooms"
                 ],
-                "summary": "Delete a university",
+                "summary": "Search free classrooms",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "University ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Semester",
+                        "name": "semester",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Week type",
+                        "name": "week_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Day of the week",
+                        "name": "day_of_week",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start time (15:04)",
+                        "name": "start_time",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum capacity",
+                        "name": "min_capacity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group that must fit into the classroom",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Required features separated by commas",
+                        "name": "features",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Classroom"
+                            }
                         }
                     },
                     "400": {
@@ -908,39 +924,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/attendances": {
-            "get": {
-                "description": "Get a list of all attendances",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Attendance"
-                ],
-                "summary": "Get all attendances",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Attendance"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    }
-                }
-            },
+        "/admins/curriculum": {
             "put": {
-                "description": "Update multiple existing attendances",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update a line of the curriculum of a profile",
                 "consumes": [
                     "application/json"
                 ],
@@ -948,17 +939,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Attendance"
+                    "Curriculum"
                 ],
-                "summary": "Update multiple attendances",
+                "summary": "Update a curriculum item",
                 "parameters": [
                     {
-                        "description": "Attendances info",
-                        "name": "attendance",
+                        "description": "Curriculum item info",
+                        "name": "item",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PutAttendancesRequest"
+                            "$ref": "#/definitions/handler.PutCurriculumItemRequest"
                         }
                     }
                 ],
@@ -984,7 +975,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new attendance",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Plan the hours of a discipline type of a discipline for a profile in a semester",
                 "consumes": [
                     "application/json"
                 ],
@@ -992,17 +988,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Attendance"
+                    "Curriculum"
                 ],
-                "summary": "Create an attendance",
+                "summary": "Create a curriculum item",
                 "parameters": [
                     {
-                        "description": "Attendance info",
-                        "name": "attendance",
+                        "description": "Curriculum item info",
+                        "name": "item",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateAttendanceRequest"
+                            "$ref": "#/definitions/handler.CurriculumItemRequest"
                         }
                     }
                 ],
@@ -1010,7 +1006,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/domain.CurriculumItem"
                         }
                     },
                     "400": {
@@ -1026,35 +1022,40 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Partially update an existing attendance",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/admins/curriculum/profile/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get the study plan of a profile for all semesters",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Attendance"
+                    "Curriculum"
                 ],
-                "summary": "Partially update an attendance",
+                "summary": "Get the curriculum of a profile",
                 "parameters": [
                     {
-                        "description": "Attendance info",
-                        "name": "attendance",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.PatchAttendanceRequest"
-                        }
+                        "type": "integer",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.CurriculumItemInfo"
+                            }
                         }
                     },
                     "400": {
@@ -1072,24 +1073,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/attendances/student/{student_id}": {
+        "/admins/curriculum/report/group/{group_id}/semester/{semester}": {
             "get": {
-                "description": "Get attendances by student ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Compare the curriculum hours of a group in a semester with the hours of the actual timetable on the teaching days and the hours of the lessons with a marked attendance",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Attendance"
+                    "Curriculum"
                 ],
-                "summary": "Get attendances by student ID",
+                "summary": "Get the planned, scheduled and held hours of a group",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Student ID",
-                        "name": "student_id",
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester",
+                        "name": "semester",
                         "in": "path",
                         "required": true
                     }
@@ -1098,10 +1108,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Attendance"
-                            }
+                            "$ref": "#/definitions/domain.CurriculumReport"
                         }
                     },
                     "400": {
@@ -1119,23 +1126,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/attendances/{id}": {
+        "/admins/curriculum/{id}": {
             "get": {
-                "description": "Get an attendance by its ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get a line of the curriculum by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Attendance"
+                    "Curriculum"
                 ],
-                "summary": "Get an attendance by ID",
+                "summary": "Get a curriculum item",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Attendance ID",
+                        "description": "Curriculum item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1145,7 +1154,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Attendance"
+                            "$ref": "#/definitions/domain.CurriculumItemInfo"
                         }
                     },
                     "400": {
@@ -1154,6 +1163,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1163,21 +1178,23 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete an existing attendance",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Delete a line of the curriculum by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Attendance"
+                    "Curriculum"
                 ],
-                "summary": "Delete an attendance",
+                "summary": "Delete a curriculum item",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Attendance ID",
+                        "description": "Curriculum item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1205,35 +1222,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/signin": {
-            "post": {
-                "description": "Sign in a user",
-                "consumes": [
-                    "application/json"
+        "/admins/gradebook/student/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get the marks, the control points and the final results of a student per discipline",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Gradebook"
                 ],
-                "summary": "Sign in a user",
+                "summary": "Get the gradebook of a student",
                 "parameters": [
                     {
-                        "description": "User sign-in info",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.SignInUserRequest"
-                        }
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester, all semesters by default",
+                        "name": "semester",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.Tokens"
+                            "$ref": "#/definitions/domain.Gradebook"
                         }
                     },
                     "400": {
@@ -1242,12 +1265,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1257,27 +1274,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/classrooms": {
+        "/admins/gradebook/student/{id}/admission/discipline/{discipline_id}/semester/{semester}": {
             "get": {
-                "description": "Get a list of all classrooms",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Check the admission of a student to the credit or the exam of a discipline by the attendance and the current marks",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Classrooms"
+                    "Gradebook"
+                ],
+                "summary": "Check the admission of a student",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Discipline ID",
+                        "name": "discipline_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester",
+                        "name": "semester",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all classrooms",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Classroom"
-                            }
+                            "$ref": "#/definitions/domain.Admission"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -1287,9 +1332,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing classroom",
+            }
+        },
+        "/admins/groups/promotions": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Move all students of every source group to its target group from the given date in one transaction, missing target groups are created with the profile of the source group",
                 "consumes": [
                     "application/json"
                 ],
@@ -1297,25 +1349,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Classrooms"
+                    "Groups"
                 ],
-                "summary": "Update a classroom",
+                "summary": "Promote groups",
                 "parameters": [
                     {
-                        "description": "Classroom info",
-                        "name": "classroom",
+                        "description": "Promotion info",
+                        "name": "promotion",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PutClassroomRequest"
+                            "$ref": "#/definitions/handler.PromoteGroupsRequest"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only preview the changes",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/domain.PromotionResult"
                         }
                     },
                     "400": {
@@ -1324,6 +1382,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1331,9 +1395,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new classroom",
+            }
+        },
+        "/admins/lesson_slots": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update a lesson of the bell schedule, the start time of the schedules in the slot follows it",
                 "consumes": [
                     "application/json"
                 ],
@@ -1341,23 +1412,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Classrooms"
+                    "LessonSlots"
                 ],
-                "summary": "Create a classroom",
+                "summary": "Update a lesson slot",
                 "parameters": [
                     {
-                        "description": "Classroom info",
-                        "name": "classroom",
+                        "description": "Lesson slot info",
+                        "name": "slot",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateClassroomRequest"
+                            "$ref": "#/definitions/handler.PutLessonSlotRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/handler.SuccessResponse"
                         }
@@ -1376,8 +1447,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
-                "description": "Partially update an existing classroom",
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add a numbered lesson to the bell schedule of a university",
                 "consumes": [
                     "application/json"
                 ],
@@ -1385,25 +1461,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Classrooms"
+                    "LessonSlots"
                 ],
-                "summary": "Partially update a classroom",
+                "summary": "Create a lesson slot",
                 "parameters": [
                     {
-                        "description": "Classroom info",
-                        "name": "classroom",
+                        "description": "Lesson slot info",
+                        "name": "slot",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PatchClassroomRequest"
+                            "$ref": "#/definitions/handler.LessonSlotRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/domain.LessonSlot"
                         }
                     },
                     "400": {
@@ -1421,23 +1497,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/classrooms/{id}": {
+        "/admins/lesson_slots/university/{id}": {
             "get": {
-                "description": "Get a classroom by its ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get the lesson slots of a university ordered by number",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Classrooms"
+                    "LessonSlots"
                 ],
-                "summary": "Get a classroom by ID",
+                "summary": "Get the bell schedule of a university",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Classroom ID",
+                        "description": "University ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1447,7 +1525,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Classroom"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.LessonSlot"
+                            }
                         }
                     },
                     "400": {
@@ -1463,23 +1544,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete an existing classroom",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/admins/lesson_slots/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get a lesson of the bell schedule by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Classrooms"
+                    "LessonSlots"
                 ],
-                "summary": "Delete a classroom",
+                "summary": "Get a lesson slot",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Classroom ID",
+                        "description": "Lesson slot ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1489,7 +1574,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/domain.LessonSlot"
                         }
                     },
                     "400": {
@@ -1498,6 +1583,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1505,29 +1596,41 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/departaments": {
-            "get": {
-                "description": "Get a list of all departaments",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Delete a lesson of the bell schedule by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Departaments"
+                    "LessonSlots"
+                ],
+                "summary": "Delete a lesson slot",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lesson slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all departaments",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.DepartamentInfo"
-                            }
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -1537,27 +1640,34 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admins/schedules/exceptions": {
             "put": {
-                "description": "Update an existing departament",
-                "consumes": [
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update a one-off change of a lesson",
+                "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Departaments"
+                    "Schedules"
                 ],
-                "summary": "Update a departament",
+                "summary": "Update a schedule exception",
                 "parameters": [
                     {
-                        "description": "Departament info",
-                        "name": "departament",
+                        "description": "Schedule exception info",
+                        "name": "exception",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PutDepartamentRequest"
+                            "$ref": "#/definitions/handler.PutScheduleExceptionRequest"
                         }
                     }
                 ],
@@ -1574,6 +1684,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1583,7 +1699,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new departament",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cancel a lesson on the date or change its teacher, classroom, date or time",
                 "consumes": [
                     "application/json"
                 ],
@@ -1591,17 +1712,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Departaments"
+                    "Schedules"
                 ],
-                "summary": "Create a departament",
+                "summary": "Create a schedule exception",
                 "parameters": [
                     {
-                        "description": "Departament info",
-                        "name": "departament",
+                        "description": "Schedule exception info",
+                        "name": "exception",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateDepartamentRequest"
+                            "$ref": "#/definitions/handler.ScheduleExceptionRequest"
                         }
                     }
                 ],
@@ -1609,7 +1730,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/domain.ScheduleExceptionInfo"
                         }
                     },
                     "400": {
@@ -1618,6 +1739,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1625,35 +1752,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Partially update an existing departament",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/admins/schedules/exceptions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get a schedule exception by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Departaments"
+                    "Schedules"
                 ],
-                "summary": "Partially update a departament",
+                "summary": "Get a schedule exception",
                 "parameters": [
                     {
-                        "description": "Departament info",
-                        "name": "departament",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.PatchDepartamentRequest"
-                        }
+                        "type": "integer",
+                        "description": "Schedule exception ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/domain.ScheduleExceptionInfo"
                         }
                     },
                     "400": {
@@ -1662,6 +1791,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1669,26 +1804,26 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/departaments/faculty/{faculty_id}": {
-            "get": {
-                "description": "Get a list of departaments by faculty ID",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Delete a one-off change, the lesson follows the timetable again",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Departaments"
+                    "Schedules"
                 ],
-                "summary": "Get departaments by faculty ID",
+                "summary": "Delete a schedule exception",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Faculty ID",
-                        "name": "faculty_id",
+                        "description": "Schedule exception ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1697,10 +1832,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.DepartamentInfo"
-                            }
+                            "$ref": "#/definitions/handler.SuccessResponse"
                         }
                     },
                     "400": {
@@ -1718,24 +1850,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/departaments/name/{name}": {
+        "/admins/schedules/group/{group_id}/date/{date}": {
             "get": {
-                "description": "Get a departament by its name",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get the lessons of a group on a date with substitutions, cancellations and room changes applied",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Departaments"
+                    "Schedules"
                 ],
-                "summary": "Get a departament by name",
+                "summary": "Get the timetable of a group on a date",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Departament Name",
-                        "name": "name",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date (2006-01-02)",
+                        "name": "date",
                         "in": "path",
                         "required": true
                     }
@@ -1744,7 +1885,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.DepartamentInfo"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Lesson"
+                            }
                         }
                     },
                     "400": {
@@ -1762,33 +1906,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/departaments/{id}": {
-            "get": {
-                "description": "Get a departament by its ID",
+        "/admins/schedules/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import schedules from a CSV or XLSX file with the columns Группа, День, Неделя, Время, Дисциплина, Тип, Преподаватель, Аудитория. The teacher is an email or a name like \"Иванов И.И.\". Unresolved or ambiguous names and time conflicts are reported per row, nothing is inserted until the whole file is valid",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Departaments"
+                    "Schedules"
                 ],
-                "summary": "Get a departament by ID",
+                "summary": "Import a timetable",
                 "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or XLSX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "Departament ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Semester of the imported schedules",
+                        "name": "semester",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the file",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.DepartamentInfo"
+                            "$ref": "#/definitions/domain.ScheduleImportResult"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduleImportResult"
                         }
                     },
                     "400": {
@@ -1797,6 +1965,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduleImportResult"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1804,9 +1978,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete an existing departament",
+            }
+        },
+        "/admins/schedules/rollover": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mark the actual schedules of the semester as not actual and clone the schedules of the selected groups into the next semester in one transaction. With dry_run only the diff is returned",
                 "consumes": [
                     "application/json"
                 ],
@@ -1814,23 +1995,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Departaments"
+                    "Schedules"
                 ],
-                "summary": "Delete a departament",
+                "summary": "Roll the timetable over to the next semester",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Departament ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Rollover info",
+                        "name": "rollover",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RolloverRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only preview the changes",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/domain.RolloverDiff"
                         }
                     },
                     "400": {
@@ -1839,6 +2028,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1848,29 +2043,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/discipline_types": {
+        "/admins/schedules/teacher/{id}/date/{date}": {
             "get": {
-                "description": "Get a list of all discipline types",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get the lessons of a teacher on a date including substitutions",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "DisciplineTypes"
+                    "Schedules"
+                ],
+                "summary": "Get the timetable of a teacher on a date",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date (2006-01-02)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all discipline types",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.DisciplineType"
+                                "$ref": "#/definitions/domain.Lesson"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1878,35 +2097,40 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing discipline type",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/admins/schedules/{id}/exceptions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get all one-off changes of the lessons of a schedule",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "DisciplineTypes"
+                    "Schedules"
                 ],
-                "summary": "Update a discipline type",
+                "summary": "Get the exceptions of a schedule",
                 "parameters": [
                     {
-                        "description": "Discipline type info",
-                        "name": "discipline_type",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.PutDisciplineTypeRequest"
-                        }
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ScheduleExceptionInfo"
+                            }
                         }
                     },
                     "400": {
@@ -1922,35 +2146,58 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admins/students/import": {
             "post": {
-                "description": "Create a new discipline type",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import students from a CSV or XLSX file with the columns last_name, first_name, middle_name, group_id (or Фамилия, Имя, Отчество, Группа). A dry run only reports row errors, otherwise the whole file is imported in one transaction",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "DisciplineTypes"
+                    "Students"
                 ],
-                "summary": "Create a discipline type",
+                "summary": "Import students",
                 "parameters": [
                     {
-                        "description": "Discipline type info",
-                        "name": "discipline_type",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.CreateDisciplineTypeRequest"
-                        }
+                        "type": "file",
+                        "description": "CSV or XLSX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the file",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Create user accounts with generated logins",
+                        "name": "create_accounts",
+                        "in": "query"
                     }
                 ],
                 "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.StudentImportResult"
+                        }
+                    },
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/domain.StudentImportResult"
                         }
                     },
                     "400": {
@@ -1959,6 +2206,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/domain.StudentImportResult"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1966,39 +2219,41 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Partially update an existing discipline type",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/admins/students/import/credentials/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Download the logins and passwords generated by an import as CSV, the file can be downloaded only once",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "DisciplineTypes"
+                    "Students"
                 ],
-                "summary": "Partially update a discipline type",
+                "summary": "Download generated student logins",
                 "parameters": [
                     {
-                        "description": "Discipline type info",
-                        "name": "discipline_type",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.PatchDisciplineTypeRequest"
-                        }
+                        "type": "string",
+                        "description": "Credentials ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "type": "file"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -2012,9 +2267,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/discipline_types/{id}": {
-            "get": {
-                "description": "Get a discipline type by its ID",
+        "/admins/students/transfers": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Move a student to another group from the given date, the previous group stays in the history",
                 "consumes": [
                     "application/json"
                 ],
@@ -2022,23 +2282,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "DisciplineTypes"
+                    "Students"
                 ],
-                "summary": "Get a discipline type by ID",
+                "summary": "Transfer a student",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Discipline type ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Transfer info",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TransferStudentRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.DisciplineType"
+                            "$ref": "#/definitions/handler.SuccessResponse"
                         }
                     },
                     "400": {
@@ -2047,6 +2309,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2054,23 +2322,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete an existing discipline type",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/admins/students/{id}/groups": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get all groups of a student with the periods of membership",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "DisciplineTypes"
+                    "Students"
                 ],
-                "summary": "Delete a discipline type",
+                "summary": "Get the group history of a student",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Discipline type ID",
+                        "description": "Student ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2080,7 +2352,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.GroupMembership"
+                            }
                         }
                     },
                     "400": {
@@ -2089,37 +2364,11 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/disciplines": {
-            "get": {
-                "description": "Get a list of all disciplines",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Disciplines"
-                ],
-                "summary": "Get all disciplines",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.DisciplineInfo"
-                            }
-                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -2128,9 +2377,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admins/subgroups": {
             "put": {
-                "description": "Update an existing discipline",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update a subgroup and replace its students",
                 "consumes": [
                     "application/json"
                 ],
@@ -2138,17 +2394,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Disciplines"
+                    "Subgroups"
                 ],
-                "summary": "Update a discipline",
+                "summary": "Update a subgroup",
                 "parameters": [
                     {
-                        "description": "Discipline info",
-                        "name": "discipline",
+                        "description": "Subgroup info",
+                        "name": "subgroup",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PutDisciplineRequest"
+                            "$ref": "#/definitions/handler.PutSubgroupRequest"
                         }
                     }
                 ],
@@ -2174,7 +2430,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new discipline",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add a named subgroup of the students of a group, labs can be scheduled for it",
                 "consumes": [
                     "application/json"
                 ],
@@ -2182,17 +2443,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Disciplines"
+                    "Subgroups"
                 ],
-                "summary": "Create a discipline",
+                "summary": "Create a subgroup",
                 "parameters": [
                     {
-                        "description": "Discipline info",
-                        "name": "discipline",
+                        "description": "Subgroup info",
+                        "name": "subgroup",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateDisciplineRequest"
+                            "$ref": "#/definitions/handler.SubgroupRequest"
                         }
                     }
                 ],
@@ -2200,7 +2461,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/domain.Subgroup"
                         }
                     },
                     "400": {
@@ -2216,41 +2477,40 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Partially update an existing discipline",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/admins/subgroups/group/{group_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get the subgroups of a group with their students",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Disciplines"
+                    "Subgroups"
                 ],
-                "summary": "Partially update a discipline",
+                "summary": "Get the subgroups of a group",
                 "parameters": [
                     {
-                        "description": "Discipline info",
-                        "name": "discipline",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.PatchDisciplineRequest"
-                        }
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Subgroup"
+                            }
                         }
                     },
                     "500": {
@@ -2262,24 +2522,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/disciplines/departament/{departament_id}": {
+        "/admins/subgroups/{id}": {
             "get": {
-                "description": "Get a list of disciplines by departament ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get a subgroup with its students by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Disciplines"
+                    "Subgroups"
                 ],
-                "summary": "Get disciplines by departament ID",
+                "summary": "Get a subgroup",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Departament ID",
-                        "name": "departament_id",
+                        "description": "Subgroup ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -2288,10 +2550,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.DisciplineInfo"
-                            }
+                            "$ref": "#/definitions/domain.Subgroup"
                         }
                     },
                     "400": {
@@ -2300,6 +2559,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2307,26 +2572,26 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/disciplines/name/{name}": {
-            "get": {
-                "description": "Get a discipline by its name",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Delete a subgroup by ID, a subgroup with schedules can't be deleted",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Disciplines"
+                    "Subgroups"
                 ],
-                "summary": "Get a discipline by name",
+                "summary": "Delete a subgroup",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Discipline Name",
-                        "name": "name",
+                        "type": "integer",
+                        "description": "Subgroup ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -2335,7 +2600,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.DisciplineInfo"
+                            "$ref": "#/definitions/handler.SuccessResponse"
                         }
                     },
                     "400": {
@@ -2353,9 +2618,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/disciplines/{id}": {
+        "/admins/universities": {
             "get": {
-                "description": "Get a discipline by its ID",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a list of all universities",
                 "consumes": [
                     "application/json"
                 ],
@@ -2363,29 +2633,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Disciplines"
-                ],
-                "summary": "Get a discipline by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Discipline ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Universities"
                 ],
+                "summary": "Get all universities",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.DisciplineInfo"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.University"
+                            }
                         }
                     },
                     "500": {
@@ -2396,8 +2654,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Delete an existing discipline",
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing university",
                 "consumes": [
                     "application/json"
                 ],
@@ -2405,16 +2668,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Disciplines"
+                    "Universities"
                 ],
-                "summary": "Delete a discipline",
+                "summary": "Update a university",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Discipline ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "University info",
+                        "name": "university",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PutUniversityRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -2437,41 +2702,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/education_levels": {
-            "get": {
-                "description": "Get a list of all education levels",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "EducationLevels"
-                ],
-                "summary": "Get all education levels",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.EducationLevel"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    }
-                }
             },
-            "put": {
-                "description": "Update an existing education level",
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new university",
                 "consumes": [
                     "application/json"
                 ],
@@ -2479,23 +2717,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EducationLevels"
+                    "Universities"
                 ],
-                "summary": "Update an education level",
+                "summary": "Create a university",
                 "parameters": [
                     {
-                        "description": "Education level info",
-                        "name": "education_level",
+                        "description": "University info",
+                        "name": "university",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PutEducationLevelRequest"
+                            "$ref": "#/definitions/handler.CreateUniversityRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/handler.SuccessResponse"
                         }
@@ -2514,8 +2752,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Create a new education level",
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Partially update an existing university",
                 "consumes": [
                     "application/json"
                 ],
@@ -2523,23 +2766,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EducationLevels"
+                    "Universities"
                 ],
-                "summary": "Create an education level",
+                "summary": "Partially update a university",
                 "parameters": [
                     {
-                        "description": "Education level info",
-                        "name": "education_level",
+                        "description": "University info",
+                        "name": "university",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateEducationLevelRequest"
+                            "$ref": "#/definitions/handler.PatchUniversityRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/handler.SuccessResponse"
                         }
@@ -2557,9 +2800,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Partially update an existing education level",
+            }
+        },
+        "/admins/universities/name/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a university by its name",
                 "consumes": [
                     "application/json"
                 ],
@@ -2567,25 +2817,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EducationLevels"
+                    "Universities"
                 ],
-                "summary": "Partially update an education level",
+                "summary": "Get a university by name",
                 "parameters": [
                     {
-                        "description": "Education level info",
-                        "name": "education_level",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.PatchEducationLevelRequest"
-                        }
+                        "type": "string",
+                        "description": "University Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/domain.University"
                         }
                     },
                     "400": {
@@ -2603,9 +2851,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/education_levels/{id}": {
+        "/admins/universities/{id}": {
             "get": {
-                "description": "Get an education level by its ID",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a university by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2613,13 +2866,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EducationLevels"
+                    "Universities"
                 ],
-                "summary": "Get an education level by ID",
+                "summary": "Get a university by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Education level ID",
+                        "description": "University ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2629,7 +2882,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.EducationLevel"
+                            "$ref": "#/definitions/domain.University"
                         }
                     },
                     "400": {
@@ -2647,7 +2900,12 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete an existing education level",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an existing university",
                 "consumes": [
                     "application/json"
                 ],
@@ -2655,13 +2913,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EducationLevels"
+                    "Universities"
                 ],
-                "summary": "Delete an education level",
+                "summary": "Delete a university",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Education level ID",
+                        "description": "University ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2689,27 +2947,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/education_types": {
-            "get": {
-                "description": "Get a list of all education types",
-                "consumes": [
-                    "application/json"
+        "/admins/users/{id}/password_reset": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Issue a single-use password reset token for a user, the token is shown once",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "EducationTypes"
+                    "Users"
                 ],
-                "summary": "Get all education types",
+                "summary": "Issue a password reset token",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.EducationType"
-                            }
+                            "$ref": "#/definitions/service.ResetToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -2719,35 +2988,49 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing education type",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/admins/workload/departament/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get the workload of every teacher of a departament with the departament totals",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "EducationTypes"
+                    "Workload"
                 ],
-                "summary": "Update an education type",
+                "summary": "Get the workload of a departament",
                 "parameters": [
                     {
-                        "description": "Education type info",
-                        "name": "education_type",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.PutEducationTypeRequest"
-                        }
+                        "type": "integer",
+                        "description": "Departament ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester, all semesters by default",
+                        "name": "semester",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json, csv or xlsx",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/domain.DepartamentWorkload"
                         }
                     },
                     "400": {
@@ -2763,35 +3046,49 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new education type",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            }
+        },
+        "/admins/workload/teacher/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the planned hours of the actual timetable on the teaching days and the delivered hours of the lessons with a marked attendance per discipline, group and semester",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "EducationTypes"
+                    "Workload"
                 ],
-                "summary": "Create an education type",
+                "summary": "Get the workload of a teacher",
                 "parameters": [
                     {
-                        "description": "Education type info",
-                        "name": "education_type",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.CreateEducationTypeRequest"
-                        }
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester, all semesters by default",
+                        "name": "semester",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json, csv or xlsx",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/domain.TeacherWorkload"
                         }
                     },
                     "400": {
@@ -2800,6 +3097,44 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances": {
+            "get": {
+                "description": "Get a list of all attendances",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Get all attendances",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Attendance"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2808,8 +3143,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
-                "description": "Partially update an existing education type",
+            "put": {
+                "description": "Update multiple existing attendances",
                 "consumes": [
                     "application/json"
                 ],
@@ -2817,17 +3152,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EducationTypes"
+                    "Attendance"
                 ],
-                "summary": "Partially update an education type",
+                "summary": "Update multiple attendances",
                 "parameters": [
                     {
-                        "description": "Education type info",
-                        "name": "education_type",
+                        "description": "Attendances info",
+                        "name": "attendance",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PatchEducationTypeRequest"
+                            "$ref": "#/definitions/handler.PutAttendancesRequest"
                         }
                     }
                 ],
@@ -2851,11 +3186,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/education_types/name/{name}": {
-            "get": {
-                "description": "Get an education type by its name",
+            },
+            "post": {
+                "description": "Create a new attendance",
                 "consumes": [
                     "application/json"
                 ],
@@ -2863,23 +3196,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EducationTypes"
+                    "Attendance"
                 ],
-                "summary": "Get an education type by name",
+                "summary": "Create an attendance",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Education type Name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "Attendance info",
+                        "name": "attendance",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateAttendanceRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.EducationType"
+                            "$ref": "#/definitions/handler.SuccessResponse"
                         }
                     },
                     "400": {
@@ -2895,11 +3230,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/education_types/{id}": {
-            "get": {
-                "description": "Get an education type by its ID",
+            },
+            "patch": {
+                "description": "Partially update an existing attendance",
                 "consumes": [
                     "application/json"
                 ],
@@ -2907,23 +3240,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EducationTypes"
+                    "Attendance"
                 ],
-                "summary": "Get an education type by ID",
+                "summary": "Partially update an attendance",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Education type ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Attendance info",
+                        "name": "attendance",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PatchAttendanceRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.EducationType"
+                            "$ref": "#/definitions/handler.SuccessResponse"
                         }
                     },
                     "400": {
@@ -2939,9 +3274,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete an existing education type",
+            }
+        },
+        "/attendances/student/{student_id}": {
+            "get": {
+                "description": "Get attendances by student ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2949,14 +3286,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "EducationTypes"
+                    "Attendance"
                 ],
-                "summary": "Delete an education type",
+                "summary": "Get attendances by student ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Education type ID",
-                        "name": "id",
+                        "description": "Student ID",
+                        "name": "student_id",
                         "in": "path",
                         "required": true
                     }
@@ -2965,7 +3302,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Attendance"
+                            }
                         }
                     },
                     "400": {
@@ -2983,14 +3323,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/faculties": {
+        "/attendances/{id}": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Get a list of all faculties",
+                "description": "Get an attendance by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2998,17 +3333,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Faculties"
+                    "Attendance"
+                ],
+                "summary": "Get an attendance by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Attendance ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all faculties",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Faculty"
-                            }
+                            "$ref": "#/definitions/domain.Attendance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -3019,13 +3366,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Update an existing faculty",
+            "delete": {
+                "description": "Delete an existing attendance",
                 "consumes": [
                     "application/json"
                 ],
@@ -3033,18 +3375,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Faculties"
+                    "Attendance"
                 ],
-                "summary": "Update a faculty",
+                "summary": "Delete an attendance",
                 "parameters": [
                     {
-                        "description": "Faculty info",
-                        "name": "faculty",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.PutFacultyRequest"
-                        }
+                        "type": "integer",
+                        "description": "Attendance ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -3067,14 +3407,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/password/forgot": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Create a new faculty",
+                "description": "Send a password reset code to the email of the user, if it is known",
                 "consumes": [
                     "application/json"
                 ],
@@ -3082,23 +3419,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Faculties"
+                    "Users"
                 ],
-                "summary": "Create a faculty",
+                "summary": "Request a password reset",
                 "parameters": [
                     {
-                        "description": "Faculty info",
-                        "name": "faculty",
+                        "description": "Username",
+                        "name": "user",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateFacultyRequest"
+                            "$ref": "#/definitions/handler.ForgotPasswordRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "$ref": "#/definitions/handler.SuccessResponse"
                         }
@@ -3116,14 +3453,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Partially update an existing faculty",
+            }
+        },
+        "/auth/password/reset": {
+            "post": {
+                "description": "Set a new password using a single-use reset token",
                 "consumes": [
                     "application/json"
                 ],
@@ -3131,17 +3465,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Faculties"
+                    "Users"
                 ],
-                "summary": "Partially update a faculty",
+                "summary": "Reset a password",
                 "parameters": [
                     {
-                        "description": "Faculty info",
-                        "name": "faculty",
+                        "description": "Reset token and new password",
+                        "name": "reset",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PatchFacultyRequest"
+                            "$ref": "#/definitions/handler.ResetPasswordRequest"
                         }
                     }
                 ],
@@ -3167,63 +3501,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/faculties/id/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Get a faculty by its ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Faculties"
-                ],
-                "summary": "Get a faculty by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Faculty ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/domain.Faculty"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/faculties/name/{name}": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Get a faculty by its ID",
+        "/auth/signin": {
+            "post": {
+                "description": "Sign in a user",
                 "consumes": [
                     "application/json"
                 ],
@@ -3231,75 +3511,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Faculties"
+                    "Users"
                 ],
-                "summary": "Get a faculty by ID",
+                "summary": "Sign in a user",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Faculty ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/domain.Faculty"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "User sign-in info",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/handler.SignInUserRequest"
                         }
                     }
-                }
-            }
-        },
-        "/faculties/university/id/{university_id}": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Get a list of faculties by university ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Faculties"
-                ],
-                "summary": "Get faculties by university ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "University ID",
-                        "name": "university_id",
-                        "in": "path",
-                        "required": true
-                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Faculty"
-                            }
+                            "$ref": "#/definitions/service.Tokens"
                         }
                     },
                     "400": {
@@ -3308,51 +3538,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/faculties/{id}": {
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Delete an existing faculty",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Faculties"
-                ],
-                "summary": "Delete a faculty",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Faculty ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -3366,9 +3559,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/groups": {
+        "/classrooms": {
             "get": {
-                "description": "Get a list of all groups",
+                "description": "Get a list of all classrooms",
                 "consumes": [
                     "application/json"
                 ],
@@ -3376,16 +3569,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Groups"
+                    "Classrooms"
                 ],
-                "summary": "Get all groups",
+                "summary": "Get all classrooms",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.GroupInfo"
+                                "$ref": "#/definitions/domain.Classroom"
                             }
                         }
                     },
@@ -3398,7 +3591,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update an existing group",
+                "description": "Update an existing classroom",
                 "consumes": [
                     "application/json"
                 ],
@@ -3406,17 +3599,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Groups"
+                    "Classrooms"
                 ],
-                "summary": "Update a group",
+                "summary": "Update a classroom",
                 "parameters": [
                     {
-                        "description": "Group info",
-                        "name": "group",
+                        "description": "Classroom info",
+                        "name": "classroom",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PutGroupRequest"
+                            "$ref": "#/definitions/handler.PutClassroomRequest"
                         }
                     }
                 ],
@@ -3442,7 +3635,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new group",
+                "description": "Create a new classroom",
                 "consumes": [
                     "application/json"
                 ],
@@ -3450,17 +3643,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Groups"
+                    "Classrooms"
                 ],
-                "summary": "Create a group",
+                "summary": "Create a classroom",
                 "parameters": [
                     {
-                        "description": "Group info",
-                        "name": "group",
+                        "description": "Classroom info",
+                        "name": "classroom",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateGroupRequest"
+                            "$ref": "#/definitions/handler.CreateClassroomRequest"
                         }
                     }
                 ],
@@ -3486,7 +3679,7 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "Partially update an existing group",
+                "description": "Partially update an existing classroom",
                 "consumes": [
                     "application/json"
                 ],
@@ -3494,17 +3687,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Groups"
+                    "Classrooms"
                 ],
-                "summary": "Partially update a group",
+                "summary": "Partially update a classroom",
                 "parameters": [
                     {
-                        "description": "Group info",
-                        "name": "group",
+                        "description": "Classroom info",
+                        "name": "classroom",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PatchGroupRequest"
+                            "$ref": "#/definitions/handler.PatchClassroomRequest"
                         }
                     }
                 ],
@@ -3530,9 +3723,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/groups/name/{name}": {
+        "/classrooms/{id}": {
             "get": {
-                "description": "Get a group by its name",
+                "description": "Get a classroom by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -3540,14 +3733,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Groups"
+                    "Classrooms"
                 ],
-                "summary": "Get a group by name",
+                "summary": "Get a classroom by ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Group Name",
-                        "name": "name",
+                        "type": "integer",
+                        "description": "Classroom ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -3556,7 +3749,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.GroupInfo"
+                            "$ref": "#/definitions/domain.Classroom"
                         }
                     },
                     "400": {
@@ -3572,11 +3765,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/groups/profile/{profile_id}": {
-            "get": {
-                "description": "Get a list of groups by profile ID",
+            },
+            "delete": {
+                "description": "Delete an existing classroom",
                 "consumes": [
                     "application/json"
                 ],
@@ -3584,14 +3775,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Groups"
+                    "Classrooms"
                 ],
-                "summary": "Get groups by profile ID",
+                "summary": "Delete a classroom",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Profile ID",
-                        "name": "profile_id",
+                        "description": "Classroom ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -3600,10 +3791,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.GroupInfo"
-                            }
+                            "$ref": "#/definitions/handler.SuccessResponse"
                         }
                     },
                     "400": {
@@ -3621,9 +3809,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/groups/{id}": {
+        "/departaments": {
             "get": {
-                "description": "Get a group by its ID",
+                "description": "Get a list of all departaments",
                 "consumes": [
                     "application/json"
                 ],
@@ -3631,29 +3819,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Groups"
-                ],
-                "summary": "Get a group by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Departaments"
                 ],
+                "summary": "Get all departaments",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.GroupInfo"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.DepartamentInfo"
+                            }
                         }
                     },
                     "500": {
@@ -3664,8 +3840,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Delete an existing group",
+            "put": {
+                "description": "Update an existing departament",
                 "consumes": [
                     "application/json"
                 ],
@@ -3673,16 +3849,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Groups"
+                    "Departaments"
                 ],
-                "summary": "Delete a group",
+                "summary": "Update a departament",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Departament info",
+                        "name": "departament",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PutDepartamentRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -3705,11 +3883,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/headmen": {
-            "get": {
-                "description": "Get a list of all headmen",
+            },
+            "post": {
+                "description": "Create a new departament",
                 "consumes": [
                     "application/json"
                 ],
@@ -3717,17 +3893,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Headmen"
+                    "Departaments"
+                ],
+                "summary": "Create a departament",
+                "parameters": [
+                    {
+                        "description": "Departament info",
+                        "name": "departament",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateDepartamentRequest"
+                        }
+                    }
                 ],
-                "summary": "Get all headmen",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.HeadmanInfo"
-                            }
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -3738,8 +3928,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Update an existing headman",
+            "patch": {
+                "description": "Partially update an existing departament",
                 "consumes": [
                     "application/json"
                 ],
@@ -3747,17 +3937,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Headmen"
+                    "Departaments"
                 ],
-                "summary": "Update a headman",
+                "summary": "Partially update a departament",
                 "parameters": [
                     {
-                        "description": "Headman info",
-                        "name": "headman",
+                        "description": "Departament info",
+                        "name": "departament",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PutHeadmanRequest"
+                            "$ref": "#/definitions/handler.PatchDepartamentRequest"
                         }
                     }
                 ],
@@ -3781,9 +3971,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new headman",
+            }
+        },
+        "/departaments/faculty/{faculty_id}": {
+            "get": {
+                "description": "Get a list of departaments by faculty ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -3791,25 +3983,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Headmen"
+                    "Departaments"
                 ],
-                "summary": "Create a headman",
+                "summary": "Get departaments by faculty ID",
                 "parameters": [
                     {
-                        "description": "Headman info",
-                        "name": "headman",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.CreateHeadmanRequest"
-                        }
+                        "type": "integer",
+                        "description": "Faculty ID",
+                        "name": "faculty_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.DepartamentInfo"
+                            }
                         }
                     },
                     "400": {
@@ -3825,9 +4018,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Partially update an existing headman",
+            }
+        },
+        "/departaments/name/{name}": {
+            "get": {
+                "description": "Get a departament by its name",
                 "consumes": [
                     "application/json"
                 ],
@@ -3835,25 +4030,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Headmen"
+                    "Departaments"
                 ],
-                "summary": "Partially update a headman",
+                "summary": "Get a departament by name",
                 "parameters": [
                     {
-                        "description": "Headman info",
-                        "name": "headman",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.PatchHeadmanRequest"
-                        }
+                        "type": "string",
+                        "description": "Departament Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/domain.DepartamentInfo"
                         }
                     },
                     "400": {
@@ -3871,9 +4064,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/headmen/{id}": {
+        "/departaments/{id}": {
             "get": {
-                "description": "Get a headman by its ID",
+                "description": "Get a departament by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -3881,13 +4074,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Headmen"
+                    "Departaments"
                 ],
-                "summary": "Get a headman by ID",
+                "summary": "Get a departament by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Headman ID",
+                        "description": "Departament ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3897,7 +4090,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.HeadmanInfo"
+                            "$ref": "#/definitions/domain.DepartamentInfo"
                         }
                     },
                     "400": {
@@ -3915,7 +4108,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete an existing headman",
+                "description": "Delete an existing departament",
                 "consumes": [
                     "application/json"
                 ],
@@ -3923,13 +4116,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Headmen"
+                    "Departaments"
                 ],
-                "summary": "Delete a headman",
+                "summary": "Delete a departament",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Headman ID",
+                        "description": "Departament ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3957,9 +4150,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/profiles": {
+        "/discipline_types": {
             "get": {
-                "description": "Get a list of all profiles",
+                "description": "Get a list of all discipline types",
                 "consumes": [
                     "application/json"
                 ],
@@ -3967,16 +4160,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Profiles"
+                    "DisciplineTypes"
                 ],
-                "summary": "Get all profiles",
+                "summary": "Get all discipline types",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.ProfileInfo"
+                                "$ref": "#/definitions/domain.DisciplineType"
                             }
                         }
                     },
@@ -3989,7 +4182,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update an existing profile",
+                "description": "Update an existing discipline type",
                 "consumes": [
                     "application/json"
                 ],
@@ -3997,17 +4190,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Profiles"
+                    "DisciplineTypes"
                 ],
-                "summary": "Update a profile",
+                "summary": "Update a discipline type",
                 "parameters": [
                     {
-                        "description": "Profile info",
-                        "name": "profile",
+                        "description": "Discipline type info",
+                        "name": "discipline_type",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PutProfileRequest"
+                            "$ref": "#/definitions/handler.PutDisciplineTypeRequest"
                         }
                     }
                 ],
@@ -4033,7 +4226,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new profile",
+                "description": "Create a new discipline type",
                 "consumes": [
                     "application/json"
                 ],
@@ -4041,17 +4234,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Profiles"
+                    "DisciplineTypes"
                 ],
-                "summary": "Create a profile",
+                "summary": "Create a discipline type",
                 "parameters": [
                     {
-                        "description": "Profile info",
-                        "name": "profile",
+                        "description": "Discipline type info",
+                        "name": "discipline_type",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateProfileRequest"
+                            "$ref": "#/definitions/handler.CreateDisciplineTypeRequest"
                         }
                     }
                 ],
@@ -4077,7 +4270,7 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "Partially update an existing profile",
+                "description": "Partially update an existing discipline type",
                 "consumes": [
                     "application/json"
                 ],
@@ -4085,17 +4278,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Profiles"
+                    "DisciplineTypes"
                 ],
-                "summary": "Partially update a profile",
+                "summary": "Partially update a discipline type",
                 "parameters": [
                     {
-                        "description": "Profile info",
-                        "name": "profile",
+                        "description": "Discipline type info",
+                        "name": "discipline_type",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PatchProfileRequest"
+                            "$ref": "#/definitions/handler.PatchDisciplineTypeRequest"
                         }
                     }
                 ],
@@ -4121,9 +4314,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/profiles/education_type/{education_type_id}": {
+        "/discipline_types/{id}": {
             "get": {
-                "description": "Get a list of profiles by education type ID",
+                "description": "Get a discipline type by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -4131,14 +4324,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Profiles"
+                    "DisciplineTypes"
                 ],
-                "summary": "Get profiles by education type ID",
+                "summary": "Get a discipline type by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Education Type ID",
-                        "name": "education_type_id",
+                        "description": "Discipline type ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -4147,10 +4340,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.ProfileInfo"
-                            }
+                            "$ref": "#/definitions/domain.DisciplineType"
                         }
                     },
                     "400": {
@@ -4166,11 +4356,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/profiles/name/{name}": {
-            "get": {
-                "description": "Get a profile by its name",
+            },
+            "delete": {
+                "description": "Delete an existing discipline type",
                 "consumes": [
                     "application/json"
                 ],
@@ -4178,15 +4366,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Profiles"
+                    "DisciplineTypes"
                 ],
-                "summary": "Get a profile by name",
+                "summary": "Delete a discipline type",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Profile Name",
-                        "name": "name",
-                        "in": "path",
+                        "type": "integer",
+                        "description": "Discipline type ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -4194,7 +4382,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.ProfileInfo"
+                            "$ref": "#/definitions/handler.SuccessResponse"
                         }
                     },
                     "400": {
@@ -4212,9 +4400,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/profiles/specialty/{specialty_code}": {
+        "/disciplines": {
             "get": {
-                "description": "Get a list of profiles by specialty code",
+                "description": "Get a list of all disciplines",
                 "consumes": [
                     "application/json"
                 ],
@@ -4222,34 +4410,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Profiles"
-                ],
-                "summary": "Get profiles by specialty code",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Specialty Code",
-                        "name": "specialty_code",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Disciplines"
                 ],
+                "summary": "Get all disciplines",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.ProfileInfo"
+                                "$ref": "#/definitions/domain.DisciplineInfo"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4257,11 +4430,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/profiles/{id}": {
-            "get": {
-                "description": "Get a profile by its ID",
+            },
+            "put": {
+                "description": "Update an existing discipline",
                 "consumes": [
                     "application/json"
                 ],
@@ -4269,23 +4440,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Profiles"
+                    "Disciplines"
                 ],
-                "summary": "Get a profile by ID",
+                "summary": "Update a discipline",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Profile ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Discipline info",
+                        "name": "discipline",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PutDisciplineRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.ProfileInfo"
+                            "$ref": "#/definitions/handler.SuccessResponse"
                         }
                     },
                     "400": {
@@ -4302,8 +4475,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Delete an existing profile",
+            "post": {
+                "description": "Create a new discipline",
                 "consumes": [
                     "application/json"
                 ],
@@ -4311,21 +4484,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Profiles"
+                    "Disciplines"
                 ],
-                "summary": "Delete a profile",
+                "summary": "Create a discipline",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Profile ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Discipline info",
+                        "name": "discipline",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateDisciplineRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/handler.SuccessResponse"
                         }
@@ -4343,41 +4518,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/schedules": {
-            "get": {
-                "description": "Get a list of all schedules",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Schedules"
-                ],
-                "summary": "Get all schedules",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Schedule"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    }
-                }
             },
-            "put": {
-                "description": "Update an existing schedule",
+            "patch": {
+                "description": "Partially update an existing discipline",
                 "consumes": [
                     "application/json"
                 ],
@@ -4385,17 +4528,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Schedules"
+                    "Disciplines"
                 ],
-                "summary": "Update a schedule",
+                "summary": "Partially update a discipline",
                 "parameters": [
                     {
-                        "description": "Schedule info",
-                        "name": "schedule",
+                        "description": "Discipline info",
+                        "name": "discipline",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PutScheduleRequest"
+                            "$ref": "#/definitions/handler.PatchDisciplineRequest"
                         }
                     }
                 ],
@@ -4419,9 +4562,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new schedule",
+            }
+        },
+        "/disciplines/departament/{departament_id}": {
+            "get": {
+                "description": "Get a list of disciplines by departament ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -4429,25 +4574,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Schedules"
+                    "Disciplines"
                 ],
-                "summary": "Create a schedule",
+                "summary": "Get disciplines by departament ID",
                 "parameters": [
                     {
-                        "description": "Schedule info",
-                        "name": "schedule",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.CreateScheduleRequest"
-                        }
+                        "type": "integer",
+                        "description": "Departament ID",
+                        "name": "departament_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.DisciplineInfo"
+                            }
                         }
                     },
                     "400": {
@@ -4463,9 +4609,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Partially update an existing schedule",
+            }
+        },
+        "/disciplines/name/{name}": {
+            "get": {
+                "description": "Get a discipline by its name",
                 "consumes": [
                     "application/json"
                 ],
@@ -4473,25 +4621,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Schedules"
+                    "Disciplines"
                 ],
-                "summary": "Partially update a schedule",
+                "summary": "Get a discipline by name",
                 "parameters": [
                     {
-                        "description": "Schedule info",
-                        "name": "schedule",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.PatchScheduleRequest"
-                        }
+                        "type": "string",
+                        "description": "Discipline Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/domain.DisciplineInfo"
                         }
                     },
                     "400": {
@@ -4509,9 +4655,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/schedules/group/{group_id}": {
+        "/disciplines/{id}": {
             "get": {
-                "description": "Get schedules by group ID",
+                "description": "Get a discipline by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -4519,14 +4665,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Schedules"
+                    "Disciplines"
                 ],
-                "summary": "Get schedules by group ID",
+                "summary": "Get a discipline by ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group_id",
+                        "type": "integer",
+                        "description": "Discipline ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -4535,10 +4681,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Schedule"
-                            }
+                            "$ref": "#/definitions/domain.DisciplineInfo"
                         }
                     },
                     "400": {
@@ -4554,11 +4697,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/schedules/group/{group_id}/actual": {
-            "get": {
-                "description": "Get actual schedules by group ID",
+            },
+            "delete": {
+                "description": "Delete an existing discipline",
                 "consumes": [
                     "application/json"
                 ],
@@ -4566,14 +4707,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Schedules"
+                    "Disciplines"
                 ],
-                "summary": "Get actual schedules by group ID",
+                "summary": "Delete a discipline",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group_id",
+                        "type": "integer",
+                        "description": "Discipline ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -4582,10 +4723,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Schedule"
-                            }
+                            "$ref": "#/definitions/handler.SuccessResponse"
                         }
                     },
                     "400": {
@@ -4603,9 +4741,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/schedules/group/{group_id}/week/{week_type}": {
+        "/education_levels": {
             "get": {
-                "description": "Get schedules by group ID and week type",
+                "description": "Get a list of all education levels",
                 "consumes": [
                     "application/json"
                 ],
@@ -4613,41 +4751,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Schedules"
-                ],
-                "summary": "Get schedules by group ID and week type",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Week Type",
-                        "name": "week_type",
-                        "in": "path",
-                        "required": true
-                    }
+                    "EducationLevels"
                 ],
+                "summary": "Get all education levels",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.Schedule"
+                                "$ref": "#/definitions/domain.EducationLevel"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4655,11 +4771,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/schedules/group/{group_id}/week/{week_type}/day/{day_of_week}": {
-            "get": {
-                "description": "Get schedules by group ID, week type, and day of the week",
+            },
+            "put": {
+                "description": "Update an existing education level",
                 "consumes": [
                     "application/json"
                 ],
@@ -4667,40 +4781,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Schedules"
+                    "EducationLevels"
                 ],
-                "summary": "Get schedules by group ID, week type, and day of the week",
+                "summary": "Update an education level",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Week Type",
-                        "name": "week_type",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Day of the Week",
-                        "name": "day_of_week",
-                        "in": "path",
-                        "required": true
+                        "description": "Education level info",
+                        "name": "education_level",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PutEducationLevelRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Schedule"
-                            }
+                            "$ref": "#/definitions/handler.SuccessResponse"
                         }
                     },
                     "400": {
@@ -4716,11 +4815,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/schedules/teacher/{teacher_id}": {
-            "get": {
-                "description": "Get schedules by teacher ID",
+            },
+            "post": {
+                "description": "Create a new education level",
                 "consumes": [
                     "application/json"
                 ],
@@ -4728,26 +4825,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Schedules"
+                    "EducationLevels"
                 ],
-                "summary": "Get schedules by teacher ID",
+                "summary": "Create an education level",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Teacher ID",
-                        "name": "teacher_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Education level info",
+                        "name": "education_level",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateEducationLevelRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Schedule"
-                            }
+                            "$ref": "#/definitions/handler.SuccessResponse"
                         }
                     },
                     "400": {
@@ -4763,11 +4859,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/schedules/teacher/{teacher_id}/actual": {
-            "get": {
-                "description": "Get actual schedules by teacher ID",
+            },
+            "patch": {
+                "description": "Partially update an existing education level",
                 "consumes": [
                     "application/json"
                 ],
@@ -4775,26 +4869,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Schedules"
+                    "EducationLevels"
                 ],
-                "summary": "Get actual schedules by teacher ID",
+                "summary": "Partially update an education level",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Teacher ID",
-                        "name": "teacher_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Education level info",
+                        "name": "education_level",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PatchEducationLevelRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Schedule"
-                            }
+                            "$ref": "#/definitions/handler.SuccessResponse"
                         }
                     },
                     "400": {
@@ -4812,9 +4905,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/schedules/teacher/{teacher_id}/week/{week_type}": {
+        "/education_levels/{id}": {
             "get": {
-                "description": "Get schedules by teacher ID and week type",
+                "description": "Get an education level by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -4822,21 +4915,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Schedules"
+                    "EducationLevels"
                 ],
-                "summary": "Get schedules by teacher ID and week type",
+                "summary": "Get an education level by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Teacher ID",
-                        "name": "teacher_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Week Type",
-                        "name": "week_type",
+                        "description": "Education level ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -4845,10 +4931,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Schedule"
-                            }
+                            "$ref": "#/definitions/domain.EducationLevel"
                         }
                     },
                     "400": {
@@ -4864,11 +4947,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/schedules/teacher/{teacher_id}/week/{week_type}/day/{day_of_week}": {
-            "get": {
-                "description": "Get schedules by teacher ID, week type, and day of the week",
+            },
+            "delete": {
+                "description": "Delete an existing education level",
                 "consumes": [
                     "application/json"
                 ],
@@ -4876,28 +4957,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Schedules"
+                    "EducationLevels"
                 ],
-                "summary": "Get schedules by teacher ID, week type, and day of the week",
+                "summary": "Delete an education level",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Teacher ID",
-                        "name": "teacher_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Week Type",
-                        "name": "week_type",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Day of the Week",
-                        "name": "day_of_week",
+                        "description": "Education level ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -4906,10 +4973,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Schedule"
-                            }
+                            "$ref": "#/definitions/handler.SuccessResponse"
                         }
                     },
                     "400": {
@@ -4927,9 +4991,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/schedules/{id}": {
+        "/education_types": {
             "get": {
-                "description": "Get a schedule by its ID",
+                "description": "Get a list of all education types",
                 "consumes": [
                     "application/json"
                 ],
@@ -4937,29 +5001,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Schedules"
-                ],
-                "summary": "Get a schedule by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Schedule ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "EducationTypes"
                 ],
+                "summary": "Get all education types",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Schedule"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.EducationType"
+                            }
                         }
                     },
                     "500": {
@@ -4970,8 +5022,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Delete an existing schedule",
+            "put": {
+                "description": "Update an existing education type",
                 "consumes": [
                     "application/json"
                 ],
@@ -4979,16 +5031,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Schedules"
+                    "EducationTypes"
                 ],
-                "summary": "Delete a schedule",
+                "summary": "Update an education type",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Schedule ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Education type info",
+                        "name": "education_type",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PutEducationTypeRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -5011,11 +5065,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/specialties": {
-            "get": {
-                "description": "Get a list of all specialties",
+            },
+            "post": {
+                "description": "Create a new education type",
                 "consumes": [
                     "application/json"
                 ],
@@ -5023,105 +5075,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Specialties"
+                    "EducationTypes"
+                ],
+                "summary": "Create an education type",
+                "parameters": [
+                    {
+                        "description": "Education type info",
+                        "name": "education_type",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateEducationTypeRequest"
+                        }
+                    }
                 ],
-                "summary": "Get all specialties",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.SpecialtyInfo"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "put": {
-                "description": "Update an existing specialty",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Specialties"
-                ],
-                "summary": "Update a specialty",
-                "parameters": [
-                    {
-                        "description": "Specialty info",
-                        "name": "specialty",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.PutSpecialtyRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Create a new specialty",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Specialties"
-                ],
-                "summary": "Create a specialty",
-                "parameters": [
-                    {
-                        "description": "Specialty info",
-                        "name": "specialty",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.CreateSpecialtyRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -5133,7 +5111,7 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "Partially update an existing specialty",
+                "description": "Partially update an existing education type",
                 "consumes": [
                     "application/json"
                 ],
@@ -5141,17 +5119,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Specialties"
+                    "EducationTypes"
                 ],
-                "summary": "Partially update a specialty",
+                "summary": "Partially update an education type",
                 "parameters": [
                     {
-                        "description": "Specialty info",
-                        "name": "specialty",
+                        "description": "Education type info",
+                        "name": "education_type",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PatchSpecialtyRequest"
+                            "$ref": "#/definitions/handler.PatchEducationTypeRequest"
                         }
                     }
                 ],
@@ -5177,56 +5155,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/specialties/departament/{departament_id}": {
-            "get": {
-                "description": "Get a list of specialties by departament ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Specialties"
-                ],
-                "summary": "Get specialties by departament ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Departament ID",
-                        "name": "departament_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.SpecialtyInfo"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/specialties/name/{name}": {
+        "/education_types/name/{name}": {
             "get": {
-                "description": "Get a specialty by its name",
+                "description": "Get an education type by its name",
                 "consumes": [
                     "application/json"
                 ],
@@ -5234,13 +5165,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Specialties"
+                    "EducationTypes"
                 ],
-                "summary": "Get a specialty by name",
+                "summary": "Get an education type by name",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Specialty Name",
+                        "description": "Education type Name",
                         "name": "name",
                         "in": "path",
                         "required": true
@@ -5250,7 +5181,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SpecialtyInfo"
+                            "$ref": "#/definitions/domain.EducationType"
                         }
                     },
                     "400": {
@@ -5268,9 +5199,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/specialties/{code}": {
+        "/education_types/{id}": {
             "get": {
-                "description": "Get a specialty by its code",
+                "description": "Get an education type by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -5278,14 +5209,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Specialties"
+                    "EducationTypes"
                 ],
-                "summary": "Get a specialty by code",
+                "summary": "Get an education type by ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Specialty Code",
-                        "name": "code",
+                        "type": "integer",
+                        "description": "Education type ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -5294,7 +5225,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SpecialtyInfo"
+                            "$ref": "#/definitions/domain.EducationType"
                         }
                     },
                     "400": {
@@ -5312,7 +5243,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete an existing specialty",
+                "description": "Delete an existing education type",
                 "consumes": [
                     "application/json"
                 ],
@@ -5320,14 +5251,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Specialties"
+                    "EducationTypes"
                 ],
-                "summary": "Delete a specialty",
+                "summary": "Delete an education type",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Specialty Code",
-                        "name": "code",
+                        "type": "integer",
+                        "description": "Education type ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -5354,9 +5285,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/students": {
+        "/faculties": {
             "get": {
-                "description": "Get a list of all students",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a list of all faculties",
                 "consumes": [
                     "application/json"
                 ],
@@ -5364,16 +5300,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Faculties"
                 ],
-                "summary": "Get all students",
+                "summary": "Get all faculties",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.Student"
+                                "$ref": "#/definitions/domain.Faculty"
                             }
                         }
                     },
@@ -5386,7 +5322,12 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update an existing student",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing faculty",
                 "consumes": [
                     "application/json"
                 ],
@@ -5394,17 +5335,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Faculties"
                 ],
-                "summary": "Update a student",
+                "summary": "Update a faculty",
                 "parameters": [
                     {
-                        "description": "Student info",
-                        "name": "student",
+                        "description": "Faculty info",
+                        "name": "faculty",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PutStudentRequest"
+                            "$ref": "#/definitions/handler.PutFacultyRequest"
                         }
                     }
                 ],
@@ -5430,7 +5371,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new student",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new faculty",
                 "consumes": [
                     "application/json"
                 ],
@@ -5438,17 +5384,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Faculties"
                 ],
-                "summary": "Create a student",
+                "summary": "Create a faculty",
                 "parameters": [
                     {
-                        "description": "Student info",
-                        "name": "student",
+                        "description": "Faculty info",
+                        "name": "faculty",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateStudentRequest"
+                            "$ref": "#/definitions/handler.CreateFacultyRequest"
                         }
                     }
                 ],
@@ -5474,7 +5420,12 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "Partially update an existing student",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Partially update an existing faculty",
                 "consumes": [
                     "application/json"
                 ],
@@ -5482,17 +5433,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Faculties"
                 ],
-                "summary": "Partially update a student",
+                "summary": "Partially update a faculty",
                 "parameters": [
                     {
-                        "description": "Student info",
-                        "name": "student",
+                        "description": "Faculty info",
+                        "name": "faculty",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PatchStudentRequest"
+                            "$ref": "#/definitions/handler.PatchFacultyRequest"
                         }
                     }
                 ],
@@ -5518,24 +5469,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/students/group/{group_id}": {
+        "/faculties/id/{id}": {
             "get": {
-                "description": "Get a list of students by group ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a faculty by its ID",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Faculties"
                 ],
-                "summary": "Get students by group ID",
+                "summary": "Get a faculty by ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group_id",
+                        "type": "integer",
+                        "description": "Faculty ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -5544,10 +5500,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Student"
-                            }
+                            "$ref": "#/definitions/domain.Faculty"
                         }
                     },
                     "400": {
@@ -5565,9 +5518,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/students/{id}": {
+        "/faculties/name/{name}": {
             "get": {
-                "description": "Get a student by its ID",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a faculty by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -5575,13 +5533,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Faculties"
                 ],
-                "summary": "Get a student by ID",
+                "summary": "Get a faculty by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Student ID",
+                        "description": "Faculty ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -5591,7 +5549,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Student"
+                            "$ref": "#/definitions/domain.Faculty"
                         }
                     },
                     "400": {
@@ -5607,9 +5565,68 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/faculties/university/id/{university_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a list of faculties by university ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Faculties"
+                ],
+                "summary": "Get faculties by university ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Faculty"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/faculties/{id}": {
             "delete": {
-                "description": "Delete an existing student",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an existing faculty",
                 "consumes": [
                     "application/json"
                 ],
@@ -5617,13 +5634,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Faculties"
                 ],
-                "summary": "Delete a student",
+                "summary": "Delete a faculty",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Student ID",
+                        "description": "Faculty ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -5651,9 +5668,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/teachers": {
+        "/groups": {
             "get": {
-                "description": "Get a list of all teachers",
+                "description": "Get a list of all groups",
                 "consumes": [
                     "application/json"
                 ],
@@ -5661,16 +5678,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teachers"
+                    "Groups"
                 ],
-                "summary": "Get all teachers",
+                "summary": "Get all groups",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.TeacherInfo"
+                                "$ref": "#/definitions/domain.GroupInfo"
                             }
                         }
                     },
@@ -5683,7 +5700,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update an existing teacher",
+                "description": "Update an existing group",
                 "consumes": [
                     "application/json"
                 ],
@@ -5691,17 +5708,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teachers"
+                    "Groups"
                 ],
-                "summary": "Update a teacher",
+                "summary": "Update a group",
                 "parameters": [
                     {
-                        "description": "Teacher info",
-                        "name": "teacher",
+                        "description": "Group info",
+                        "name": "group",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PutTeacherRequest"
+                            "$ref": "#/definitions/handler.PutGroupRequest"
                         }
                     }
                 ],
@@ -5727,7 +5744,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new teacher",
+                "description": "Create a new group",
                 "consumes": [
                     "application/json"
                 ],
@@ -5735,17 +5752,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teachers"
+                    "Groups"
                 ],
-                "summary": "Create a teacher",
+                "summary": "Create a group",
                 "parameters": [
                     {
-                        "description": "Teacher info",
-                        "name": "teacher",
+                        "description": "Group info",
+                        "name": "group",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateTeacherRequest"
+                            "$ref": "#/definitions/handler.CreateGroupRequest"
                         }
                     }
                 ],
@@ -5771,7 +5788,7 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "Partially update an existing teacher",
+                "description": "Partially update an existing group",
                 "consumes": [
                     "application/json"
                 ],
@@ -5779,17 +5796,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teachers"
+                    "Groups"
                 ],
-                "summary": "Partially update a teacher",
+                "summary": "Partially update a group",
                 "parameters": [
                     {
-                        "description": "Teacher info",
-                        "name": "teacher",
+                        "description": "Group info",
+                        "name": "group",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PatchTeacherRequest"
+                            "$ref": "#/definitions/handler.PatchGroupRequest"
                         }
                     }
                 ],
@@ -5815,9 +5832,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/teachers/departament/{departament_id}": {
+        "/groups/name/{name}": {
             "get": {
-                "description": "Get a list of teachers by departament ID",
+                "description": "Get a group by its name",
                 "consumes": [
                     "application/json"
                 ],
@@ -5825,14 +5842,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teachers"
+                    "Groups"
                 ],
-                "summary": "Get teachers by departament ID",
+                "summary": "Get a group by name",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Departament ID",
-                        "name": "departament_id",
+                        "type": "string",
+                        "description": "Group Name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
@@ -5841,10 +5858,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.TeacherInfo"
-                            }
+                            "$ref": "#/definitions/domain.GroupInfo"
                         }
                     },
                     "400": {
@@ -5862,9 +5876,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/teachers/email/{email}": {
+        "/groups/profile/{profile_id}": {
             "get": {
-                "description": "Get a teacher by its email",
+                "description": "Get a list of groups by profile ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -5872,14 +5886,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teachers"
+                    "Groups"
                 ],
-                "summary": "Get a teacher by email",
+                "summary": "Get groups by profile ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Teacher Email",
-                        "name": "email",
+                        "type": "integer",
+                        "description": "Profile ID",
+                        "name": "profile_id",
                         "in": "path",
                         "required": true
                     }
@@ -5888,7 +5902,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.TeacherInfo"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.GroupInfo"
+                            }
                         }
                     },
                     "400": {
@@ -5906,9 +5923,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/teachers/{id}": {
+        "/groups/{id}": {
             "get": {
-                "description": "Get a teacher by its ID",
+                "description": "Get a group by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -5916,13 +5933,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teachers"
+                    "Groups"
                 ],
-                "summary": "Get a teacher by ID",
+                "summary": "Get a group by ID",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Teacher ID",
+                        "type": "string",
+                        "description": "Group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -5932,7 +5949,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.TeacherInfo"
+                            "$ref": "#/definitions/domain.GroupInfo"
                         }
                     },
                     "400": {
@@ -5950,7 +5967,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete an existing teacher",
+                "description": "Delete an existing group",
                 "consumes": [
                     "application/json"
                 ],
@@ -5958,13 +5975,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teachers"
+                    "Groups"
                 ],
-                "summary": "Delete a teacher",
+                "summary": "Delete a group",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Teacher ID",
+                        "type": "string",
+                        "description": "Group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -5983,6 +6000,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5991,325 +6014,4244 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.Attendance": {
-            "type": "object",
-            "properties": {
-                "attendance_id": {
-                    "type": "integer"
-                },
-                "created": {
-                    "type": "string"
-                },
-                "late_arrival": {
-                    "type": "boolean"
-                },
-                "presence": {
-                    "type": "boolean"
-                },
-                "reason": {
-                    "type": "string"
-                },
-                "respectfulness": {
-                    "type": "boolean"
-                },
-                "schedule_id": {
-                    "type": "integer"
-                },
-                "student_id": {
-                    "type": "integer"
-                }
-            }
         },
-        "domain.Classroom": {
+        "/headmans/gradebook": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the marks, the control points and the final results of the current student or headman",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gradebook"
+                ],
+                "summary": "Get my gradebook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Semester, all semesters by default",
+                        "name": "semester",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Gradebook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/headmans/gradebook/admission/discipline/{discipline_id}/semester/{semester}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Check the admission of the current student or headman to the credit or the exam of a discipline",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gradebook"
+                ],
+                "summary": "Check my admission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Discipline ID",
+                        "name": "discipline_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester",
+                        "name": "semester",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Admission"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/headmen": {
+            "get": {
+                "description": "Get a list of all headmen",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Headmen"
+                ],
+                "summary": "Get all headmen",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.HeadmanInfo"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing headman",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Headmen"
+                ],
+                "summary": "Update a headman",
+                "parameters": [
+                    {
+                        "description": "Headman info",
+                        "name": "headman",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PutHeadmanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new headman",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Headmen"
+                ],
+                "summary": "Create a headman",
+                "parameters": [
+                    {
+                        "description": "Headman info",
+                        "name": "headman",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateHeadmanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update an existing headman",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Headmen"
+                ],
+                "summary": "Partially update a headman",
+                "parameters": [
+                    {
+                        "description": "Headman info",
+                        "name": "headman",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PatchHeadmanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/headmen/group/{group_id}": {
+            "get": {
+                "description": "Get all terms of the headmen and the deputy headmen of a group",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Headmen"
+                ],
+                "summary": "Get the headman terms of a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.HeadmanInfo"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/headmen/{id}": {
+            "get": {
+                "description": "Get a headman by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Headmen"
+                ],
+                "summary": "Get a headman by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Headman ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.HeadmanInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an existing headman",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Headmen"
+                ],
+                "summary": "Delete a headman",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Headman ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the account of the authorized user with group and teacher data",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Get the current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.UserInfo"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/lessons/current": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the lesson going on right now for the teacher or for the group of the student",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LessonSlots"
+                ],
+                "summary": "Get the current lesson",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SlotLesson"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/lessons/next": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the nearest upcoming lesson for the teacher or for the group of the student",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LessonSlots"
+                ],
+                "summary": "Get the next lesson",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SlotLesson"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/password": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change the password of the authorized user, previously issued tokens are revoked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Change the own password",
+                "parameters": [
+                    {
+                        "description": "Old and new passwords",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Tokens"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profiles": {
+            "get": {
+                "description": "Get a list of all profiles",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profiles"
+                ],
+                "summary": "Get all profiles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ProfileInfo"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profiles"
+                ],
+                "summary": "Update a profile",
+                "parameters": [
+                    {
+                        "description": "Profile info",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PutProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profiles"
+                ],
+                "summary": "Create a profile",
+                "parameters": [
+                    {
+                        "description": "Profile info",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update an existing profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profiles"
+                ],
+                "summary": "Partially update a profile",
+                "parameters": [
+                    {
+                        "description": "Profile info",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PatchProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profiles/education_type/{education_type_id}": {
+            "get": {
+                "description": "Get a list of profiles by education type ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profiles"
+                ],
+                "summary": "Get profiles by education type ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Education Type ID",
+                        "name": "education_type_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ProfileInfo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profiles/name/{name}": {
+            "get": {
+                "description": "Get a profile by its name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profiles"
+                ],
+                "summary": "Get a profile by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProfileInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profiles/specialty/{specialty_code}": {
+            "get": {
+                "description": "Get a list of profiles by specialty code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profiles"
+                ],
+                "summary": "Get profiles by specialty code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Specialty Code",
+                        "name": "specialty_code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ProfileInfo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profiles/{id}": {
+            "get": {
+                "description": "Get a profile by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profiles"
+                ],
+                "summary": "Get a profile by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProfileInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an existing profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profiles"
+                ],
+                "summary": "Delete a profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules": {
+            "get": {
+                "description": "Get a list of all schedules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get all schedules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Schedule"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Update a schedule",
+                "parameters": [
+                    {
+                        "description": "Schedule info",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PutScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Create a schedule",
+                "parameters": [
+                    {
+                        "description": "Schedule info",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update an existing schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Partially update a schedule",
+                "parameters": [
+                    {
+                        "description": "Schedule info",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PatchScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/group/{group_id}": {
+            "get": {
+                "description": "Get schedules by group ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get schedules by group ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Schedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/group/{group_id}/actual": {
+            "get": {
+                "description": "Get actual schedules by group ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get actual schedules by group ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Schedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/group/{group_id}/week/{week_type}": {
+            "get": {
+                "description": "Get schedules by group ID and week type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get schedules by group ID and week type",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Week Type",
+                        "name": "week_type",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Schedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/group/{group_id}/week/{week_type}/day/{day_of_week}": {
+            "get": {
+                "description": "Get schedules by group ID, week type, and day of the week",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get schedules by group ID, week type, and day of the week",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Week Type",
+                        "name": "week_type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Day of the Week",
+                        "name": "day_of_week",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Schedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/teacher/{teacher_id}": {
+            "get": {
+                "description": "Get schedules by teacher ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get schedules by teacher ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "teacher_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Schedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/teacher/{teacher_id}/actual": {
+            "get": {
+                "description": "Get actual schedules by teacher ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get actual schedules by teacher ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "teacher_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Schedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/teacher/{teacher_id}/week/{week_type}": {
+            "get": {
+                "description": "Get schedules by teacher ID and week type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get schedules by teacher ID and week type",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "teacher_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Week Type",
+                        "name": "week_type",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Schedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/teacher/{teacher_id}/week/{week_type}/day/{day_of_week}": {
+            "get": {
+                "description": "Get schedules by teacher ID, week type, and day of the week",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get schedules by teacher ID, week type, and day of the week",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "teacher_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Week Type",
+                        "name": "week_type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Day of the Week",
+                        "name": "day_of_week",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Schedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/{id}": {
+            "get": {
+                "description": "Get a schedule by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get a schedule by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an existing schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Delete a schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/specialties": {
+            "get": {
+                "description": "Get a list of all specialties",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Specialties"
+                ],
+                "summary": "Get all specialties",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.SpecialtyInfo"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing specialty",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Specialties"
+                ],
+                "summary": "Update a specialty",
+                "parameters": [
+                    {
+                        "description": "Specialty info",
+                        "name": "specialty",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PutSpecialtyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new specialty",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Specialties"
+                ],
+                "summary": "Create a specialty",
+                "parameters": [
+                    {
+                        "description": "Specialty info",
+                        "name": "specialty",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateSpecialtyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update an existing specialty",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Specialties"
+                ],
+                "summary": "Partially update a specialty",
+                "parameters": [
+                    {
+                        "description": "Specialty info",
+                        "name": "specialty",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PatchSpecialtyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/specialties/departament/{departament_id}": {
+            "get": {
+                "description": "Get a list of specialties by departament ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Specialties"
+                ],
+                "summary": "Get specialties by departament ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Departament ID",
+                        "name": "departament_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.SpecialtyInfo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/specialties/name/{name}": {
+            "get": {
+                "description": "Get a specialty by its name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Specialties"
+                ],
+                "summary": "Get a specialty by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Specialty Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SpecialtyInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/specialties/{code}": {
+            "get": {
+                "description": "Get a specialty by its code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Specialties"
+                ],
+                "summary": "Get a specialty by code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Specialty Code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SpecialtyInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an existing specialty",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Specialties"
+                ],
+                "summary": "Delete a specialty",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Specialty Code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students": {
+            "get": {
+                "description": "Get a list of all students",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get all students",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Student"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Update a student",
+                "parameters": [
+                    {
+                        "description": "Student info",
+                        "name": "student",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PutStudentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new student, with a username and a password the user account of the student is created in the same transaction",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Create a student",
+                "parameters": [
+                    {
+                        "description": "Student info",
+                        "name": "student",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateStudentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update an existing student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Partially update a student",
+                "parameters": [
+                    {
+                        "description": "Student info",
+                        "name": "student",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PatchStudentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/gradebook": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the marks, the control points and the final results of the current student or headman",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gradebook"
+                ],
+                "summary": "Get my gradebook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Semester, all semesters by default",
+                        "name": "semester",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Gradebook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/gradebook/admission/discipline/{discipline_id}/semester/{semester}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Check the admission of the current student or headman to the credit or the exam of a discipline",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gradebook"
+                ],
+                "summary": "Check my admission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Discipline ID",
+                        "name": "discipline_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester",
+                        "name": "semester",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Admission"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/group/{group_id}": {
+            "get": {
+                "description": "Get a list of students by group ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get students by group ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Student"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/schedules/date/{date}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the lessons of the group of the student or the headman on a date with the exceptions applied",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get the timetable of the own group on a date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date (2006-01-02)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Lesson"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}": {
+            "get": {
+                "description": "Get a student by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get a student by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Student"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an existing student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Delete a student",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers": {
+            "get": {
+                "description": "Get a list of all teachers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Get all teachers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.TeacherInfo"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing teacher",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Update a teacher",
+                "parameters": [
+                    {
+                        "description": "Teacher info",
+                        "name": "teacher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PutTeacherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new teacher",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Create a teacher",
+                "parameters": [
+                    {
+                        "description": "Teacher info",
+                        "name": "teacher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateTeacherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update an existing teacher",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Partially update a teacher",
+                "parameters": [
+                    {
+                        "description": "Teacher info",
+                        "name": "teacher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PatchTeacherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/control_points": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add a test or another control work of a discipline the teacher gives to the group",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gradebook"
+                ],
+                "summary": "Create a control point",
+                "parameters": [
+                    {
+                        "description": "Control point info",
+                        "name": "point",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ControlPointRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ControlPoint"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/control_points/group/{group_id}/discipline/{discipline_id}/semester/{semester}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the control points of a discipline the teacher gives to the group in the semester",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gradebook"
+                ],
+                "summary": "Get the control points of a discipline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Discipline ID",
+                        "name": "discipline_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester",
+                        "name": "semester",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ControlPoint"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/control_points/results": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Put the score of a student for a control point, a new score replaces the previous one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gradebook"
+                ],
+                "summary": "Put a control point score",
+                "parameters": [
+                    {
+                        "description": "Score info",
+                        "name": "result",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ControlPointResultRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/control_points/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a control point with its scores",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gradebook"
+                ],
+                "summary": "Delete a control point",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Control point ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/departament/{departament_id}": {
+            "get": {
+                "description": "Get a list of teachers by departament ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Get teachers by departament ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Departament ID",
+                        "name": "departament_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.TeacherInfo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/email/{email}": {
+            "get": {
+                "description": "Get a teacher by its email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Get a teacher by email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Teacher Email",
+                        "name": "email",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TeacherInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/final_results": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Put the final result of a student, a credit is graded \"зачтено\"/\"не зачтено\", an exam from \"отлично\" to \"неудовлетворительно\"",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gradebook"
+                ],
+                "summary": "Put a credit or an exam grade",
+                "parameters": [
+                    {
+                        "description": "Final result info",
+                        "name": "result",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.FinalResultRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.FinalResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/marks": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Put the mark of a student for a lesson the teacher gives on the date, a new mark replaces the previous one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gradebook"
+                ],
+                "summary": "Put a mark",
+                "parameters": [
+                    {
+                        "description": "Mark info",
+                        "name": "mark",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.MarkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Mark"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/marks/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a mark of a discipline the teacher gives to the group",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gradebook"
+                ],
+                "summary": "Delete a mark",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mark ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/schedules/date/{date}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the lessons of the teacher on a date including substitutions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get the own timetable on a date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date (2006-01-02)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Lesson"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/workload": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the planned and delivered hours of the current teacher",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workload"
+                ],
+                "summary": "Get my workload",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Semester, all semesters by default",
+                        "name": "semester",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json, csv or xlsx",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TeacherWorkload"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/{id}": {
+            "get": {
+                "description": "Get a teacher by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Get a teacher by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TeacherInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an existing teacher",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Delete a teacher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.Admission": {
+            "type": "object",
+            "properties": {
+                "admitted": {
+                    "type": "boolean"
+                },
+                "attendance_percentage": {
+                    "type": "number"
+                },
+                "average_mark": {
+                    "type": "number"
+                },
+                "discipline_id": {
+                    "type": "integer"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "semester": {
+                    "type": "integer"
+                },
+                "student_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Attendance": {
+            "type": "object",
+            "properties": {
+                "attendance_id": {
+                    "type": "integer"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "late_arrival": {
+                    "type": "boolean"
+                },
+                "presence": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "respectfulness": {
+                    "type": "boolean"
+                },
+                "schedule_id": {
+                    "type": "integer"
+                },
+                "student_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.CalendarPeriod": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "period_id": {
+                    "type": "integer"
+                },
+                "period_kind": {
+                    "type": "string"
+                },
+                "semester": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "university_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Classroom": {
+            "type": "object",
+            "properties": {
+                "building": {
+                    "type": "string"
+                },
+                "capacity": {
+                    "type": "integer"
+                },
+                "classroom_id": {
+                    "type": "integer"
+                },
+                "classroom_name": {
+                    "type": "string"
+                },
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "floor": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ControlPoint": {
+            "type": "object",
+            "properties": {
+                "control_point_id": {
+                    "type": "integer"
+                },
+                "control_point_name": {
+                    "type": "string"
+                },
+                "discipline_id": {
+                    "type": "integer"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "max_score": {
+                    "type": "integer"
+                },
+                "semester": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ControlPointScore": {
+            "type": "object",
+            "properties": {
+                "control_point": {
+                    "$ref": "#/definitions/domain.ControlPoint"
+                },
+                "score": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.CurriculumHours": {
+            "type": "object",
+            "properties": {
+                "discipline_id": {
+                    "type": "integer"
+                },
+                "discipline_name": {
+                    "type": "string"
+                },
+                "discipline_type_id": {
+                    "type": "integer"
+                },
+                "discipline_type_name": {
+                    "type": "string"
+                },
+                "held_hours": {
+                    "type": "integer"
+                },
+                "planned_hours": {
+                    "type": "integer"
+                },
+                "scheduled_hours": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.CurriculumItem": {
+            "type": "object",
+            "properties": {
+                "curriculum_id": {
+                    "type": "integer"
+                },
+                "discipline_id": {
+                    "type": "integer"
+                },
+                "discipline_type_id": {
+                    "type": "integer"
+                },
+                "planned_hours": {
+                    "type": "integer"
+                },
+                "profile_id": {
+                    "type": "integer"
+                },
+                "semester": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.CurriculumItemInfo": {
+            "type": "object",
+            "properties": {
+                "curriculum_item": {
+                    "$ref": "#/definitions/domain.CurriculumItem"
+                },
+                "curriculum_item_sub": {
+                    "$ref": "#/definitions/domain.CurriculumItemSub"
+                }
+            }
+        },
+        "domain.CurriculumItemSub": {
+            "type": "object",
+            "properties": {
+                "discipline_name": {
+                    "type": "string"
+                },
+                "discipline_type_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CurriculumReport": {
+            "type": "object",
+            "properties": {
+                "group_id": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CurriculumHours"
+                    }
+                },
+                "semester": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Departament": {
+            "type": "object",
+            "properties": {
+                "departament_email": {
+                    "type": "string"
+                },
+                "departament_id": {
+                    "type": "integer"
+                },
+                "departament_name": {
+                    "type": "string"
+                },
+                "faculty_id": {
+                    "type": "integer"
+                },
+                "head_first_name": {
+                    "type": "string"
+                },
+                "head_last_name": {
+                    "type": "string"
+                },
+                "head_middle_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DepartamentInfo": {
+            "type": "object",
+            "properties": {
+                "departament": {
+                    "$ref": "#/definitions/domain.Departament"
+                },
+                "departament_sub": {
+                    "$ref": "#/definitions/domain.DepartamentSub"
+                }
+            }
+        },
+        "domain.DepartamentSub": {
+            "type": "object",
+            "properties": {
+                "faculty_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DepartamentWorkload": {
+            "type": "object",
+            "properties": {
+                "delivered_hours": {
+                    "type": "integer"
+                },
+                "departament_id": {
+                    "type": "integer"
+                },
+                "planned_hours": {
+                    "type": "integer"
+                },
+                "teachers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TeacherWorkload"
+                    }
+                }
+            }
+        },
+        "domain.Discipline": {
+            "type": "object",
+            "properties": {
+                "departament_id": {
+                    "type": "integer"
+                },
+                "discipline_id": {
+                    "type": "integer"
+                },
+                "discipline_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DisciplineGrades": {
+            "type": "object",
+            "properties": {
+                "average_mark": {
+                    "type": "number"
+                },
+                "control_points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ControlPointScore"
+                    }
+                },
+                "discipline_id": {
+                    "type": "integer"
+                },
+                "discipline_name": {
+                    "type": "string"
+                },
+                "final_results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FinalResult"
+                    }
+                },
+                "marks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Mark"
+                    }
+                },
+                "semester": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.DisciplineInfo": {
+            "type": "object",
+            "properties": {
+                "discipline": {
+                    "$ref": "#/definitions/domain.Discipline"
+                },
+                "discipline_sub": {
+                    "$ref": "#/definitions/domain.DisciplineSub"
+                }
+            }
+        },
+        "domain.DisciplineSub": {
+            "type": "object",
+            "properties": {
+                "departament_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DisciplineType": {
+            "type": "object",
+            "properties": {
+                "discipline_type_id": {
+                    "type": "integer"
+                },
+                "discipline_type_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EducationLevel": {
+            "type": "object",
+            "properties": {
+                "education_level_id": {
+                    "type": "integer"
+                },
+                "education_level_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EducationType": {
+            "type": "object",
+            "properties": {
+                "education_type_id": {
+                    "type": "integer"
+                },
+                "education_type_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Faculty": {
+            "type": "object",
+            "properties": {
+                "faculty_email": {
+                    "type": "string"
+                },
+                "faculty_id": {
+                    "type": "integer"
+                },
+                "faculty_name": {
+                    "type": "string"
+                },
+                "head_first_name": {
+                    "type": "string"
+                },
+                "head_last_name": {
+                    "type": "string"
+                },
+                "head_middle_name": {
+                    "type": "string"
+                },
+                "university_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.FinalResult": {
+            "type": "object",
+            "properties": {
+                "control_type": {
+                    "type": "string"
+                },
+                "discipline_id": {
+                    "type": "integer"
+                },
+                "final_result_id": {
+                    "type": "integer"
+                },
+                "grade": {
+                    "type": "string"
+                },
+                "graded_on": {
+                    "type": "string"
+                },
+                "semester": {
+                    "type": "integer"
+                },
+                "student_id": {
+                    "type": "integer"
+                },
+                "teacher_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Gradebook": {
             "type": "object",
             "properties": {
-                "classroom_id": {
+                "disciplines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DisciplineGrades"
+                    }
+                },
+                "semester": {
                     "type": "integer"
                 },
-                "classroom_name": {
-                    "type": "string"
+                "student_id": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.Departament": {
+        "domain.Group": {
             "type": "object",
             "properties": {
-                "departament_email": {
+                "group_id": {
                     "type": "string"
                 },
-                "departament_id": {
+                "profile_id": {
                     "type": "integer"
+                }
+            }
+        },
+        "domain.GroupInfo": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "$ref": "#/definitions/domain.Group"
                 },
-                "departament_name": {
+                "group_sub": {
+                    "$ref": "#/definitions/domain.GroupSub"
+                }
+            }
+        },
+        "domain.GroupMembership": {
+            "type": "object",
+            "properties": {
+                "group_id": {
                     "type": "string"
                 },
-                "faculty_id": {
+                "history_id": {
                     "type": "integer"
                 },
-                "head_first_name": {
+                "reason": {
                     "type": "string"
                 },
-                "head_last_name": {
+                "student_id": {
+                    "type": "integer"
+                },
+                "valid_from": {
                     "type": "string"
                 },
-                "head_middle_name": {
+                "valid_to": {
                     "type": "string"
                 }
             }
         },
-        "domain.DepartamentInfo": {
+        "domain.GroupPromotion": {
             "type": "object",
             "properties": {
-                "departament": {
-                    "$ref": "#/definitions/domain.Departament"
+                "from_group_id": {
+                    "type": "string"
                 },
-                "departament_sub": {
-                    "$ref": "#/definitions/domain.DepartamentSub"
+                "students": {
+                    "type": "integer"
+                },
+                "to_group_id": {
+                    "type": "string"
                 }
             }
         },
-        "domain.DepartamentSub": {
+        "domain.GroupSub": {
             "type": "object",
             "properties": {
-                "faculty_name": {
+                "profile_name": {
                     "type": "string"
                 }
             }
         },
-        "domain.Discipline": {
+        "domain.Headman": {
             "type": "object",
             "properties": {
-                "departament_id": {
+                "group_id": {
+                    "type": "string"
+                },
+                "headman_id": {
                     "type": "integer"
                 },
-                "discipline_id": {
+                "is_deputy": {
+                    "type": "boolean"
+                },
+                "student_id": {
                     "type": "integer"
                 },
-                "discipline_name": {
+                "term_end": {
+                    "type": "string"
+                },
+                "term_start": {
                     "type": "string"
                 }
             }
         },
-        "domain.DisciplineInfo": {
+        "domain.HeadmanInfo": {
             "type": "object",
             "properties": {
-                "discipline": {
-                    "$ref": "#/definitions/domain.Discipline"
+                "headman": {
+                    "$ref": "#/definitions/domain.Headman"
                 },
-                "discipline_sub": {
-                    "$ref": "#/definitions/domain.DisciplineSub"
+                "headman_sub": {
+                    "$ref": "#/definitions/domain.HeadmanSub"
                 }
             }
         },
-        "domain.DisciplineSub": {
+        "domain.HeadmanSub": {
             "type": "object",
             "properties": {
-                "departament_name": {
+                "group_name": {
                     "type": "string"
+                },
+                "student_full_name": {
+                    "$ref": "#/definitions/domain.StudentFullName"
                 }
             }
         },
-        "domain.DisciplineType": {
+        "domain.ImportError": {
             "type": "object",
             "properties": {
-                "discipline_type_id": {
-                    "type": "integer"
+                "field": {
+                    "type": "string"
                 },
-                "discipline_type_name": {
+                "message": {
                     "type": "string"
+                },
+                "row": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.EducationLevel": {
+        "domain.Lesson": {
             "type": "object",
             "properties": {
-                "education_level_id": {
-                    "type": "integer"
-                },
-                "education_level_name": {
+                "date": {
                     "type": "string"
+                },
+                "exception": {
+                    "$ref": "#/definitions/domain.ScheduleExceptionInfo"
+                },
+                "is_cancelled": {
+                    "type": "boolean"
+                },
+                "schedule_info": {
+                    "$ref": "#/definitions/domain.ScheduleInfo"
                 }
             }
         },
-        "domain.EducationType": {
+        "domain.LessonSlot": {
             "type": "object",
             "properties": {
-                "education_type_id": {
+                "break_minutes": {
                     "type": "integer"
                 },
-                "education_type_name": {
+                "end_time": {
+                    "type": "string"
+                },
+                "slot_id": {
+                    "type": "integer"
+                },
+                "slot_number": {
+                    "type": "integer"
+                },
+                "start_time": {
                     "type": "string"
+                },
+                "university_id": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.Faculty": {
+        "domain.Mark": {
             "type": "object",
             "properties": {
-                "faculty_email": {
+                "comment": {
                     "type": "string"
                 },
-                "faculty_id": {
-                    "type": "integer"
-                },
-                "faculty_name": {
+                "lesson_date": {
                     "type": "string"
                 },
-                "head_first_name": {
-                    "type": "string"
+                "mark": {
+                    "type": "integer"
                 },
-                "head_last_name": {
-                    "type": "string"
+                "mark_id": {
+                    "type": "integer"
                 },
-                "head_middle_name": {
-                    "type": "string"
+                "schedule_id": {
+                    "type": "integer"
                 },
-                "university_id": {
+                "student_id": {
+                    "type": "integer"
+                },
+                "teacher_id": {
                     "type": "integer"
                 }
             }
         },
-        "domain.Group": {
+        "domain.Profile": {
             "type": "object",
             "properties": {
-                "group_id": {
-                    "type": "string"
+                "education_type_id": {
+                    "type": "integer"
                 },
                 "profile_id": {
                     "type": "integer"
+                },
+                "profile_name": {
+                    "type": "string"
+                },
+                "specialty_code": {
+                    "type": "string"
                 }
             }
         },
-        "domain.GroupInfo": {
+        "domain.ProfileInfo": {
             "type": "object",
             "properties": {
-                "group": {
-                    "$ref": "#/definitions/domain.Group"
+                "profile": {
+                    "$ref": "#/definitions/domain.Profile"
                 },
-                "group_sub": {
-                    "$ref": "#/definitions/domain.GroupSub"
+                "profile_sub": {
+                    "$ref": "#/definitions/domain.ProfileSub"
                 }
             }
         },
-        "domain.GroupSub": {
+        "domain.ProfileSub": {
             "type": "object",
             "properties": {
-                "profile_name": {
+                "education_type_name": {
                     "type": "string"
                 }
             }
         },
-        "domain.Headman": {
+        "domain.PromotionResult": {
             "type": "object",
             "properties": {
-                "group_id": {
+                "created_groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "date": {
                     "type": "string"
                 },
-                "headman_id": {
-                    "type": "integer"
+                "dry_run": {
+                    "type": "boolean"
                 },
-                "student_id": {
-                    "type": "integer"
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.GroupPromotion"
+                    }
                 }
             }
         },
-        "domain.HeadmanInfo": {
+        "domain.RolloverDiff": {
             "type": "object",
             "properties": {
-                "headman": {
-                    "$ref": "#/definitions/domain.Headman"
+                "archived": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ScheduleInfo"
+                    }
                 },
-                "headman_sub": {
-                    "$ref": "#/definitions/domain.HeadmanSub"
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ScheduleClone"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.Schedule": {
+            "type": "object",
+            "properties": {
+                "begin_studies": {
+                    "type": "string"
+                },
+                "classroom_id": {
+                    "type": "integer"
+                },
+                "day_of_week": {
+                    "type": "string"
+                },
+                "discipline_id": {
+                    "type": "integer"
+                },
+                "discipline_type_id": {
+                    "type": "integer"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "is_actual": {
+                    "type": "boolean"
+                },
+                "schedule_id": {
+                    "type": "integer"
+                },
+                "semester": {
+                    "type": "integer"
+                },
+                "slot_id": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "subgroup_id": {
+                    "type": "integer"
+                },
+                "teacher_id": {
+                    "type": "integer"
+                },
+                "week_type": {
+                    "type": "string"
                 }
             }
         },
-        "domain.HeadmanSub": {
+        "domain.ScheduleClone": {
             "type": "object",
             "properties": {
-                "group_name": {
-                    "type": "string"
+                "classroom_changed": {
+                    "type": "boolean"
                 },
-                "student_full_name": {
-                    "$ref": "#/definitions/domain.StudentFullName"
+                "schedule": {
+                    "$ref": "#/definitions/domain.Schedule"
+                },
+                "source_schedule_id": {
+                    "type": "integer"
+                },
+                "teacher_changed": {
+                    "type": "boolean"
                 }
             }
         },
-        "domain.Profile": {
+        "domain.ScheduleException": {
             "type": "object",
             "properties": {
-                "education_type_id": {
+                "classroom_id": {
                     "type": "integer"
                 },
-                "profile_id": {
+                "created_at": {
+                    "type": "string"
+                },
+                "exception_id": {
                     "type": "integer"
                 },
-                "profile_name": {
+                "is_cancelled": {
+                    "type": "boolean"
+                },
+                "lesson_date": {
                     "type": "string"
                 },
-                "specialty_code": {
+                "moved_to_date": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "schedule_id": {
+                    "type": "integer"
+                },
+                "start_time": {
                     "type": "string"
+                },
+                "teacher_id": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.ProfileInfo": {
+        "domain.ScheduleExceptionInfo": {
             "type": "object",
             "properties": {
-                "profile": {
-                    "$ref": "#/definitions/domain.Profile"
+                "schedule_exception": {
+                    "$ref": "#/definitions/domain.ScheduleException"
                 },
-                "profile_sub": {
-                    "$ref": "#/definitions/domain.ProfileSub"
+                "schedule_exception_sub": {
+                    "$ref": "#/definitions/domain.ScheduleExceptionSub"
                 }
             }
         },
-        "domain.ProfileSub": {
+        "domain.ScheduleExceptionSub": {
             "type": "object",
             "properties": {
-                "education_type_name": {
+                "classroom_name": {
                     "type": "string"
+                },
+                "teacher_full_name": {
+                    "$ref": "#/definitions/domain.TeacherFullName"
                 }
             }
         },
-        "domain.Schedule": {
+        "domain.ScheduleImportResult": {
             "type": "object",
             "properties": {
-                "begin_studies": {
-                    "type": "string"
-                },
-                "classroom_id": {
+                "created": {
                     "type": "integer"
                 },
-                "day_of_week": {
-                    "type": "string"
+                "dry_run": {
+                    "type": "boolean"
                 },
-                "discipline_id": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ImportError"
+                    }
+                },
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Schedule"
+                    }
+                },
+                "total": {
                     "type": "integer"
                 },
-                "discipline_type_id": {
+                "valid": {
                     "type": "integer"
+                }
+            }
+        },
+        "domain.ScheduleInfo": {
+            "type": "object",
+            "properties": {
+                "schedule": {
+                    "$ref": "#/definitions/domain.Schedule"
                 },
-                "group_id": {
+                "schedule_sub": {
+                    "$ref": "#/definitions/domain.ScheduleSub"
+                }
+            }
+        },
+        "domain.ScheduleSub": {
+            "type": "object",
+            "properties": {
+                "classroom_name": {
                     "type": "string"
                 },
-                "is_actual": {
-                    "type": "boolean"
-                },
-                "schedule_id": {
-                    "type": "integer"
+                "discipline_name": {
+                    "type": "string"
                 },
-                "semester": {
-                    "type": "integer"
+                "discipline_type_name": {
+                    "type": "string"
                 },
-                "start_time": {
+                "teacher_full_name": {
+                    "$ref": "#/definitions/domain.TeacherFullName"
+                }
+            }
+        },
+        "domain.SlotLesson": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
                     "type": "string"
                 },
-                "teacher_id": {
-                    "type": "integer"
+                "lesson": {
+                    "$ref": "#/definitions/domain.Lesson"
                 },
-                "week_type": {
+                "slot": {
+                    "$ref": "#/definitions/domain.LessonSlot"
+                },
+                "starts_at": {
                     "type": "string"
                 }
             }
@@ -6387,6 +10329,52 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.StudentImportResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "credentials_id": {
+                    "type": "string"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ImportError"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Subgroup": {
+            "type": "object",
+            "properties": {
+                "group_id": {
+                    "type": "string"
+                },
+                "student_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "subgroup_id": {
+                    "type": "integer"
+                },
+                "subgroup_name": {
+                    "type": "string"
+                }
+            }
+        },
         "domain.Teacher": {
             "type": "object",
             "properties": {
@@ -6443,6 +10431,32 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.TeacherWorkload": {
+            "type": "object",
+            "properties": {
+                "delivered_hours": {
+                    "type": "integer"
+                },
+                "departament_id": {
+                    "type": "integer"
+                },
+                "planned_hours": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WorkloadRow"
+                    }
+                },
+                "teacher_full_name": {
+                    "$ref": "#/definitions/domain.TeacherFullName"
+                },
+                "teacher_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "domain.University": {
             "type": "object",
             "properties": {
@@ -6503,14 +10517,158 @@ const docTemplate = `{
         "domain.UserSub": {
             "type": "object",
             "properties": {
-                "group_id": {
-                    "type": "string"
+                "group_id": {
+                    "type": "string"
+                },
+                "student_full_name": {
+                    "$ref": "#/definitions/domain.StudentFullName"
+                },
+                "teacher_full_name": {
+                    "$ref": "#/definitions/domain.TeacherFullName"
+                }
+            }
+        },
+        "domain.WorkloadRow": {
+            "type": "object",
+            "properties": {
+                "delivered_hours": {
+                    "type": "integer"
+                },
+                "discipline_id": {
+                    "type": "integer"
+                },
+                "discipline_name": {
+                    "type": "string"
+                },
+                "discipline_type_id": {
+                    "type": "integer"
+                },
+                "discipline_type_name": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "planned_hours": {
+                    "type": "integer"
+                },
+                "semester": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.CalendarPeriodRequest": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "period_kind",
+                "start_date",
+                "university_id"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "period_kind": {
+                    "type": "string",
+                    "enum": [
+                        "semester",
+                        "holiday",
+                        "exam_session",
+                        "practice"
+                    ]
+                },
+                "semester": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "university_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "handler.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "minLength": 8
+                },
+                "old_password": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "minLength": 8
+                }
+            }
+        },
+        "handler.ControlPointRequest": {
+            "type": "object",
+            "required": [
+                "control_point_name",
+                "discipline_id",
+                "group_id",
+                "max_score",
+                "semester"
+            ],
+            "properties": {
+                "control_point_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "discipline_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "max_score": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                },
+                "semester": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1
+                }
+            }
+        },
+        "handler.ControlPointResultRequest": {
+            "type": "object",
+            "required": [
+                "control_point_id",
+                "student_id"
+            ],
+            "properties": {
+                "control_point_id": {
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "student_full_name": {
-                    "$ref": "#/definitions/domain.StudentFullName"
+                "score": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "teacher_full_name": {
-                    "$ref": "#/definitions/domain.TeacherFullName"
+                "student_id": {
+                    "type": "integer",
+                    "minimum": 1
                 }
             }
         },
@@ -6551,8 +10709,29 @@ const docTemplate = `{
                 "classroom_name"
             ],
             "properties": {
+                "building": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "capacity": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 1
+                },
                 "classroom_name": {
                     "type": "string"
+                },
+                "features": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "floor": {
+                    "type": "integer",
+                    "maximum": 200,
+                    "minimum": -10
                 }
             }
         },
@@ -6702,8 +10881,17 @@ const docTemplate = `{
                 "group_id": {
                     "type": "string"
                 },
+                "is_deputy": {
+                    "type": "boolean"
+                },
                 "student_id": {
                     "type": "integer"
+                },
+                "term_end": {
+                    "type": "string"
+                },
+                "term_start": {
+                    "type": "string"
                 }
             }
         },
@@ -6737,7 +10925,7 @@ const docTemplate = `{
                 "group_id",
                 "is_actual",
                 "semester",
-                "start_time",
+                "slot_id",
                 "teacher_id",
                 "week_type"
             ],
@@ -6775,8 +10963,13 @@ const docTemplate = `{
                 "semester": {
                     "type": "integer"
                 },
-                "start_time": {
-                    "type": "string"
+                "slot_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "subgroup_id": {
+                    "type": "integer",
+                    "minimum": 1
                 },
                 "teacher_id": {
                     "type": "integer"
@@ -6833,6 +11026,16 @@ const docTemplate = `{
                 },
                 "middle_name": {
                     "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "minLength": 8
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "minLength": 8
                 }
             }
         },
@@ -6941,6 +11144,40 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.CurriculumItemRequest": {
+            "type": "object",
+            "required": [
+                "discipline_id",
+                "discipline_type_id",
+                "planned_hours",
+                "profile_id",
+                "semester"
+            ],
+            "properties": {
+                "discipline_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "discipline_type_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "planned_hours": {
+                    "type": "integer",
+                    "maximum": 2000,
+                    "minimum": 1
+                },
+                "profile_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "semester": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1
+                }
+            }
+        },
         "handler.ErrorResponse": {
             "type": "object",
             "properties": {
@@ -6949,6 +11186,131 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.FinalResultRequest": {
+            "type": "object",
+            "required": [
+                "control_type",
+                "discipline_id",
+                "grade",
+                "semester",
+                "student_id"
+            ],
+            "properties": {
+                "control_type": {
+                    "type": "string",
+                    "enum": [
+                        "зачёт",
+                        "экзамен"
+                    ]
+                },
+                "discipline_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "grade": {
+                    "type": "string"
+                },
+                "semester": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1
+                },
+                "student_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "handler.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "username"
+            ],
+            "properties": {
+                "username": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "minLength": 8
+                }
+            }
+        },
+        "handler.GroupPromotionRequest": {
+            "type": "object",
+            "required": [
+                "from_group_id",
+                "to_group_id"
+            ],
+            "properties": {
+                "from_group_id": {
+                    "type": "string"
+                },
+                "to_group_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.LessonSlotRequest": {
+            "type": "object",
+            "required": [
+                "end_time",
+                "slot_number",
+                "start_time",
+                "university_id"
+            ],
+            "properties": {
+                "break_minutes": {
+                    "type": "integer",
+                    "maximum": 240,
+                    "minimum": 0
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "slot_number": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "university_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "handler.MarkRequest": {
+            "type": "object",
+            "required": [
+                "lesson_date",
+                "mark",
+                "schedule_id",
+                "student_id"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "lesson_date": {
+                    "type": "string"
+                },
+                "mark": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 2
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "student_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
         "handler.PatchAttendanceRequest": {
             "type": "object",
             "required": [
@@ -6987,11 +11349,32 @@ const docTemplate = `{
                 "classroom_id"
             ],
             "properties": {
+                "building": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "capacity": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 1
+                },
                 "classroom_id": {
                     "type": "integer"
                 },
                 "classroom_name": {
                     "type": "string"
+                },
+                "features": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "floor": {
+                    "type": "integer",
+                    "maximum": 200,
+                    "minimum": -10
                 }
             }
         },
@@ -7153,6 +11536,12 @@ const docTemplate = `{
                 },
                 "student_id": {
                     "type": "integer"
+                },
+                "term_end": {
+                    "type": "string"
+                },
+                "term_start": {
+                    "type": "string"
                 }
             }
         },
@@ -7218,8 +11607,13 @@ const docTemplate = `{
                 "semester": {
                     "type": "integer"
                 },
-                "start_time": {
-                    "type": "string"
+                "slot_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "subgroup_id": {
+                    "type": "integer",
+                    "minimum": 1
                 },
                 "teacher_id": {
                     "type": "integer"
@@ -7381,6 +11775,25 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.PromoteGroupsRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "promotions"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "promotions": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/handler.GroupPromotionRequest"
+                    }
+                }
+            }
+        },
         "handler.PutAttendanceRequest": {
             "type": "object",
             "required": [
@@ -7421,23 +11834,127 @@ const docTemplate = `{
                 "attendances": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handler.PutAttendanceRequest"
+                        "$ref": "#/definitions/handler.PutAttendanceRequest"
+                    }
+                }
+            }
+        },
+        "handler.PutCalendarPeriodRequest": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "period_id",
+                "period_kind",
+                "start_date",
+                "university_id"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "period_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "period_kind": {
+                    "type": "string",
+                    "enum": [
+                        "semester",
+                        "holiday",
+                        "exam_session",
+                        "practice"
+                    ]
+                },
+                "semester": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "university_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "handler.PutClassroomRequest": {
+            "type": "object",
+            "required": [
+                "classroom_id",
+                "classroom_name"
+            ],
+            "properties": {
+                "building": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "capacity": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 1
+                },
+                "classroom_id": {
+                    "type": "integer"
+                },
+                "classroom_name": {
+                    "type": "string"
+                },
+                "features": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
                     }
+                },
+                "floor": {
+                    "type": "integer",
+                    "maximum": 200,
+                    "minimum": -10
                 }
             }
         },
-        "handler.PutClassroomRequest": {
+        "handler.PutCurriculumItemRequest": {
             "type": "object",
             "required": [
-                "classroom_id",
-                "classroom_name"
+                "curriculum_id",
+                "discipline_id",
+                "discipline_type_id",
+                "planned_hours",
+                "profile_id",
+                "semester"
             ],
             "properties": {
-                "classroom_id": {
-                    "type": "integer"
+                "curriculum_id": {
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "classroom_name": {
-                    "type": "string"
+                "discipline_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "discipline_type_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "planned_hours": {
+                    "type": "integer",
+                    "maximum": 2000,
+                    "minimum": 1
+                },
+                "profile_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "semester": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1
                 }
             }
         },
@@ -7607,7 +12124,8 @@ const docTemplate = `{
             "required": [
                 "group_id",
                 "headman_id",
-                "student_id"
+                "student_id",
+                "term_start"
             ],
             "properties": {
                 "group_id": {
@@ -7616,8 +12134,53 @@ const docTemplate = `{
                 "headman_id": {
                     "type": "integer"
                 },
+                "is_deputy": {
+                    "type": "boolean"
+                },
                 "student_id": {
                     "type": "integer"
+                },
+                "term_end": {
+                    "type": "string"
+                },
+                "term_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.PutLessonSlotRequest": {
+            "type": "object",
+            "required": [
+                "end_time",
+                "slot_id",
+                "slot_number",
+                "start_time",
+                "university_id"
+            ],
+            "properties": {
+                "break_minutes": {
+                    "type": "integer",
+                    "maximum": 240,
+                    "minimum": 0
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "slot_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "slot_number": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "university_id": {
+                    "type": "integer",
+                    "minimum": 1
                 }
             }
         },
@@ -7644,6 +12207,48 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.PutScheduleExceptionRequest": {
+            "type": "object",
+            "required": [
+                "exception_id",
+                "lesson_date",
+                "schedule_id"
+            ],
+            "properties": {
+                "classroom_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "exception_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "is_cancelled": {
+                    "type": "boolean"
+                },
+                "lesson_date": {
+                    "type": "string"
+                },
+                "moved_to_date": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "teacher_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
         "handler.PutScheduleRequest": {
             "type": "object",
             "required": [
@@ -7656,7 +12261,7 @@ const docTemplate = `{
                 "is_actual",
                 "schedule_id",
                 "semester",
-                "start_time",
+                "slot_id",
                 "teacher_id",
                 "week_type"
             ],
@@ -7697,8 +12302,13 @@ const docTemplate = `{
                 "semester": {
                     "type": "integer"
                 },
-                "start_time": {
-                    "type": "string"
+                "slot_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "subgroup_id": {
+                    "type": "integer",
+                    "minimum": 1
                 },
                 "teacher_id": {
                     "type": "integer"
@@ -7762,6 +12372,33 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.PutSubgroupRequest": {
+            "type": "object",
+            "required": [
+                "group_id",
+                "subgroup_id",
+                "subgroup_name"
+            ],
+            "properties": {
+                "group_id": {
+                    "type": "string"
+                },
+                "student_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "subgroup_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "subgroup_name": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
         "handler.PutTeacherRequest": {
             "type": "object",
             "required": [
@@ -7879,6 +12516,120 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "minLength": 8
+                },
+                "token": {
+                    "type": "string",
+                    "maxLength": 128
+                }
+            }
+        },
+        "handler.RolloverMapping": {
+            "type": "object",
+            "required": [
+                "from",
+                "to"
+            ],
+            "properties": {
+                "from": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "to": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "handler.RolloverRequest": {
+            "type": "object",
+            "required": [
+                "begin_studies",
+                "group_ids",
+                "next_semester",
+                "semester"
+            ],
+            "properties": {
+                "begin_studies": {
+                    "type": "string"
+                },
+                "classroom_map": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.RolloverMapping"
+                    }
+                },
+                "group_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "next_semester": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "semester": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "teacher_map": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.RolloverMapping"
+                    }
+                }
+            }
+        },
+        "handler.ScheduleExceptionRequest": {
+            "type": "object",
+            "required": [
+                "lesson_date",
+                "schedule_id"
+            ],
+            "properties": {
+                "classroom_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "is_cancelled": {
+                    "type": "boolean"
+                },
+                "lesson_date": {
+                    "type": "string"
+                },
+                "moved_to_date": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "teacher_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
         "handler.SignInUserRequest": {
             "type": "object",
             "required": [
@@ -7898,6 +12649,28 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.SubgroupRequest": {
+            "type": "object",
+            "required": [
+                "group_id",
+                "subgroup_name"
+            ],
+            "properties": {
+                "group_id": {
+                    "type": "string"
+                },
+                "student_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "subgroup_name": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
         "handler.SuccessResponse": {
             "type": "object",
             "properties": {
@@ -7906,6 +12679,41 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.TransferStudentRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "group_id",
+                "student_id"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "student_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "service.ResetToken": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
         "service.Tokens": {
             "type": "object",
             "properties": {
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// Kinds of the academic calendar periods, only semesters are teaching periods
+const (
+	PeriodSemester    = "semester"
+	PeriodHoliday     = "holiday"
+	PeriodExamSession = "exam_session"
+	PeriodPractice    = "practice"
+)
+
+// CalendarPeriod is a period of the academic calendar of a university,
+// EndDate is the last day of the period
+type CalendarPeriod struct {
+	PeriodID     int64     `json:"period_id"`
+	UniversityID int64     `json:"university_id"`
+	Kind         string    `json:"period_kind"`
+	Title        string    `json:"title"`
+	Semester     *int      `json:"semester"`
+	StartDate    time.Time `json:"start_date"`
+	EndDate      time.Time `json:"end_date"`
+}
+
+// Covers reports whether the period includes the date
+func (p CalendarPeriod) Covers(date time.Time) bool {
+	day := date.Format("2006-01-02")
+	return p.StartDate.Format("2006-01-02") <= day && day <= p.EndDate.Format("2006-01-02")
+}
@@ -1,6 +1,32 @@
 package domain
 
+import "time"
+
+// Features of the classroom equipment
+const (
+	FeatureProjector    = "projector"
+	FeatureComputerLab  = "computer_lab"
+	FeatureLabEquipment = "lab_equipment"
+)
+
+// Classroom is a room for lessons, zero capacity means the capacity is unknown
 type Classroom struct {
-	ClassroomID   int64  `json:"classroom_id"`
-	ClassroomName string `json:"classroom_name"`
+	ClassroomID   int64    `json:"classroom_id"`
+	ClassroomName string   `json:"classroom_name"`
+	Capacity      int      `json:"capacity"`
+	Building      string   `json:"building"`
+	Floor         *int     `json:"floor"`
+	Features      []string `json:"features"`
+}
+
+// FreeClassroomFilter selects the classrooms not used by any actual schedule in the slot,
+// GroupID raises the minimum capacity to the number of students of the group
+type FreeClassroomFilter struct {
+	Semester    int       `json:"semester"`
+	WeekType    string    `json:"week_type"`
+	DayOfWeek   string    `json:"day_of_week"`
+	StartTime   time.Time `json:"start_time"`
+	MinCapacity int       `json:"min_capacity"`
+	GroupID     string    `json:"group_id"`
+	Features    []string  `json:"features"`
 }
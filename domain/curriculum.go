@@ -0,0 +1,50 @@
+package domain
+
+// CurriculumItem is a line of the study plan of a profile: the hours of a discipline
+// type (lectures, practices, labs) of a discipline in a semester
+type CurriculumItem struct {
+	CurriculumID     int64 `json:"curriculum_id"`
+	ProfileID        int64 `json:"profile_id"`
+	Semester         int   `json:"semester"`
+	DisciplineID     int64 `json:"discipline_id"`
+	DisciplineTypeID int64 `json:"discipline_type_id"`
+	PlannedHours     int   `json:"planned_hours"`
+}
+
+type CurriculumItemInfo struct {
+	CurriculumItemSub CurriculumItemSub `json:"curriculum_item_sub"`
+	CurriculumItem    CurriculumItem    `json:"curriculum_item"`
+}
+
+type CurriculumItemSub struct {
+	DisciplineName     string `json:"discipline_name"`
+	DisciplineTypeName string `json:"discipline_type_name"`
+}
+
+// CurriculumHours compares the planned hours of a discipline type with the hours
+// the actual timetable gives in the semester and the hours already held
+type CurriculumHours struct {
+	DisciplineID       int64  `json:"discipline_id"`
+	DisciplineName     string `json:"discipline_name"`
+	DisciplineTypeID   int64  `json:"discipline_type_id"`
+	DisciplineTypeName string `json:"discipline_type_name"`
+	PlannedHours       int    `json:"planned_hours"`
+	ScheduledHours     int    `json:"scheduled_hours"`
+	HeldHours          int    `json:"held_hours"`
+}
+
+type CurriculumReport struct {
+	GroupID  string            `json:"group_id"`
+	Semester int               `json:"semester"`
+	Hours    []CurriculumHours `json:"hours"`
+}
+
+// HeldLessons is the number of lessons of a discipline type held by a group or one of its subgroups
+type HeldLessons struct {
+	DisciplineID       int64  `json:"discipline_id"`
+	DisciplineName     string `json:"discipline_name"`
+	DisciplineTypeID   int64  `json:"discipline_type_id"`
+	DisciplineTypeName string `json:"discipline_type_name"`
+	SubgroupID         *int64 `json:"subgroup_id"`
+	Lessons            int    `json:"lessons"`
+}
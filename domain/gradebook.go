@@ -0,0 +1,106 @@
+package domain
+
+import "time"
+
+// Control types of the exam session
+const (
+	ControlTypeCredit = "зачёт"
+	ControlTypeExam   = "экзамен"
+)
+
+// GradeScale lists the grades allowed for each control type
+var GradeScale = map[string][]string{
+	ControlTypeCredit: {"зачтено", "не зачтено"},
+	ControlTypeExam:   {"отлично", "хорошо", "удовлетворительно", "неудовлетворительно"},
+}
+
+// Mark is a mark (2-5) of a student for a lesson of a schedule
+type Mark struct {
+	MarkID     int64     `json:"mark_id"`
+	ScheduleID int64     `json:"schedule_id"`
+	StudentID  int64     `json:"student_id"`
+	LessonDate time.Time `json:"lesson_date"`
+	Mark       int       `json:"mark"`
+	Comment    *string   `json:"comment"`
+	TeacherID  *int64    `json:"teacher_id"`
+}
+
+type MarkInfo struct {
+	MarkSub MarkSub `json:"mark_sub"`
+	Mark    Mark    `json:"mark"`
+}
+
+type MarkSub struct {
+	DisciplineID int64 `json:"discipline_id"`
+	Semester     int   `json:"semester"`
+}
+
+// ControlPoint is a test, a colloquium or another control work of a discipline for a group in a semester
+type ControlPoint struct {
+	ControlPointID int64      `json:"control_point_id"`
+	GroupID        string     `json:"group_id"`
+	DisciplineID   int64      `json:"discipline_id"`
+	Semester       int        `json:"semester"`
+	Name           string     `json:"control_point_name"`
+	MaxScore       int        `json:"max_score"`
+	DueDate        *time.Time `json:"due_date"`
+}
+
+type ControlPointResult struct {
+	ControlPointID int64  `json:"control_point_id"`
+	StudentID      int64  `json:"student_id"`
+	Score          int    `json:"score"`
+	TeacherID      *int64 `json:"teacher_id"`
+}
+
+// FinalResult is the result of a student in a credit or an exam of a discipline in a semester
+type FinalResult struct {
+	FinalResultID int64     `json:"final_result_id"`
+	StudentID     int64     `json:"student_id"`
+	DisciplineID  int64     `json:"discipline_id"`
+	Semester      int       `json:"semester"`
+	ControlType   string    `json:"control_type"`
+	Grade         string    `json:"grade"`
+	TeacherID     *int64    `json:"teacher_id"`
+	GradedOn      time.Time `json:"graded_on"`
+}
+
+// ControlPointScore is a control point with the score of a student, the score is nil until graded
+type ControlPointScore struct {
+	ControlPoint ControlPoint `json:"control_point"`
+	Score        *int         `json:"score"`
+}
+
+// DisciplineGrades are the marks, the control points and the final results of a student in a discipline
+type DisciplineGrades struct {
+	DisciplineID   int64               `json:"discipline_id"`
+	DisciplineName string              `json:"discipline_name"`
+	Semester       int                 `json:"semester"`
+	Marks          []Mark              `json:"marks"`
+	AverageMark    float64             `json:"average_mark"`
+	ControlPoints  []ControlPointScore `json:"control_points"`
+	FinalResults   []FinalResult       `json:"final_results"`
+}
+
+type Gradebook struct {
+	StudentID   int64              `json:"student_id"`
+	Semester    int                `json:"semester"`
+	Disciplines []DisciplineGrades `json:"disciplines"`
+}
+
+// DisciplineAttendance is the number of lessons of a discipline a student attended out of the marked ones
+type DisciplineAttendance struct {
+	Visits int `json:"visits"`
+	Total  int `json:"total"`
+}
+
+// Admission tells whether a student is admitted to the credit or the exam of a discipline
+type Admission struct {
+	StudentID            int64    `json:"student_id"`
+	DisciplineID         int64    `json:"discipline_id"`
+	Semester             int      `json:"semester"`
+	AttendancePercentage float64  `json:"attendance_percentage"`
+	AverageMark          float64  `json:"average_mark"`
+	Admitted             bool     `json:"admitted"`
+	Reasons              []string `json:"reasons"`
+}
@@ -1,9 +1,36 @@
 package domain
 
+import "time"
+
+// Headman is a term of a student as the headman or the deputy headman of a group,
+// TermEnd is the last day of the term and nil for an open term
 type Headman struct {
-	HeadmanID int64  `json:"headman_id"`
-	StudentID int64  `json:"student_id"`
-	GroupID   string `json:"group_id"`
+	HeadmanID int64      `json:"headman_id"`
+	StudentID int64      `json:"student_id"`
+	GroupID   string     `json:"group_id"`
+	TermStart time.Time  `json:"term_start"`
+	TermEnd   *time.Time `json:"term_end"`
+	IsDeputy  bool       `json:"is_deputy"`
+}
+
+// IsActiveOn reports whether the term covers the date
+func (h Headman) IsActiveOn(date time.Time) bool {
+	day := date.Format("2006-01-02")
+	if h.TermStart.Format("2006-01-02") > day {
+		return false
+	}
+	return h.TermEnd == nil || h.TermEnd.Format("2006-01-02") >= day
+}
+
+// Overlaps reports whether two terms share at least one day
+func (h Headman) Overlaps(other Headman) bool {
+	if h.TermEnd != nil && h.TermEnd.Format("2006-01-02") < other.TermStart.Format("2006-01-02") {
+		return false
+	}
+	if other.TermEnd != nil && other.TermEnd.Format("2006-01-02") < h.TermStart.Format("2006-01-02") {
+		return false
+	}
+	return true
 }
 
 type HeadmanInfo struct {
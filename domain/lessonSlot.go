@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// LessonSlot is a numbered lesson ("пара") of the bell schedule of a university,
+// BreakMinutes is the break after the lesson
+type LessonSlot struct {
+	SlotID       int64     `json:"slot_id"`
+	UniversityID int64     `json:"university_id"`
+	SlotNumber   int       `json:"slot_number"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	BreakMinutes int       `json:"break_minutes"`
+}
+
+// SlotLesson is a lesson at the concrete time, Slot is nil when the lesson
+// starts outside the bell schedule
+type SlotLesson struct {
+	Slot     *LessonSlot `json:"slot"`
+	Lesson   Lesson      `json:"lesson"`
+	StartsAt time.Time   `json:"starts_at"`
+	EndsAt   time.Time   `json:"ends_at"`
+}
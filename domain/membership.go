@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// GroupMembership is a period when a student belonged to a group, ValidTo is
+// exclusive and nil for the current group
+type GroupMembership struct {
+	HistoryID int64      `json:"history_id"`
+	StudentID int64      `json:"student_id"`
+	GroupID   string     `json:"group_id"`
+	ValidFrom time.Time  `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to"`
+	Reason    *string    `json:"reason"`
+}
+
+type StudentTransfer struct {
+	StudentID int64     `json:"student_id"`
+	GroupID   string    `json:"group_id"`
+	Date      time.Time `json:"date"`
+	Reason    string    `json:"reason"`
+}
+
+// GroupPromotion moves all current students of a group to another group
+type GroupPromotion struct {
+	FromGroupID string `json:"from_group_id"`
+	ToGroupID   string `json:"to_group_id"`
+	Students    int    `json:"students"`
+}
+
+type PromotionResult struct {
+	DryRun        bool             `json:"dry_run"`
+	Date          time.Time        `json:"date"`
+	Promotions    []GroupPromotion `json:"promotions"`
+	CreatedGroups []string         `json:"created_groups"`
+}
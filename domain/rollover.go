@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// SemesterRollover moves the timetable from one semester to the next one
+type SemesterRollover struct {
+	Semester     int             `json:"semester"`
+	NextSemester int             `json:"next_semester"`
+	BeginStudies time.Time       `json:"begin_studies"`
+	GroupIDs     []string        `json:"group_ids"`
+	ClassroomMap map[int64]int64 `json:"classroom_map"`
+	TeacherMap   map[int64]int64 `json:"teacher_map"`
+}
+
+// ScheduleClone is a new schedule copied from a schedule of the previous semester
+type ScheduleClone struct {
+	SourceScheduleID int64    `json:"source_schedule_id"`
+	Schedule         Schedule `json:"schedule"`
+	ClassroomChanged bool     `json:"classroom_changed"`
+	TeacherChanged   bool     `json:"teacher_changed"`
+}
+
+// RolloverDiff shows what a rollover changes, it is returned for a preview and after the rollover
+type RolloverDiff struct {
+	DryRun   bool            `json:"dry_run"`
+	Archived []ScheduleInfo  `json:"archived"`
+	Created  []ScheduleClone `json:"created"`
+	Warnings []string        `json:"warnings"`
+}
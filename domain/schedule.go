@@ -14,6 +14,8 @@ type Schedule struct {
 	WeekType         string    `json:"week_type"`
 	DayOfWeek        string    `json:"day_of_week"`
 	StartTime        time.Time `json:"start_time"`
+	SlotID           *int64    `json:"slot_id"`
+	SubgroupID       *int64    `json:"subgroup_id"`
 	IsActual         *bool     `json:"is_actual"`
 }
 
@@ -0,0 +1,70 @@
+package domain
+
+import "time"
+
+// ScheduleException is a one-off change of a lesson on the date, a lesson is either
+// cancelled or held with the substitute teacher, the other classroom or at the other time.
+// MovedToDate moves the lesson to another day, nil fields keep the timetable values
+type ScheduleException struct {
+	ExceptionID int64      `json:"exception_id"`
+	ScheduleID  int64      `json:"schedule_id"`
+	LessonDate  time.Time  `json:"lesson_date"`
+	IsCancelled bool       `json:"is_cancelled"`
+	TeacherID   *int64     `json:"teacher_id"`
+	ClassroomID *int64     `json:"classroom_id"`
+	MovedToDate *time.Time `json:"moved_to_date"`
+	StartTime   *time.Time `json:"start_time"`
+	Reason      *string    `json:"reason"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// HeldOn returns the date the lesson is held on
+func (e ScheduleException) HeldOn() time.Time {
+	if e.MovedToDate != nil {
+		return *e.MovedToDate
+	}
+	return e.LessonDate
+}
+
+type ScheduleExceptionInfo struct {
+	ScheduleExceptionSub ScheduleExceptionSub `json:"schedule_exception_sub"`
+	ScheduleException    ScheduleException    `json:"schedule_exception"`
+}
+
+// ScheduleExceptionSub holds the names of the substitute teacher and the other classroom
+type ScheduleExceptionSub struct {
+	TeacherFullName *TeacherFullName `json:"teacher_full_name"`
+	ClassroomName   *string          `json:"classroom_name"`
+}
+
+// Lesson is a lesson of the timetable on the date with the exceptions applied,
+// ScheduleInfo holds the teacher, the classroom and the time of the lesson on that date
+type Lesson struct {
+	Date         time.Time              `json:"date"`
+	ScheduleInfo ScheduleInfo           `json:"schedule_info"`
+	IsCancelled  bool                   `json:"is_cancelled"`
+	Exception    *ScheduleExceptionInfo `json:"exception"`
+}
+
+// Apply returns the lesson with the changes of the exception
+func (l Lesson) Apply(exception ScheduleExceptionInfo) Lesson {
+	e := exception.ScheduleException
+	l.Exception = &exception
+	l.IsCancelled = e.IsCancelled
+	if e.TeacherID != nil {
+		l.ScheduleInfo.Schedule.TeacherID = *e.TeacherID
+		if exception.ScheduleExceptionSub.TeacherFullName != nil {
+			l.ScheduleInfo.ScheduleSub.TeacherFullName = *exception.ScheduleExceptionSub.TeacherFullName
+		}
+	}
+	if e.ClassroomID != nil {
+		l.ScheduleInfo.Schedule.ClassroomID = *e.ClassroomID
+		if exception.ScheduleExceptionSub.ClassroomName != nil {
+			l.ScheduleInfo.ScheduleSub.ClassroomName = *exception.ScheduleExceptionSub.ClassroomName
+		}
+	}
+	if e.StartTime != nil {
+		l.ScheduleInfo.Schedule.StartTime = *e.StartTime
+	}
+	return l
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// ScheduleImportRow is a timetable row with references by name, they are resolved to IDs on import
+type ScheduleImportRow struct {
+	Row            int       `json:"row"`
+	GroupID        string    `json:"group_id"`
+	DayOfWeek      string    `json:"day_of_week"`
+	WeekType       string    `json:"week_type"`
+	StartTime      time.Time `json:"start_time"`
+	Discipline     string    `json:"discipline"`
+	DisciplineType string    `json:"discipline_type"`
+	Teacher        string    `json:"teacher"`
+	Classroom      string    `json:"classroom"`
+}
+
+type ScheduleImportBatch struct {
+	Total  int                 `json:"total"`
+	Rows   []ScheduleImportRow `json:"rows"`
+	Errors []ImportError       `json:"errors"`
+}
+
+type ScheduleImportResult struct {
+	DryRun    bool          `json:"dry_run"`
+	Total     int           `json:"total"`
+	Valid     int           `json:"valid"`
+	Created   int           `json:"created"`
+	Errors    []ImportError `json:"errors"`
+	Schedules []Schedule    `json:"schedules"`
+}
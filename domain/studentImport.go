@@ -0,0 +1,46 @@
+package domain
+
+// ImportError describes a problem with a single row of an imported file
+type ImportError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type StudentImportRow struct {
+	Row     int     `json:"row"`
+	Student Student `json:"student"`
+}
+
+// StudentImportBatch holds the parsed rows of a file and the rows rejected while parsing
+type StudentImportBatch struct {
+	Total  int                `json:"total"`
+	Rows   []StudentImportRow `json:"rows"`
+	Errors []ImportError      `json:"errors"`
+}
+
+// StudentAccount is a student to be created together with an optional user account
+type StudentAccount struct {
+	Student Student `json:"student"`
+	User    *User   `json:"user"`
+}
+
+type StudentImportResult struct {
+	DryRun        bool          `json:"dry_run"`
+	Total         int           `json:"total"`
+	Valid         int           `json:"valid"`
+	Created       int           `json:"created"`
+	Errors        []ImportError `json:"errors"`
+	CredentialsID string        `json:"credentials_id,omitempty"`
+}
+
+// StudentCredential is a generated login, the password is kept in plain text only until downloaded
+type StudentCredential struct {
+	StudentID  int64  `json:"student_id"`
+	GroupID    string `json:"group_id"`
+	LastName   string `json:"last_name"`
+	FirstName  string `json:"first_name"`
+	MiddleName string `json:"middle_name"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+}
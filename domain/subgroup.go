@@ -0,0 +1,10 @@
+package domain
+
+// Subgroup is a named part of the students of a group, labs of a subgroup
+// are attended only by its students
+type Subgroup struct {
+	SubgroupID int64   `json:"subgroup_id"`
+	GroupID    string  `json:"group_id"`
+	Name       string  `json:"subgroup_name"`
+	StudentIDs []int64 `json:"student_ids"`
+}
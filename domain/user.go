@@ -10,6 +10,8 @@ type User struct {
 	HeadmanID *int64    `json:"headman_id"`
 	StudentID *int64    `json:"student_id"`
 	TeacherID *int64    `json:"teacher_id"`
+	// TokenVersion is incremented to invalidate all issued access tokens
+	TokenVersion int64 `json:"-"`
 }
 
 type UserInfo struct {
@@ -0,0 +1,38 @@
+package domain
+
+// WorkloadRow is the load of a teacher in a discipline type of a discipline with a group in a semester,
+// planned hours follow the actual timetable and delivered hours the lessons with a marked attendance
+type WorkloadRow struct {
+	DisciplineID       int64  `json:"discipline_id"`
+	DisciplineName     string `json:"discipline_name"`
+	DisciplineTypeID   int64  `json:"discipline_type_id"`
+	DisciplineTypeName string `json:"discipline_type_name"`
+	GroupID            string `json:"group_id"`
+	Semester           int    `json:"semester"`
+	PlannedHours       int    `json:"planned_hours"`
+	DeliveredHours     int    `json:"delivered_hours"`
+}
+
+type TeacherWorkload struct {
+	TeacherID       int64           `json:"teacher_id"`
+	TeacherFullName TeacherFullName `json:"teacher_full_name"`
+	DepartamentID   int64           `json:"departament_id"`
+	PlannedHours    int             `json:"planned_hours"`
+	DeliveredHours  int             `json:"delivered_hours"`
+	Rows            []WorkloadRow   `json:"rows"`
+}
+
+type DepartamentWorkload struct {
+	DepartamentID  int64             `json:"departament_id"`
+	PlannedHours   int               `json:"planned_hours"`
+	DeliveredHours int               `json:"delivered_hours"`
+	Teachers       []TeacherWorkload `json:"teachers"`
+}
+
+// DeliveredLessons is the number of lessons of a schedule held by a teacher,
+// a substitute teacher delivers the lessons of another teacher's schedule
+type DeliveredLessons struct {
+	TeacherID  int64 `json:"teacher_id"`
+	ScheduleID int64 `json:"schedule_id"`
+	Lessons    int   `json:"lessons"`
+}
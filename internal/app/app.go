@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"log"
 	"log/slog"
 	"os"
@@ -12,7 +13,11 @@ import (
 	"github.com/BeRebornBng/OsauAmsApi/internal/service"
 	"github.com/BeRebornBng/OsauAmsApi/pkg/auth"
 	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/mailer"
 	"github.com/BeRebornBng/OsauAmsApi/pkg/myhash"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/ratelimit"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/requestid"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/tracing"
 )
 
 const (
@@ -31,27 +36,61 @@ func Run() {
 
 	// TO DO INIT LOGGER
 	log := setupLogger(cfg.Env)
+	slog.SetDefault(log)
 	log.Info(
 		"starting osau ams api",
 		slog.String("env", cfg.Env),
 	)
 
+	if cfg.Tracing.Enabled {
+		shutdown, err := tracing.Init(context.Background(), tracing.Options{
+			ServiceName: cfg.Tracing.ServiceName,
+			Exporter:    cfg.Tracing.Exporter,
+			Endpoint:    cfg.Tracing.Endpoint,
+			Insecure:    cfg.Tracing.Insecure,
+			SampleRatio: cfg.Tracing.SampleRatio,
+		})
+		if err != nil {
+			log.Error("unable to init tracing", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		defer func() {
+			if err := shutdown(context.Background()); err != nil {
+				log.Error("unable to flush traces", slog.String("error", err.Error()))
+			}
+		}()
+	}
+
 	// TO DO INIT DATABASE
 	db, err := postgres.New(cfg.Postgres.Url)
 	if err != nil {
-		log.Debug("unable to connect to database: ", err)
+		log.Debug("unable to connect to database", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
 	defer func() {
 		db.Close()
 		log.Debug("database connection closed")
 	}()
-	hasher := myhash.NewHasher("salt")
+	hasher := NewHasher(cfg)
 	tokenManager := auth.NewManager(cfg.Jwt.SecretKey)
 	if err != nil {
 		log.Debug(err.Error())
 	}
 
+	limiterStore := ratelimit.NewMemoryStore()
+	loginGuard := service.NewLoginGuard(limiterStore, service.LoginPolicy{
+		MaxAttempts:    cfg.LoginGuard.MaxAttempts,
+		MaxIPAttempts:  cfg.LoginGuard.MaxIPAttempts,
+		BaseLockout:    cfg.LoginGuard.BaseLockout,
+		MaxLockout:     cfg.LoginGuard.MaxLockout,
+		AttemptsWindow: cfg.LoginGuard.AttemptsWindow,
+	})
+
+	var mailSender mailer.Sender = mailer.NewLogSender(log)
+	if cfg.Mail.Host != "" {
+		mailSender = mailer.NewSMTPSender(cfg.Mail.Host, cfg.Mail.Port, cfg.Mail.Username, cfg.Mail.Password, cfg.Mail.From)
+	}
+
 	// TO DO INIT REPOSITORIES
 	repos := repository.NewRepositories(db)
 
@@ -61,12 +100,35 @@ func Run() {
 			Repos:          repos,
 			Hasher:         hasher,
 			TokenManager:   tokenManager,
+			LoginGuard:     loginGuard,
+			Mailer:         mailSender,
 			AccessTokenTTL: cfg.Jwt.AccessTokenTTL,
+			ResetTokenTTL:  cfg.Jwt.ResetTokenTTL,
 		},
 	)
 
+	roleSyncCtx, stopRoleSync := context.WithCancel(context.Background())
+	defer stopRoleSync()
+	go services.HeadmanService.RunRoleSync(roleSyncCtx, cfg.Headman.RoleSyncInterval)
+
 	// TO DO INIT ROUTER
-	h := handler.NewHandler(tokenManager, services, log)
+	h := handler.NewHandler(tokenManager, services, log, handler.Options{
+		Limiter: limiterStore,
+		RateLimits: handler.RateLimits{
+			Auth:     ratelimit.Limit(cfg.RateLimit.Auth),
+			Headmans: ratelimit.Limit(cfg.RateLimit.Headmans),
+			Teachers: ratelimit.Limit(cfg.RateLimit.Teachers),
+		},
+		CORS: handler.CORS{
+			AllowOrigins:     cfg.HTPP.CORS.AllowOrigins,
+			AllowMethods:     cfg.HTPP.CORS.AllowMethods,
+			AllowCredentials: cfg.HTPP.CORS.AllowCredentials,
+			MaxAge:           cfg.HTPP.CORS.MaxAge,
+		},
+		MaxBodyBytes:  cfg.HTPP.MaxBodyBytes,
+		DisableSignup: !cfg.Auth.SignupEnabled,
+		HSTS:          cfg.HTPP.TLS.Enabled(),
+	})
 
 	// TO DO RUN SERVER
 	s := server.NewServer(cfg, h.InitRoutes())
@@ -76,22 +138,31 @@ func Run() {
 	}
 }
 
+// NewHasher builds the password hasher of the configuration, osauctl hashes passwords the same way
+func NewHasher(cfg *config.Config) myhash.PasswordHasher {
+	return myhash.NewArgon2Hasher(
+		myhash.Argon2Params{
+			Memory:      cfg.Hash.Memory,
+			Iterations:  cfg.Hash.Iterations,
+			Parallelism: cfg.Hash.Parallelism,
+			SaltLength:  cfg.Hash.SaltLength,
+			KeyLength:   cfg.Hash.KeyLength,
+		},
+		cfg.Hash.Pepper,
+		myhash.NewHasher(cfg.Hash.LegacySalt, cfg.Hash.BcryptCost),
+	)
+}
+
 func setupLogger(env string) *slog.Logger {
-	var log *slog.Logger
+	var handler slog.Handler
 
 	switch env {
 	case envLocal:
-		log = slog.New(
-			slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
-		)
+		handler = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})
 	case envProd:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
-		)
+		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})
 	default:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
-		)
+		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})
 	}
-	return log
+	return slog.New(requestid.NewLogHandler(tracing.NewLogHandler(handler)))
 }
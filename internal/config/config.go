@@ -1,30 +1,83 @@
 package config
 
 import (
+	"crypto/tls"
 	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
 )
 
+// envPrefix prefixes the environment variables overriding the config file,
+// a key maps to its upper-cased path joined by "_": postgres.url is OSAU_POSTGRES_URL
+// and jwt.secretKey is OSAU_JWT_SECRETKEY
+const envPrefix = "OSAU"
+
+// minSecretKeyLength is the shortest JWT secret key accepted at startup
+const minSecretKeyLength = 32
+
+// maxPort is the largest TCP port, the ports are decoded as int so Validate sees
+// the value from the config instead of one truncated to uint16
+const maxPort = 65535
+
 type (
 	Config struct {
-		Env      string `mapstructure:"env"`
-		HTPP     HTTPConfig
-		Postgres PostgresConfig
-		Jwt      JWTConfig
+		Env        string           `mapstructure:"env"`
+		HTPP       HTTPConfig       `mapstructure:"http"`
+		Postgres   PostgresConfig   `mapstructure:"postgres"`
+		Jwt        JWTConfig        `mapstructure:"jwt"`
+		Tracing    TracingConfig    `mapstructure:"tracing"`
+		RateLimit  RateLimitConfig  `mapstructure:"rateLimit"`
+		LoginGuard LoginGuardConfig `mapstructure:"loginGuard"`
+		Hash       HashConfig       `mapstructure:"hash"`
+		Mail       MailConfig       `mapstructure:"mail"`
+		Headman    HeadmanConfig    `mapstructure:"headman"`
+		Auth       AuthConfig       `mapstructure:"auth"`
 	}
 
 	HTTPConfig struct {
-		Host         string        `mapstructure:"host"`
-		Port         uint16        `mapstructure:"port"`
-		ReadTimeout  time.Duration `mapstructure:"read_timeout"`
-		WriteTimeout time.Duration `mapstructure:"write_timeout"`
+		Host              string        `mapstructure:"host"`
+		Port              int           `mapstructure:"port"`
+		ReadTimeout       time.Duration `mapstructure:"read_timeout"`
+		WriteTimeout      time.Duration `mapstructure:"write_timeout"`
+		IdleTimeout       time.Duration `mapstructure:"idle_timeout"`
+		ReadHeaderTimeout time.Duration `mapstructure:"read_header_timeout"`
+		MaxHeaderBytes    int           `mapstructure:"max_header_bytes"`
+		// MaxBodyBytes limits the size of a request body, 0 disables the limit
+		MaxBodyBytes int64      `mapstructure:"max_body_bytes"`
+		CORS         CORSConfig `mapstructure:"cors"`
+		TLS          TLSConfig  `mapstructure:"tls"`
+	}
+
+	// CORSConfig lists the browser origins allowed to call the API, "*" allows any origin
+	// without credentials and no origins disable CORS
+	CORSConfig struct {
+		AllowOrigins     []string      `mapstructure:"allow_origins"`
+		AllowMethods     []string      `mapstructure:"allow_methods"`
+		AllowCredentials bool          `mapstructure:"allow_credentials"`
+		MaxAge           time.Duration `mapstructure:"max_age"`
 	}
 
+	// TLSConfig serves HTTPS when both the certificate and the key files are set
+	TLSConfig struct {
+		CertFile   string `mapstructure:"cert_file"`
+		KeyFile    string `mapstructure:"key_file"`
+		MinVersion string `mapstructure:"min_version"`
+	}
+
+	// PostgresConfig is a connection URL or the parts it is assembled from when the URL is empty
 	PostgresConfig struct {
 		Host     string `mapstructure:"host"`
-		Port     uint16 `mapstructure:"port"`
+		Port     int    `mapstructure:"port"`
 		User     string `mapstructure:"user"`
 		Password string `mapstructure:"password"`
 		Name     string `mapstructure:"dbname"`
@@ -35,45 +88,248 @@ type (
 		AccessTokenTTL time.Duration `mapstructure:"accessTokenTTL"`
 		//RefreshTokenTTL time.Duration `mapstructure:"refreshTokenTTL"`
 		SecretKey string `mapstructure:"secretKey"`
+		// ResetTokenTTL is how long a password reset token stays valid
+		ResetTokenTTL time.Duration `mapstructure:"resetTokenTTL"`
+	}
+
+	MailConfig struct {
+		Host     string `mapstructure:"host"`
+		Port     int    `mapstructure:"port"`
+		Username string `mapstructure:"username"`
+		Password string `mapstructure:"password"`
+		From     string `mapstructure:"from"`
+	}
+
+	// AuthConfig controls the public endpoints of /api/auth, with the signup disabled
+	// users are created by an admin or by osauctl
+	AuthConfig struct {
+		SignupEnabled bool `mapstructure:"signup_enabled"`
+	}
+
+	// HeadmanConfig controls switching of user roles by headman terms
+	HeadmanConfig struct {
+		RoleSyncInterval time.Duration `mapstructure:"roleSyncInterval"`
+	}
+
+	TracingConfig struct {
+		Enabled     bool    `mapstructure:"enabled"`
+		ServiceName string  `mapstructure:"serviceName"`
+		Exporter    string  `mapstructure:"exporter"`
+		Endpoint    string  `mapstructure:"endpoint"`
+		Insecure    bool    `mapstructure:"insecure"`
+		SampleRatio float64 `mapstructure:"sampleRatio"`
+	}
+
+	RateLimitConfig struct {
+		Auth     LimitConfig `mapstructure:"auth"`
+		Headmans LimitConfig `mapstructure:"headmans"`
+		Teachers LimitConfig `mapstructure:"teachers"`
+	}
+
+	LimitConfig struct {
+		Rate  float64 `mapstructure:"rate"`
+		Burst int     `mapstructure:"burst"`
+	}
+
+	HashConfig struct {
+		Pepper      string `mapstructure:"pepper"`
+		LegacySalt  string `mapstructure:"legacySalt"`
+		BcryptCost  int    `mapstructure:"bcryptCost"`
+		Memory      uint32 `mapstructure:"memory"`
+		Iterations  uint32 `mapstructure:"iterations"`
+		Parallelism uint8  `mapstructure:"parallelism"`
+		SaltLength  uint32 `mapstructure:"saltLength"`
+		KeyLength   uint32 `mapstructure:"keyLength"`
+	}
+
+	LoginGuardConfig struct {
+		MaxAttempts    int           `mapstructure:"maxAttempts"`
+		MaxIPAttempts  int           `mapstructure:"maxIPAttempts"`
+		BaseLockout    time.Duration `mapstructure:"baseLockout"`
+		MaxLockout     time.Duration `mapstructure:"maxLockout"`
+		AttemptsWindow time.Duration `mapstructure:"attemptsWindow"`
 	}
 )
 
+// Init loads the variables of the .env file into the environment, reads configs/config.*
+// overridden by the OSAU_* environment variables and validates the result
 func Init(cfgPath string) (*Config, error) {
 
 	if cfgPath == "" {
 		return nil, errors.New("config path is not set")
 	}
 
-	viper.AddConfigPath(cfgPath)
-	viper.SetConfigName("config")
+	// the .env file is optional, the variables already set in the environment win
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to load .env: %w", err)
+	}
+
+	v := viper.New()
+	v.AddConfigPath(cfgPath)
+	v.SetConfigName("config")
+	v.SetEnvPrefix(envPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
-	if err := viper.ReadInConfig(); err != nil {
+	setDefaults(v)
+
+	if err := v.ReadInConfig(); err != nil {
 		return nil, err
 	}
 
-	var cfg Config
+	// viper resolves the environment only for the keys it knows about
+	for _, key := range configKeys(reflect.TypeOf(Config{}), "") {
+		if err := v.BindEnv(key); err != nil {
+			return nil, err
+		}
+	}
 
-	if err := unmarshalConfig(&cfg); err != nil {
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, err
 	}
 
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	if cfg.Postgres.Url == "" {
+		cfg.Postgres.Url = cfg.Postgres.buildURL()
+	}
+
 	// the configuration has been successfully initialized
 	return &cfg, nil
 }
 
-// unmarshal the configuration to fill in the structural data
-func unmarshalConfig(cfg *Config) error {
-	if err := viper.UnmarshalKey("env", &cfg.Env); err != nil {
-		return err
+// setDefaults fills the settings the config file may omit
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("http.idle_timeout", 2*time.Minute)
+	v.SetDefault("http.read_header_timeout", 10*time.Second)
+	v.SetDefault("http.max_header_bytes", 1<<20)
+	v.SetDefault("http.max_body_bytes", 10<<20)
+	v.SetDefault("http.cors.allow_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"})
+	v.SetDefault("http.cors.max_age", 12*time.Hour)
+	v.SetDefault("http.tls.min_version", "1.2")
+	v.SetDefault("auth.signup_enabled", true)
+	// the salt and the cost the passwords were hashed with before argon2id
+	v.SetDefault("hash.legacySalt", "salt")
+	v.SetDefault("hash.bcryptCost", 4)
+	v.SetDefault("loginGuard.maxAttempts", 5)
+	v.SetDefault("loginGuard.maxIPAttempts", 50)
+	v.SetDefault("loginGuard.baseLockout", time.Minute)
+	v.SetDefault("loginGuard.maxLockout", time.Hour)
+	v.SetDefault("loginGuard.attemptsWindow", 15*time.Minute)
+	v.SetDefault("rateLimit.auth.rate", 0.5)
+	v.SetDefault("rateLimit.auth.burst", 10)
+	v.SetDefault("rateLimit.headmans.rate", 5)
+	v.SetDefault("rateLimit.headmans.burst", 20)
+	v.SetDefault("rateLimit.teachers.rate", 5)
+	v.SetDefault("rateLimit.teachers.burst", 20)
+}
+
+// Enabled reports whether the server should serve HTTPS
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+// TLSVersion maps the min_version setting to the tls package constant
+func (c TLSConfig) TLSVersion() (uint16, error) {
+	switch c.MinVersion {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("http.tls.min_version must be 1.2 or 1.3, got %q", c.MinVersion)
+	}
+}
+
+// configKeys lists the dotted keys of the struct fields by their mapstructure tags
+func configKeys(t reflect.Type, prefix string) []string {
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("mapstructure")
+		if name == "" {
+			name = field.Name
+		}
+		key := prefix + name
+
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Duration(0)) {
+			keys = append(keys, configKeys(field.Type, key+".")...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// buildURL assembles the connection URL from the host, the port, the credentials and the database name
+func (c PostgresConfig) buildURL() string {
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(c.User, c.Password),
+		Host:   net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		Path:   "/" + c.Name,
+	}
+	return u.String()
+}
+
+// Validate reports every setting the server can't start with
+func (c *Config) Validate() error {
+	var errs []error
+
+	if !validPort(c.HTPP.Port) {
+		errs = append(errs, fmt.Errorf("http.port must be between 1 and %d", maxPort))
+	}
+	if c.HTPP.ReadTimeout < 0 || c.HTPP.WriteTimeout < 0 || c.HTPP.IdleTimeout < 0 || c.HTPP.ReadHeaderTimeout < 0 {
+		errs = append(errs, errors.New("http timeouts can't be negative"))
+	}
+	if c.HTPP.MaxHeaderBytes < 0 || c.HTPP.MaxBodyBytes < 0 {
+		errs = append(errs, errors.New("http.max_header_bytes and http.max_body_bytes can't be negative"))
+	}
+	if c.HTPP.CORS.AllowCredentials && slices.Contains(c.HTPP.CORS.AllowOrigins, "*") {
+		errs = append(errs, errors.New("http.cors.allow_credentials can't be used with the \"*\" origin"))
+	}
+	if c.HTPP.CORS.MaxAge < 0 {
+		errs = append(errs, errors.New("http.cors.max_age can't be negative"))
+	}
+	if (c.HTPP.TLS.CertFile == "") != (c.HTPP.TLS.KeyFile == "") {
+		errs = append(errs, errors.New("http.tls.cert_file and http.tls.key_file must be set together"))
+	}
+	if _, err := c.HTPP.TLS.TLSVersion(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if c.Postgres.Url == "" {
+		if c.Postgres.Host == "" || c.Postgres.Port == 0 || c.Postgres.User == "" || c.Postgres.Name == "" {
+			errs = append(errs, errors.New("postgres.url or postgres.host, port, user and dbname must be set"))
+		} else if !validPort(c.Postgres.Port) {
+			errs = append(errs, fmt.Errorf("postgres.port must be between 1 and %d", maxPort))
+		}
+	} else if _, err := url.Parse(c.Postgres.Url); err != nil {
+		errs = append(errs, fmt.Errorf("postgres.url is invalid: %w", err))
+	}
+
+	if len(c.Jwt.SecretKey) < minSecretKeyLength {
+		errs = append(errs, fmt.Errorf("jwt.secretKey must be at least %d characters long", minSecretKeyLength))
+	}
+	if c.Jwt.AccessTokenTTL <= 0 {
+		errs = append(errs, errors.New("jwt.accessTokenTTL must be positive"))
 	}
-	if err := viper.UnmarshalKey("http", &cfg.HTPP); err != nil {
-		return err
+	if c.Jwt.ResetTokenTTL <= 0 {
+		errs = append(errs, errors.New("jwt.resetTokenTTL must be positive"))
 	}
-	if err := viper.UnmarshalKey("postgres", &cfg.Postgres); err != nil {
-		return err
+
+	if c.Headman.RoleSyncInterval < 0 {
+		errs = append(errs, errors.New("headman.roleSyncInterval can't be negative"))
 	}
-	if err := viper.UnmarshalKey("jwt", &cfg.Jwt); err != nil {
-		return err
+	if c.Mail.Host != "" && !validPort(c.Mail.Port) {
+		errs = append(errs, fmt.Errorf("mail.port must be between 1 and %d", maxPort))
 	}
-	return nil
+
+	return errors.Join(errs...)
+}
+
+func validPort(port int) bool {
+	return port >= 1 && port <= maxPort
 }
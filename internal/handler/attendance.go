@@ -1,13 +1,16 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
 )
 
 // CreateAttendanceRequest represents the request body for creating an attendance
@@ -97,7 +100,7 @@ func (h *Handler) CreateAttendance(c *gin.Context) {
 
 	err = h.services.AttendanceService.Create(c.Request.Context(), attendance)
 	if err != nil {
-		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		h.respondAttendanceError(c, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -131,6 +134,20 @@ func (h *Handler) CreateAttendances(c *gin.Context) {
 			respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
 			return
 		}
+		if headmanID, ok := c.Get(headmanCtx); ok {
+			err := h.services.AttendanceService.AuthorizeHeadman(c.Request.Context(), headmanID.(int64), attendance.ScheduleID, date)
+			if err != nil {
+				h.respondAttendanceError(c, err, http.StatusInternalServerError)
+				return
+			}
+		}
+		if teacherID, ok := c.Get(teacherCtx); ok {
+			err := h.services.AttendanceService.AuthorizeTeacher(c.Request.Context(), teacherID.(int64), attendance.ScheduleID, date)
+			if err != nil {
+				h.respondAttendanceError(c, err, http.StatusInternalServerError)
+				return
+			}
+		}
 		attendance := domain.Attendance{
 			StudentID:      attendance.StudentID,
 			ScheduleID:     attendance.ScheduleID,
@@ -141,7 +158,7 @@ func (h *Handler) CreateAttendances(c *gin.Context) {
 			Created:        date,
 		}
 		if err := h.services.AttendanceService.Create(c.Request.Context(), attendance); err != nil {
-			respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+			h.respondAttendanceError(c, err, http.StatusBadRequest)
 			return
 		}
 	}
@@ -149,6 +166,24 @@ func (h *Handler) CreateAttendances(c *gin.Context) {
 	c.JSON(http.StatusCreated, SuccessResponse{Message: "Attendance created successfully"})
 }
 
+// respondAttendanceError maps the authorization and lesson errors of an attendance,
+// other errors are responded with the given status
+func (h *Handler) respondAttendanceError(c *gin.Context, err error, status int) {
+	switch {
+	case errors.Is(err, service.ErrNotHeadmanOnDate), errors.Is(err, service.ErrNotLessonTeacher):
+		respondWithError(h.logger, c, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrLessonCancelled), errors.Is(err, service.ErrLessonMoved):
+		respondWithError(h.logger, c, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrNotTeachingDay), errors.Is(err, service.ErrNotSubgroupStudent),
+		errors.Is(err, service.ErrStudentNotInGroup):
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		respondWithError(h.logger, c, http.StatusNotFound, err.Error())
+	default:
+		respondWithError(h.logger, c, status, err.Error())
+	}
+}
+
 // PutAttendance godoc
 // @Summary Update an attendance
 // @Description Update an existing attendance
@@ -228,6 +263,20 @@ func (h *Handler) PutAttendances(c *gin.Context) {
 	}
 
 	for _, attendance := range req.Attendances {
+		if headmanID, ok := c.Get(headmanCtx); ok {
+			err := h.services.AttendanceService.AuthorizeHeadmanUpdate(c.Request.Context(), headmanID.(int64), attendance.AttendanceID)
+			if err != nil {
+				h.respondAttendanceError(c, err, http.StatusInternalServerError)
+				return
+			}
+		}
+		if teacherID, ok := c.Get(teacherCtx); ok {
+			err := h.services.AttendanceService.AuthorizeTeacherUpdate(c.Request.Context(), teacherID.(int64), attendance.AttendanceID)
+			if err != nil {
+				h.respondAttendanceError(c, err, http.StatusInternalServerError)
+				return
+			}
+		}
 
 		attendance := domain.Attendance{
 			AttendanceID:   attendance.AttendanceID,
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+)
+
+const testGroup = "2023-35.03.06-1"
+
+func testDay(value string) time.Time {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return date
+}
+
+// createTimetable adds the group with students 1-3 and the student 4 of another group, the autumn
+// semester with a holiday on 2024-11-11, the lecture 1 of teacher 1 and the lab 2 of teacher 2 for
+// the subgroup of students 1 and 2 on upper Mondays, the lecture is cancelled on 2024-09-16 and
+// given to teacher 2 on 2024-09-30, student 1 is the headman 1 of the semester
+func createTimetable(t *testing.T, repos *repository.Repositories) {
+	t.Helper()
+	ctx := context.Background()
+	check := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("create timetable: %v", err)
+		}
+	}
+
+	check(repos.University.Create(ctx, domain.University{UniversityName: "ОГАУ"}))
+	check(repos.Faculty.Create(ctx, domain.Faculty{UniversityID: 1, FacultyName: "Агрономический"}))
+	check(repos.Departament.Create(ctx, domain.Departament{FacultyID: 1, DepartamentName: "Информатики"}))
+	check(repos.Specialty.Create(ctx, domain.Specialty{SpecialtyCode: "35.03.06", SpecialtyName: "Агроинженерия", DepartamentID: 1}))
+	check(repos.Profile.Create(ctx, domain.Profile{SpecialtyCode: "35.03.06", ProfileName: "Электрооборудование"}))
+	for _, groupID := range []string{testGroup, "2023-35.03.06-2"} {
+		check(repos.Group.Create(ctx, domain.Group{GroupID: groupID, ProfileID: 1}))
+	}
+	for i, lastName := range []string{"Иванов", "Петров", "Сидоров", "Кузнецов"} {
+		groupID := testGroup
+		if i == 3 {
+			groupID = "2023-35.03.06-2"
+		}
+		_, err := repos.Student.Create(ctx, domain.Student{GroupID: groupID, LastName: lastName, FirstName: "Иван", MiddleName: "Иванович"})
+		check(err)
+	}
+	for _, lastName := range []string{"Новикова", "Смирнов"} {
+		check(repos.Teacher.Create(ctx, domain.Teacher{DepartamentID: 1, LastName: lastName, FirstName: "Анна", MiddleName: "Игоревна", TeacherEmail: lastName + "@example.com"}))
+	}
+	for _, name := range []string{"Математика", "Физика"} {
+		check(repos.Discipline.Create(ctx, domain.Discipline{DepartamentID: 1, DisciplineName: name}))
+	}
+
+	semester := 1
+	for _, period := range []domain.CalendarPeriod{
+		{UniversityID: 1, Kind: domain.PeriodSemester, Title: "Осенний семестр", Semester: &semester, StartDate: testDay("2024-09-01"), EndDate: testDay("2024-12-31")},
+		{UniversityID: 1, Kind: domain.PeriodHoliday, Title: "Выходной", StartDate: testDay("2024-11-11"), EndDate: testDay("2024-11-11")},
+	} {
+		_, err := repos.Calendar.Create(ctx, period)
+		check(err)
+	}
+
+	subgroupID, err := repos.Subgroup.Create(ctx, domain.Subgroup{GroupID: testGroup, Name: "1", StudentIDs: []int64{1, 2}})
+	check(err)
+	actual := true
+	for _, schedule := range []domain.Schedule{
+		{DisciplineID: 1, TeacherID: 1, DisciplineTypeID: 1, ClassroomID: 1, StartTime: time.Date(0, 1, 1, 8, 30, 0, 0, time.UTC)},
+		{DisciplineID: 2, TeacherID: 2, DisciplineTypeID: 2, ClassroomID: 2, StartTime: time.Date(0, 1, 1, 10, 10, 0, 0, time.UTC), SubgroupID: &subgroupID},
+	} {
+		schedule.GroupID, schedule.Semester, schedule.BeginStudies = testGroup, 1, testDay("2024-09-02")
+		schedule.WeekType, schedule.DayOfWeek, schedule.IsActual = "Верхняя", "Понедельник", &actual
+		check(repos.Schedule.Create(ctx, schedule))
+	}
+
+	substitute := int64(2)
+	for _, exception := range []domain.ScheduleException{
+		{ScheduleID: 1, LessonDate: testDay("2024-09-16"), IsCancelled: true},
+		{ScheduleID: 1, LessonDate: testDay("2024-09-30"), TeacherID: &substitute},
+	} {
+		_, err := repos.ScheduleException.Create(ctx, exception)
+		check(err)
+	}
+
+	termEnd := testDay("2024-12-31")
+	check(repos.Headman.Create(ctx, domain.Headman{StudentID: 1, GroupID: testGroup, TermStart: testDay("2024-09-01"), TermEnd: &termEnd}))
+}
+
+func TestCreateAttendances(t *testing.T) {
+	api := newTestAPI(t)
+	createTimetable(t, api.repos)
+
+	firstTeacher, secondTeacher, headmanID, studentID := int64(1), int64(2), int64(1), int64(3)
+	lecturer := api.account(t, domain.User{Username: "novikova", Password: "hash", Role: "Преподаватель", TeacherID: &firstTeacher})
+	substitute := api.account(t, domain.User{Username: "smirnov", Password: "hash", Role: "Преподаватель", TeacherID: &secondTeacher})
+	headman := api.account(t, domain.User{Username: "ivanov", Password: "hash", Role: "Староста", HeadmanID: &headmanID})
+	student := api.account(t, domain.User{Username: "sidorov", Password: "hash", Role: "Студент", StudentID: &studentID})
+
+	attendances := func(studentID, scheduleID int, created string) string {
+		return fmt.Sprintf(`{"attendances":[{"student_id":%d,"schedule_id":%d,"presence":true,"created":%q}]}`, studentID, scheduleID, created)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		token      string
+		body       string
+		wantStatus int
+	}{
+		{name: "teacher of the lesson", path: "/api/teachers/attendances", token: lecturer, body: attendances(1, 1, "2024-09-02"), wantStatus: http.StatusCreated},
+		{name: "another teacher", path: "/api/teachers/attendances", token: substitute, body: attendances(2, 1, "2024-09-02"), wantStatus: http.StatusForbidden},
+		{name: "substitute teacher", path: "/api/teachers/attendances", token: substitute, body: attendances(1, 1, "2024-09-30"), wantStatus: http.StatusCreated},
+		{name: "replaced teacher", path: "/api/teachers/attendances", token: lecturer, body: attendances(2, 1, "2024-09-30"), wantStatus: http.StatusForbidden},
+		{name: "cancelled lesson", path: "/api/teachers/attendances", token: lecturer, body: attendances(1, 1, "2024-09-16"), wantStatus: http.StatusConflict},
+		{name: "holiday", path: "/api/teachers/attendances", token: lecturer, body: attendances(1, 1, "2024-11-11"), wantStatus: http.StatusBadRequest},
+		{name: "student outside the subgroup", path: "/api/teachers/attendances", token: substitute, body: attendances(3, 2, "2024-09-02"), wantStatus: http.StatusBadRequest},
+		{name: "student of another group", path: "/api/teachers/attendances", token: lecturer, body: attendances(4, 1, "2024-09-02"), wantStatus: http.StatusBadRequest},
+		{name: "headman for another group", path: "/api/headmans/attendances", token: headman, body: attendances(4, 1, "2024-10-14"), wantStatus: http.StatusBadRequest},
+		{name: "unknown schedule", path: "/api/teachers/attendances", token: lecturer, body: attendances(1, 9, "2024-09-02"), wantStatus: http.StatusNotFound},
+		{name: "invalid date", path: "/api/teachers/attendances", token: lecturer, body: attendances(1, 1, "2024-13-01"), wantStatus: http.StatusBadRequest},
+		{name: "headman during the term", path: "/api/headmans/attendances", token: headman, body: attendances(3, 1, "2024-10-14"), wantStatus: http.StatusCreated},
+		{name: "headman before the term", path: "/api/headmans/attendances", token: headman, body: attendances(3, 1, "2024-08-26"), wantStatus: http.StatusForbidden},
+		{name: "student", path: "/api/teachers/attendances", token: student, body: attendances(3, 1, "2024-09-02"), wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := api.do(http.MethodPost, tt.path, tt.token, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+
+	stored, err := api.repos.Attendance.GetAll(context.Background())
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(stored) != 3 {
+		t.Errorf("stored attendance = %d, want the 3 created", len(stored))
+	}
+}
@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ErrInvalidPeriodID     = "Invalid calendar period ID"
+	ErrPeriodNotFound      = "Calendar period not found"
+	ErrInvalidDateRange    = "Invalid date range"
+	ErrInvalidUniversityID = "Invalid university ID"
+)
+
+// CalendarPeriodRequest represents the request body for a period of the academic calendar,
+// the end date is the last day of the period
+type CalendarPeriodRequest struct {
+	UniversityID int64  `json:"university_id" validate:"required,min=1"`
+	Kind         string `json:"period_kind" validate:"required,oneof=semester holiday exam_session practice"`
+	Title        string `json:"title" validate:"omitempty,max=255"`
+	Semester     *int   `json:"semester" validate:"omitempty,min=1,max=12"`
+	StartDate    string `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate      string `json:"end_date" validate:"required,datetime=2006-01-02"`
+}
+
+// PutCalendarPeriodRequest represents the request body for updating a period of the academic calendar
+type PutCalendarPeriodRequest struct {
+	PeriodID int64 `json:"period_id" validate:"required,min=1"`
+	CalendarPeriodRequest
+}
+
+func (r CalendarPeriodRequest) toDomain() (domain.CalendarPeriod, error) {
+	period := domain.CalendarPeriod{
+		UniversityID: r.UniversityID,
+		Kind:         r.Kind,
+		Title:        r.Title,
+		Semester:     r.Semester,
+	}
+
+	startDate, err := time.Parse("2006-01-02", r.StartDate)
+	if err != nil {
+		return period, err
+	}
+	endDate, err := time.Parse("2006-01-02", r.EndDate)
+	if err != nil {
+		return period, err
+	}
+	period.StartDate = startDate
+	period.EndDate = endDate
+	return period, nil
+}
+
+func (h *Handler) respondCalendarError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrCalendarPeriodDates) || errors.Is(err, service.ErrCalendarSemester) {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+}
+
+// CreateCalendarPeriod godoc
+// @Security ApiKeyAuth
+// @Summary Create a period of the academic calendar
+// @Description Add a semester, a holiday, an exam session or a practice period to the calendar of a university
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Param period body CalendarPeriodRequest true "Calendar period info"
+// @Success 201 {object} domain.CalendarPeriod
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/calendar [post]
+func (h *Handler) CreateCalendarPeriod(c *gin.Context) {
+	var req CalendarPeriodRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	period, err := req.toDomain()
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	period.PeriodID, err = h.services.CalendarService.Create(c.Request.Context(), period)
+	if err != nil {
+		h.respondCalendarError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, period)
+}
+
+// PutCalendarPeriod godoc
+// @Security ApiKeyAuth
+// @Summary Update a period of the academic calendar
+// @Description Update an existing period of the academic calendar
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Param period body PutCalendarPeriodRequest true "Calendar period info"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/calendar [put]
+func (h *Handler) PutCalendarPeriod(c *gin.Context) {
+	var req PutCalendarPeriodRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	period, err := req.toDomain()
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+	period.PeriodID = req.PeriodID
+
+	if err := h.services.CalendarService.Put(c.Request.Context(), period); err != nil {
+		h.respondCalendarError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Calendar period updated successfully"})
+}
+
+// DeleteCalendarPeriod godoc
+// @Security ApiKeyAuth
+// @Summary Delete a period of the academic calendar
+// @Description Delete a period of the academic calendar by ID
+// @Tags Calendar
+// @Produce json
+// @Param id path int64 true "Calendar period ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/calendar/{id} [delete]
+func (h *Handler) DeleteCalendarPeriod(c *gin.Context) {
+	periodID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidPeriodID)
+		return
+	}
+
+	if err := h.services.CalendarService.Delete(c.Request.Context(), periodID); err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Calendar period deleted successfully"})
+}
+
+// GetCalendarPeriodByID godoc
+// @Security ApiKeyAuth
+// @Summary Get a period of the academic calendar
+// @Description Get a period of the academic calendar by ID
+// @Tags Calendar
+// @Produce json
+// @Param id path int64 true "Calendar period ID"
+// @Success 200 {object} domain.CalendarPeriod
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/calendar/{id} [get]
+func (h *Handler) GetCalendarPeriodByID(c *gin.Context) {
+	periodID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidPeriodID)
+		return
+	}
+
+	period, err := h.services.CalendarService.GetByID(c.Request.Context(), periodID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			respondWithError(h.logger, c, http.StatusNotFound, ErrPeriodNotFound)
+			return
+		}
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, period)
+}
+
+// GetCalendarByUniversityID godoc
+// @Security ApiKeyAuth
+// @Summary Get the academic calendar of a university
+// @Description Get the periods of the academic calendar that intersect the range, the whole calendar without a range
+// @Tags Calendar
+// @Produce json
+// @Param id path int64 true "University ID"
+// @Param from query string false "Start of the range (2006-01-02)"
+// @Param to query string false "End of the range (2006-01-02)"
+// @Success 200 {array} domain.CalendarPeriod
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/calendar/university/{id} [get]
+func (h *Handler) GetCalendarByUniversityID(c *gin.Context) {
+	universityID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidUniversityID)
+		return
+	}
+
+	from := time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	if value := c.Query("from"); value != "" {
+		if from, err = time.Parse("2006-01-02", value); err != nil {
+			respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidDateRange)
+			return
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		if to, err = time.Parse("2006-01-02", value); err != nil {
+			respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidDateRange)
+			return
+		}
+	}
+
+	periods, err := h.services.CalendarService.GetByUniversityID(c.Request.Context(), universityID, from, to)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, periods)
+}
@@ -3,23 +3,49 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 )
 
 type CreateClassroomRequest struct {
-	ClassroomName string `json:"classroom_name" validate:"required,customfieldrusnumregex"`
+	ClassroomName string   `json:"classroom_name" validate:"required,customfieldrusnumregex"`
+	Capacity      int      `json:"capacity" validate:"omitempty,min=1,max=10000"`
+	Building      string   `json:"building" validate:"omitempty,max=100"`
+	Floor         *int     `json:"floor" validate:"omitempty,min=-10,max=200"`
+	Features      []string `json:"features" validate:"omitempty,unique,dive,oneof=projector computer_lab lab_equipment"`
 }
 
 type PutClassroomRequest struct {
-	ClassroomID   int64  `json:"classroom_id" validate:"required,numeric"`
-	ClassroomName string `json:"classroom_name" validate:"required,customfieldrusnumregex"`
+	ClassroomID   int64    `json:"classroom_id" validate:"required,numeric"`
+	ClassroomName string   `json:"classroom_name" validate:"required,customfieldrusnumregex"`
+	Capacity      int      `json:"capacity" validate:"omitempty,min=1,max=10000"`
+	Building      string   `json:"building" validate:"omitempty,max=100"`
+	Floor         *int     `json:"floor" validate:"omitempty,min=-10,max=200"`
+	Features      []string `json:"features" validate:"omitempty,unique,dive,oneof=projector computer_lab lab_equipment"`
 }
 
 type PatchClassroomRequest struct {
-	ClassroomID   int64  `json:"classroom_id" validate:"required,numeric"`
-	ClassroomName string `json:"classroom_name" validate:"omitempty,customfieldrusnumregex"`
+	ClassroomID   int64    `json:"classroom_id" validate:"required,numeric"`
+	ClassroomName string   `json:"classroom_name" validate:"omitempty,customfieldrusnumregex"`
+	Capacity      int      `json:"capacity" validate:"omitempty,min=1,max=10000"`
+	Building      string   `json:"building" validate:"omitempty,max=100"`
+	Floor         *int     `json:"floor" validate:"omitempty,min=-10,max=200"`
+	Features      []string `json:"features" validate:"omitempty,unique,dive,oneof=projector computer_lab lab_equipment"`
+}
+
+// FreeClassroomsRequest represents the query of the free classroom search
+type FreeClassroomsRequest struct {
+	Semester    int      `validate:"required,min=1,max=12"`
+	WeekType    string   `validate:"required,oneof=Верхняя Нижняя"`
+	DayOfWeek   string   `validate:"required,oneof=Понедельник Вторник Среда Четверг Пятница Суббота Воскресенье"`
+	StartTime   string   `validate:"required,time"`
+	MinCapacity int      `validate:"omitempty,min=0"`
+	GroupID     string   `validate:"omitempty,customgroupidregex"`
+	Features    []string `validate:"omitempty,unique,dive,oneof=projector computer_lab lab_equipment"`
 }
 
 // CreateClassroom godoc
@@ -47,6 +73,10 @@ func (h *Handler) CreateClassroom(c *gin.Context) {
 
 	classroom := domain.Classroom{
 		ClassroomName: req.ClassroomName,
+		Capacity:      req.Capacity,
+		Building:      req.Building,
+		Floor:         req.Floor,
+		Features:      req.Features,
 	}
 
 	err := h.services.ClassroomService.Create(c.Request.Context(), classroom)
@@ -84,6 +114,10 @@ func (h *Handler) PutClassroom(c *gin.Context) {
 	classroom := domain.Classroom{
 		ClassroomID:   req.ClassroomID,
 		ClassroomName: req.ClassroomName,
+		Capacity:      req.Capacity,
+		Building:      req.Building,
+		Floor:         req.Floor,
+		Features:      req.Features,
 	}
 
 	err := h.services.ClassroomService.Put(c.Request.Context(), classroom)
@@ -121,6 +155,10 @@ func (h *Handler) PatchClassroom(c *gin.Context) {
 	classroom := domain.Classroom{
 		ClassroomID:   req.ClassroomID,
 		ClassroomName: req.ClassroomName,
+		Capacity:      req.Capacity,
+		Building:      req.Building,
+		Floor:         req.Floor,
+		Features:      req.Features,
 	}
 
 	err := h.services.ClassroomService.Patch(c.Request.Context(), classroom)
@@ -204,3 +242,71 @@ func (h *Handler) GetAllClassrooms(c *gin.Context) {
 
 	c.JSON(http.StatusOK, classrooms)
 }
+
+// GetFreeClassrooms godoc
+// @Security ApiKeyAuth
+// @Summary Search free classrooms
+// @Description Get the classrooms not used by any actual schedule in the slot, filtered by capacity and features
+// @Tags Classrooms
+// @Produce json
+// @Param semester query int true "Semester"
+// @Param week_type query string true "Week type"
+// @Param day_of_week query string true "Day of the week"
+// @Param start_time query string true "Start time (15:04)"
+// @Param min_capacity query int false "Minimum capacity"
+// @Param group_id query string false "Group that must fit into the classroom"
+// @Param features query string false "Required features separated by commas"
+// @Success 200 {array} domain.Classroom
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/classrooms/free [get]
+func (h *Handler) GetFreeClassrooms(c *gin.Context) {
+	req := FreeClassroomsRequest{
+		WeekType:  c.Query("week_type"),
+		DayOfWeek: c.Query("day_of_week"),
+		StartTime: c.Query("start_time"),
+		GroupID:   c.Query("group_id"),
+	}
+	var err error
+	if req.Semester, err = strconv.Atoi(c.Query("semester")); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, "Invalid semester")
+		return
+	}
+	if value := c.Query("min_capacity"); value != "" {
+		if req.MinCapacity, err = strconv.Atoi(value); err != nil {
+			respondWithError(h.logger, c, http.StatusBadRequest, "Invalid minimum capacity")
+			return
+		}
+	}
+	if value := c.Query("features"); value != "" {
+		req.Features = strings.Split(value, ",")
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	startTime, err := time.Parse("15:04", req.StartTime)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	classrooms, err := h.services.ClassroomService.GetFree(c.Request.Context(), domain.FreeClassroomFilter{
+		Semester:    req.Semester,
+		WeekType:    req.WeekType,
+		DayOfWeek:   req.DayOfWeek,
+		StartTime:   startTime,
+		MinCapacity: req.MinCapacity,
+		GroupID:     req.GroupID,
+		Features:    req.Features,
+	})
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, classrooms)
+}
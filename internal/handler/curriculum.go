@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ErrInvalidCurriculumID = "Invalid curriculum item ID"
+	ErrCurriculumNotFound  = "Curriculum item not found"
+)
+
+// CurriculumItemRequest represents the request body for a line of the curriculum of a profile
+type CurriculumItemRequest struct {
+	ProfileID        int64 `json:"profile_id" validate:"required,min=1"`
+	Semester         int   `json:"semester" validate:"required,min=1,max=12"`
+	DisciplineID     int64 `json:"discipline_id" validate:"required,min=1"`
+	DisciplineTypeID int64 `json:"discipline_type_id" validate:"required,min=1"`
+	PlannedHours     int   `json:"planned_hours" validate:"required,min=1,max=2000"`
+}
+
+// PutCurriculumItemRequest represents the request body for updating a line of the curriculum
+type PutCurriculumItemRequest struct {
+	CurriculumID int64 `json:"curriculum_id" validate:"required,min=1"`
+	CurriculumItemRequest
+}
+
+func (r CurriculumItemRequest) toDomain() domain.CurriculumItem {
+	return domain.CurriculumItem{
+		ProfileID:        r.ProfileID,
+		Semester:         r.Semester,
+		DisciplineID:     r.DisciplineID,
+		DisciplineTypeID: r.DisciplineTypeID,
+		PlannedHours:     r.PlannedHours,
+	}
+}
+
+// CreateCurriculumItem godoc
+// @Security ApiKeyAuth
+// @Summary Create a curriculum item
+// @Description Plan the hours of a discipline type of a discipline for a profile in a semester
+// @Tags Curriculum
+// @Accept json
+// @Produce json
+// @Param item body CurriculumItemRequest true "Curriculum item info"
+// @Success 201 {object} domain.CurriculumItem
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/curriculum [post]
+func (h *Handler) CreateCurriculumItem(c *gin.Context) {
+	var req CurriculumItemRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	item := req.toDomain()
+	curriculumID, err := h.services.CurriculumService.Create(c.Request.Context(), item)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	item.CurriculumID = curriculumID
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// PutCurriculumItem godoc
+// @Security ApiKeyAuth
+// @Summary Update a curriculum item
+// @Description Update a line of the curriculum of a profile
+// @Tags Curriculum
+// @Accept json
+// @Produce json
+// @Param item body PutCurriculumItemRequest true "Curriculum item info"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/curriculum [put]
+func (h *Handler) PutCurriculumItem(c *gin.Context) {
+	var req PutCurriculumItemRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	item := req.toDomain()
+	item.CurriculumID = req.CurriculumID
+
+	if err := h.services.CurriculumService.Put(c.Request.Context(), item); err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Curriculum item updated successfully"})
+}
+
+// DeleteCurriculumItem godoc
+// @Security ApiKeyAuth
+// @Summary Delete a curriculum item
+// @Description Delete a line of the curriculum by ID
+// @Tags Curriculum
+// @Produce json
+// @Param id path int64 true "Curriculum item ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/curriculum/{id} [delete]
+func (h *Handler) DeleteCurriculumItem(c *gin.Context) {
+	curriculumID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidCurriculumID)
+		return
+	}
+
+	if err := h.services.CurriculumService.Delete(c.Request.Context(), curriculumID); err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Curriculum item deleted successfully"})
+}
+
+// GetCurriculumItemByID godoc
+// @Security ApiKeyAuth
+// @Summary Get a curriculum item
+// @Description Get a line of the curriculum by ID
+// @Tags Curriculum
+// @Produce json
+// @Param id path int64 true "Curriculum item ID"
+// @Success 200 {object} domain.CurriculumItemInfo
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/curriculum/{id} [get]
+func (h *Handler) GetCurriculumItemByID(c *gin.Context) {
+	curriculumID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidCurriculumID)
+		return
+	}
+
+	item, err := h.services.CurriculumService.GetByID(c.Request.Context(), curriculumID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			respondWithError(h.logger, c, http.StatusNotFound, ErrCurriculumNotFound)
+			return
+		}
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// GetCurriculumByProfileID godoc
+// @Security ApiKeyAuth
+// @Summary Get the curriculum of a profile
+// @Description Get the study plan of a profile for all semesters
+// @Tags Curriculum
+// @Produce json
+// @Param id path int64 true "Profile ID"
+// @Success 200 {array} domain.CurriculumItemInfo
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/curriculum/profile/{id} [get]
+func (h *Handler) GetCurriculumByProfileID(c *gin.Context) {
+	profileID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, "Invalid profile ID")
+		return
+	}
+
+	items, err := h.services.CurriculumService.GetByProfileID(c.Request.Context(), profileID)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// GetCurriculumHoursReport godoc
+// @Security ApiKeyAuth
+// @Summary Get the planned, scheduled and held hours of a group
+// @Description Compare the curriculum hours of a group in a semester with the hours of the actual timetable on the teaching days and the hours of the lessons with a marked attendance
+// @Tags Curriculum
+// @Produce json
+// @Param group_id path string true "Group ID"
+// @Param semester path int true "Semester"
+// @Success 200 {object} domain.CurriculumReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/curriculum/report/group/{group_id}/semester/{semester} [get]
+func (h *Handler) GetCurriculumHoursReport(c *gin.Context) {
+	semester, err := strconv.Atoi(c.Param("semester"))
+	if err != nil || semester < 1 || semester > 12 {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidSemester)
+		return
+	}
+
+	report, err := h.services.CurriculumService.GetHoursReport(c.Request.Context(), c.Param("group_id"), semester)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
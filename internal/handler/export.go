@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/BeRebornBng/OsauAmsApi/pkg/spreadsheet"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	formatJSON = "json"
+
+	ErrInvalidExportFormat = "Invalid export format, expected json, csv or xlsx"
+)
+
+// exportFormat reads the format query parameter of a report, JSON is the default
+func exportFormat(c *gin.Context) (string, bool) {
+	switch format := c.DefaultQuery("format", formatJSON); format {
+	case formatJSON, spreadsheet.FormatCSV, spreadsheet.FormatXLSX:
+		return format, true
+	default:
+		return "", false
+	}
+}
+
+// respondTable sends the rows of a report as a csv or xlsx attachment
+func (h *Handler) respondTable(c *gin.Context, format, filename string, header []string, rows [][]string) {
+	c.Header("Content-Disposition", `attachment; filename="`+filename+"."+format+`"`)
+	c.Status(http.StatusOK)
+	c.Writer.Header().Set("Content-Type", spreadsheet.ContentType(format))
+
+	if err := spreadsheet.Write(c.Writer, format, header, rows); err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "failed to write report", "error", err.Error())
+	}
+}
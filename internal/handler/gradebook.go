@@ -0,0 +1,534 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ErrInvalidMarkID         = "Invalid mark ID"
+	ErrInvalidControlPointID = "Invalid control point ID"
+)
+
+// MarkRequest represents the request body for a mark of a student for a lesson
+type MarkRequest struct {
+	ScheduleID int64   `json:"schedule_id" validate:"required,min=1"`
+	StudentID  int64   `json:"student_id" validate:"required,min=1"`
+	LessonDate string  `json:"lesson_date" validate:"required,datetime=2006-01-02"`
+	Mark       int     `json:"mark" validate:"required,min=2,max=5"`
+	Comment    *string `json:"comment" validate:"omitempty,max=255"`
+}
+
+// ControlPointRequest represents the request body for a control point of a discipline
+type ControlPointRequest struct {
+	GroupID      string  `json:"group_id" validate:"required,customgroupidregex"`
+	DisciplineID int64   `json:"discipline_id" validate:"required,min=1"`
+	Semester     int     `json:"semester" validate:"required,min=1,max=12"`
+	Name         string  `json:"control_point_name" validate:"required,max=100"`
+	MaxScore     int     `json:"max_score" validate:"required,min=1,max=1000"`
+	DueDate      *string `json:"due_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// ControlPointResultRequest represents the request body for the score of a student for a control point
+type ControlPointResultRequest struct {
+	ControlPointID int64 `json:"control_point_id" validate:"required,min=1"`
+	StudentID      int64 `json:"student_id" validate:"required,min=1"`
+	Score          int   `json:"score" validate:"min=0"`
+}
+
+// FinalResultRequest represents the request body for a credit or an exam grade
+type FinalResultRequest struct {
+	StudentID    int64  `json:"student_id" validate:"required,min=1"`
+	DisciplineID int64  `json:"discipline_id" validate:"required,min=1"`
+	Semester     int    `json:"semester" validate:"required,min=1,max=12"`
+	ControlType  string `json:"control_type" validate:"required,oneof=зачёт экзамен"`
+	Grade        string `json:"grade" validate:"required"`
+}
+
+func (h *Handler) respondGradebookError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotGroupTeacher), errors.Is(err, service.ErrNotLessonTeacher):
+		respondWithError(h.logger, c, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrLessonCancelled), errors.Is(err, service.ErrLessonMoved):
+		respondWithError(h.logger, c, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrLessonNotOnDate), errors.Is(err, service.ErrStudentNotInGroup),
+		errors.Is(err, service.ErrNotSubgroupStudent), errors.Is(err, service.ErrScoreAboveMax),
+		errors.Is(err, service.ErrGradeScale):
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		respondWithError(h.logger, c, http.StatusNotFound, err.Error())
+	default:
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// contextID reads the ID of the signed in teacher or student
+func (h *Handler) contextID(c *gin.Context, key, name string) (int64, bool) {
+	data, ok := c.Get(key)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusUnauthorized, name+" ID not found in context")
+		return 0, false
+	}
+
+	id, ok := data.(int64)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusInternalServerError, "Failed to convert "+name+" ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// SetMark godoc
+// @Security ApiKeyAuth
+// @Summary Put a mark
+// @Description Put the mark of a student for a lesson the teacher gives on the date, a new mark replaces the previous one
+// @Tags Gradebook
+// @Accept json
+// @Produce json
+// @Param mark body MarkRequest true "Mark info"
+// @Success 200 {object} domain.Mark
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teachers/marks [put]
+func (h *Handler) SetMark(c *gin.Context) {
+	teacherID, ok := h.contextID(c, teacherCtx, "Teacher")
+	if !ok {
+		return
+	}
+
+	var req MarkRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	lessonDate, _ := time.Parse("2006-01-02", req.LessonDate)
+	mark := domain.Mark{
+		ScheduleID: req.ScheduleID,
+		StudentID:  req.StudentID,
+		LessonDate: lessonDate,
+		Mark:       req.Mark,
+		Comment:    req.Comment,
+	}
+
+	markID, err := h.services.GradebookService.SetMark(c.Request.Context(), teacherID, mark)
+	if err != nil {
+		h.respondGradebookError(c, err)
+		return
+	}
+	mark.MarkID = markID
+	mark.TeacherID = &teacherID
+
+	c.JSON(http.StatusOK, mark)
+}
+
+// DeleteMark godoc
+// @Security ApiKeyAuth
+// @Summary Delete a mark
+// @Description Delete a mark of a discipline the teacher gives to the group
+// @Tags Gradebook
+// @Produce json
+// @Param id path int64 true "Mark ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teachers/marks/{id} [delete]
+func (h *Handler) DeleteMark(c *gin.Context) {
+	teacherID, ok := h.contextID(c, teacherCtx, "Teacher")
+	if !ok {
+		return
+	}
+
+	markID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidMarkID)
+		return
+	}
+
+	if err := h.services.GradebookService.DeleteMark(c.Request.Context(), teacherID, markID); err != nil {
+		h.respondGradebookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Mark deleted successfully"})
+}
+
+// CreateControlPoint godoc
+// @Security ApiKeyAuth
+// @Summary Create a control point
+// @Description Add a test or another control work of a discipline the teacher gives to the group
+// @Tags Gradebook
+// @Accept json
+// @Produce json
+// @Param point body ControlPointRequest true "Control point info"
+// @Success 201 {object} domain.ControlPoint
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teachers/control_points [post]
+func (h *Handler) CreateControlPoint(c *gin.Context) {
+	teacherID, ok := h.contextID(c, teacherCtx, "Teacher")
+	if !ok {
+		return
+	}
+
+	var req ControlPointRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	point := domain.ControlPoint{
+		GroupID:      req.GroupID,
+		DisciplineID: req.DisciplineID,
+		Semester:     req.Semester,
+		Name:         req.Name,
+		MaxScore:     req.MaxScore,
+	}
+	if req.DueDate != nil {
+		dueDate, _ := time.Parse("2006-01-02", *req.DueDate)
+		point.DueDate = &dueDate
+	}
+
+	controlPointID, err := h.services.GradebookService.CreateControlPoint(c.Request.Context(), teacherID, point)
+	if err != nil {
+		h.respondGradebookError(c, err)
+		return
+	}
+	point.ControlPointID = controlPointID
+
+	c.JSON(http.StatusCreated, point)
+}
+
+// DeleteControlPoint godoc
+// @Security ApiKeyAuth
+// @Summary Delete a control point
+// @Description Delete a control point with its scores
+// @Tags Gradebook
+// @Produce json
+// @Param id path int64 true "Control point ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teachers/control_points/{id} [delete]
+func (h *Handler) DeleteControlPoint(c *gin.Context) {
+	teacherID, ok := h.contextID(c, teacherCtx, "Teacher")
+	if !ok {
+		return
+	}
+
+	controlPointID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidControlPointID)
+		return
+	}
+
+	if err := h.services.GradebookService.DeleteControlPoint(c.Request.Context(), teacherID, controlPointID); err != nil {
+		h.respondGradebookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Control point deleted successfully"})
+}
+
+// GetControlPoints godoc
+// @Security ApiKeyAuth
+// @Summary Get the control points of a discipline
+// @Description Get the control points of a discipline the teacher gives to the group in the semester
+// @Tags Gradebook
+// @Produce json
+// @Param group_id path string true "Group ID"
+// @Param discipline_id path int64 true "Discipline ID"
+// @Param semester path int true "Semester"
+// @Success 200 {array} domain.ControlPoint
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teachers/control_points/group/{group_id}/discipline/{discipline_id}/semester/{semester} [get]
+func (h *Handler) GetControlPoints(c *gin.Context) {
+	teacherID, ok := h.contextID(c, teacherCtx, "Teacher")
+	if !ok {
+		return
+	}
+
+	disciplineID, err := strconv.ParseInt(c.Param("discipline_id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, "Invalid discipline ID")
+		return
+	}
+	semester, err := strconv.Atoi(c.Param("semester"))
+	if err != nil || semester < 1 || semester > 12 {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidSemester)
+		return
+	}
+
+	points, err := h.services.GradebookService.GetControlPoints(c.Request.Context(), teacherID, c.Param("group_id"), disciplineID, semester)
+	if err != nil {
+		h.respondGradebookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, points)
+}
+
+// SetControlPointResult godoc
+// @Security ApiKeyAuth
+// @Summary Put a control point score
+// @Description Put the score of a student for a control point, a new score replaces the previous one
+// @Tags Gradebook
+// @Accept json
+// @Produce json
+// @Param result body ControlPointResultRequest true "Score info"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teachers/control_points/results [put]
+func (h *Handler) SetControlPointResult(c *gin.Context) {
+	teacherID, ok := h.contextID(c, teacherCtx, "Teacher")
+	if !ok {
+		return
+	}
+
+	var req ControlPointResultRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	result := domain.ControlPointResult{ControlPointID: req.ControlPointID, StudentID: req.StudentID, Score: req.Score}
+	if err := h.services.GradebookService.SetControlPointResult(c.Request.Context(), teacherID, result); err != nil {
+		h.respondGradebookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Score saved successfully"})
+}
+
+// SetFinalResult godoc
+// @Security ApiKeyAuth
+// @Summary Put a credit or an exam grade
+// @Description Put the final result of a student, a credit is graded "зачтено"/"не зачтено", an exam from "отлично" to "неудовлетворительно"
+// @Tags Gradebook
+// @Accept json
+// @Produce json
+// @Param result body FinalResultRequest true "Final result info"
+// @Success 200 {object} domain.FinalResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teachers/final_results [put]
+func (h *Handler) SetFinalResult(c *gin.Context) {
+	teacherID, ok := h.contextID(c, teacherCtx, "Teacher")
+	if !ok {
+		return
+	}
+
+	var req FinalResultRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	result := domain.FinalResult{
+		StudentID:    req.StudentID,
+		DisciplineID: req.DisciplineID,
+		Semester:     req.Semester,
+		ControlType:  req.ControlType,
+		Grade:        req.Grade,
+	}
+	finalResultID, err := h.services.GradebookService.SetFinalResult(c.Request.Context(), teacherID, result)
+	if err != nil {
+		h.respondGradebookError(c, err)
+		return
+	}
+	result.FinalResultID = finalResultID
+	result.TeacherID = &teacherID
+
+	c.JSON(http.StatusOK, result)
+}
+
+// respondGradebook writes the gradebook of the student for the optional semester query parameter
+func (h *Handler) respondGradebook(c *gin.Context, studentID int64) {
+	semester, ok := optionalSemester(c)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidSemester)
+		return
+	}
+
+	gradebook, err := h.services.GradebookService.GetGradebook(c.Request.Context(), studentID, semester)
+	if err != nil {
+		h.respondGradebookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gradebook)
+}
+
+// respondAdmission writes the admission of the student to the credit or the exam of the discipline
+func (h *Handler) respondAdmission(c *gin.Context, studentID int64) {
+	disciplineID, err := strconv.ParseInt(c.Param("discipline_id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, "Invalid discipline ID")
+		return
+	}
+	semester, err := strconv.Atoi(c.Param("semester"))
+	if err != nil || semester < 1 || semester > 12 {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidSemester)
+		return
+	}
+
+	admission, err := h.services.GradebookService.GetAdmission(c.Request.Context(), studentID, disciplineID, semester)
+	if err != nil {
+		h.respondGradebookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, admission)
+}
+
+// GetStudentGradebook godoc
+// @Security ApiKeyAuth
+// @Summary Get the gradebook of a student
+// @Description Get the marks, the control points and the final results of a student per discipline
+// @Tags Gradebook
+// @Produce json
+// @Param id path int64 true "Student ID"
+// @Param semester query int false "Semester, all semesters by default"
+// @Success 200 {object} domain.Gradebook
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/gradebook/student/{id} [get]
+func (h *Handler) GetStudentGradebook(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, "Invalid student ID")
+		return
+	}
+
+	h.respondGradebook(c, studentID)
+}
+
+// GetStudentAdmission godoc
+// @Security ApiKeyAuth
+// @Summary Check the admission of a student
+// @Description Check the admission of a student to the credit or the exam of a discipline by the attendance and the current marks
+// @Tags Gradebook
+// @Produce json
+// @Param id path int64 true "Student ID"
+// @Param discipline_id path int64 true "Discipline ID"
+// @Param semester path int true "Semester"
+// @Success 200 {object} domain.Admission
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/gradebook/student/{id}/admission/discipline/{discipline_id}/semester/{semester} [get]
+func (h *Handler) GetStudentAdmission(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, "Invalid student ID")
+		return
+	}
+
+	h.respondAdmission(c, studentID)
+}
+
+// currentStudentID returns the student of the signed in account, the account of a
+// headman refers to the headman term and the student is taken from it
+func (h *Handler) currentStudentID(c *gin.Context) (int64, bool) {
+	if _, ok := c.Get(studentCtx); ok {
+		return h.contextID(c, studentCtx, "Student")
+	}
+
+	headmanID, ok := h.contextID(c, headmanCtx, "Headman")
+	if !ok {
+		return 0, false
+	}
+	headman, err := h.services.HeadmanService.GetByID(c.Request.Context(), headmanID)
+	if err != nil {
+		h.respondGradebookError(c, err)
+		return 0, false
+	}
+	return headman.Headman.StudentID, true
+}
+
+// GetMyGradebook godoc
+// @Security ApiKeyAuth
+// @Summary Get my gradebook
+// @Description Get the marks, the control points and the final results of the current student or headman
+// @Tags Gradebook
+// @Produce json
+// @Param semester query int false "Semester, all semesters by default"
+// @Success 200 {object} domain.Gradebook
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /students/gradebook [get]
+// @Router /headmans/gradebook [get]
+func (h *Handler) GetMyGradebook(c *gin.Context) {
+	studentID, ok := h.currentStudentID(c)
+	if !ok {
+		return
+	}
+
+	h.respondGradebook(c, studentID)
+}
+
+// GetMyAdmission godoc
+// @Security ApiKeyAuth
+// @Summary Check my admission
+// @Description Check the admission of the current student or headman to the credit or the exam of a discipline
+// @Tags Gradebook
+// @Produce json
+// @Param discipline_id path int64 true "Discipline ID"
+// @Param semester path int true "Semester"
+// @Success 200 {object} domain.Admission
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /students/gradebook/admission/discipline/{discipline_id}/semester/{semester} [get]
+// @Router /headmans/gradebook/admission/discipline/{discipline_id}/semester/{semester} [get]
+func (h *Handler) GetMyAdmission(c *gin.Context) {
+	studentID, ok := h.currentStudentID(c)
+	if !ok {
+		return
+	}
+
+	h.respondAdmission(c, studentID)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+func TestMyGradebookOfHeadman(t *testing.T) {
+	api := newTestAPI(t)
+	ctx := context.Background()
+
+	if err := api.repos.Group.Create(ctx, domain.Group{GroupID: testGroup, ProfileID: 1}); err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+	for _, lastName := range []string{"Иванов", "Петров"} {
+		if _, err := api.repos.Student.Create(ctx, domain.Student{GroupID: testGroup, LastName: lastName, FirstName: "Иван", MiddleName: "Иванович"}); err != nil {
+			t.Fatalf("create student: %v", err)
+		}
+	}
+	if err := api.repos.Headman.Create(ctx, domain.Headman{StudentID: 2, GroupID: testGroup, TermStart: time.Now().AddDate(0, -1, 0)}); err != nil {
+		t.Fatalf("create headman: %v", err)
+	}
+
+	studentID := int64(1)
+	student := api.token(t, "studentstudent", "Студент", &studentID)
+	teacher := api.token(t, "teacherteacher", "Преподаватель", nil)
+	// the account of a headman refers to the term and has no student
+	headmanID := int64(1)
+	headman := api.account(t, domain.User{Username: "headmanheadman", Password: "hash", Role: "Староста", HeadmanID: &headmanID})
+
+	tests := []struct {
+		name          string
+		path          string
+		token         string
+		wantStatus    int
+		wantStudentID int64
+	}{
+		{name: "student", path: "/api/students/gradebook", token: student, wantStatus: http.StatusOK, wantStudentID: 1},
+		{name: "headman", path: "/api/headmans/gradebook", token: headman, wantStatus: http.StatusOK, wantStudentID: 2},
+		{name: "headman by semester", path: "/api/headmans/gradebook?semester=1", token: headman, wantStatus: http.StatusOK, wantStudentID: 2},
+		{name: "headman on the student route", path: "/api/students/gradebook", token: headman, wantStatus: http.StatusForbidden},
+		{name: "student on the headman route", path: "/api/headmans/gradebook", token: student, wantStatus: http.StatusForbidden},
+		{name: "teacher", path: "/api/headmans/gradebook", token: teacher, wantStatus: http.StatusForbidden},
+		{name: "headman admission", path: "/api/headmans/gradebook/admission/discipline/1/semester/13", token: headman, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := api.do(http.MethodGet, tt.path, tt.token, "")
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStudentID == 0 {
+				return
+			}
+			var gradebook domain.Gradebook
+			if err := json.Unmarshal(w.Body.Bytes(), &gradebook); err != nil {
+				t.Fatalf("decode gradebook: %v", err)
+			}
+			if gradebook.StudentID != tt.wantStudentID {
+				t.Errorf("gradebook of student %d, want %d", gradebook.StudentID, tt.wantStudentID)
+			}
+		})
+	}
+}
+
+func TestSetMark(t *testing.T) {
+	api := newTestAPI(t)
+	createTimetable(t, api.repos)
+
+	firstTeacher, secondTeacher, studentID := int64(1), int64(2), int64(1)
+	lecturer := api.account(t, domain.User{Username: "novikova", Password: "hash", Role: "Преподаватель", TeacherID: &firstTeacher})
+	substitute := api.account(t, domain.User{Username: "smirnov", Password: "hash", Role: "Преподаватель", TeacherID: &secondTeacher})
+	student := api.account(t, domain.User{Username: "ivanov", Password: "hash", Role: "Студент", StudentID: &studentID})
+
+	mark := func(studentID, scheduleID int, date string, value int) string {
+		return fmt.Sprintf(`{"schedule_id":%d,"student_id":%d,"lesson_date":%q,"mark":%d}`, scheduleID, studentID, date, value)
+	}
+
+	tests := []struct {
+		name        string
+		token       string
+		body        string
+		wantStatus  int
+		wantTeacher int64
+	}{
+		{name: "teacher of the lesson", token: lecturer, body: mark(1, 1, "2024-09-02", 5), wantStatus: http.StatusOK, wantTeacher: 1},
+		{name: "substitute teacher", token: substitute, body: mark(1, 1, "2024-09-30", 4), wantStatus: http.StatusOK, wantTeacher: 2},
+		{name: "another teacher", token: substitute, body: mark(1, 1, "2024-09-02", 5), wantStatus: http.StatusForbidden},
+		{name: "cancelled lesson", token: lecturer, body: mark(1, 1, "2024-09-16", 5), wantStatus: http.StatusConflict},
+		{name: "student of another group", token: lecturer, body: mark(4, 1, "2024-09-02", 5), wantStatus: http.StatusBadRequest},
+		{name: "student outside the subgroup", token: substitute, body: mark(3, 2, "2024-09-02", 5), wantStatus: http.StatusBadRequest},
+		{name: "mark out of the scale", token: lecturer, body: mark(1, 1, "2024-09-02", 6), wantStatus: http.StatusBadRequest},
+		{name: "invalid date", token: lecturer, body: mark(1, 1, "2024-09-31", 5), wantStatus: http.StatusBadRequest},
+		{name: "unknown schedule", token: lecturer, body: mark(1, 9, "2024-09-02", 5), wantStatus: http.StatusNotFound},
+		{name: "student", token: student, body: mark(1, 1, "2024-09-02", 5), wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := api.do(http.MethodPut, "/api/teachers/marks", tt.token, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var stored domain.Mark
+			if err := json.Unmarshal(w.Body.Bytes(), &stored); err != nil {
+				t.Fatalf("decode mark: %v", err)
+			}
+			if stored.MarkID == 0 || stored.TeacherID == nil || *stored.TeacherID != tt.wantTeacher {
+				t.Errorf("mark = %+v, want an id and teacher %d", stored, tt.wantTeacher)
+			}
+		})
+	}
+}
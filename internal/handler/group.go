@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
 	"github.com/gin-gonic/gin"
 )
 
@@ -143,12 +145,17 @@ func (h *Handler) PatchGroup(c *gin.Context) {
 // @Param id path string true "Group ID"
 // @Success 200 {object} SuccessResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /groups/{id} [delete]
 func (h *Handler) DeleteGroup(c *gin.Context) {
 	groupID := c.Param("id")
 
 	err := h.services.GroupService.Delete(c.Request.Context(), groupID)
+	if errors.Is(err, service.ErrGroupHasAttendance) {
+		respondWithError(h.logger, c, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
 		return
@@ -1,11 +1,18 @@
 package handler
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/BeRebornBng/OsauAmsApi/internal/service"
 	"github.com/BeRebornBng/OsauAmsApi/pkg/auth"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/ratelimit"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/requestid"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/locales/ru"
@@ -14,17 +21,49 @@ import (
 	translations "github.com/go-playground/validator/v10/translations/ru"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
+const serviceName = "osau-ams-api"
+
 type Handler struct {
 	TokenManager auth.TokenManager
 	services     *service.Services
 	logger       *slog.Logger
 	validate     *validator.Validate
 	translator   ut.Translator
+	options      Options
+}
+
+// Options holds router settings that come from the configuration
+type Options struct {
+	Limiter    ratelimit.Store
+	RateLimits RateLimits
+	CORS       CORS
+	// MaxBodyBytes limits the size of a request body, 0 disables the limit
+	MaxBodyBytes int64
+	// DisableSignup removes the public /auth/signup endpoint
+	DisableSignup bool
+	// HSTS makes browsers use HTTPS only, it is set when the server serves TLS
+	HSTS bool
+}
+
+// CORS lists the browser origins allowed to call the API, "*" allows any origin
+type CORS struct {
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// RateLimits configures a token bucket for each route group
+type RateLimits struct {
+	Auth     ratelimit.Limit
+	Headmans ratelimit.Limit
+	Teachers ratelimit.Limit
 }
 
-func NewHandler(TokenManager auth.TokenManager, services *service.Services, logger *slog.Logger) *Handler {
+func NewHandler(TokenManager auth.TokenManager, services *service.Services, logger *slog.Logger, options Options) *Handler {
 	validate := validator.New()
 
 	uni := ut.New(ru.New())
@@ -54,10 +93,25 @@ func NewHandler(TokenManager auth.TokenManager, services *service.Services, logg
 		return t
 	})
 
+	validate.RegisterTranslation("customfieldrusnumregex", trans, func(ut ut.Translator) error {
+		return ut.Add("customfieldrusnumregex", "{0} должен содержать только кирилицу и цифры", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("customfieldrusnumregex", fe.Field())
+		return t
+	})
+
+	validate.RegisterTranslation("customgroupidregex", trans, func(ut ut.Translator) error {
+		return ut.Add("customgroupidregex", "{0} должен иметь формат 2023-35.03.06-1", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("customgroupidregex", fe.Field())
+		return t
+	})
+
 	validate.RegisterValidation("customspecialtycoderegex", CustomSpecialtyCodeRegex)
 	validate.RegisterValidation("customgroupidregex", CustomGroupIDRegex)
 	validate.RegisterValidation("custompasswordregex", CustomPasswordRegex)
 	validate.RegisterValidation("customfieldrusregex", CustomFieldRusRegex)
+	validate.RegisterValidation("customfieldrusnumregex", CustomFieldRusNumRegex)
 	validate.RegisterValidation("time", ValidateTime)
 	validate.RegisterValidation("roledependentfields", roleDependentFields)
 
@@ -67,13 +121,22 @@ func NewHandler(TokenManager auth.TokenManager, services *service.Services, logg
 		logger:       logger,
 		validate:     validate,
 		translator:   trans,
+		options:      options,
 	}
 }
 
+const (
+	maxLoggedBody = 4 << 10
+	redactedBody  = "[REDACTED]"
+	authPathPart  = "/api/auth"
+)
+
 func Logger(log *slog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		start := time.Now()
 		method := c.Request.Method
 		path := c.Request.URL.Path
+		body := readBodyForLog(c, strings.HasPrefix(path, authPathPart))
 
 		// Выполняем запрос
 		c.Next()
@@ -84,37 +147,150 @@ func Logger(log *slog.Logger) gin.HandlerFunc {
 		if c.Request.TLS != nil {
 			protocol = "HTTPS"
 		}
-		log.Info("Request", slog.String("method", method), slog.String("path", path), slog.String("protocol", protocol), slog.Int("status", status))
-		// if c.Request.Method == "OPTIONS" || c.Request.Method == "GET" {
-		// 	log.Info("Request", slog.String("method", method), slog.String("path", path), slog.String("protocol", protocol), slog.Int("status", status))
-		// }
+		attrs := []slog.Attr{
+			slog.String("method", method),
+			slog.String("path", path),
+			slog.String("protocol", protocol),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("response_size", c.Writer.Size()),
+		}
+		if userID, ok := c.Get(userCtx); ok {
+			attrs = append(attrs, slog.Any("user_id", userID))
+		}
+		if role, ok := c.Get(roleCtx); ok {
+			attrs = append(attrs, slog.Any("user_role", role))
+		}
+		log.LogAttrs(c.Request.Context(), slog.LevelInfo, "Request", attrs...)
+		if body != "" {
+			log.DebugContext(c.Request.Context(), "Request body", slog.String("body", body))
+		}
+	}
+}
+
+// readBodyForLog returns the beginning of the request body and puts it back for the handlers
+func readBodyForLog(c *gin.Context, redact bool) string {
+	if c.Request.Body == nil || c.Request.ContentLength == 0 {
+		return ""
+	}
+	if redact {
+		return redactedBody
+	}
+
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxLoggedBody))
+	if err != nil {
+		return ""
+	}
+	c.Request.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(data), c.Request.Body), Closer: c.Request.Body}
+	return redactBody(data)
+}
+
+// sensitiveFields are the JSON fields whose values never get into the log
+var sensitiveFields = []string{"password", "new_password", "old_password", "token"}
+
+// redactBody hides the values of the sensitive fields of a JSON body. A body that can't be
+// parsed, e.g. cut at maxLoggedBody, is hidden whole when it mentions one of the fields
+func redactBody(data []byte) string {
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		lower := bytes.ToLower(data)
+		for _, field := range sensitiveFields {
+			if bytes.Contains(lower, []byte(`"`+field+`"`)) {
+				return redactedBody
+			}
+		}
+		return string(data)
+	}
+	if !redactFields(body) {
+		return string(data)
+	}
+
+	redacted, err := json.Marshal(body)
+	if err != nil {
+		return redactedBody
+	}
+	return string(redacted)
+}
+
+// redactFields replaces the values of the sensitive fields of a decoded JSON in place
+// and reports whether there were any. The fields are matched case-insensitively as
+// encoding/json binds them
+func redactFields(value interface{}) bool {
+	found := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if slices.Contains(sensitiveFields, strings.ToLower(key)) {
+				v[key] = redactedBody
+				found = true
+			} else if redactFields(field) {
+				found = true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if redactFields(item) {
+				found = true
+			}
+		}
 	}
+	return found
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
 }
 
 func (h *Handler) InitRoutes() *gin.Engine {
 	router := gin.New()
-	config := cors.Config{
-		AllowOrigins:     []string{"", "", ""},
-		AllowMethods:     []string{"POST", "GET", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept", "User-Agent", "Cache-Control", "Pragma"},
-		ExposeHeaders:    []string{"Content-Length", "Connection"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
+	// without origins the API is served to the same origin only
+	if len(h.options.CORS.AllowOrigins) > 0 {
+		config := cors.Config{
+			AllowMethods:     h.options.CORS.AllowMethods,
+			AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept", "User-Agent", "Cache-Control", "Pragma", requestid.Header},
+			ExposeHeaders:    []string{"Content-Length", "Connection", "Content-Disposition", requestid.Header},
+			AllowCredentials: h.options.CORS.AllowCredentials,
+			MaxAge:           h.options.CORS.MaxAge,
+		}
+		if slices.Contains(h.options.CORS.AllowOrigins, "*") {
+			config.AllowAllOrigins = true
+		} else {
+			config.AllowOrigins = h.options.CORS.AllowOrigins
+		}
+		router.Use(cors.New(config))
 	}
-	router.Use(cors.New(config))
+	router.Use(SecurityHeaders(h.options.HSTS))
+	router.Use(BodyLimit(h.options.MaxBodyBytes))
+	router.Use(RequestID())
+	router.Use(otelgin.Middleware(serviceName))
 	router.Use(Logger(h.logger))
 
 	api := router.Group("/api")
 
 	auth := api.Group("/auth")
+	auth.Use(h.rateLimit("auth", h.options.RateLimits.Auth))
 	{
 		auth.POST("/signin", h.SignInUser)
-		auth.POST("/signup", h.CreateUser)
+		if !h.options.DisableSignup {
+			auth.POST("/signup", h.CreateUser)
+		}
+		auth.POST("/password/forgot", h.ForgotPassword)
+		auth.POST("/password/reset", h.ResetPassword)
 	}
 
 	authorized := api.Group("/")
 	authorized.Use(h.userIdentity)
 	{
+		me := authorized.Group("/me")
+		{
+			me.GET("", h.GetMe)
+			me.PUT("/password", h.ChangeMyPassword)
+			me.GET("/lessons/current", h.GetMyCurrentLesson)
+			me.GET("/lessons/next", h.GetMyNextLesson)
+		}
+
 		admin := authorized.Group("/admins")
 		admin.Use(RoleMiddleware("Админ"))
 		{
@@ -139,6 +315,7 @@ func (h *Handler) InitRoutes() *gin.Engine {
 			admin.PUT("/users", h.PutUser)
 			admin.PATCH("/users", h.PatchUser)
 			admin.DELETE("/users/:id", h.DeleteUser)
+			admin.POST("/users/:id/password_reset", h.CreateUserPasswordReset)
 			admin.GET("/users/:id", h.GetUserByID)
 			admin.GET("/users/name/:name", h.GetUserByName)
 			admin.GET("/users/student/:id", h.GetUserByStudentID)
@@ -147,6 +324,68 @@ func (h *Handler) InitRoutes() *gin.Engine {
 			admin.GET("/users/role/:role", h.GetAllUsersByRole)
 			admin.GET("/users", h.GetAll)
 
+			admin.POST("/students", h.CreateStudent)
+			admin.PUT("/students", h.PutStudent)
+			admin.PATCH("/students", h.PatchStudent)
+			admin.DELETE("/students/:id", h.DeleteStudent)
+			admin.GET("/students/:id", h.GetStudentByID)
+			admin.GET("/students/group/:group_id", h.GetStudentsByGroupID)
+			admin.GET("/students", h.GetAllStudents)
+			admin.POST("/students/import", h.ImportStudents)
+			admin.GET("/students/import/credentials/:id", h.DownloadStudentCredentials)
+			admin.GET("/students/:id/groups", h.GetStudentGroups)
+			admin.POST("/students/transfers", h.TransferStudent)
+
+			admin.POST("/schedules/import", h.ImportSchedules)
+			admin.POST("/schedules/rollover", h.RolloverSchedules)
+			admin.POST("/schedules/exceptions", h.CreateScheduleException)
+			admin.PUT("/schedules/exceptions", h.PutScheduleException)
+			admin.DELETE("/schedules/exceptions/:id", h.DeleteScheduleException)
+			admin.GET("/schedules/exceptions/:id", h.GetScheduleExceptionByID)
+			admin.GET("/schedules/:id/exceptions", h.GetScheduleExceptionsByScheduleID)
+			admin.GET("/schedules/group/:group_id/date/:date", h.GetLessonsByGroupAndDate)
+			admin.GET("/schedules/teacher/:id/date/:date", h.GetLessonsByTeacherAndDate)
+
+			admin.POST("/headmen", h.CreateHeadman)
+			admin.PUT("/headmen", h.PutHeadman)
+			admin.PATCH("/headmen", h.PatchHeadman)
+			admin.DELETE("/headmen/:id", h.DeleteHeadman)
+			admin.GET("/headmen/:id", h.GetHeadmanByID)
+			admin.GET("/headmen/group/:group_id", h.GetHeadmenByGroupID)
+			admin.GET("/headmen/student/:id", h.GetHeadmanByStudentID)
+			admin.GET("/headmen", h.GetAllHeadmen)
+
+			admin.POST("/calendar", h.CreateCalendarPeriod)
+			admin.PUT("/calendar", h.PutCalendarPeriod)
+			admin.DELETE("/calendar/:id", h.DeleteCalendarPeriod)
+			admin.GET("/calendar/:id", h.GetCalendarPeriodByID)
+			admin.GET("/calendar/university/:id", h.GetCalendarByUniversityID)
+
+			admin.POST("/lesson_slots", h.CreateLessonSlot)
+			admin.PUT("/lesson_slots", h.PutLessonSlot)
+			admin.DELETE("/lesson_slots/:id", h.DeleteLessonSlot)
+			admin.GET("/lesson_slots/:id", h.GetLessonSlotByID)
+			admin.GET("/lesson_slots/university/:id", h.GetLessonSlotsByUniversityID)
+
+			admin.POST("/subgroups", h.CreateSubgroup)
+			admin.PUT("/subgroups", h.PutSubgroup)
+			admin.DELETE("/subgroups/:id", h.DeleteSubgroup)
+			admin.GET("/subgroups/:id", h.GetSubgroupByID)
+			admin.GET("/subgroups/group/:group_id", h.GetSubgroupsByGroupID)
+
+			admin.POST("/curriculum", h.CreateCurriculumItem)
+			admin.PUT("/curriculum", h.PutCurriculumItem)
+			admin.DELETE("/curriculum/:id", h.DeleteCurriculumItem)
+			admin.GET("/curriculum/:id", h.GetCurriculumItemByID)
+			admin.GET("/curriculum/profile/:id", h.GetCurriculumByProfileID)
+			admin.GET("/curriculum/report/group/:group_id/semester/:semester", h.GetCurriculumHoursReport)
+
+			admin.GET("/workload/teacher/:id", h.GetTeacherWorkload)
+			admin.GET("/workload/departament/:id", h.GetDepartamentWorkload)
+
+			admin.GET("/gradebook/student/:id", h.GetStudentGradebook)
+			admin.GET("/gradebook/student/:id/admission/discipline/:discipline_id/semester/:semester", h.GetStudentAdmission)
+
 			admin.POST("/departaments", h.CreateDepartament)
 			admin.PUT("/departaments", h.PutDepartament)
 			admin.PATCH("/departaments", h.PatchDepartament)
@@ -182,6 +421,7 @@ func (h *Handler) InitRoutes() *gin.Engine {
 			admin.PUT("/classrooms", h.PutClassroom)
 			admin.PATCH("/classrooms", h.PatchClassroom)
 			admin.DELETE("/classrooms/:id", h.DeleteClassroom)
+			admin.GET("/classrooms/free", h.GetFreeClassrooms)
 			admin.GET("/classrooms/:id", h.GetClassroomByID)
 			admin.GET("/classrooms", h.GetAllClassrooms)
 
@@ -215,6 +455,7 @@ func (h *Handler) InitRoutes() *gin.Engine {
 			admin.GET("/groups/:id", h.GetGroupByID)
 			admin.GET("/groups/name/:name", h.GetGroupByName)
 			admin.GET("/groups", h.GetAllGroups)
+			admin.POST("/groups/promotions", h.PromoteGroups)
 
 			admin.POST("/education_types", h.CreateEducationType)
 			admin.PUT("/education_types", h.PutEducationType)
@@ -225,14 +466,17 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		}
 
 		headman := authorized.Group("/headmans")
-		headman.Use(RoleMiddleware("Староста"))
+		headman.Use(RoleMiddleware("Староста"), h.rateLimit("headmans", h.options.RateLimits.Headmans))
 		{
 			headman.POST("/attendances", h.CreateAttendances)
 			headman.PUT("/attendances", h.PutAttendances)
 			headman.GET("/schedules/week/:week", h.GetActualSchedulesByGroupAndWeekType)
 			headman.GET("/schedules/week/:week/day/:day", h.GetActualSchedulesByGroupWeekTypeAndDay)
+			headman.GET("/schedules/date/:date", h.GetMyGroupLessonsByDate)
 			headman.GET("/attendances/schedule/:id/date/:date", h.GetHeadmanAllAttendances)
 			headman.GET("/reports/start/:start_date/end/:end_date", h.GetActualReportByGroupIDAndCreated)
+			headman.GET("/gradebook", h.GetMyGradebook)
+			headman.GET("/gradebook/admission/discipline/:discipline_id/semester/:semester", h.GetMyAdmission)
 		}
 
 		student := authorized.Group("/students")
@@ -240,16 +484,28 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		{
 			student.GET("/schedules/week/:week", h.GetActualSchedulesByGroupAndWeekType)
 			student.GET("/schedules/week/:week/day/:day", h.GetActualSchedulesByGroupWeekTypeAndDay)
+			student.GET("/schedules/date/:date", h.GetMyGroupLessonsByDate)
+			student.GET("/gradebook", h.GetMyGradebook)
+			student.GET("/gradebook/admission/discipline/:discipline_id/semester/:semester", h.GetMyAdmission)
 		}
 
 		teacher := authorized.Group("/teachers")
-		teacher.Use(RoleMiddleware("Преподаватель"))
+		teacher.Use(RoleMiddleware("Преподаватель"), h.rateLimit("teachers", h.options.RateLimits.Teachers))
 		{
 			teacher.POST("/attendances", h.CreateAttendances)
 			teacher.PUT("/attendances", h.PutAttendances)
 			teacher.GET("/schedules/week/:week", h.GetActualSchedulesByTeacherIDWeekType)
 			teacher.GET("/schedules/week/:week/day/:day", h.GetActualSchedulesByTeacherIDWeekTypeAndDay)
+			teacher.GET("/schedules/date/:date", h.GetMyLessonsByDate)
 			teacher.GET("/attendances/group/:group_id/schedule/:id/date/:date", h.GetTeacherAllAttendances)
+			teacher.GET("/workload", h.GetMyWorkload)
+			teacher.PUT("/marks", h.SetMark)
+			teacher.DELETE("/marks/:id", h.DeleteMark)
+			teacher.POST("/control_points", h.CreateControlPoint)
+			teacher.DELETE("/control_points/:id", h.DeleteControlPoint)
+			teacher.GET("/control_points/group/:group_id/discipline/:discipline_id/semester/:semester", h.GetControlPoints)
+			teacher.PUT("/control_points/results", h.SetControlPointResult)
+			teacher.PUT("/final_results", h.SetFinalResult)
 		}
 
 	}
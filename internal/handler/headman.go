@@ -1,17 +1,24 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
 	"github.com/gin-gonic/gin"
 )
 
-// CreateHeadmanRequest represents the request body for creating a headman
+// CreateHeadmanRequest represents the request body for creating a headman term,
+// the term starts today if term_start is empty
 type CreateHeadmanRequest struct {
 	StudentID int64  `json:"student_id" validate:"required,numeric"`
 	GroupID   string `json:"group_id" validate:"required,customgroupidregex"`
+	TermStart string `json:"term_start" validate:"omitempty,datetime=2006-01-02"`
+	TermEnd   string `json:"term_end" validate:"omitempty,datetime=2006-01-02"`
+	IsDeputy  bool   `json:"is_deputy"`
 }
 
 // PutHeadmanRequest represents the request body for updating a headman
@@ -19,13 +26,48 @@ type PutHeadmanRequest struct {
 	HeadmanID int64  `json:"headman_id" validate:"required,numeric"`
 	StudentID int64  `json:"student_id" validate:"required,numeric"`
 	GroupID   string `json:"group_id" validate:"required,customgroupidregex"`
+	TermStart string `json:"term_start" validate:"required,datetime=2006-01-02"`
+	TermEnd   string `json:"term_end" validate:"omitempty,datetime=2006-01-02"`
+	IsDeputy  bool   `json:"is_deputy"`
 }
 
-// PatchHeadmanRequest represents the request body for partially updating a headman
+// PatchHeadmanRequest represents the request body for partially updating a headman,
+// set term_end to finish the term
 type PatchHeadmanRequest struct {
 	HeadmanID int64  `json:"headman_id" validate:"required,numeric"`
 	StudentID int64  `json:"student_id" validate:"omitempty,numeric"`
 	GroupID   string `json:"group_id" validate:"omitempty,customgroupidregex"`
+	TermStart string `json:"term_start" validate:"omitempty,datetime=2006-01-02"`
+	TermEnd   string `json:"term_end" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// parseTerm parses optional term dates of a headman request
+func parseTerm(termStart, termEnd string) (time.Time, *time.Time, error) {
+	var start time.Time
+	var end *time.Time
+	if termStart != "" {
+		date, err := time.Parse("2006-01-02", termStart)
+		if err != nil {
+			return start, end, err
+		}
+		start = date
+	}
+	if termEnd != "" {
+		date, err := time.Parse("2006-01-02", termEnd)
+		if err != nil {
+			return start, end, err
+		}
+		end = &date
+	}
+	return start, end, nil
+}
+
+func (h *Handler) respondHeadmanError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrHeadmanTermDates) || errors.Is(err, service.ErrHeadmanTermOverlap) {
+		respondWithError(h.logger, c, http.StatusConflict, err.Error())
+		return
+	}
+	respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
 }
 
 // CreateHeadman godoc
@@ -51,14 +93,23 @@ func (h *Handler) CreateHeadman(c *gin.Context) {
 		return
 	}
 
+	termStart, termEnd, err := parseTerm(req.TermStart, req.TermEnd)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	headman := domain.Headman{
 		StudentID: req.StudentID,
 		GroupID:   req.GroupID,
+		TermStart: termStart,
+		TermEnd:   termEnd,
+		IsDeputy:  req.IsDeputy,
 	}
 
-	err := h.services.HeadmanService.Create(c.Request.Context(), headman)
+	err = h.services.HeadmanService.Create(c.Request.Context(), headman)
 	if err != nil {
-		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		h.respondHeadmanError(c, err)
 		return
 	}
 
@@ -88,15 +139,24 @@ func (h *Handler) PutHeadman(c *gin.Context) {
 		return
 	}
 
+	termStart, termEnd, err := parseTerm(req.TermStart, req.TermEnd)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	headman := domain.Headman{
 		HeadmanID: req.HeadmanID,
 		StudentID: req.StudentID,
 		GroupID:   req.GroupID,
+		TermStart: termStart,
+		TermEnd:   termEnd,
+		IsDeputy:  req.IsDeputy,
 	}
 
-	err := h.services.HeadmanService.Put(c.Request.Context(), headman)
+	err = h.services.HeadmanService.Put(c.Request.Context(), headman)
 	if err != nil {
-		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		h.respondHeadmanError(c, err)
 		return
 	}
 
@@ -126,15 +186,23 @@ func (h *Handler) PatchHeadman(c *gin.Context) {
 		return
 	}
 
+	termStart, termEnd, err := parseTerm(req.TermStart, req.TermEnd)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	headman := domain.Headman{
 		HeadmanID: req.HeadmanID,
 		StudentID: req.StudentID,
 		GroupID:   req.GroupID,
+		TermStart: termStart,
+		TermEnd:   termEnd,
 	}
 
-	err := h.services.HeadmanService.Patch(c.Request.Context(), headman)
+	err = h.services.HeadmanService.Patch(c.Request.Context(), headman)
 	if err != nil {
-		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		h.respondHeadmanError(c, err)
 		return
 	}
 
@@ -222,6 +290,26 @@ func (h *Handler) GetHeadmanByStudentID(c *gin.Context) {
 	c.JSON(http.StatusOK, headman)
 }
 
+// GetHeadmenByGroupID godoc
+// @Summary Get the headman terms of a group
+// @Description Get all terms of the headmen and the deputy headmen of a group
+// @Tags Headmen
+// @Accept json
+// @Produce json
+// @Param group_id path string true "Group ID"
+// @Success 200 {array} domain.HeadmanInfo
+// @Failure 500 {object} ErrorResponse
+// @Router /headmen/group/{group_id} [get]
+func (h *Handler) GetHeadmenByGroupID(c *gin.Context) {
+	headmen, err := h.services.HeadmanService.GetByGroupID(c.Request.Context(), c.Param("group_id"))
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, headmen)
+}
+
 // GetAllHeadmen godoc
 // @Summary Get all headmen
 // @Description Get a list of all headmen
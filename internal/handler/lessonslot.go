@@ -0,0 +1,297 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ErrInvalidSlotID   = "Invalid lesson slot ID"
+	ErrSlotNotFound    = "Lesson slot not found"
+	ErrNoCurrentLesson = "No current lesson"
+	ErrNoNextLesson    = "No next lesson"
+)
+
+// LessonSlotRequest represents the request body for a lesson of the bell schedule
+type LessonSlotRequest struct {
+	UniversityID int64  `json:"university_id" validate:"required,min=1"`
+	SlotNumber   int    `json:"slot_number" validate:"required,min=1,max=12"`
+	StartTime    string `json:"start_time" validate:"required,time"`
+	EndTime      string `json:"end_time" validate:"required,time"`
+	BreakMinutes int    `json:"break_minutes" validate:"min=0,max=240"`
+}
+
+// PutLessonSlotRequest represents the request body for updating a lesson of the bell schedule
+type PutLessonSlotRequest struct {
+	SlotID int64 `json:"slot_id" validate:"required,min=1"`
+	LessonSlotRequest
+}
+
+func (r LessonSlotRequest) toDomain() (domain.LessonSlot, error) {
+	slot := domain.LessonSlot{
+		UniversityID: r.UniversityID,
+		SlotNumber:   r.SlotNumber,
+		BreakMinutes: r.BreakMinutes,
+	}
+
+	startTime, err := time.Parse("15:04", r.StartTime)
+	if err != nil {
+		return slot, err
+	}
+	endTime, err := time.Parse("15:04", r.EndTime)
+	if err != nil {
+		return slot, err
+	}
+	slot.StartTime = startTime
+	slot.EndTime = endTime
+	return slot, nil
+}
+
+func (h *Handler) respondLessonSlotError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrSlotTime) || errors.Is(err, service.ErrSlotOverlap) {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+}
+
+// CreateLessonSlot godoc
+// @Security ApiKeyAuth
+// @Summary Create a lesson slot
+// @Description Add a numbered lesson to the bell schedule of a university
+// @Tags LessonSlots
+// @Accept json
+// @Produce json
+// @Param slot body LessonSlotRequest true "Lesson slot info"
+// @Success 201 {object} domain.LessonSlot
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/lesson_slots [post]
+func (h *Handler) CreateLessonSlot(c *gin.Context) {
+	var req LessonSlotRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	slot, err := req.toDomain()
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	slot.SlotID, err = h.services.LessonSlotService.Create(c.Request.Context(), slot)
+	if err != nil {
+		h.respondLessonSlotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, slot)
+}
+
+// PutLessonSlot godoc
+// @Security ApiKeyAuth
+// @Summary Update a lesson slot
+// @Description Update a lesson of the bell schedule, the start time of the schedules in the slot follows it
+// @Tags LessonSlots
+// @Accept json
+// @Produce json
+// @Param slot body PutLessonSlotRequest true "Lesson slot info"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/lesson_slots [put]
+func (h *Handler) PutLessonSlot(c *gin.Context) {
+	var req PutLessonSlotRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	slot, err := req.toDomain()
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+	slot.SlotID = req.SlotID
+
+	if err := h.services.LessonSlotService.Put(c.Request.Context(), slot); err != nil {
+		h.respondLessonSlotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Lesson slot updated successfully"})
+}
+
+// DeleteLessonSlot godoc
+// @Security ApiKeyAuth
+// @Summary Delete a lesson slot
+// @Description Delete a lesson of the bell schedule by ID
+// @Tags LessonSlots
+// @Produce json
+// @Param id path int64 true "Lesson slot ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/lesson_slots/{id} [delete]
+func (h *Handler) DeleteLessonSlot(c *gin.Context) {
+	slotID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidSlotID)
+		return
+	}
+
+	if err := h.services.LessonSlotService.Delete(c.Request.Context(), slotID); err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Lesson slot deleted successfully"})
+}
+
+// GetLessonSlotByID godoc
+// @Security ApiKeyAuth
+// @Summary Get a lesson slot
+// @Description Get a lesson of the bell schedule by ID
+// @Tags LessonSlots
+// @Produce json
+// @Param id path int64 true "Lesson slot ID"
+// @Success 200 {object} domain.LessonSlot
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/lesson_slots/{id} [get]
+func (h *Handler) GetLessonSlotByID(c *gin.Context) {
+	slotID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidSlotID)
+		return
+	}
+
+	slot, err := h.services.LessonSlotService.GetByID(c.Request.Context(), slotID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			respondWithError(h.logger, c, http.StatusNotFound, ErrSlotNotFound)
+			return
+		}
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, slot)
+}
+
+// GetLessonSlotsByUniversityID godoc
+// @Security ApiKeyAuth
+// @Summary Get the bell schedule of a university
+// @Description Get the lesson slots of a university ordered by number
+// @Tags LessonSlots
+// @Produce json
+// @Param id path int64 true "University ID"
+// @Success 200 {array} domain.LessonSlot
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/lesson_slots/university/{id} [get]
+func (h *Handler) GetLessonSlotsByUniversityID(c *gin.Context) {
+	universityID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidUniversityID)
+		return
+	}
+
+	slots, err := h.services.LessonSlotService.GetByUniversityID(c.Request.Context(), universityID)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, slots)
+}
+
+// GetMyCurrentLesson godoc
+// @Security ApiKeyAuth
+// @Summary Get the current lesson
+// @Description Get the lesson going on right now for the teacher or for the group of the student
+// @Tags LessonSlots
+// @Produce json
+// @Success 200 {object} domain.SlotLesson
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /me/lessons/current [get]
+func (h *Handler) GetMyCurrentLesson(c *gin.Context) {
+	h.respondMyLesson(c,
+		h.services.ScheduleExceptionService.GetCurrentByTeacher,
+		h.services.ScheduleExceptionService.GetCurrentByGroup,
+		ErrNoCurrentLesson)
+}
+
+// GetMyNextLesson godoc
+// @Security ApiKeyAuth
+// @Summary Get the next lesson
+// @Description Get the nearest upcoming lesson for the teacher or for the group of the student
+// @Tags LessonSlots
+// @Produce json
+// @Success 200 {object} domain.SlotLesson
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /me/lessons/next [get]
+func (h *Handler) GetMyNextLesson(c *gin.Context) {
+	h.respondMyLesson(c,
+		h.services.ScheduleExceptionService.GetNextByTeacher,
+		h.services.ScheduleExceptionService.GetNextByGroup,
+		ErrNoNextLesson)
+}
+
+type (
+	teacherLessonFunc func(ctx context.Context, teacherID int64, now time.Time) (*domain.SlotLesson, error)
+	groupLessonFunc   func(ctx context.Context, groupID string, now time.Time) (*domain.SlotLesson, error)
+)
+
+// respondMyLesson looks the lesson up by the teacher of the user, or by the group when the user is not a teacher
+func (h *Handler) respondMyLesson(c *gin.Context, byTeacher teacherLessonFunc, byGroup groupLessonFunc, notFound string) {
+	var (
+		lesson *domain.SlotLesson
+		err    error
+	)
+	if data, ok := c.Get(teacherCtx); ok {
+		lesson, err = byTeacher(c.Request.Context(), data.(int64), time.Now())
+	} else if data, ok := c.Get(groupCtx); ok {
+		lesson, err = byGroup(c.Request.Context(), data.(string), time.Now())
+	} else {
+		respondWithError(h.logger, c, http.StatusForbidden, "Neither teacher nor group found in context")
+		return
+	}
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if lesson == nil {
+		respondWithError(h.logger, c, http.StatusNotFound, notFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, lesson)
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ErrWrongPassword     = "the current password is wrong"
+	ErrInvalidResetToken = "the reset token is invalid or expired"
+)
+
+// ChangePasswordRequest represents the request body for changing the own password
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" validate:"required,min=8,max=40"`
+	NewPassword string `json:"new_password" validate:"required,min=8,max=40,custompasswordregex,nefield=OldPassword"`
+}
+
+// ForgotPasswordRequest represents the request body for requesting a reset email
+type ForgotPasswordRequest struct {
+	Username string `json:"username" validate:"required,min=8,max=40,alphanum"`
+}
+
+// ResetPasswordRequest represents the request body for setting a password with a reset token
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required,max=128"`
+	NewPassword string `json:"new_password" validate:"required,min=8,max=40,custompasswordregex"`
+}
+
+// GetMe godoc
+// @Security ApiKeyAuth
+// @Summary Get the current user
+// @Description Get the account of the authorized user with group and teacher data
+// @Tags Me
+// @Produce json
+// @Success 200 {object} domain.UserInfo
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /me [get]
+func (h *Handler) GetMe(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.services.UserService.GetByID(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			respondWithError(h.logger, c, http.StatusNotFound, ErrUserNotFound)
+			return
+		}
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ChangeMyPassword godoc
+// @Security ApiKeyAuth
+// @Summary Change the own password
+// @Description Change the password of the authorized user, previously issued tokens are revoked
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Param password body ChangePasswordRequest true "Old and new passwords"
+// @Success 200 {object} service.Tokens
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /me/password [put]
+func (h *Handler) ChangeMyPassword(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	tokens, err := h.services.UserService.ChangePassword(c.Request.Context(), userID, req.OldPassword, req.NewPassword)
+	if err != nil {
+		if errors.Is(err, service.ErrWrongPassword) {
+			respondWithError(h.logger, c, http.StatusForbidden, ErrWrongPassword)
+			return
+		}
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Send a password reset code to the email of the user, if it is known
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param user body ForgotPasswordRequest true "Username"
+// @Success 202 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/password/forgot [post]
+func (h *Handler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	if err := h.services.PasswordResetService.Request(c.Request.Context(), req.Username); err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithSuccess(c, http.StatusAccepted, "If the account has an email, a reset code has been sent")
+}
+
+// ResetPassword godoc
+// @Summary Reset a password
+// @Description Set a new password using a single-use reset token
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param reset body ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/password/reset [post]
+func (h *Handler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	if err := h.services.PasswordResetService.Reset(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		if errors.Is(err, service.ErrInvalidResetToken) {
+			respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidResetToken)
+			return
+		}
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, "Password changed successfully")
+}
+
+// CreateUserPasswordReset godoc
+// @Security ApiKeyAuth
+// @Summary Issue a password reset token
+// @Description Issue a single-use password reset token for a user, the token is shown once
+// @Tags Users
+// @Produce json
+// @Param id path uuid.UUID true "User ID"
+// @Success 201 {object} service.ResetToken
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/users/{id}/password_reset [post]
+func (h *Handler) CreateUserPasswordReset(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidUserID)
+		return
+	}
+
+	token, err := h.services.PasswordResetService.CreateForUser(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			respondWithError(h.logger, c, http.StatusNotFound, ErrUserNotFound)
+			return
+		}
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, token)
+}
+
+func (h *Handler) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	data, ok := c.Get(userCtx)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusUnauthorized, "User ID not found in context")
+		return uuid.Nil, false
+	}
+
+	userID, ok := data.(uuid.UUID)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusInternalServerError, "Failed to convert user ID")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+)
+
+const ErrStudentNotFound = "Student not found"
+
+// TransferStudentRequest represents the request body for moving a student to another group
+type TransferStudentRequest struct {
+	StudentID int64  `json:"student_id" validate:"required,min=1"`
+	GroupID   string `json:"group_id" validate:"required,customgroupidregex"`
+	Date      string `json:"date" validate:"required,datetime=2006-01-02"`
+	Reason    string `json:"reason" validate:"omitempty,max=255"`
+}
+
+// PromoteGroupsRequest represents the request body for the yearly promotion of groups
+type PromoteGroupsRequest struct {
+	Date       string                  `json:"date" validate:"required,datetime=2006-01-02"`
+	Promotions []GroupPromotionRequest `json:"promotions" validate:"required,min=1,dive"`
+}
+
+type GroupPromotionRequest struct {
+	FromGroupID string `json:"from_group_id" validate:"required,customgroupidregex"`
+	ToGroupID   string `json:"to_group_id" validate:"required,customgroupidregex,nefield=FromGroupID"`
+}
+
+// GetStudentGroups godoc
+// @Security ApiKeyAuth
+// @Summary Get the group history of a student
+// @Description Get all groups of a student with the periods of membership
+// @Tags Students
+// @Produce json
+// @Param id path int64 true "Student ID"
+// @Success 200 {array} domain.GroupMembership
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/students/{id}/groups [get]
+func (h *Handler) GetStudentGroups(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidStudentID)
+		return
+	}
+
+	memberships, err := h.services.MembershipService.GetByStudentID(c.Request.Context(), studentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			respondWithError(h.logger, c, http.StatusNotFound, ErrStudentNotFound)
+			return
+		}
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, memberships)
+}
+
+// TransferStudent godoc
+// @Security ApiKeyAuth
+// @Summary Transfer a student
+// @Description Move a student to another group from the given date, the previous group stays in the history
+// @Tags Students
+// @Accept json
+// @Produce json
+// @Param transfer body TransferStudentRequest true "Transfer info"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/students/transfers [post]
+func (h *Handler) TransferStudent(c *gin.Context) {
+	var req TransferStudentRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	transfer := domain.StudentTransfer{
+		StudentID: req.StudentID,
+		GroupID:   req.GroupID,
+		Date:      date,
+		Reason:    req.Reason,
+	}
+
+	err = h.services.MembershipService.Transfer(c.Request.Context(), transfer)
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			respondWithError(h.logger, c, http.StatusNotFound, ErrStudentNotFound)
+		case errors.Is(err, service.ErrGroupNotFound):
+			respondWithError(h.logger, c, http.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrSameGroup), errors.Is(err, service.ErrTransferDate):
+			respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		default:
+			respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, "Student transferred successfully")
+}
+
+// PromoteGroups godoc
+// @Security ApiKeyAuth
+// @Summary Promote groups
+// @Description Move all students of every source group to its target group from the given date in one transaction, missing target groups are created with the profile of the source group
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param promotion body PromoteGroupsRequest true "Promotion info"
+// @Param dry_run query bool false "Only preview the changes"
+// @Success 200 {object} domain.PromotionResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/groups/promotions [post]
+func (h *Handler) PromoteGroups(c *gin.Context) {
+	dryRun, err := queryBool(c, "dry_run")
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidImportOption)
+		return
+	}
+
+	var req PromoteGroupsRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	promotions := make([]domain.GroupPromotion, 0, len(req.Promotions))
+	for _, promotion := range req.Promotions {
+		promotions = append(promotions, domain.GroupPromotion{
+			FromGroupID: promotion.FromGroupID,
+			ToGroupID:   promotion.ToGroupID,
+		})
+	}
+
+	result, err := h.services.MembershipService.Promote(c.Request.Context(), promotions, date, dryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrGroupNotFound):
+			respondWithError(h.logger, c, http.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrSameGroup), errors.Is(err, service.ErrDuplicatePromotion), errors.Is(err, service.ErrTransferDate):
+			respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		default:
+			respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
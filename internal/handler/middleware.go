@@ -2,9 +2,16 @@ package handler
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/BeRebornBng/OsauAmsApi/pkg/auth"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/ratelimit"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/requestid"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -15,33 +22,87 @@ const (
 	roleCtx             = "user_role"
 	groupCtx            = "group_id"
 	teacherCtx          = "teacher_id"
+	studentCtx          = "student_id"
+	headmanCtx          = "headman_id"
+	requestIDCtx        = "request_id"
+	ErrTooManyRequests  = "Too many requests"
+	ErrTokenRevoked     = "Token has been revoked"
+	ErrRequestTooLarge  = "Request body is too large"
 )
 
-func (h *Handler) parseAuthHeader(c *gin.Context) (string, error) {
+// RequestID propagates the incoming X-Request-ID or generates a new one. The incoming id
+// ends up in the logs and the response headers, so only short ids of safe characters are kept
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !requestIDRegex.MatchString(id) {
+			id = uuid.NewString()
+		}
+
+		c.Set(requestIDCtx, id)
+		c.Request = c.Request.WithContext(requestid.WithID(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+		c.Next()
+	}
+}
+
+// SecurityHeaders asks browsers not to sniff content types, frame the API or leak the referrer,
+// with hsts they also keep to HTTPS
+func SecurityHeaders(hsts bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("X-Content-Type-Options", "nosniff")
+		c.Header("X-Frame-Options", "DENY")
+		c.Header("Referrer-Policy", "no-referrer")
+		c.Header("Content-Security-Policy", "frame-ancestors 'none'")
+		if hsts {
+			c.Header("Strict-Transport-Security", "max-age=31536000; includeSubDomains")
+		}
+		c.Next()
+	}
+}
+
+// BodyLimit rejects request bodies larger than limit bytes, 0 disables the limit
+func BodyLimit(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+		if c.Request.ContentLength > limit {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, ErrorResponse{Message: ErrRequestTooLarge})
+			return
+		}
+		// bodies without a length fail to read past the limit
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
+
+func (h *Handler) parseAuthHeader(c *gin.Context) (*auth.CustomClaims, error) {
 	header := c.GetHeader(authorizationHeader)
 	if header == "" {
-		return "", errors.New("empty auth header")
+		return nil, errors.New("empty auth header")
 	}
 
 	headerParts := strings.Split(header, " ")
 	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
-		return "", errors.New("invalid auth header")
+		return nil, errors.New("invalid auth header")
 	}
 
 	if len(headerParts[1]) == 0 {
-		return "", errors.New("token is empty")
+		return nil, errors.New("token is empty")
 	}
 
-	return h.TokenManager.Parse(headerParts[1])
+	return h.TokenManager.ParseClaims(headerParts[1])
 }
 
 func (h *Handler) userIdentity(c *gin.Context) {
-	id, err := h.parseAuthHeader(c)
+	claims, err := h.parseAuthHeader(c)
 	if err != nil {
 		respondWithError(h.logger, c, http.StatusForbidden, err.Error())
 		return
 	}
-	userID, err := uuid.Parse(id)
+	userID, err := uuid.Parse(claims.Subject)
 	if err != nil {
 		respondWithError(h.logger, c, http.StatusForbidden, err.Error())
 		return
@@ -51,6 +112,10 @@ func (h *Handler) userIdentity(c *gin.Context) {
 		respondWithError(h.logger, c, http.StatusForbidden, err.Error())
 		return
 	}
+	if claims.TokenVersion != user.User.TokenVersion {
+		respondWithError(h.logger, c, http.StatusUnauthorized, ErrTokenRevoked)
+		return
+	}
 
 	c.Set(userCtx, userID)
 	c.Set(roleCtx, user.User.Role)
@@ -60,6 +125,12 @@ func (h *Handler) userIdentity(c *gin.Context) {
 	if user.User.TeacherID != nil {
 		c.Set(teacherCtx, *user.User.TeacherID)
 	}
+	if user.User.StudentID != nil {
+		c.Set(studentCtx, *user.User.StudentID)
+	}
+	if user.User.HeadmanID != nil {
+		c.Set(headmanCtx, *user.User.HeadmanID)
+	}
 }
 
 func RoleMiddleware(role string) gin.HandlerFunc {
@@ -72,3 +143,34 @@ func RoleMiddleware(role string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// rateLimit applies a token bucket per user, or per client IP for anonymous requests
+func (h *Handler) rateLimit(scope string, limit ratelimit.Limit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.options.Limiter == nil || !limit.Enabled() {
+			c.Next()
+			return
+		}
+
+		key := scope + ":ip:" + c.ClientIP()
+		if userID, ok := c.Get(userCtx); ok {
+			key = fmt.Sprintf("%s:user:%v", scope, userID)
+		}
+
+		allowed, retryAfter, err := h.options.Limiter.Allow(c.Request.Context(), key, limit)
+		if err != nil {
+			respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if !allowed {
+			setRetryAfter(c, retryAfter)
+			respondWithError(h.logger, c, http.StatusTooManyRequests, ErrTooManyRequests)
+			return
+		}
+		c.Next()
+	}
+}
+
+func setRetryAfter(c *gin.Context, retryAfter time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+}
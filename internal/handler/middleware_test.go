@@ -0,0 +1,348 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository/memory"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/auth"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/myhash"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestRoleMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       any
+		wantStatus int
+	}{
+		{name: "no role", wantStatus: http.StatusForbidden},
+		{name: "another role", role: "Студент", wantStatus: http.StatusForbidden},
+		{name: "role of another type", role: []byte("Админ"), wantStatus: http.StatusForbidden},
+		{name: "required role", role: "Админ", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.role != nil {
+					c.Set(roleCtx, tt.role)
+				}
+			})
+			router.GET("/", RoleMiddleware("Админ"), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusForbidden && !strings.Contains(w.Body.String(), "Forbidden") {
+				t.Fatalf("body = %s, want the forbidden message", w.Body.String())
+			}
+		})
+	}
+}
+
+// testAPI serves the routes with the in-memory repositories and signs tokens
+// for the users it creates
+type testAPI struct {
+	router *gin.Engine
+	repos  *repository.Repositories
+	tokens *auth.Manager
+}
+
+func newTestAPI(t *testing.T) *testAPI {
+	t.Helper()
+	return newTestAPIWith(t, nil)
+}
+
+// newTestAPIWith lets setup change the services support, e.g. to add a login guard
+func newTestAPIWith(t *testing.T, setup func(*service.Support)) *testAPI {
+	t.Helper()
+	repos := memory.NewRepositories()
+	tokens := auth.NewManager("test-signing-key")
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	support := service.Support{
+		Repos:          repos,
+		Hasher:         myhash.NewHasher("salt", 4),
+		TokenManager:   tokens,
+		AccessTokenTTL: time.Hour,
+		ResetTokenTTL:  time.Hour,
+	}
+	if setup != nil {
+		setup(&support)
+	}
+	h := NewHandler(tokens, service.NewServices(support), logger, Options{})
+	return &testAPI{router: h.InitRoutes(), repos: repos, tokens: tokens}
+}
+
+// token creates a user with the role and returns an access token for it
+func (a *testAPI) token(t *testing.T, username, role string, studentID *int64) string {
+	t.Helper()
+	return a.account(t, domain.User{Username: username, Password: "hash", Role: role, StudentID: studentID})
+}
+
+// account creates the user and returns an access token for it
+func (a *testAPI) account(t *testing.T, user domain.User) string {
+	t.Helper()
+	ctx := context.Background()
+	if err := a.repos.User.Create(ctx, user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	created, err := a.repos.User.GetByName(ctx, user.Username)
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	token, err := a.tokens.NewJWT(created.User.UserID.String(), user.Role, created.User.TokenVersion, time.Hour)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+func (a *testAPI) do(method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set(authorizationHeader, "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	a.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAdminRoutes(t *testing.T) {
+	api := newTestAPI(t)
+	studentID := int64(1)
+	admin := api.token(t, "adminadmin", "Админ", nil)
+	student := api.token(t, "studentstudent", "Студент", &studentID)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		body       string
+		wantStatus int
+	}{
+		{name: "no token", method: http.MethodGet, path: "/api/admins/faculties", wantStatus: http.StatusForbidden},
+		{name: "invalid token", method: http.MethodGet, path: "/api/admins/faculties", token: "invalid", wantStatus: http.StatusForbidden},
+		{name: "student", method: http.MethodGet, path: "/api/admins/faculties", token: student, wantStatus: http.StatusForbidden},
+		{name: "admin", method: http.MethodGet, path: "/api/admins/faculties", token: admin, wantStatus: http.StatusOK},
+		{
+			name:       "user with the id of another role",
+			method:     http.MethodPost,
+			path:       "/api/admins/users",
+			token:      admin,
+			body:       `{"username":"teacherteacher","password":"password1","role":"Преподаватель","teacher_id":1,"student_id":1}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "admin with a teacher id",
+			method:     http.MethodPost,
+			path:       "/api/admins/users",
+			token:      admin,
+			body:       `{"username":"secondadmin","password":"password1","role":"Админ","teacher_id":1}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "teacher",
+			method:     http.MethodPost,
+			path:       "/api/admins/users",
+			token:      admin,
+			body:       `{"username":"teacherteacher","password":"password1","role":"Преподаватель","teacher_id":1}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "taken username",
+			method:     http.MethodPost,
+			path:       "/api/admins/users",
+			token:      admin,
+			body:       `{"username":"teacherteacher","password":"password1","role":"Преподаватель","teacher_id":2}`,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "student creates a user",
+			method:     http.MethodPost,
+			path:       "/api/admins/users",
+			token:      student,
+			body:       `{"username":"otherteacher","password":"password1","role":"Преподаватель","teacher_id":3}`,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := api.do(tt.method, tt.path, tt.token, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRevokedToken(t *testing.T) {
+	api := newTestAPI(t)
+	admin := api.token(t, "adminadmin", "Админ", nil)
+
+	user, err := api.repos.User.GetByName(context.Background(), "adminadmin")
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	if err := api.repos.User.RevokeTokens(context.Background(), user.User.UserID); err != nil {
+		t.Fatalf("revoke tokens: %v", err)
+	}
+
+	if w := api.do(http.MethodGet, "/api/admins/faculties", admin, ""); w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestLoggerRedactsSecrets(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		hidden []string
+		shown  []string
+	}{
+		{
+			name:   "auth",
+			path:   "/api/auth/signin",
+			body:   `{"username":"studentuser","password":"Password1!"}`,
+			hidden: []string{"studentuser", "Password1!"},
+		},
+		{
+			name:   "password change",
+			path:   "/api/me/password",
+			body:   `{"old_password":"Password1!","new_password":"Password2!"}`,
+			hidden: []string{"Password1!", "Password2!"},
+		},
+		{
+			name:   "user",
+			path:   "/api/admins/users",
+			body:   `{"username":"novikova","Password":"Password1!","role":"Преподаватель"}`,
+			hidden: []string{"Password1!"},
+			shown:  []string{"novikova", "Преподаватель"},
+		},
+		{
+			name:   "student with an account",
+			path:   "/api/admins/students",
+			body:   `{"last_name":"Борисов","account":{"username":"borisov","password":"Password1!"}}`,
+			hidden: []string{"Password1!"},
+			shown:  []string{"Борисов", "borisov"},
+		},
+		{
+			name:   "cut body",
+			path:   "/api/admins/users",
+			body:   `{"username":"novikova","password":"Password1!"`,
+			hidden: []string{"Password1!"},
+		},
+		{
+			name:  "no secrets",
+			path:  "/api/admins/faculties",
+			body:  `{"faculty_name":"Инженерный"}`,
+			shown: []string{"Инженерный"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs strings.Builder
+			log := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			var got string
+			router := gin.New()
+			router.Use(Logger(log))
+			router.POST(tt.path, func(c *gin.Context) {
+				body, _ := io.ReadAll(c.Request.Body)
+				got = string(body)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.body {
+				t.Errorf("handler body = %q, want %q", got, tt.body)
+			}
+			for _, secret := range tt.hidden {
+				if strings.Contains(logs.String(), secret) {
+					t.Errorf("log has %q: %s", secret, logs.String())
+				}
+			}
+			for _, value := range tt.shown {
+				if !strings.Contains(logs.String(), value) {
+					t.Errorf("log has no %q: %s", value, logs.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		kept     bool
+	}{
+		{name: "uuid", incoming: "3f2b8c1e-9d4a-4f6b-8e2a-1c5d7e9f0a3b", kept: true},
+		{name: "trace id", incoming: "trace.01:span_2-A", kept: true},
+		{name: "longest", incoming: strings.Repeat("a", 128), kept: true},
+		{name: "missing"},
+		{name: "too long", incoming: strings.Repeat("a", 129)},
+		{name: "space", incoming: "request id"},
+		{name: "log injection", incoming: "id\" level=ERROR msg=\"forged"},
+		{name: "not ascii", incoming: "запрос"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			router := gin.New()
+			router.Use(RequestID())
+			router.GET("/", func(c *gin.Context) {
+				got, _ = requestid.FromContext(c.Request.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(requestid.Header, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if header := w.Header().Get(requestid.Header); header != got {
+				t.Errorf("header = %q, context = %q", header, got)
+			}
+			if tt.kept {
+				if got != tt.incoming {
+					t.Errorf("request id = %q, want %q", got, tt.incoming)
+				}
+				return
+			}
+			if _, err := uuid.Parse(got); err != nil {
+				t.Errorf("request id = %q, want a generated uuid", got)
+			}
+		})
+	}
+}
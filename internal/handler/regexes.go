@@ -10,6 +10,7 @@ const (
 	alphaRusRegexString     = "^[А-Яа-я ]+$"
 	alphaNumRusRegexString  = "^[А-Яа-я\\d ]+$"
 	dateRegexString         = `^\d{4}-\d{2}-\d{2}$`
+	requestIDRegexString    = `^[A-Za-z0-9._:-]{1,128}$`
 )
 
 var (
@@ -20,4 +21,5 @@ var (
 	alphaRusRegex     = regexp.MustCompile(alphaRusRegexString)
 	alphaNumRusRegex  = regexp.MustCompile(alphaNumRusRegexString)
 	dateRegex         = regexp.MustCompile(dateRegexString)
+	requestIDRegex    = regexp.MustCompile(requestIDRegexString)
 )
@@ -11,7 +11,7 @@ type response struct {
 }
 
 func respondWithError(logger *slog.Logger, c *gin.Context, statusCode int, message string) {
-	logger.Error(message)
+	logger.ErrorContext(c.Request.Context(), message, slog.Int("status", statusCode), slog.String("path", c.Request.URL.Path))
 	c.AbortWithStatusJSON(statusCode, ErrorResponse{Message: message})
 }
 
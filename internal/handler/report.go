@@ -2,9 +2,13 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
 )
 
 type HeadmanReport struct {
@@ -13,7 +17,58 @@ type HeadmanReport struct {
 	EndRange   string `json:"end_range" validate:"required,datetime=2006-01-02"`
 }
 
+// attendanceReportHeader is the header of an exported attendance report
+var attendanceReportHeader = []string{
+	"Дата", "Семестр", "Неделя", "День недели", "Дисциплина", "Вид занятия", "Аудитория", "Преподаватель",
+	"Студент", "Присутствие", "Опоздание", "Уважительная причина", "Причина", "Посещения", "Пропуски", "Всего", "Процент посещений",
+}
+
+func attendanceReportRows(report *domain.AttendanceReport) [][]string {
+	flag := func(value *bool) string {
+		if value == nil {
+			return ""
+		}
+		if *value {
+			return "да"
+		}
+		return "нет"
+	}
+
+	rows := make([][]string, 0, len(report.ReportData))
+	for _, data := range report.ReportData {
+		reason := ""
+		if data.Reason != nil {
+			reason = *data.Reason
+		}
+		rows = append(rows, []string{
+			data.Created.Format("2006-01-02"),
+			strconv.FormatInt(data.Semester, 10),
+			data.WeekType,
+			data.DayOfWeek,
+			data.DisciplineName,
+			data.DisciplineTypeName,
+			data.ClassroomName,
+			data.TeacherName,
+			data.StudentName,
+			flag(data.Presence),
+			flag(data.LateArrival),
+			flag(data.Respectfulness),
+			reason,
+			strconv.FormatInt(data.Visits, 10),
+			strconv.FormatInt(data.Passes, 10),
+			strconv.FormatInt(data.Total, 10),
+			strconv.FormatFloat(data.PercentageOfVisits, 'f', 2, 64),
+		})
+	}
+	return rows
+}
+
 func (h *Handler) GetActualReportByGroupIDAndCreated(c *gin.Context) {
+	format, ok := exportFormat(c)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidExportFormat)
+		return
+	}
 
 	start_range, err := time.Parse("2006-01-02", c.Param("start_date"))
 	if err != nil {
@@ -46,7 +101,13 @@ func (h *Handler) GetActualReportByGroupIDAndCreated(c *gin.Context) {
 	}
 
 	if attendanceData, err := h.services.ReportService.GetActualReportByGroupIDCreated(c.Request.Context(), report.GroupID, start_range, end_range); err == nil {
-		c.JSON(http.StatusOK, attendanceData)
+		_, span := otel.Tracer(serviceName).Start(c.Request.Context(), "handler.EncodeReport")
+		if format == formatJSON {
+			c.JSON(http.StatusOK, attendanceData)
+		} else {
+			h.respondTable(c, format, "attendance_"+report.GroupID, attendanceReportHeader, attendanceReportRows(attendanceData))
+		}
+		span.End()
 	} else {
 		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
 		return
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// RolloverRequest represents the request body for moving the timetable to the next semester
+type RolloverRequest struct {
+	Semester     int               `json:"semester" validate:"required,min=1"`
+	NextSemester int               `json:"next_semester" validate:"required,min=1,nefield=Semester"`
+	BeginStudies string            `json:"begin_studies" validate:"required,datetime=2006-01-02"`
+	GroupIDs     []string          `json:"group_ids" validate:"required,min=1,unique,dive,customgroupidregex"`
+	ClassroomMap []RolloverMapping `json:"classroom_map" validate:"omitempty,dive"`
+	TeacherMap   []RolloverMapping `json:"teacher_map" validate:"omitempty,dive"`
+}
+
+// RolloverMapping replaces an old classroom or teacher with a new one in the cloned schedules
+type RolloverMapping struct {
+	From int64 `json:"from" validate:"required,min=1"`
+	To   int64 `json:"to" validate:"required,min=1"`
+}
+
+// RolloverSchedules godoc
+// @Security ApiKeyAuth
+// @Summary Roll the timetable over to the next semester
+// @Description Mark the actual schedules of the semester as not actual and clone the schedules of the selected groups into the next semester in one transaction. With dry_run only the diff is returned
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Param rollover body RolloverRequest true "Rollover info"
+// @Param dry_run query bool false "Only preview the changes"
+// @Success 200 {object} domain.RolloverDiff
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/schedules/rollover [post]
+func (h *Handler) RolloverSchedules(c *gin.Context) {
+	dryRun, err := queryBool(c, "dry_run")
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidImportOption)
+		return
+	}
+
+	var req RolloverRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	beginStudies, err := time.Parse("2006-01-02", req.BeginStudies)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	rollover := domain.SemesterRollover{
+		Semester:     req.Semester,
+		NextSemester: req.NextSemester,
+		BeginStudies: beginStudies,
+		GroupIDs:     req.GroupIDs,
+		ClassroomMap: rolloverMappings(req.ClassroomMap),
+		TeacherMap:   rolloverMappings(req.TeacherMap),
+	}
+
+	diff, err := h.services.RolloverService.Rollover(c.Request.Context(), rollover, dryRun)
+	if err != nil {
+		var mappingErr *service.MappingError
+		switch {
+		case errors.As(err, &mappingErr), errors.Is(err, service.ErrSameSemester):
+			respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrNothingToRollover):
+			respondWithError(h.logger, c, http.StatusConflict, err.Error())
+		default:
+			respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+func rolloverMappings(mappings []RolloverMapping) map[int64]int64 {
+	result := make(map[int64]int64, len(mappings))
+	for _, mapping := range mappings {
+		result[mapping.From] = mapping.To
+	}
+	return result
+}
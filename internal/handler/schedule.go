@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
 	"github.com/gin-gonic/gin"
 )
 
@@ -32,7 +34,8 @@ type CreateScheduleRequest struct {
 	BeginStudies     string `json:"begin_studies" validate:"required,datetime=2006-01-02"`
 	WeekType         string `json:"week_type" validate:"required,oneof=Верхняя Нижняя"`
 	DayOfWeek        string `json:"day_of_week" validate:"required,oneof=Понедельник Вторник Среда Четверг Пятница Суббота Воскресенье"`
-	StartTime        string `json:"start_time" validate:"required,time"`
+	SlotID           int64  `json:"slot_id" validate:"required,min=1"`
+	SubgroupID       *int64 `json:"subgroup_id" validate:"omitempty,min=1"`
 	IsActual         *bool  `json:"is_actual" validate:"required,boolean"`
 }
 
@@ -48,7 +51,8 @@ type PutScheduleRequest struct {
 	BeginStudies     string `json:"begin_studies" validate:"required,datetime=2006-01-02"`
 	WeekType         string `json:"week_type" validate:"required,oneof=Верхняя Нижняя"`
 	DayOfWeek        string `json:"day_of_week" validate:"required,oneof=Понедельник Вторник Среда Четверг Пятница Суббота Воскресенье"`
-	StartTime        string `json:"start_time" validate:"required,time"`
+	SlotID           int64  `json:"slot_id" validate:"required,min=1"`
+	SubgroupID       *int64 `json:"subgroup_id" validate:"omitempty,min=1"`
 	IsActual         *bool  `json:"is_actual" validate:"required,boolean"`
 }
 
@@ -64,7 +68,8 @@ type PatchScheduleRequest struct {
 	BeginStudies     string `json:"begin_studies" validate:"omitempty,datetime=2006-01-02"`
 	WeekType         string `json:"week_type" validate:"omitempty,oneof=Верхняя Нижняя"`
 	DayOfWeek        string `json:"day_of_week" validate:"omitempty,oneof=Понедельник Вторник Среда Четверг Пятница Суббота Воскресенье"`
-	StartTime        string `json:"start_time" validate:"omitempty,time"`
+	SlotID           int64  `json:"slot_id" validate:"omitempty,min=1"`
+	SubgroupID       *int64 `json:"subgroup_id" validate:"omitempty,min=1"`
 	IsActual         *bool  `json:"is_actual" validate:"omitempty,boolean"`
 }
 
@@ -123,12 +128,6 @@ func (h *Handler) CreateSchedule(c *gin.Context) {
 		return
 	}
 
-	startTime, err := time.Parse("15:04", req.StartTime)
-	if err != nil {
-		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
-		return
-	}
-
 	schedule := domain.Schedule{
 		GroupID:          req.GroupID,
 		DisciplineID:     req.DisciplineID,
@@ -139,11 +138,16 @@ func (h *Handler) CreateSchedule(c *gin.Context) {
 		BeginStudies:     date,
 		WeekType:         req.WeekType,
 		DayOfWeek:        req.DayOfWeek,
-		StartTime:        startTime,
+		SlotID:           &req.SlotID,
+		SubgroupID:       req.SubgroupID,
 		IsActual:         req.IsActual,
 	}
 
 	err = h.services.ScheduleService.Create(c.Request.Context(), schedule)
+	if errors.Is(err, service.ErrSlotNotFound) || errors.Is(err, service.ErrSubgroupGroup) || errors.Is(err, service.ErrNotInCurriculum) {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		respondWithError(h.logger, c, http.StatusInternalServerError, ErrInternalServerError)
 		return
@@ -181,12 +185,6 @@ func (h *Handler) PutSchedule(c *gin.Context) {
 		return
 	}
 
-	startTime, err := time.Parse("15:04", req.StartTime)
-	if err != nil {
-		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
-		return
-	}
-
 	schedule := domain.Schedule{
 		ScheduleID:       req.ScheduleID,
 		GroupID:          req.GroupID,
@@ -198,11 +196,16 @@ func (h *Handler) PutSchedule(c *gin.Context) {
 		BeginStudies:     date,
 		WeekType:         req.WeekType,
 		DayOfWeek:        req.DayOfWeek,
-		StartTime:        startTime,
+		SlotID:           &req.SlotID,
+		SubgroupID:       req.SubgroupID,
 		IsActual:         req.IsActual,
 	}
 
 	err = h.services.ScheduleService.Put(c.Request.Context(), schedule)
+	if errors.Is(err, service.ErrSlotNotFound) || errors.Is(err, service.ErrSubgroupGroup) || errors.Is(err, service.ErrNotInCurriculum) {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		respondWithError(h.logger, c, http.StatusInternalServerError, ErrInternalServerError)
 		return
@@ -240,12 +243,6 @@ func (h *Handler) PatchSchedule(c *gin.Context) {
 		return
 	}
 
-	startTime, err := time.Parse("15:04", req.StartTime)
-	if err != nil {
-		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
-		return
-	}
-
 	schedule := domain.Schedule{
 		ScheduleID:       req.ScheduleID,
 		GroupID:          req.GroupID,
@@ -257,11 +254,18 @@ func (h *Handler) PatchSchedule(c *gin.Context) {
 		BeginStudies:     date,
 		WeekType:         req.WeekType,
 		DayOfWeek:        req.DayOfWeek,
-		StartTime:        startTime,
+		SubgroupID:       req.SubgroupID,
 		IsActual:         req.IsActual,
 	}
+	if req.SlotID != 0 {
+		schedule.SlotID = &req.SlotID
+	}
 
 	err = h.services.ScheduleService.Patch(c.Request.Context(), schedule)
+	if errors.Is(err, service.ErrSlotNotFound) || errors.Is(err, service.ErrSubgroupGroup) || errors.Is(err, service.ErrNotInCurriculum) {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		respondWithError(h.logger, c, http.StatusInternalServerError, ErrInternalServerError)
 		return
@@ -0,0 +1,382 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ErrInvalidExceptionID = "Invalid schedule exception ID"
+	ErrExceptionNotFound  = "Schedule exception not found"
+	ErrInvalidDate        = "Invalid date"
+)
+
+// ScheduleExceptionRequest represents the request body for a one-off change of a lesson,
+// the lesson is either cancelled or held with the given teacher, classroom, date or time
+type ScheduleExceptionRequest struct {
+	ScheduleID  int64   `json:"schedule_id" validate:"required,min=1"`
+	LessonDate  string  `json:"lesson_date" validate:"required,datetime=2006-01-02"`
+	IsCancelled bool    `json:"is_cancelled"`
+	TeacherID   *int64  `json:"teacher_id" validate:"omitempty,min=1"`
+	ClassroomID *int64  `json:"classroom_id" validate:"omitempty,min=1"`
+	MovedToDate string  `json:"moved_to_date" validate:"omitempty,datetime=2006-01-02"`
+	StartTime   string  `json:"start_time" validate:"omitempty,time"`
+	Reason      *string `json:"reason" validate:"omitempty,max=255"`
+}
+
+// PutScheduleExceptionRequest represents the request body for updating a schedule exception
+type PutScheduleExceptionRequest struct {
+	ExceptionID int64 `json:"exception_id" validate:"required,min=1"`
+	ScheduleExceptionRequest
+}
+
+func (r ScheduleExceptionRequest) toDomain() (domain.ScheduleException, error) {
+	exception := domain.ScheduleException{
+		ScheduleID:  r.ScheduleID,
+		IsCancelled: r.IsCancelled,
+		TeacherID:   r.TeacherID,
+		ClassroomID: r.ClassroomID,
+		Reason:      r.Reason,
+	}
+
+	lessonDate, err := time.Parse("2006-01-02", r.LessonDate)
+	if err != nil {
+		return exception, err
+	}
+	exception.LessonDate = lessonDate
+
+	if r.MovedToDate != "" {
+		movedToDate, err := time.Parse("2006-01-02", r.MovedToDate)
+		if err != nil {
+			return exception, err
+		}
+		exception.MovedToDate = &movedToDate
+	}
+	if r.StartTime != "" {
+		startTime, err := time.Parse("15:04", r.StartTime)
+		if err != nil {
+			return exception, err
+		}
+		exception.StartTime = &startTime
+	}
+	return exception, nil
+}
+
+func (h *Handler) respondScheduleExceptionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrExceptionNoChanges), errors.Is(err, service.ErrExceptionCancelled), errors.Is(err, service.ErrLessonNotOnDate),
+		errors.Is(err, service.ErrNotTeachingDay):
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		respondWithError(h.logger, c, http.StatusNotFound, ErrScheduleNotFound)
+	default:
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// CreateScheduleException godoc
+// @Security ApiKeyAuth
+// @Summary Create a schedule exception
+// @Description Cancel a lesson on the date or change its teacher, classroom, date or time
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Param exception body ScheduleExceptionRequest true "Schedule exception info"
+// @Success 201 {object} domain.ScheduleExceptionInfo
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/schedules/exceptions [post]
+func (h *Handler) CreateScheduleException(c *gin.Context) {
+	var req ScheduleExceptionRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	exception, err := req.toDomain()
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	exceptionID, err := h.services.ScheduleExceptionService.Create(c.Request.Context(), exception)
+	if err != nil {
+		h.respondScheduleExceptionError(c, err)
+		return
+	}
+
+	created, err := h.services.ScheduleExceptionService.GetByID(c.Request.Context(), exceptionID)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// PutScheduleException godoc
+// @Security ApiKeyAuth
+// @Summary Update a schedule exception
+// @Description Update a one-off change of a lesson
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Param exception body PutScheduleExceptionRequest true "Schedule exception info"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/schedules/exceptions [put]
+func (h *Handler) PutScheduleException(c *gin.Context) {
+	var req PutScheduleExceptionRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	exception, err := req.toDomain()
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+	exception.ExceptionID = req.ExceptionID
+
+	if err := h.services.ScheduleExceptionService.Put(c.Request.Context(), exception); err != nil {
+		h.respondScheduleExceptionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Schedule exception updated successfully"})
+}
+
+// DeleteScheduleException godoc
+// @Security ApiKeyAuth
+// @Summary Delete a schedule exception
+// @Description Delete a one-off change, the lesson follows the timetable again
+// @Tags Schedules
+// @Produce json
+// @Param id path int64 true "Schedule exception ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/schedules/exceptions/{id} [delete]
+func (h *Handler) DeleteScheduleException(c *gin.Context) {
+	exceptionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidExceptionID)
+		return
+	}
+
+	if err := h.services.ScheduleExceptionService.Delete(c.Request.Context(), exceptionID); err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Schedule exception deleted successfully"})
+}
+
+// GetScheduleExceptionByID godoc
+// @Security ApiKeyAuth
+// @Summary Get a schedule exception
+// @Description Get a schedule exception by ID
+// @Tags Schedules
+// @Produce json
+// @Param id path int64 true "Schedule exception ID"
+// @Success 200 {object} domain.ScheduleExceptionInfo
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/schedules/exceptions/{id} [get]
+func (h *Handler) GetScheduleExceptionByID(c *gin.Context) {
+	exceptionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidExceptionID)
+		return
+	}
+
+	exception, err := h.services.ScheduleExceptionService.GetByID(c.Request.Context(), exceptionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			respondWithError(h.logger, c, http.StatusNotFound, ErrExceptionNotFound)
+			return
+		}
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, exception)
+}
+
+// GetScheduleExceptionsByScheduleID godoc
+// @Security ApiKeyAuth
+// @Summary Get the exceptions of a schedule
+// @Description Get all one-off changes of the lessons of a schedule
+// @Tags Schedules
+// @Produce json
+// @Param id path int64 true "Schedule ID"
+// @Success 200 {array} domain.ScheduleExceptionInfo
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/schedules/{id}/exceptions [get]
+func (h *Handler) GetScheduleExceptionsByScheduleID(c *gin.Context) {
+	scheduleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidScheduleID)
+		return
+	}
+
+	exceptions, err := h.services.ScheduleExceptionService.GetByScheduleID(c.Request.Context(), scheduleID)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, exceptions)
+}
+
+// GetLessonsByGroupAndDate godoc
+// @Security ApiKeyAuth
+// @Summary Get the timetable of a group on a date
+// @Description Get the lessons of a group on a date with substitutions, cancellations and room changes applied
+// @Tags Schedules
+// @Produce json
+// @Param group_id path string true "Group ID"
+// @Param date path string true "Date (2006-01-02)"
+// @Success 200 {array} domain.Lesson
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/schedules/group/{group_id}/date/{date} [get]
+func (h *Handler) GetLessonsByGroupAndDate(c *gin.Context) {
+	h.respondGroupLessons(c, c.Param("group_id"))
+}
+
+// GetMyGroupLessonsByDate godoc
+// @Security ApiKeyAuth
+// @Summary Get the timetable of the own group on a date
+// @Description Get the lessons of the group of the student or the headman on a date with the exceptions applied
+// @Tags Schedules
+// @Produce json
+// @Param date path string true "Date (2006-01-02)"
+// @Success 200 {array} domain.Lesson
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /students/schedules/date/{date} [get]
+func (h *Handler) GetMyGroupLessonsByDate(c *gin.Context) {
+	data, ok := c.Get(groupCtx)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusUnauthorized, "Group ID not found in context")
+		return
+	}
+
+	groupID, ok := data.(string)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusInternalServerError, "Failed to convert group ID")
+		return
+	}
+
+	h.respondGroupLessons(c, groupID)
+}
+
+func (h *Handler) respondGroupLessons(c *gin.Context, groupID string) {
+	date, err := time.Parse("2006-01-02", c.Param("date"))
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidDate)
+		return
+	}
+
+	lessons, err := h.services.ScheduleExceptionService.GetByGroupAndDate(c.Request.Context(), groupID, date)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, lessons)
+}
+
+// GetLessonsByTeacherAndDate godoc
+// @Security ApiKeyAuth
+// @Summary Get the timetable of a teacher on a date
+// @Description Get the lessons of a teacher on a date including substitutions
+// @Tags Schedules
+// @Produce json
+// @Param id path int64 true "Teacher ID"
+// @Param date path string true "Date (2006-01-02)"
+// @Success 200 {array} domain.Lesson
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/schedules/teacher/{id}/date/{date} [get]
+func (h *Handler) GetLessonsByTeacherAndDate(c *gin.Context) {
+	teacherID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidTeacherID)
+		return
+	}
+
+	h.respondTeacherLessons(c, teacherID)
+}
+
+// GetMyLessonsByDate godoc
+// @Security ApiKeyAuth
+// @Summary Get the own timetable on a date
+// @Description Get the lessons of the teacher on a date including substitutions
+// @Tags Schedules
+// @Produce json
+// @Param date path string true "Date (2006-01-02)"
+// @Success 200 {array} domain.Lesson
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teachers/schedules/date/{date} [get]
+func (h *Handler) GetMyLessonsByDate(c *gin.Context) {
+	data, ok := c.Get(teacherCtx)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusUnauthorized, "Teacher ID not found in context")
+		return
+	}
+
+	teacherID, ok := data.(int64)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusInternalServerError, "Failed to convert teacher ID")
+		return
+	}
+
+	h.respondTeacherLessons(c, teacherID)
+}
+
+func (h *Handler) respondTeacherLessons(c *gin.Context, teacherID int64) {
+	date, err := time.Parse("2006-01-02", c.Param("date"))
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidDate)
+		return
+	}
+
+	lessons, err := h.services.ScheduleExceptionService.GetByTeacherAndDate(c.Request.Context(), teacherID, date)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, lessons)
+}
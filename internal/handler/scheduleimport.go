@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/spreadsheet"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+const ErrInvalidSemester = "Invalid semester"
+
+// ImportScheduleRow represents a timetable row of an imported file
+type ImportScheduleRow struct {
+	GroupID        string `json:"group_id" validate:"required,customgroupidregex"`
+	DayOfWeek      string `json:"day_of_week" validate:"required,oneof=Понедельник Вторник Среда Четверг Пятница Суббота Воскресенье"`
+	WeekType       string `json:"week_type" validate:"required,oneof=Верхняя Нижняя"`
+	StartTime      string `json:"start_time" validate:"required,time"`
+	Discipline     string `json:"discipline" validate:"required,max=255"`
+	DisciplineType string `json:"discipline_type" validate:"required,max=255"`
+	Teacher        string `json:"teacher" validate:"required,max=255"`
+	Classroom      string `json:"classroom" validate:"required,max=255"`
+}
+
+var scheduleImportColumns = map[string][]string{
+	"group_id":        {"group_id", "Группа"},
+	"day_of_week":     {"day_of_week", "День"},
+	"week_type":       {"week_type", "Неделя"},
+	"start_time":      {"start_time", "Время"},
+	"discipline":      {"discipline", "Дисциплина"},
+	"discipline_type": {"discipline_type", "Тип"},
+	"teacher":         {"teacher", "Преподаватель"},
+	"classroom":       {"classroom", "Аудитория"},
+}
+
+var scheduleImportFields = map[string]string{
+	"GroupID":        "group_id",
+	"DayOfWeek":      "day_of_week",
+	"WeekType":       "week_type",
+	"StartTime":      "start_time",
+	"Discipline":     "discipline",
+	"DisciplineType": "discipline_type",
+	"Teacher":        "teacher",
+	"Classroom":      "classroom",
+}
+
+// ImportSchedules godoc
+// @Security ApiKeyAuth
+// @Summary Import a timetable
+// @Description Import schedules from a CSV or XLSX file with the columns Группа, День, Неделя, Время, Дисциплина, Тип, Преподаватель, Аудитория. The teacher is an email or a name like "Иванов И.И.". Unresolved or ambiguous names and time conflicts are reported per row, nothing is inserted until the whole file is valid
+// @Tags Schedules
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX file"
+// @Param semester query int true "Semester of the imported schedules"
+// @Param dry_run query bool false "Only validate the file"
+// @Success 200 {object} domain.ScheduleImportResult
+// @Success 201 {object} domain.ScheduleImportResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 422 {object} domain.ScheduleImportResult
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/schedules/import [post]
+func (h *Handler) ImportSchedules(c *gin.Context) {
+	semester, err := strconv.Atoi(c.Query("semester"))
+	if err != nil || semester < 1 {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidSemester)
+		return
+	}
+
+	dryRun, err := queryBool(c, "dry_run")
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidImportOption)
+		return
+	}
+
+	table, ok := h.readImportFile(c)
+	if !ok {
+		return
+	}
+
+	batch, err := h.scheduleImportBatch(table)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	opts := service.ScheduleImportOptions{DryRun: dryRun, Semester: semester}
+	result, err := h.services.ScheduleImportService.Import(c.Request.Context(), batch, opts)
+	if err != nil {
+		if errors.Is(err, service.ErrImportHasErrors) {
+			c.JSON(http.StatusUnprocessableEntity, result)
+			return
+		}
+		if errors.Is(err, service.ErrImportEmpty) {
+			respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if dryRun {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+func (h *Handler) scheduleImportBatch(table *spreadsheet.Table) (domain.ScheduleImportBatch, error) {
+	columns, err := table.Columns(scheduleImportColumns)
+	if err != nil {
+		return domain.ScheduleImportBatch{}, err
+	}
+
+	batch := domain.ScheduleImportBatch{
+		Total: len(table.Rows),
+		Rows:  make([]domain.ScheduleImportRow, 0, len(table.Rows)),
+	}
+	for _, row := range table.Rows {
+		req := ImportScheduleRow{
+			GroupID:        row.Cell(columns["group_id"]),
+			DayOfWeek:      row.Cell(columns["day_of_week"]),
+			WeekType:       row.Cell(columns["week_type"]),
+			StartTime:      row.Cell(columns["start_time"]),
+			Discipline:     row.Cell(columns["discipline"]),
+			DisciplineType: row.Cell(columns["discipline_type"]),
+			Teacher:        row.Cell(columns["teacher"]),
+			Classroom:      row.Cell(columns["classroom"]),
+		}
+
+		if err := h.validate.Struct(req); err != nil {
+			var validationErrors validator.ValidationErrors
+			if !errors.As(err, &validationErrors) {
+				return domain.ScheduleImportBatch{}, err
+			}
+			for _, fe := range validationErrors {
+				batch.Errors = append(batch.Errors, domain.ImportError{
+					Row:     row.Line,
+					Field:   scheduleImportFields[fe.StructField()],
+					Message: fe.Translate(h.translator),
+				})
+			}
+			continue
+		}
+
+		startTime, err := time.Parse("15:04", req.StartTime)
+		if err != nil {
+			batch.Errors = append(batch.Errors, domain.ImportError{Row: row.Line, Field: "start_time", Message: err.Error()})
+			continue
+		}
+
+		batch.Rows = append(batch.Rows, domain.ScheduleImportRow{
+			Row:            row.Line,
+			GroupID:        req.GroupID,
+			DayOfWeek:      req.DayOfWeek,
+			WeekType:       req.WeekType,
+			StartTime:      startTime,
+			Discipline:     req.Discipline,
+			DisciplineType: req.DisciplineType,
+			Teacher:        req.Teacher,
+			Classroom:      req.Classroom,
+		})
+	}
+
+	return batch, nil
+}
@@ -1,18 +1,23 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
 	"github.com/gin-gonic/gin"
 )
 
+// CreateStudentRequest with Username and Password creates the user account of the student too
 type CreateStudentRequest struct {
 	LastName   string `json:"last_name" validate:"required,customfieldrusnumregex"`
 	FirstName  string `json:"first_name" validate:"required,customfieldrusnumregex"`
 	MiddleName string `json:"middle_name" validate:"required,customfieldrusnumregex"`
 	GroupID    string `json:"group_id" validate:"required,customgroupidregex"`
+	Username   string `json:"username" validate:"omitempty,min=8,max=40,alphanum"`
+	Password   string `json:"password" validate:"required_with=Username,excluded_without=Username,omitempty,min=8,max=40,custompasswordregex"`
 }
 
 type PutStudentRequest struct {
@@ -33,13 +38,14 @@ type PatchStudentRequest struct {
 
 // CreateStudent godoc
 // @Summary Create a student
-// @Description Create a new student
+// @Description Create a new student, with a username and a password the user account of the student is created in the same transaction
 // @Tags Students
 // @Accept json
 // @Produce json
 // @Param student body CreateStudentRequest true "Student info"
 // @Success 201 {object} SuccessResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /students [post]
 func (h *Handler) CreateStudent(c *gin.Context) {
@@ -61,8 +67,17 @@ func (h *Handler) CreateStudent(c *gin.Context) {
 		GroupID:    req.GroupID,
 	}
 
-	err := h.services.StudentService.Create(c.Request.Context(), student)
+	var err error
+	if req.Username != "" {
+		_, err = h.services.StudentService.CreateWithAccount(c.Request.Context(), student, req.Username, req.Password)
+	} else {
+		err = h.services.StudentService.Create(c.Request.Context(), student)
+	}
 	if err != nil {
+		if errors.Is(err, service.ErrUserNameExists) {
+			respondWithError(h.logger, c, http.StatusConflict, ErrDuplicateUserName)
+			return
+		}
 		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/spreadsheet"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	ErrImportFileRequired  = "the import file is required"
+	ErrImportFileTooLarge  = "the import file is too large"
+	ErrInvalidImportOption = "Invalid import option"
+	maxImportFileSize      = 10 << 20
+	importFileField        = "file"
+)
+
+var studentImportColumns = map[string][]string{
+	"last_name":   {"last_name", "Фамилия"},
+	"first_name":  {"first_name", "Имя"},
+	"middle_name": {"middle_name", "Отчество"},
+	"group_id":    {"group_id", "Группа"},
+}
+
+var studentImportFields = map[string]string{
+	"LastName":   "last_name",
+	"FirstName":  "first_name",
+	"MiddleName": "middle_name",
+	"GroupID":    "group_id",
+}
+
+// ImportStudents godoc
+// @Security ApiKeyAuth
+// @Summary Import students
+// @Description Import students from a CSV or XLSX file with the columns last_name, first_name, middle_name, group_id (or Фамилия, Имя, Отчество, Группа). A dry run only reports row errors, otherwise the whole file is imported in one transaction
+// @Tags Students
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX file"
+// @Param dry_run query bool false "Only validate the file"
+// @Param create_accounts query bool false "Create user accounts with generated logins"
+// @Success 200 {object} domain.StudentImportResult
+// @Success 201 {object} domain.StudentImportResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 422 {object} domain.StudentImportResult
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/students/import [post]
+func (h *Handler) ImportStudents(c *gin.Context) {
+	opts, err := parseStudentImportOptions(c)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidImportOption)
+		return
+	}
+
+	table, ok := h.readImportFile(c)
+	if !ok {
+		return
+	}
+
+	batch, err := h.studentImportBatch(table)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.services.StudentImportService.Import(c.Request.Context(), batch, opts)
+	if err != nil {
+		if errors.Is(err, service.ErrImportHasErrors) {
+			c.JSON(http.StatusUnprocessableEntity, result)
+			return
+		}
+		if errors.Is(err, service.ErrImportEmpty) {
+			respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if opts.DryRun {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+// DownloadStudentCredentials godoc
+// @Security ApiKeyAuth
+// @Summary Download generated student logins
+// @Description Download the logins and passwords generated by an import as CSV, the file can be downloaded only once
+// @Tags Students
+// @Produce text/csv
+// @Param id path string true "Credentials ID"
+// @Success 200 {file} file
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/students/import/credentials/{id} [get]
+func (h *Handler) DownloadStudentCredentials(c *gin.Context) {
+	credentials, err := h.services.StudentImportService.TakeCredentials(c.Param("id"))
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="credentials.csv"`)
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+	c.Writer.Header().Set("Content-Type", "text/csv; charset=utf-8")
+
+	// BOM lets Excel open the cyrillic names correctly
+	c.Writer.WriteString("\uFEFF")
+	writer := csv.NewWriter(c.Writer)
+	writer.Write([]string{"student_id", "group_id", "last_name", "first_name", "middle_name", "username", "password"})
+	for _, credential := range credentials {
+		writer.Write([]string{
+			strconv.FormatInt(credential.StudentID, 10),
+			credential.GroupID,
+			credential.LastName,
+			credential.FirstName,
+			credential.MiddleName,
+			credential.Username,
+			credential.Password,
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "failed to write credentials", "error", err.Error())
+	}
+}
+
+func parseStudentImportOptions(c *gin.Context) (service.StudentImportOptions, error) {
+	var opts service.StudentImportOptions
+	var err error
+
+	if opts.DryRun, err = queryBool(c, "dry_run"); err != nil {
+		return opts, err
+	}
+	if opts.CreateAccounts, err = queryBool(c, "create_accounts"); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// queryBool parses an optional boolean query parameter, a missing one is false
+func queryBool(c *gin.Context, key string) (bool, error) {
+	value := c.Query(key)
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// readImportFile parses the uploaded spreadsheet and responds with an error if it can't
+func (h *Handler) readImportFile(c *gin.Context) (*spreadsheet.Table, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+
+	fileHeader, err := c.FormFile(importFileField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondWithError(h.logger, c, http.StatusRequestEntityTooLarge, ErrImportFileTooLarge)
+			return nil, false
+		}
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrImportFileRequired)
+		return nil, false
+	}
+
+	format, err := spreadsheet.FormatFromFilename(fileHeader.Filename)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	defer file.Close()
+
+	table, err := spreadsheet.Read(file, format)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	return table, true
+}
+
+// studentImportBatch maps the rows to students and validates them like CreateStudentRequest
+func (h *Handler) studentImportBatch(table *spreadsheet.Table) (domain.StudentImportBatch, error) {
+	columns, err := table.Columns(studentImportColumns)
+	if err != nil {
+		return domain.StudentImportBatch{}, err
+	}
+
+	batch := domain.StudentImportBatch{
+		Total: len(table.Rows),
+		Rows:  make([]domain.StudentImportRow, 0, len(table.Rows)),
+	}
+	for _, row := range table.Rows {
+		req := CreateStudentRequest{
+			LastName:   row.Cell(columns["last_name"]),
+			FirstName:  row.Cell(columns["first_name"]),
+			MiddleName: row.Cell(columns["middle_name"]),
+			GroupID:    row.Cell(columns["group_id"]),
+		}
+
+		if err := h.validate.Struct(req); err != nil {
+			var validationErrors validator.ValidationErrors
+			if !errors.As(err, &validationErrors) {
+				return domain.StudentImportBatch{}, err
+			}
+			for _, fe := range validationErrors {
+				batch.Errors = append(batch.Errors, domain.ImportError{
+					Row:     row.Line,
+					Field:   studentImportFields[fe.StructField()],
+					Message: fe.Translate(h.translator),
+				})
+			}
+			continue
+		}
+
+		batch.Rows = append(batch.Rows, domain.StudentImportRow{
+			Row: row.Line,
+			Student: domain.Student{
+				LastName:   req.LastName,
+				FirstName:  req.FirstName,
+				MiddleName: req.MiddleName,
+				GroupID:    req.GroupID,
+			},
+		})
+	}
+
+	return batch, nil
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ErrInvalidSubgroupID = "Invalid subgroup ID"
+	ErrSubgroupNotFound  = "Subgroup not found"
+)
+
+// SubgroupRequest represents the request body for a subgroup of a group
+type SubgroupRequest struct {
+	GroupID    string  `json:"group_id" validate:"required,customgroupidregex"`
+	Name       string  `json:"subgroup_name" validate:"required,max=50"`
+	StudentIDs []int64 `json:"student_ids" validate:"dive,min=1"`
+}
+
+// PutSubgroupRequest represents the request body for updating a subgroup, the students are replaced
+type PutSubgroupRequest struct {
+	SubgroupID int64 `json:"subgroup_id" validate:"required,min=1"`
+	SubgroupRequest
+}
+
+func (r SubgroupRequest) toDomain() domain.Subgroup {
+	return domain.Subgroup{
+		GroupID:    r.GroupID,
+		Name:       r.Name,
+		StudentIDs: r.StudentIDs,
+	}
+}
+
+func (h *Handler) respondSubgroupError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrSubgroupStudent) {
+		respondWithError(h.logger, c, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+}
+
+// CreateSubgroup godoc
+// @Security ApiKeyAuth
+// @Summary Create a subgroup
+// @Description Add a named subgroup of the students of a group, labs can be scheduled for it
+// @Tags Subgroups
+// @Accept json
+// @Produce json
+// @Param subgroup body SubgroupRequest true "Subgroup info"
+// @Success 201 {object} domain.Subgroup
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/subgroups [post]
+func (h *Handler) CreateSubgroup(c *gin.Context) {
+	var req SubgroupRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	subgroup := req.toDomain()
+	subgroupID, err := h.services.SubgroupService.Create(c.Request.Context(), subgroup)
+	if err != nil {
+		h.respondSubgroupError(c, err)
+		return
+	}
+	subgroup.SubgroupID = subgroupID
+
+	c.JSON(http.StatusCreated, subgroup)
+}
+
+// PutSubgroup godoc
+// @Security ApiKeyAuth
+// @Summary Update a subgroup
+// @Description Update a subgroup and replace its students
+// @Tags Subgroups
+// @Accept json
+// @Produce json
+// @Param subgroup body PutSubgroupRequest true "Subgroup info"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/subgroups [put]
+func (h *Handler) PutSubgroup(c *gin.Context) {
+	var req PutSubgroupRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errs := translateValidationErrors(err.(validator.ValidationErrors), h.translator)
+		respondWithError(h.logger, c, http.StatusBadRequest, errs[0])
+		return
+	}
+
+	subgroup := req.toDomain()
+	subgroup.SubgroupID = req.SubgroupID
+
+	if err := h.services.SubgroupService.Put(c.Request.Context(), subgroup); err != nil {
+		h.respondSubgroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Subgroup updated successfully"})
+}
+
+// DeleteSubgroup godoc
+// @Security ApiKeyAuth
+// @Summary Delete a subgroup
+// @Description Delete a subgroup by ID, a subgroup with schedules can't be deleted
+// @Tags Subgroups
+// @Produce json
+// @Param id path int64 true "Subgroup ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/subgroups/{id} [delete]
+func (h *Handler) DeleteSubgroup(c *gin.Context) {
+	subgroupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidSubgroupID)
+		return
+	}
+
+	if err := h.services.SubgroupService.Delete(c.Request.Context(), subgroupID); err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Subgroup deleted successfully"})
+}
+
+// GetSubgroupByID godoc
+// @Security ApiKeyAuth
+// @Summary Get a subgroup
+// @Description Get a subgroup with its students by ID
+// @Tags Subgroups
+// @Produce json
+// @Param id path int64 true "Subgroup ID"
+// @Success 200 {object} domain.Subgroup
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/subgroups/{id} [get]
+func (h *Handler) GetSubgroupByID(c *gin.Context) {
+	subgroupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidSubgroupID)
+		return
+	}
+
+	subgroup, err := h.services.SubgroupService.GetByID(c.Request.Context(), subgroupID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			respondWithError(h.logger, c, http.StatusNotFound, ErrSubgroupNotFound)
+			return
+		}
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, subgroup)
+}
+
+// GetSubgroupsByGroupID godoc
+// @Security ApiKeyAuth
+// @Summary Get the subgroups of a group
+// @Description Get the subgroups of a group with their students
+// @Tags Subgroups
+// @Produce json
+// @Param group_id path string true "Group ID"
+// @Success 200 {array} domain.Subgroup
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/subgroups/group/{group_id} [get]
+func (h *Handler) GetSubgroupsByGroupID(c *gin.Context) {
+	subgroups, err := h.services.SubgroupService.GetByGroupID(c.Request.Context(), c.Param("group_id"))
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, subgroups)
+}
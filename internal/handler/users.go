@@ -125,6 +125,7 @@ func translateValidationErrors(validationErrors validator.ValidationErrors, tran
 // @Success 200 {object} service.Tokens
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /auth/signin [post]
 func (h *Handler) SignInUser(c *gin.Context) {
@@ -140,8 +141,15 @@ func (h *Handler) SignInUser(c *gin.Context) {
 		return
 	}
 
-	tokens, err := h.services.UserService.SignIn(c.Request.Context(), userReq.Username, userReq.Password)
+	tokens, err := h.services.UserService.SignIn(c.Request.Context(), userReq.Username, userReq.Password, c.ClientIP())
 	if err != nil {
+		var lockedErr *service.LoginLockedError
+		if errors.As(err, &lockedErr) {
+			setRetryAfter(c, lockedErr.RetryAfter)
+			respondWithError(h.logger, c, http.StatusTooManyRequests, err.Error())
+			return
+		}
+
 		if errors.Is(err, pgx.ErrNoRows) {
 			respondWithError(h.logger, c, http.StatusUnauthorized, err.Error())
 			return
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/myhash"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/ratelimit"
+)
+
+func TestSignInUserLockout(t *testing.T) {
+	policy := service.LoginPolicy{MaxAttempts: 3, MaxIPAttempts: 10, BaseLockout: time.Minute, MaxLockout: time.Hour, AttemptsWindow: 15 * time.Minute}
+	api := newTestAPIWith(t, func(support *service.Support) {
+		support.LoginGuard = service.NewLoginGuard(ratelimit.NewMemoryStore(), policy)
+	})
+
+	hash, err := myhash.NewHasher("salt", 4).HashPassword("Password1!")
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	if err := api.repos.User.Create(context.Background(), domain.User{Username: "studentuser", Password: hash, Role: "Студент"}); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	signIn := func(username, password string) string {
+		return `{"username":"` + username + `","password":"` + password + `"}`
+	}
+	// the steps run in order, the lockout follows the failures before it
+	steps := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "valid password", body: signIn("studentuser", "Password1!"), wantStatus: http.StatusOK},
+		{name: "first failure", body: signIn("studentuser", "Password2!"), wantStatus: http.StatusUnauthorized},
+		{name: "second failure", body: signIn("studentuser", "Password2!"), wantStatus: http.StatusUnauthorized},
+		{name: "third failure", body: signIn("studentuser", "Password2!"), wantStatus: http.StatusUnauthorized},
+		{name: "valid password while locked", body: signIn("studentuser", "Password1!"), wantStatus: http.StatusTooManyRequests},
+		{name: "another user", body: signIn("otherusers", "Password1!"), wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, step := range steps {
+		w := api.do(http.MethodPost, "/api/auth/signin", "", step.body)
+		if w.Code != step.wantStatus {
+			t.Fatalf("%s: status = %d, want %d, body %s", step.name, w.Code, step.wantStatus, w.Body.String())
+		}
+		if step.wantStatus != http.StatusTooManyRequests {
+			continue
+		}
+		retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+		if err != nil || retryAfter <= 0 || retryAfter > int(policy.BaseLockout.Seconds()) {
+			t.Errorf("%s: Retry-After = %q, want up to %v", step.name, w.Header().Get("Retry-After"), policy.BaseLockout)
+		}
+	}
+}
@@ -63,6 +63,15 @@ func CustomPasswordRegex(fl validator.FieldLevel) bool {
 	return regex.MatchString(value)
 }
 
+func CustomFieldRusNumRegex(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+
+	pattern := "^[а-яА-ЯёЁ\\d\\s-]+$"
+	regex := regexp.MustCompile(pattern)
+
+	return regex.MatchString(value)
+}
+
 func CustomFieldRusRegex(fl validator.FieldLevel) bool {
 	value := fl.Field().String()
 
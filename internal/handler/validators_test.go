@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type roleFields struct {
+	Role      string `validate:"roledependentfields"`
+	HeadmanID *int64
+	StudentID *int64
+	TeacherID *int64
+}
+
+func TestRoleDependentFields(t *testing.T) {
+	id := int64(1)
+
+	tests := []struct {
+		name  string
+		input roleFields
+		valid bool
+	}{
+		{name: "headman", input: roleFields{Role: "Староста", HeadmanID: &id}, valid: true},
+		{name: "headman without id", input: roleFields{Role: "Староста"}},
+		{name: "headman with student id", input: roleFields{Role: "Староста", HeadmanID: &id, StudentID: &id}},
+		{name: "headman with teacher id", input: roleFields{Role: "Староста", TeacherID: &id}},
+		{name: "student", input: roleFields{Role: "Студент", StudentID: &id}, valid: true},
+		{name: "student without id", input: roleFields{Role: "Студент"}},
+		{name: "student with headman id", input: roleFields{Role: "Студент", StudentID: &id, HeadmanID: &id}},
+		{name: "teacher", input: roleFields{Role: "Преподаватель", TeacherID: &id}, valid: true},
+		{name: "teacher without id", input: roleFields{Role: "Преподаватель"}},
+		{name: "teacher with student id", input: roleFields{Role: "Преподаватель", TeacherID: &id, StudentID: &id}},
+		{name: "admin", input: roleFields{Role: "Админ"}, valid: true},
+		{name: "admin with teacher id", input: roleFields{Role: "Админ", TeacherID: &id}},
+		{name: "empty role", input: roleFields{}, valid: true},
+		{name: "empty role with student id", input: roleFields{StudentID: &id}},
+	}
+
+	validate := validator.New()
+	if err := validate.RegisterValidation("roledependentfields", roleDependentFields); err != nil {
+		t.Fatalf("register validation: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Struct(tt.input)
+			if (err == nil) != tt.valid {
+				t.Fatalf("Struct() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// workloadHeader is the header of an exported workload
+var workloadHeader = []string{
+	"Преподаватель", "Дисциплина", "Вид занятия", "Группа", "Семестр", "Плановые часы", "Проведенные часы",
+}
+
+// optionalSemester reads the optional semester query parameter, 0 means all semesters
+func optionalSemester(c *gin.Context) (int, bool) {
+	value := c.Query("semester")
+	if value == "" {
+		return 0, true
+	}
+	semester, err := strconv.Atoi(value)
+	if err != nil || semester < 1 || semester > 12 {
+		return 0, false
+	}
+	return semester, true
+}
+
+func workloadRows(teachers []domain.TeacherWorkload) [][]string {
+	rows := make([][]string, 0)
+	for _, teacher := range teachers {
+		name := teacher.TeacherFullName.LastName + " " + teacher.TeacherFullName.FirstName + " " + teacher.TeacherFullName.MiddleName
+		for _, row := range teacher.Rows {
+			rows = append(rows, []string{
+				name,
+				row.DisciplineName,
+				row.DisciplineTypeName,
+				row.GroupID,
+				strconv.Itoa(row.Semester),
+				strconv.Itoa(row.PlannedHours),
+				strconv.Itoa(row.DeliveredHours),
+			})
+		}
+	}
+	return rows
+}
+
+// respondTeacherWorkload writes the workload of a teacher in the requested format
+func (h *Handler) respondTeacherWorkload(c *gin.Context, teacherID int64) {
+	semester, ok := optionalSemester(c)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidSemester)
+		return
+	}
+	format, ok := exportFormat(c)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidExportFormat)
+		return
+	}
+
+	workload, err := h.services.WorkloadService.GetByTeacher(c.Request.Context(), teacherID, semester)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			respondWithError(h.logger, c, http.StatusNotFound, "Teacher not found")
+			return
+		}
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if format == formatJSON {
+		c.JSON(http.StatusOK, workload)
+		return
+	}
+	h.respondTable(c, format, "workload_teacher_"+strconv.FormatInt(teacherID, 10), workloadHeader, workloadRows([]domain.TeacherWorkload{workload}))
+}
+
+// GetTeacherWorkload godoc
+// @Security ApiKeyAuth
+// @Summary Get the workload of a teacher
+// @Description Get the planned hours of the actual timetable on the teaching days and the delivered hours of the lessons with a marked attendance per discipline, group and semester
+// @Tags Workload
+// @Produce json
+// @Param id path int64 true "Teacher ID"
+// @Param semester query int false "Semester, all semesters by default"
+// @Param format query string false "json, csv or xlsx"
+// @Success 200 {object} domain.TeacherWorkload
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/workload/teacher/{id} [get]
+func (h *Handler) GetTeacherWorkload(c *gin.Context) {
+	teacherID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, "Invalid teacher ID")
+		return
+	}
+
+	h.respondTeacherWorkload(c, teacherID)
+}
+
+// GetMyWorkload godoc
+// @Security ApiKeyAuth
+// @Summary Get my workload
+// @Description Get the planned and delivered hours of the current teacher
+// @Tags Workload
+// @Produce json
+// @Param semester query int false "Semester, all semesters by default"
+// @Param format query string false "json, csv or xlsx"
+// @Success 200 {object} domain.TeacherWorkload
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teachers/workload [get]
+func (h *Handler) GetMyWorkload(c *gin.Context) {
+	data, ok := c.Get(teacherCtx)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusUnauthorized, "Teacher ID not found in context")
+		return
+	}
+
+	teacherID, ok := data.(int64)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusInternalServerError, "Failed to convert teacher ID")
+		return
+	}
+
+	h.respondTeacherWorkload(c, teacherID)
+}
+
+// GetDepartamentWorkload godoc
+// @Security ApiKeyAuth
+// @Summary Get the workload of a departament
+// @Description Get the workload of every teacher of a departament with the departament totals
+// @Tags Workload
+// @Produce json
+// @Param id path int64 true "Departament ID"
+// @Param semester query int false "Semester, all semesters by default"
+// @Param format query string false "json, csv or xlsx"
+// @Success 200 {object} domain.DepartamentWorkload
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admins/workload/departament/{id} [get]
+func (h *Handler) GetDepartamentWorkload(c *gin.Context) {
+	departamentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusBadRequest, "Invalid departament ID")
+		return
+	}
+	semester, ok := optionalSemester(c)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidSemester)
+		return
+	}
+	format, ok := exportFormat(c)
+	if !ok {
+		respondWithError(h.logger, c, http.StatusBadRequest, ErrInvalidExportFormat)
+		return
+	}
+
+	workload, err := h.services.WorkloadService.GetByDepartament(c.Request.Context(), departamentID, semester)
+	if err != nil {
+		respondWithError(h.logger, c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if format == formatJSON {
+		c.JSON(http.StatusOK, workload)
+		return
+	}
+	h.respondTable(c, format, "workload_departament_"+strconv.FormatInt(departamentID, 10), workloadHeader, workloadRows(workload.Teachers))
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -20,14 +21,14 @@ func NewAttendanceRepo(db *pgxpool.Pool) *AttendanceRepo {
 func (r *AttendanceRepo) Create(ctx context.Context, attendance domain.Attendance) error {
 	query := `INSERT INTO attendance (student_id, schedule_id, presence, late_arrival, respectfulness, reason, created)
               VALUES ($1, $2, $3, $4, $5, $6, $7)`
-	_, err := r.db.Exec(ctx, query, attendance.StudentID, attendance.ScheduleID, attendance.Presence, attendance.LateArrival, attendance.Respectfulness, attendance.Reason, attendance.Created)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, attendance.StudentID, attendance.ScheduleID, attendance.Presence, attendance.LateArrival, attendance.Respectfulness, attendance.Reason, attendance.Created)
 
 	return err
 }
 
 func (r *AttendanceRepo) Put(ctx context.Context, attendance domain.Attendance) error {
 	query := `UPDATE attendance SET presence=$1, late_arrival=$2, respectfulness=$3, reason=$4 WHERE attendance_id=$5`
-	_, err := r.db.Exec(ctx, query, attendance.Presence, attendance.LateArrival, attendance.Respectfulness, attendance.Reason, attendance.AttendanceID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, attendance.Presence, attendance.LateArrival, attendance.Respectfulness, attendance.Reason, attendance.AttendanceID)
 
 	return err
 }
@@ -46,14 +47,14 @@ func (r *AttendanceRepo) Patch(ctx context.Context, attendanceID int64, updates
 	query = query[:len(query)-1]
 	query += " WHERE attendance_id = $" + strconv.Itoa(argsCounter)
 	args = append(args, attendanceID)
-	_, err := r.db.Exec(ctx, query, args...)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, args...)
 
 	return err
 }
 
 func (r *AttendanceRepo) Delete(ctx context.Context, attendanceID int64) error {
 	query := `DELETE FROM attendance WHERE attendance_id = $1`
-	_, err := r.db.Exec(ctx, query, attendanceID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, attendanceID)
 
 	return err
 }
@@ -69,7 +70,7 @@ func (r *AttendanceRepo) GetByID(ctx context.Context, attendanceID int64) (domai
 		WHERE a.attendance_id = $1`
 
 	attendanceInfo := domain.AttendanceInfo{}
-	err := r.db.QueryRow(ctx, query, attendanceID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, attendanceID).Scan(
 		&attendanceInfo.Attendance.AttendanceID,
 		&attendanceInfo.Attendance.StudentID,
 		&attendanceInfo.Attendance.ScheduleID,
@@ -96,7 +97,7 @@ func (r *AttendanceRepo) GetByStudentID(ctx context.Context, studentID int64) ([
 			students s ON a.student_id = s.student_id
 		WHERE a.student_id = $1`
 
-	rows, err := r.db.Query(ctx, query, studentID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, studentID)
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +142,7 @@ func (r *AttendanceRepo) GetAll(ctx context.Context) ([]domain.AttendanceInfo, e
 		LEFT JOIN 
 			students s ON a.student_id = s.student_id`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -199,9 +200,14 @@ func (r *AttendanceRepo) GetAllByGroupIDAndCreated(ctx context.Context, groupID
 	RIGHT JOIN
 		students s ON s.student_id = a.student_id AND a.created = $2
 	WHERE
-		s.group_id = $3`
-
-	rows, err := r.db.Query(ctx, query, scheduleID, created, groupID)
+		s.group_id = $3
+		AND NOT EXISTS (
+			SELECT 1 FROM schedules lab
+			WHERE lab.schedule_id = $1 AND lab.subgroup_id IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM subgroup_students ss WHERE ss.subgroup_id = lab.subgroup_id AND ss.student_id = s.student_id)
+		)`
+
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, scheduleID, created, groupID)
 	if err != nil {
 		return nil, err
 	}
@@ -238,9 +244,19 @@ func (r *AttendanceRepo) GetAllByGroupIDAndCreated(ctx context.Context, groupID
 	return attendances, nil
 }
 
+// ExistsByGroupID reports whether attendance is marked for any schedule of the group
+func (r *AttendanceRepo) ExistsByGroupID(ctx context.Context, groupID string) (bool, error) {
+	query := `SELECT EXISTS (
+		SELECT 1 FROM attendance a JOIN schedules s ON s.schedule_id = a.schedule_id WHERE s.group_id = $1
+	)`
+	var exists bool
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, groupID).Scan(&exists)
+	return exists, err
+}
+
 func (r *AttendanceRepo) getCountAttendance(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM attendance;`
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -259,7 +275,7 @@ func (r *AttendanceRepo) getCountAttendance(ctx context.Context) (int64, error)
 
 // func (r *AttendanceRepo) getCountAttendanceByStudentID(ctx context.Context, studentID int64) (int64, error) {
 // 	query := `SELECT COUNT(*) FROM attendance WHERE student_id = $1;`
-// 	rows, err := r.db.Query(ctx, query, studentID)
+// 	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, studentID)
 // 	if err != nil {
 // 		return 0, err
 // 	}
@@ -0,0 +1,206 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestAttendanceRepoGetAllByGroupIDAndCreated(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	// students maps the student to the attendance of the row, 0 is a row without attendance
+	tests := []struct {
+		name       string
+		groupID    string
+		scheduleID int64
+		created    time.Time
+		students   map[int64]int64
+	}{
+		{
+			name:       "everybody is marked",
+			groupID:    firstGroup,
+			scheduleID: 1,
+			created:    date("2024-09-02"),
+			students:   map[int64]int64{1: 1, 2: 2, 3: 3},
+		},
+		{
+			name:       "a student without a mark",
+			groupID:    firstGroup,
+			scheduleID: 1,
+			created:    date("2024-09-16"),
+			students:   map[int64]int64{1: 5, 2: 6, 3: 0},
+		},
+		{
+			name:       "nobody is marked",
+			groupID:    firstGroup,
+			scheduleID: 1,
+			created:    date("2024-09-09"),
+			students:   map[int64]int64{1: 0, 2: 0, 3: 0},
+		},
+		{
+			name:       "marks of another schedule on the day",
+			groupID:    firstGroup,
+			scheduleID: 3,
+			created:    date("2024-09-02"),
+			students:   map[int64]int64{1: 0, 2: 0, 3: 0},
+		},
+		{
+			name:       "students of the subgroup only",
+			groupID:    firstGroup,
+			scheduleID: 2,
+			created:    date("2024-09-02"),
+			students:   map[int64]int64{1: 9, 2: 0},
+		},
+		{
+			name:       "transferred student is in the current group",
+			groupID:    secondGroup,
+			scheduleID: 4,
+			created:    date("2024-09-16"),
+			students:   map[int64]int64{4: 11},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := repos.Attendance.GetAllByGroupIDAndCreated(ctx, tt.groupID, tt.scheduleID, tt.created)
+			if err != nil {
+				t.Fatalf("GetAllByGroupIDAndCreated() error = %v", err)
+			}
+			if len(rows) != len(tt.students) {
+				t.Fatalf("rows = %d, want %d: %+v", len(rows), len(tt.students), rows)
+			}
+
+			for _, row := range rows {
+				attendance := row.Attendance
+				if attendance.StudentID == nil {
+					t.Fatalf("row without a student: %+v", row)
+				}
+				want, ok := tt.students[*attendance.StudentID]
+				if !ok {
+					t.Fatalf("unexpected student %d", *attendance.StudentID)
+				}
+				if row.AttendanceSub.Student.GroupID != tt.groupID || row.AttendanceSub.Student.LastName == "" {
+					t.Errorf("student of the row = %+v", row.AttendanceSub.Student)
+				}
+
+				if want == 0 {
+					if attendance.AttendanceID != nil || attendance.ScheduleID != nil || attendance.Created != nil || attendance.Presence != nil {
+						t.Errorf("row of student %d = %+v, want no attendance", *attendance.StudentID, attendance)
+					}
+					continue
+				}
+				if attendance.AttendanceID == nil || *attendance.AttendanceID != want {
+					t.Errorf("attendance of student %d = %v, want %d", *attendance.StudentID, attendance.AttendanceID, want)
+				}
+				if attendance.ScheduleID == nil || *attendance.ScheduleID != tt.scheduleID {
+					t.Errorf("schedule of student %d = %v, want %d", *attendance.StudentID, attendance.ScheduleID, tt.scheduleID)
+				}
+				if attendance.Created == nil || !attendance.Created.Equal(tt.created) {
+					t.Errorf("created of student %d = %v, want %v", *attendance.StudentID, attendance.Created, tt.created)
+				}
+			}
+		})
+	}
+}
+
+func TestAttendanceRepoCRUD(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+	present, late := true, false
+	created := time.Date(2024, 9, 23, 0, 0, 0, 0, time.UTC)
+
+	if err := repos.Attendance.Create(ctx, domain.Attendance{StudentID: 3, ScheduleID: 1, Presence: &present, LateArrival: &late, Created: created}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	rows, err := repos.Attendance.GetAllByGroupIDAndCreated(ctx, firstGroup, 1, created)
+	if err != nil {
+		t.Fatalf("GetAllByGroupIDAndCreated() error = %v", err)
+	}
+	var id int64
+	for _, row := range rows {
+		if *row.Attendance.StudentID == 3 && row.Attendance.AttendanceID != nil {
+			id = *row.Attendance.AttendanceID
+		}
+	}
+	if id == 0 {
+		t.Fatalf("created attendance isn't in the rows of the day: %+v", rows)
+	}
+
+	absent, reason := false, "болезнь"
+	if err := repos.Attendance.Put(ctx, domain.Attendance{AttendanceID: id, Presence: &absent, LateArrival: &late, Reason: &reason}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := repos.Attendance.Patch(ctx, id, map[string]interface{}{"respectfulness": true}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+
+	got, err := repos.Attendance.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	attendance := got.Attendance
+	if attendance.StudentID != 3 || attendance.ScheduleID != 1 || !attendance.Created.Equal(created) {
+		t.Errorf("attendance = %+v", attendance)
+	}
+	if attendance.Presence == nil || *attendance.Presence || attendance.Reason == nil || *attendance.Reason != reason || attendance.Respectfulness == nil || !*attendance.Respectfulness {
+		t.Errorf("marks = %v %v %v", attendance.Presence, attendance.Reason, attendance.Respectfulness)
+	}
+	if got.AttendanceSub.Student.LastName != "Васильев" {
+		t.Errorf("student = %+v", got.AttendanceSub.Student)
+	}
+
+	byStudent, err := repos.Attendance.GetByStudentID(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetByStudentID() error = %v", err)
+	}
+	if len(byStudent) != 3 {
+		t.Errorf("attendance of the student = %d, want 3", len(byStudent))
+	}
+	all, err := repos.Attendance.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != 12 {
+		t.Errorf("attendance = %d, want 12", len(all))
+	}
+
+	if err := repos.Attendance.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Attendance.GetByID(ctx, id); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted attendance error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
+
+func TestAttendanceRepoExistsByGroupID(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	const emptyGroup = "2024-35.03.06-1"
+	if err := repos.Group.Create(ctx, domain.Group{GroupID: emptyGroup, ProfileID: 1}); err != nil {
+		t.Fatalf("Create() of the group error = %v", err)
+	}
+	tests := []struct {
+		groupID string
+		want    bool
+	}{
+		{groupID: firstGroup, want: true},
+		{groupID: secondGroup, want: true},
+		{groupID: emptyGroup},
+	}
+	for _, tt := range tests {
+		got, err := repos.Attendance.ExistsByGroupID(ctx, tt.groupID)
+		if err != nil {
+			t.Fatalf("ExistsByGroupID() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("ExistsByGroupID(%s) = %v, want %v", tt.groupID, got, tt.want)
+		}
+	}
+}
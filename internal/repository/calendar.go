@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CalendarRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCalendarRepo(db *pgxpool.Pool) *CalendarRepo {
+	return &CalendarRepo{db: db}
+}
+
+func (r *CalendarRepo) Create(ctx context.Context, period domain.CalendarPeriod) (int64, error) {
+	query := `INSERT INTO academic_calendar (university_id, period_kind, title, semester, start_date, end_date)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING period_id`
+
+	var periodID int64
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query,
+		period.UniversityID, period.Kind, period.Title, period.Semester, period.StartDate, period.EndDate,
+	).Scan(&periodID)
+	return periodID, err
+}
+
+func (r *CalendarRepo) Put(ctx context.Context, period domain.CalendarPeriod) error {
+	query := `UPDATE academic_calendar SET
+		university_id = $1, period_kind = $2, title = $3, semester = $4, start_date = $5, end_date = $6
+	WHERE period_id = $7`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query,
+		period.UniversityID, period.Kind, period.Title, period.Semester, period.StartDate, period.EndDate, period.PeriodID)
+	return err
+}
+
+func (r *CalendarRepo) Delete(ctx context.Context, periodID int64) error {
+	query := `DELETE FROM academic_calendar WHERE period_id = $1`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, periodID)
+	return err
+}
+
+func (r *CalendarRepo) GetByID(ctx context.Context, periodID int64) (domain.CalendarPeriod, error) {
+	query := `SELECT period_id, university_id, period_kind, title, semester, start_date, end_date
+		FROM academic_calendar
+		WHERE period_id = $1`
+	return scanCalendarPeriod(postgres.Conn(ctx, r.db).QueryRow(ctx, query, periodID))
+}
+
+// GetByUniversityID returns the periods of the university that intersect the range
+func (r *CalendarRepo) GetByUniversityID(ctx context.Context, universityID int64, from, to time.Time) ([]domain.CalendarPeriod, error) {
+	query := `SELECT period_id, university_id, period_kind, title, semester, start_date, end_date
+		FROM academic_calendar
+		WHERE university_id = $1 AND start_date <= $3 AND end_date >= $2
+		ORDER BY start_date, period_id`
+
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, universityID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	periods := make([]domain.CalendarPeriod, 0)
+	for rows.Next() {
+		period, err := scanCalendarPeriod(rows)
+		if err != nil {
+			return nil, err
+		}
+		periods = append(periods, period)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return periods, nil
+}
+
+// IsTeachingDay reports whether the university of the group teaches on the date
+func (r *CalendarRepo) IsTeachingDay(ctx context.Context, groupID string, date time.Time) (bool, error) {
+	query := `SELECT is_teaching_day(group_university_id($1), $2)`
+
+	var teaching bool
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, groupID, date).Scan(&teaching)
+	return teaching, err
+}
+
+// GetTeachingDays returns the teaching days of the semester periods with the number
+// in the calendar of the university of the group
+func (r *CalendarRepo) GetTeachingDays(ctx context.Context, groupID string, semester int) ([]time.Time, error) {
+	query := `SELECT DISTINCT d::date
+		FROM academic_calendar c
+		CROSS JOIN LATERAL generate_series(c.start_date, c.end_date, INTERVAL '1 day') AS d
+		WHERE c.university_id = group_university_id($1) AND c.period_kind = 'semester' AND c.semester = $2
+		AND is_teaching_day(c.university_id, d::date)
+		ORDER BY 1`
+
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, groupID, semester)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	days := make([]time.Time, 0)
+	for rows.Next() {
+		var day time.Time
+		if err := rows.Scan(&day); err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return days, nil
+}
+
+func scanCalendarPeriod(row pgx.Row) (domain.CalendarPeriod, error) {
+	var period domain.CalendarPeriod
+	err := row.Scan(
+		&period.PeriodID,
+		&period.UniversityID,
+		&period.Kind,
+		&period.Title,
+		&period.Semester,
+		&period.StartDate,
+		&period.EndDate,
+	)
+	return period, err
+}
@@ -0,0 +1,130 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestCalendarRepoTeachingDays(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		date string
+		want bool
+	}{
+		{name: "semester", date: "2024-09-02", want: true},
+		{name: "holiday", date: "2024-11-04"},
+		{name: "before the semester", date: "2024-08-31"},
+		{name: "after the semester", date: "2025-01-15"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repos.Calendar.IsTeachingDay(ctx, firstGroup, date(tt.date))
+			if err != nil {
+				t.Fatalf("IsTeachingDay() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsTeachingDay(%s) = %v, want %v", tt.date, got, tt.want)
+			}
+		})
+	}
+
+	// the autumn semester has 122 days and one holiday
+	days, err := repos.Calendar.GetTeachingDays(ctx, firstGroup, 1)
+	if err != nil {
+		t.Fatalf("GetTeachingDays() error = %v", err)
+	}
+	if len(days) != 121 || !days[0].Equal(date("2024-09-01")) || !days[len(days)-1].Equal(date("2024-12-31")) {
+		t.Fatalf("teaching days = %d from %v to %v, want 121 from 2024-09-01 to 2024-12-31", len(days), days[0], days[len(days)-1])
+	}
+	for _, day := range days {
+		if day.Equal(date("2024-11-04")) {
+			t.Errorf("the holiday is a teaching day")
+		}
+	}
+	spring, err := repos.Calendar.GetTeachingDays(ctx, firstGroup, 2)
+	if err != nil {
+		t.Fatalf("GetTeachingDays() error = %v", err)
+	}
+	if len(spring) != 0 {
+		t.Errorf("teaching days of a semester without periods = %d, want 0", len(spring))
+	}
+}
+
+func TestCalendarRepoPeriods(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	november, err := repos.Calendar.GetByUniversityID(ctx, 1, date("2024-11-01"), date("2024-11-30"))
+	if err != nil {
+		t.Fatalf("GetByUniversityID() error = %v", err)
+	}
+	if len(november) != 2 || november[0].Kind != "semester" || november[1].Kind != "holiday" {
+		t.Errorf("periods of November = %+v, want the semester and the holiday", november)
+	}
+	january, err := repos.Calendar.GetByUniversityID(ctx, 1, date("2025-01-01"), date("2025-01-31"))
+	if err != nil {
+		t.Fatalf("GetByUniversityID() error = %v", err)
+	}
+	if len(january) != 0 {
+		t.Errorf("periods of January = %+v, want none", january)
+	}
+
+	session := domain.CalendarPeriod{UniversityID: 1, Kind: "exam_session", Title: "Сессия", StartDate: date("2024-12-20"), EndDate: date("2024-12-31")}
+	id, err := repos.Calendar.Create(ctx, session)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	created, err := repos.Calendar.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if created.Kind != session.Kind || created.Title != session.Title || created.Semester != nil || !created.StartDate.Equal(session.StartDate) || !created.EndDate.Equal(session.EndDate) {
+		t.Errorf("created period = %+v", created)
+	}
+	days, err := repos.Calendar.GetTeachingDays(ctx, firstGroup, 1)
+	if err != nil {
+		t.Fatalf("GetTeachingDays() error = %v", err)
+	}
+	if len(days) != 109 {
+		t.Errorf("teaching days with the exam session = %d, want 109", len(days))
+	}
+
+	created.StartDate = date("2024-12-25")
+	if err := repos.Calendar.Put(ctx, created); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	days, err = repos.Calendar.GetTeachingDays(ctx, firstGroup, 1)
+	if err != nil {
+		t.Fatalf("GetTeachingDays() error = %v", err)
+	}
+	if len(days) != 114 {
+		t.Errorf("teaching days with the shorter exam session = %d, want 114", len(days))
+	}
+
+	checks := []struct {
+		name   string
+		period domain.CalendarPeriod
+	}{
+		{name: "kind", period: domain.CalendarPeriod{UniversityID: 1, Kind: "vacation", StartDate: date("2025-01-01"), EndDate: date("2025-01-10")}},
+		{name: "dates", period: domain.CalendarPeriod{UniversityID: 1, Kind: "holiday", StartDate: date("2025-01-10"), EndDate: date("2025-01-01")}},
+	}
+	for _, tt := range checks {
+		if _, err := repos.Calendar.Create(ctx, tt.period); !isViolation(err, "23514") {
+			t.Errorf("Create() with a wrong %s error = %v, want a check violation", tt.name, err)
+		}
+	}
+
+	if err := repos.Calendar.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Calendar.GetByID(ctx, id); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted period error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
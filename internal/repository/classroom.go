@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -17,16 +18,16 @@ func NewClassroomRepo(db *pgxpool.Pool) *ClassroomRepo {
 }
 
 func (r *ClassroomRepo) Create(ctx context.Context, classroom domain.Classroom) error {
-	query := `INSERT INTO classrooms (classroom_name)
-              VALUES ($1)`
-	_, err := r.db.Exec(ctx, query, classroom.ClassroomName)
+	query := `INSERT INTO classrooms (classroom_name, capacity, building, floor, features)
+              VALUES ($1, $2, $3, $4, $5)`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, classroom.ClassroomName, classroom.Capacity, classroom.Building, classroom.Floor, classroomFeatures(classroom.Features))
 
 	return err
 }
 
 func (r *ClassroomRepo) Put(ctx context.Context, classroom domain.Classroom) error {
-	query := `UPDATE classrooms SET classroom_name=$1 WHERE classroom_id=$2`
-	_, err := r.db.Exec(ctx, query, classroom.ClassroomName, classroom.ClassroomID)
+	query := `UPDATE classrooms SET classroom_name=$1, capacity=$2, building=$3, floor=$4, features=$5 WHERE classroom_id=$6`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, classroom.ClassroomName, classroom.Capacity, classroom.Building, classroom.Floor, classroomFeatures(classroom.Features), classroom.ClassroomID)
 
 	return err
 }
@@ -45,46 +46,54 @@ func (r *ClassroomRepo) Patch(ctx context.Context, classroomID int64, updates ma
 	query = query[:len(query)-1]
 	query += " WHERE classroom_id = $" + strconv.Itoa(argsCounter)
 	args = append(args, classroomID)
-	_, err := r.db.Exec(ctx, query, args...)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, args...)
 
 	return err
 }
 
 func (r *ClassroomRepo) Delete(ctx context.Context, classroomID int64) error {
 	query := `DELETE FROM classrooms WHERE classroom_id = $1`
-	_, err := r.db.Exec(ctx, query, classroomID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, classroomID)
 
 	return err
 }
 
 func (r *ClassroomRepo) GetByID(ctx context.Context, classroomID int64) (domain.Classroom, error) {
-	query := `SELECT classroom_id, classroom_name FROM classrooms WHERE classroom_id = $1`
+	query := `SELECT classroom_id, classroom_name, capacity, building, floor, features FROM classrooms WHERE classroom_id = $1`
 
 	classroom := domain.Classroom{}
-	err := r.db.QueryRow(ctx, query, classroomID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, classroomID).Scan(
 		&classroom.ClassroomID,
 		&classroom.ClassroomName,
+		&classroom.Capacity,
+		&classroom.Building,
+		&classroom.Floor,
+		&classroom.Features,
 	)
 
 	return classroom, err
 }
 
 func (r *ClassroomRepo) GetByName(ctx context.Context, classroomName string) (domain.Classroom, error) {
-	query := `SELECT classroom_id, classroom_name FROM classrooms WHERE classroom_name = $1`
+	query := `SELECT classroom_id, classroom_name, capacity, building, floor, features FROM classrooms WHERE classroom_name = $1`
 
 	classroom := domain.Classroom{}
-	err := r.db.QueryRow(ctx, query, classroomName).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, classroomName).Scan(
 		&classroom.ClassroomID,
 		&classroom.ClassroomName,
+		&classroom.Capacity,
+		&classroom.Building,
+		&classroom.Floor,
+		&classroom.Features,
 	)
 
 	return classroom, err
 }
 
 func (r *ClassroomRepo) GetAll(ctx context.Context) ([]domain.Classroom, error) {
-	query := `SELECT classroom_id, classroom_name FROM classrooms`
+	query := `SELECT classroom_id, classroom_name, capacity, building, floor, features FROM classrooms`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +110,10 @@ func (r *ClassroomRepo) GetAll(ctx context.Context) ([]domain.Classroom, error)
 		err := rows.Scan(
 			&classroom.ClassroomID,
 			&classroom.ClassroomName,
+			&classroom.Capacity,
+			&classroom.Building,
+			&classroom.Floor,
+			&classroom.Features,
 		)
 		if err != nil {
 			return nil, err
@@ -115,9 +128,61 @@ func (r *ClassroomRepo) GetAll(ctx context.Context) ([]domain.Classroom, error)
 	return classrooms, nil
 }
 
+// GetFree returns the classrooms with the capacity and the features of the filter
+// that no actual schedule of the semester uses in the slot
+func (r *ClassroomRepo) GetFree(ctx context.Context, filter domain.FreeClassroomFilter) ([]domain.Classroom, error) {
+	query := `SELECT c.classroom_id, c.classroom_name, c.capacity, c.building, c.floor, c.features
+		FROM classrooms c
+		WHERE c.capacity >= $5 AND c.features @> $6
+		AND NOT EXISTS (
+			SELECT 1 FROM schedules s
+			WHERE s.classroom_id = c.classroom_id AND s.is_actual = TRUE
+			AND s.semester = $1 AND s.week_type = $2 AND s.day_of_week = $3 AND s.start_time = $4
+		)
+		ORDER BY c.capacity, c.building, c.classroom_name`
+
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query,
+		filter.Semester, filter.WeekType, filter.DayOfWeek, filter.StartTime, filter.MinCapacity, classroomFeatures(filter.Features))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	classrooms := make([]domain.Classroom, 0)
+	for rows.Next() {
+		var classroom domain.Classroom
+		err := rows.Scan(
+			&classroom.ClassroomID,
+			&classroom.ClassroomName,
+			&classroom.Capacity,
+			&classroom.Building,
+			&classroom.Floor,
+			&classroom.Features,
+		)
+		if err != nil {
+			return nil, err
+		}
+		classrooms = append(classrooms, classroom)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return classrooms, nil
+}
+
+// classroomFeatures keeps the features column an empty array instead of NULL
+func classroomFeatures(features []string) []string {
+	if features == nil {
+		return []string{}
+	}
+	return features
+}
+
 // func (r *ClassroomRepo) getCountClassrooms(ctx context.Context) (int64, error) {
 // 	query := `SELECT COUNT(*) FROM classrooms;`
-// 	rows, err := r.db.Query(ctx, query)
+// 	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 // 	if err != nil {
 // 		return 0, err
 // 	}
@@ -0,0 +1,130 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func classroomIDs(classrooms []domain.Classroom) []int64 {
+	ids := make([]int64, 0, len(classrooms))
+	for _, classroom := range classrooms {
+		ids = append(ids, classroom.ClassroomID)
+	}
+	return ids
+}
+
+func TestClassroomRepo(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	floor := 3
+	classroom := domain.Classroom{ClassroomName: "303", Capacity: 30, Building: "Лабораторный", Floor: &floor, Features: []string{"проектор", "компьютеры"}}
+	if err := repos.Classroom.Create(ctx, classroom); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repos.Classroom.Create(ctx, classroom); !isViolation(err, "23505") {
+		t.Errorf("Create() of a duplicate name error = %v, want a unique violation", err)
+	}
+	if err := repos.Classroom.Create(ctx, domain.Classroom{ClassroomName: "404", Capacity: -1}); !isViolation(err, "23514") {
+		t.Errorf("Create() with a negative capacity error = %v, want a check violation", err)
+	}
+
+	created, err := repos.Classroom.GetByName(ctx, classroom.ClassroomName)
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if created.ClassroomID != 3 || created.Floor == nil || *created.Floor != floor || !slices.Equal(created.Features, classroom.Features) {
+		t.Errorf("created classroom = %+v", created)
+	}
+
+	// a classroom without features keeps an empty array
+	created.Features = nil
+	created.Capacity = 25
+	if err := repos.Classroom.Put(ctx, created); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := repos.Classroom.Patch(ctx, created.ClassroomID, map[string]interface{}{"building": "Главный"}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	got, err := repos.Classroom.GetByID(ctx, created.ClassroomID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Capacity != 25 || got.Building != "Главный" || got.Features == nil || len(got.Features) != 0 {
+		t.Errorf("classroom after Put() and Patch() = %+v", got)
+	}
+
+	all, err := repos.Classroom.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("classrooms = %d, want 3", len(all))
+	}
+
+	if err := repos.Classroom.Delete(ctx, created.ClassroomID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Classroom.GetByID(ctx, created.ClassroomID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted classroom error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
+
+func TestClassroomRepoGetFree(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	if err := repos.Classroom.Create(ctx, domain.Classroom{ClassroomName: "303", Capacity: 30, Building: "Главный", Features: []string{"проектор"}}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// classroom 1 has the lectures of both groups on Monday at 08:30,
+	// classroom 2 the laboratory work at 10:10, the archived schedule 3 keeps nothing busy
+	tests := []struct {
+		name   string
+		filter domain.FreeClassroomFilter
+		want   []int64
+	}{
+		{
+			name:   "first lesson",
+			filter: domain.FreeClassroomFilter{Semester: 1, WeekType: "Верхняя", DayOfWeek: "Понедельник", StartTime: clock("08:30")},
+			want:   []int64{2, 3},
+		},
+		{
+			name:   "second lesson",
+			filter: domain.FreeClassroomFilter{Semester: 1, WeekType: "Верхняя", DayOfWeek: "Понедельник", StartTime: clock("10:10")},
+			want:   []int64{3, 1},
+		},
+		{
+			name:   "archived schedule",
+			filter: domain.FreeClassroomFilter{Semester: 1, WeekType: "Нижняя", DayOfWeek: "Вторник", StartTime: clock("08:30")},
+			want:   []int64{2, 3, 1},
+		},
+		{
+			name:   "another semester",
+			filter: domain.FreeClassroomFilter{Semester: 2, WeekType: "Верхняя", DayOfWeek: "Понедельник", StartTime: clock("08:30"), MinCapacity: 30},
+			want:   []int64{3, 1},
+		},
+		{
+			name:   "features",
+			filter: domain.FreeClassroomFilter{Semester: 1, WeekType: "Верхняя", DayOfWeek: "Понедельник", StartTime: clock("08:30"), Features: []string{"проектор"}},
+			want:   []int64{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classrooms, err := repos.Classroom.GetFree(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("GetFree() error = %v", err)
+			}
+			if got := classroomIDs(classrooms); !equalIDs(got, tt.want) {
+				t.Errorf("free classrooms = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
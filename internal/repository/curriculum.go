@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CurriculumRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCurriculumRepo(db *pgxpool.Pool) *CurriculumRepo {
+	return &CurriculumRepo{db: db}
+}
+
+const curriculumQuery = `SELECT c.curriculum_id, c.profile_id, c.semester, c.discipline_id, c.discipline_type_id, c.planned_hours,
+		d.discipline_name, dt.discipline_type_name
+	FROM curriculum c
+	INNER JOIN disciplines d ON d.discipline_id = c.discipline_id
+	INNER JOIN disciplineTypes dt ON dt.discipline_type_id = c.discipline_type_id`
+
+func (r *CurriculumRepo) Create(ctx context.Context, item domain.CurriculumItem) (int64, error) {
+	query := `INSERT INTO curriculum (profile_id, semester, discipline_id, discipline_type_id, planned_hours)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING curriculum_id`
+
+	var curriculumID int64
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query,
+		item.ProfileID, item.Semester, item.DisciplineID, item.DisciplineTypeID, item.PlannedHours,
+	).Scan(&curriculumID)
+	return curriculumID, err
+}
+
+func (r *CurriculumRepo) Put(ctx context.Context, item domain.CurriculumItem) error {
+	query := `UPDATE curriculum SET
+		profile_id = $1, semester = $2, discipline_id = $3, discipline_type_id = $4, planned_hours = $5
+	WHERE curriculum_id = $6`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query,
+		item.ProfileID, item.Semester, item.DisciplineID, item.DisciplineTypeID, item.PlannedHours, item.CurriculumID)
+	return err
+}
+
+func (r *CurriculumRepo) Delete(ctx context.Context, curriculumID int64) error {
+	query := `DELETE FROM curriculum WHERE curriculum_id = $1`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, curriculumID)
+	return err
+}
+
+func (r *CurriculumRepo) GetByID(ctx context.Context, curriculumID int64) (domain.CurriculumItemInfo, error) {
+	query := curriculumQuery + `
+	WHERE c.curriculum_id = $1`
+	return scanCurriculumItem(postgres.Conn(ctx, r.db).QueryRow(ctx, query, curriculumID))
+}
+
+// GetByProfileID returns the study plan of the profile ordered by semester
+func (r *CurriculumRepo) GetByProfileID(ctx context.Context, profileID int64) ([]domain.CurriculumItemInfo, error) {
+	query := curriculumQuery + `
+	WHERE c.profile_id = $1
+	ORDER BY c.semester, d.discipline_name, dt.discipline_type_name`
+	return r.getAll(ctx, query, profileID)
+}
+
+// GetByGroupAndSemester returns the semester of the study plan of the group's profile
+func (r *CurriculumRepo) GetByGroupAndSemester(ctx context.Context, groupID string, semester int) ([]domain.CurriculumItemInfo, error) {
+	query := curriculumQuery + `
+	INNER JOIN groups g ON g.profile_id = c.profile_id
+	WHERE g.group_id = $1 AND c.semester = $2
+	ORDER BY d.discipline_name, dt.discipline_type_name`
+	return r.getAll(ctx, query, groupID, semester)
+}
+
+// CountHeldLessons returns the number of lessons with a marked attendance of the group
+// in the semester per discipline, discipline type and subgroup
+func (r *CurriculumRepo) CountHeldLessons(ctx context.Context, groupID string, semester int) ([]domain.HeldLessons, error) {
+	query := `SELECT sc.discipline_id, d.discipline_name, sc.discipline_type_id, dt.discipline_type_name, sc.subgroup_id,
+		COUNT(DISTINCT (sc.schedule_id, a.created::date))
+	FROM schedules sc
+	INNER JOIN attendance a ON a.schedule_id = sc.schedule_id
+	INNER JOIN disciplines d ON d.discipline_id = sc.discipline_id
+	INNER JOIN disciplineTypes dt ON dt.discipline_type_id = sc.discipline_type_id
+	WHERE sc.group_id = $1 AND sc.semester = $2
+	GROUP BY sc.discipline_id, d.discipline_name, sc.discipline_type_id, dt.discipline_type_name, sc.subgroup_id`
+
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, groupID, semester)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	held := make([]domain.HeldLessons, 0)
+	for rows.Next() {
+		var lessons domain.HeldLessons
+		err := rows.Scan(
+			&lessons.DisciplineID,
+			&lessons.DisciplineName,
+			&lessons.DisciplineTypeID,
+			&lessons.DisciplineTypeName,
+			&lessons.SubgroupID,
+			&lessons.Lessons,
+		)
+		if err != nil {
+			return nil, err
+		}
+		held = append(held, lessons)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return held, nil
+}
+
+func (r *CurriculumRepo) getAll(ctx context.Context, query string, args ...interface{}) ([]domain.CurriculumItemInfo, error) {
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]domain.CurriculumItemInfo, 0)
+	for rows.Next() {
+		item, err := scanCurriculumItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func scanCurriculumItem(row pgx.Row) (domain.CurriculumItemInfo, error) {
+	var item domain.CurriculumItemInfo
+	err := row.Scan(
+		&item.CurriculumItem.CurriculumID,
+		&item.CurriculumItem.ProfileID,
+		&item.CurriculumItem.Semester,
+		&item.CurriculumItem.DisciplineID,
+		&item.CurriculumItem.DisciplineTypeID,
+		&item.CurriculumItem.PlannedHours,
+		&item.CurriculumItemSub.DisciplineName,
+		&item.CurriculumItemSub.DisciplineTypeName,
+	)
+	return item, err
+}
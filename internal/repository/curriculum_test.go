@@ -0,0 +1,122 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestCurriculumRepoPlan(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	items := []domain.CurriculumItem{
+		{ProfileID: 1, Semester: 1, DisciplineID: 2, DisciplineTypeID: 2, PlannedHours: 18},
+		{ProfileID: 1, Semester: 2, DisciplineID: 1, DisciplineTypeID: 1, PlannedHours: 36},
+		{ProfileID: 1, Semester: 1, DisciplineID: 1, DisciplineTypeID: 1, PlannedHours: 36},
+	}
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		id, err := repos.Curriculum.Create(ctx, item)
+		if err != nil {
+			t.Fatalf("Create(%+v) error = %v", item, err)
+		}
+		ids = append(ids, id)
+	}
+
+	plan, err := repos.Curriculum.GetByProfileID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetByProfileID() error = %v", err)
+	}
+	got := make([]int64, 0, len(plan))
+	for _, item := range plan {
+		got = append(got, item.CurriculumItem.CurriculumID)
+	}
+	// by the semester and the name of the discipline
+	if want := []int64{ids[2], ids[0], ids[1]}; !equalIDs(got, want) {
+		t.Errorf("plan = %v, want %v", got, want)
+	}
+
+	semester, err := repos.Curriculum.GetByGroupAndSemester(ctx, firstGroup, 1)
+	if err != nil {
+		t.Fatalf("GetByGroupAndSemester() error = %v", err)
+	}
+	if len(semester) != 2 || semester[0].CurriculumItemSub.DisciplineName != "Математика" || semester[1].CurriculumItemSub.DisciplineTypeName != "Лабораторная работа" {
+		t.Errorf("first semester = %+v", semester)
+	}
+
+	item, err := repos.Curriculum.GetByID(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	item.CurriculumItem.PlannedHours = 24
+	if err := repos.Curriculum.Put(ctx, item.CurriculumItem); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	item, err = repos.Curriculum.GetByID(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if item.CurriculumItem.PlannedHours != 24 || item.CurriculumItemSub.DisciplineName != "Физика" {
+		t.Errorf("item after Put() = %+v", item)
+	}
+
+	wrong := []struct {
+		name string
+		item domain.CurriculumItem
+		code string
+	}{
+		{name: "duplicate", item: items[0], code: "23505"},
+		{name: "semester", item: domain.CurriculumItem{ProfileID: 1, Semester: 13, DisciplineID: 1, DisciplineTypeID: 1, PlannedHours: 36}, code: "23514"},
+		{name: "hours", item: domain.CurriculumItem{ProfileID: 1, Semester: 3, DisciplineID: 1, DisciplineTypeID: 1, PlannedHours: 0}, code: "23514"},
+	}
+	for _, tt := range wrong {
+		if _, err := repos.Curriculum.Create(ctx, tt.item); !isViolation(err, tt.code) {
+			t.Errorf("Create() of a wrong %s error = %v, want %s", tt.name, err, tt.code)
+		}
+	}
+
+	if err := repos.Curriculum.Delete(ctx, ids[1]); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Curriculum.GetByID(ctx, ids[1]); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted item error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
+
+func TestCurriculumRepoCountHeldLessons(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	held, err := repos.Curriculum.CountHeldLessons(ctx, firstGroup, 1)
+	if err != nil {
+		t.Fatalf("CountHeldLessons() error = %v", err)
+	}
+
+	// a lesson is held once a day however many students are marked,
+	// the archived schedule 3 has no marks
+	got := make(map[int64]domain.HeldLessons, len(held))
+	for _, lessons := range held {
+		got[lessons.DisciplineID] = lessons
+	}
+	if len(held) != 2 {
+		t.Fatalf("held lessons = %+v, want two disciplines", held)
+	}
+	if lecture := got[1]; lecture.Lessons != 3 || lecture.SubgroupID != nil || lecture.DisciplineTypeName != "Лекция" {
+		t.Errorf("lectures = %+v, want 3 for the whole group", lecture)
+	}
+	if lab := got[2]; lab.Lessons != 1 || lab.SubgroupID == nil || *lab.SubgroupID != 1 {
+		t.Errorf("laboratory works = %+v, want 1 for subgroup 1", lab)
+	}
+
+	other, err := repos.Curriculum.CountHeldLessons(ctx, secondGroup, 2)
+	if err != nil {
+		t.Fatalf("CountHeldLessons() error = %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("held lessons of another semester = %+v, want none", other)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,14 +20,14 @@ func NewDepartamentRepo(db *pgxpool.Pool) *DepartamentRepo {
 func (r *DepartamentRepo) Create(ctx context.Context, departament domain.Departament) error {
 	query := `INSERT INTO departaments (faculty_id, departament_name, head_last_name, head_first_name, head_middle_name, departament_email)
               VALUES ($1, $2, $3, $4, $5, $6)`
-	_, err := r.db.Exec(ctx, query, departament.FacultyID, departament.DepartamentName, departament.HeadLastName, departament.HeadFirstName, departament.HeadMiddleName, departament.DepartamentEmail)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, departament.FacultyID, departament.DepartamentName, departament.HeadLastName, departament.HeadFirstName, departament.HeadMiddleName, departament.DepartamentEmail)
 
 	return err
 }
 
 func (r *DepartamentRepo) Put(ctx context.Context, departament domain.Departament) error {
 	query := `UPDATE departaments SET faculty_id=$1, departament_name=$2, head_last_name=$3, head_first_name=$4, head_middle_name=$5, departament_email=$6 WHERE departament_id=$7`
-	_, err := r.db.Exec(ctx, query, departament.FacultyID, departament.DepartamentName, departament.HeadLastName, departament.HeadFirstName, departament.HeadMiddleName, departament.DepartamentEmail, departament.DepartamentID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, departament.FacultyID, departament.DepartamentName, departament.HeadLastName, departament.HeadFirstName, departament.HeadMiddleName, departament.DepartamentEmail, departament.DepartamentID)
 
 	return err
 }
@@ -45,14 +46,14 @@ func (r *DepartamentRepo) Patch(ctx context.Context, departamentID int64, update
 	query = query[:len(query)-1]
 	query += " WHERE departament_id = $" + strconv.Itoa(argsCounter)
 	args = append(args, departamentID)
-	_, err := r.db.Exec(ctx, query, args...)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, args...)
 
 	return err
 }
 
 func (r *DepartamentRepo) Delete(ctx context.Context, departamentID int64) error {
 	query := `DELETE FROM departaments WHERE departament_id = $1`
-	_, err := r.db.Exec(ctx, query, departamentID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, departamentID)
 
 	return err
 }
@@ -68,7 +69,7 @@ func (r *DepartamentRepo) GetByID(ctx context.Context, departamentID int64) (dom
 		WHERE d.departament_id = $1`
 
 	departamentInfo := domain.DepartamentInfo{}
-	err := r.db.QueryRow(ctx, query, departamentID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, departamentID).Scan(
 		&departamentInfo.Departament.DepartamentID,
 		&departamentInfo.Departament.FacultyID,
 		&departamentInfo.Departament.DepartamentName,
@@ -93,7 +94,7 @@ func (r *DepartamentRepo) GetByName(ctx context.Context, departamentName string)
 		WHERE d.departament_name = $1`
 
 	departamentInfo := domain.DepartamentInfo{}
-	err := r.db.QueryRow(ctx, query, departamentName).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, departamentName).Scan(
 		&departamentInfo.Departament.DepartamentID,
 		&departamentInfo.Departament.FacultyID,
 		&departamentInfo.Departament.DepartamentName,
@@ -116,7 +117,7 @@ func (r *DepartamentRepo) GetAll(ctx context.Context) ([]domain.DepartamentInfo,
 		LEFT JOIN 
 			faculties f ON d.faculty_id = f.faculty_id`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -159,7 +160,7 @@ func (r *DepartamentRepo) GetAllByFacultyID(ctx context.Context, facultyID int64
 			faculties f ON d.faculty_id = f.faculty_id
 		WHERE d.faculty_id = $1`
 
-	rows, err := r.db.Query(ctx, query, facultyID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, facultyID)
 	if err != nil {
 		return nil, err
 	}
@@ -194,7 +195,7 @@ func (r *DepartamentRepo) GetAllByFacultyID(ctx context.Context, facultyID int64
 
 func (r *DepartamentRepo) getCountDepartaments(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM departaments;`
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -213,7 +214,7 @@ func (r *DepartamentRepo) getCountDepartaments(ctx context.Context) (int64, erro
 
 func (r *DepartamentRepo) getCountDepartamentsByFacultyID(ctx context.Context, facultyID int64) (int64, error) {
 	query := `SELECT COUNT(*) FROM departaments WHERE faculty_id = $1;`
-	rows, err := r.db.Query(ctx, query, facultyID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, facultyID)
 	if err != nil {
 		return 0, err
 	}
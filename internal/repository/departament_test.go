@@ -0,0 +1,66 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestDepartamentRepo(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	departament := domain.Departament{FacultyID: 1, DepartamentName: "Технический сервис", HeadLastName: "Орлов", HeadFirstName: "Виктор", HeadMiddleName: "Петрович", DepartamentEmail: "service@omgau.org"}
+	if err := repos.Departament.Create(ctx, departament); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repos.Departament.Create(ctx, departament); !isViolation(err, "23505") {
+		t.Errorf("Create() of a duplicate name error = %v, want a unique violation", err)
+	}
+
+	created, err := repos.Departament.GetByName(ctx, departament.DepartamentName)
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if created.Departament.DepartamentID != 2 || created.DepartamentSub.FacultyName != "Инженерный" {
+		t.Errorf("created departament = %+v", created)
+	}
+
+	put := created.Departament
+	put.HeadLastName = "Зайцев"
+	if err := repos.Departament.Put(ctx, put); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := repos.Departament.Patch(ctx, put.DepartamentID, map[string]interface{}{"departament_email": "ts@omgau.org"}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	got, err := repos.Departament.GetByID(ctx, put.DepartamentID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Departament.HeadLastName != "Зайцев" || got.Departament.DepartamentEmail != "ts@omgau.org" {
+		t.Errorf("departament after Put() and Patch() = %+v", got.Departament)
+	}
+
+	all, err := repos.Departament.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	byFaculty, err := repos.Departament.GetAllByFacultyID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetAllByFacultyID() error = %v", err)
+	}
+	if len(all) != 2 || len(byFaculty) != 2 {
+		t.Errorf("departaments = %d, of the faculty = %d, want 2", len(all), len(byFaculty))
+	}
+
+	if err := repos.Departament.Delete(ctx, put.DepartamentID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Departament.GetByID(ctx, put.DepartamentID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted departament error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,14 +20,14 @@ func NewDisciplineRepo(db *pgxpool.Pool) *DisciplineRepo {
 func (r *DisciplineRepo) Create(ctx context.Context, discipline domain.Discipline) error {
 	query := `INSERT INTO disciplines (departament_id, discipline_name)
               VALUES ($1, $2)`
-	_, err := r.db.Exec(ctx, query, discipline.DepartamentID, discipline.DisciplineName)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, discipline.DepartamentID, discipline.DisciplineName)
 
 	return err
 }
 
 func (r *DisciplineRepo) Put(ctx context.Context, discipline domain.Discipline) error {
 	query := `UPDATE disciplines SET departament_id=$1, discipline_name=$2 WHERE discipline_id=$3`
-	_, err := r.db.Exec(ctx, query, discipline.DepartamentID, discipline.DisciplineName, discipline.DisciplineID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, discipline.DepartamentID, discipline.DisciplineName, discipline.DisciplineID)
 
 	return err
 }
@@ -45,14 +46,14 @@ func (r *DisciplineRepo) Patch(ctx context.Context, disciplineID int64, updates
 	query = query[:len(query)-1]
 	query += " WHERE discipline_id = $" + strconv.Itoa(argsCounter)
 	args = append(args, disciplineID)
-	_, err := r.db.Exec(ctx, query, args...)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, args...)
 
 	return err
 }
 
 func (r *DisciplineRepo) Delete(ctx context.Context, disciplineID int64) error {
 	query := `DELETE FROM disciplines WHERE discipline_id = $1`
-	_, err := r.db.Exec(ctx, query, disciplineID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, disciplineID)
 
 	return err
 }
@@ -68,7 +69,7 @@ func (r *DisciplineRepo) GetByID(ctx context.Context, disciplineID int64) (domai
 		WHERE d.discipline_id = $1`
 
 	disciplineInfo := domain.DisciplineInfo{}
-	err := r.db.QueryRow(ctx, query, disciplineID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, disciplineID).Scan(
 		&disciplineInfo.Discipline.DisciplineID,
 		&disciplineInfo.Discipline.DepartamentID,
 		&disciplineInfo.Discipline.DisciplineName,
@@ -89,7 +90,7 @@ func (r *DisciplineRepo) GetByName(ctx context.Context, disciplineName string) (
 		WHERE d.discipline_name = $1`
 
 	disciplineInfo := domain.DisciplineInfo{}
-	err := r.db.QueryRow(ctx, query, disciplineName).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, disciplineName).Scan(
 		&disciplineInfo.Discipline.DisciplineID,
 		&disciplineInfo.Discipline.DepartamentID,
 		&disciplineInfo.Discipline.DisciplineName,
@@ -108,7 +109,7 @@ func (r *DisciplineRepo) GetAll(ctx context.Context) ([]domain.DisciplineInfo, e
 		LEFT JOIN 
 			departaments dp ON d.departament_id = dp.departament_id`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +152,7 @@ func (r *DisciplineRepo) GetAllByDepartamentID(ctx context.Context, departamentI
 			departaments dp ON d.departament_id = dp.departament_id
 		WHERE d.departament_id = $1`
 
-	rows, err := r.db.Query(ctx, query, departamentID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, departamentID)
 	if err != nil {
 		return nil, err
 	}
@@ -182,7 +183,7 @@ func (r *DisciplineRepo) GetAllByDepartamentID(ctx context.Context, departamentI
 
 func (r *DisciplineRepo) getCountDisciplines(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM disciplines;`
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -201,7 +202,7 @@ func (r *DisciplineRepo) getCountDisciplines(ctx context.Context) (int64, error)
 
 func (r *DisciplineRepo) getCountDisciplinesByDepartamentID(ctx context.Context, departamentID int64) (int64, error) {
 	query := `SELECT COUNT(*) FROM disciplines WHERE departament_id = $1;`
-	rows, err := r.db.Query(ctx, query, departamentID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, departamentID)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,66 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestDisciplineRepo(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	discipline := domain.Discipline{DepartamentID: 1, DisciplineName: "Химия"}
+	if err := repos.Discipline.Create(ctx, discipline); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repos.Discipline.Create(ctx, discipline); !isViolation(err, "23505") {
+		t.Errorf("Create() of a duplicate name error = %v, want a unique violation", err)
+	}
+
+	created, err := repos.Discipline.GetByName(ctx, discipline.DisciplineName)
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if created.Discipline.DisciplineID != 3 || created.DisciplineSub.DepartamentName != "Агроинженерия" {
+		t.Errorf("created discipline = %+v", created)
+	}
+
+	put := created.Discipline
+	put.DisciplineName = "Неорганическая химия"
+	if err := repos.Discipline.Put(ctx, put); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	got, err := repos.Discipline.GetByID(ctx, put.DisciplineID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Discipline.DisciplineName != put.DisciplineName {
+		t.Errorf("discipline after Put() = %+v", got.Discipline)
+	}
+	if err := repos.Discipline.Patch(ctx, put.DisciplineID, map[string]interface{}{"discipline_name": "Физика"}); !isViolation(err, "23505") {
+		t.Errorf("Patch() to a taken name error = %v, want a unique violation", err)
+	}
+
+	all, err := repos.Discipline.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	byDepartament, err := repos.Discipline.GetAllByDepartamentID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetAllByDepartamentID() error = %v", err)
+	}
+	if len(all) != 3 || len(byDepartament) != 3 {
+		t.Errorf("disciplines = %d, of the departament = %d, want 3", len(all), len(byDepartament))
+	}
+
+	if err := repos.Discipline.Delete(ctx, put.DisciplineID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Discipline.GetByID(ctx, put.DisciplineID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted discipline error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
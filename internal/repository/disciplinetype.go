@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,14 +20,14 @@ func NewDisciplineTypeRepo(db *pgxpool.Pool) *DisciplineTypeRepo {
 func (r *DisciplineTypeRepo) Create(ctx context.Context, disciplineType domain.DisciplineType) error {
 	query := `INSERT INTO disciplineTypes (discipline_type_name)
               VALUES ($1)`
-	_, err := r.db.Exec(ctx, query, disciplineType.DisciplineTypeName)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, disciplineType.DisciplineTypeName)
 
 	return err
 }
 
 func (r *DisciplineTypeRepo) Put(ctx context.Context, disciplineType domain.DisciplineType) error {
 	query := `UPDATE disciplineTypes SET discipline_type_name=$1 WHERE discipline_type_id=$2`
-	_, err := r.db.Exec(ctx, query, disciplineType.DisciplineTypeName, disciplineType.DisciplineTypeID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, disciplineType.DisciplineTypeName, disciplineType.DisciplineTypeID)
 
 	return err
 }
@@ -45,14 +46,14 @@ func (r *DisciplineTypeRepo) Patch(ctx context.Context, disciplineTypeID int64,
 	query = query[:len(query)-1]
 	query += " WHERE discipline_type_id = $" + strconv.Itoa(argsCounter)
 	args = append(args, disciplineTypeID)
-	_, err := r.db.Exec(ctx, query, args...)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, args...)
 
 	return err
 }
 
 func (r *DisciplineTypeRepo) Delete(ctx context.Context, disciplineTypeID int64) error {
 	query := `DELETE FROM disciplineTypes WHERE discipline_type_id = $1`
-	_, err := r.db.Exec(ctx, query, disciplineTypeID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, disciplineTypeID)
 
 	return err
 }
@@ -61,7 +62,7 @@ func (r *DisciplineTypeRepo) GetByID(ctx context.Context, disciplineTypeID int64
 	query := `SELECT discipline_type_id, discipline_type_name FROM disciplineTypes WHERE discipline_type_id = $1`
 
 	disciplineType := domain.DisciplineType{}
-	err := r.db.QueryRow(ctx, query, disciplineTypeID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, disciplineTypeID).Scan(
 		&disciplineType.DisciplineTypeID,
 		&disciplineType.DisciplineTypeName,
 	)
@@ -73,7 +74,7 @@ func (r *DisciplineTypeRepo) GetByName(ctx context.Context, disciplineTypeName s
 	query := `SELECT discipline_type_id, discipline_type_name FROM disciplineTypes WHERE discipline_type_name = $1`
 
 	disciplineType := domain.DisciplineType{}
-	err := r.db.QueryRow(ctx, query, disciplineTypeName).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, disciplineTypeName).Scan(
 		&disciplineType.DisciplineTypeID,
 		&disciplineType.DisciplineTypeName,
 	)
@@ -84,7 +85,7 @@ func (r *DisciplineTypeRepo) GetByName(ctx context.Context, disciplineTypeName s
 func (r *DisciplineTypeRepo) GetAll(ctx context.Context) ([]domain.DisciplineType, error) {
 	query := `SELECT discipline_type_id, discipline_type_name FROM disciplineTypes`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -117,7 +118,7 @@ func (r *DisciplineTypeRepo) GetAll(ctx context.Context) ([]domain.DisciplineTyp
 
 func (r *DisciplineTypeRepo) getCountDisciplineTypes(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM disciplineTypes;`
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,60 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestDisciplineTypeRepo(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	if err := repos.DisciplineType.Create(ctx, domain.DisciplineType{DisciplineTypeName: "Практика"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repos.DisciplineType.Create(ctx, domain.DisciplineType{DisciplineTypeName: "Лекция"}); !isViolation(err, "23505") {
+		t.Errorf("Create() of a duplicate name error = %v, want a unique violation", err)
+	}
+
+	created, err := repos.DisciplineType.GetByName(ctx, "Практика")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if created.DisciplineTypeID != 3 {
+		t.Errorf("id of the created type = %d, want 3", created.DisciplineTypeID)
+	}
+
+	created.DisciplineTypeName = "Практическое занятие"
+	if err := repos.DisciplineType.Put(ctx, created); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := repos.DisciplineType.Patch(ctx, created.DisciplineTypeID, map[string]interface{}{"discipline_type_name": "Семинар"}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	got, err := repos.DisciplineType.GetByID(ctx, created.DisciplineTypeID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.DisciplineTypeName != "Семинар" {
+		t.Errorf("name after Put() and Patch() = %q, want %q", got.DisciplineTypeName, "Семинар")
+	}
+
+	all, err := repos.DisciplineType.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("discipline types = %d, want 3", len(all))
+	}
+
+	if err := repos.DisciplineType.Delete(ctx, created.DisciplineTypeID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.DisciplineType.GetByID(ctx, created.DisciplineTypeID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted type error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
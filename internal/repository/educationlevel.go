@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,14 +20,14 @@ func NewEducationLevelRepo(db *pgxpool.Pool) *EducationLevelRepo {
 func (r *EducationLevelRepo) Create(ctx context.Context, educationLevel domain.EducationLevel) error {
 	query := `INSERT INTO educationLevels (education_level_name)
               VALUES ($1)`
-	_, err := r.db.Exec(ctx, query, educationLevel.EducationLevelName)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, educationLevel.EducationLevelName)
 
 	return err
 }
 
 func (r *EducationLevelRepo) Put(ctx context.Context, educationLevel domain.EducationLevel) error {
 	query := `UPDATE educationLevels SET education_level_name=$1 WHERE education_level_id=$2`
-	_, err := r.db.Exec(ctx, query, educationLevel.EducationLevelName, educationLevel.EducationLevelID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, educationLevel.EducationLevelName, educationLevel.EducationLevelID)
 
 	return err
 }
@@ -45,14 +46,14 @@ func (r *EducationLevelRepo) Patch(ctx context.Context, educationLevelID int64,
 	query = query[:len(query)-1]
 	query += " WHERE education_level_id = $" + strconv.Itoa(argsCounter)
 	args = append(args, educationLevelID)
-	_, err := r.db.Exec(ctx, query, args...)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, args...)
 
 	return err
 }
 
 func (r *EducationLevelRepo) Delete(ctx context.Context, educationLevelID int64) error {
 	query := `DELETE FROM educationLevels WHERE education_level_id = $1`
-	_, err := r.db.Exec(ctx, query, educationLevelID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, educationLevelID)
 
 	return err
 }
@@ -61,7 +62,7 @@ func (r *EducationLevelRepo) GetByID(ctx context.Context, educationLevelID int64
 	query := `SELECT education_level_id, education_level_name FROM educationLevels WHERE education_level_id = $1`
 
 	educationLevel := domain.EducationLevel{}
-	err := r.db.QueryRow(ctx, query, educationLevelID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, educationLevelID).Scan(
 		&educationLevel.EducationLevelID,
 		&educationLevel.EducationLevelName,
 	)
@@ -73,7 +74,7 @@ func (r *EducationLevelRepo) GetByName(ctx context.Context, educationLevelName s
 	query := `SELECT education_level_id, education_level_name FROM educationLevels WHERE education_level_name = $1`
 
 	educationLevel := domain.EducationLevel{}
-	err := r.db.QueryRow(ctx, query, educationLevelName).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, educationLevelName).Scan(
 		&educationLevel.EducationLevelID,
 		&educationLevel.EducationLevelName,
 	)
@@ -84,7 +85,7 @@ func (r *EducationLevelRepo) GetByName(ctx context.Context, educationLevelName s
 func (r *EducationLevelRepo) GetAll(ctx context.Context) ([]domain.EducationLevel, error) {
 	query := `SELECT education_level_id, education_level_name FROM educationLevels`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -117,7 +118,7 @@ func (r *EducationLevelRepo) GetAll(ctx context.Context) ([]domain.EducationLeve
 
 func (r *EducationLevelRepo) getCountEducationLevels(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM educationLevels;`
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,56 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestEducationLevelRepo(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	if err := repos.EducationLevel.Create(ctx, domain.EducationLevel{EducationLevelName: "Магистратура"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repos.EducationLevel.Create(ctx, domain.EducationLevel{EducationLevelName: "Бакалавриат"}); !isViolation(err, "23505") {
+		t.Errorf("Create() of a duplicate name error = %v, want a unique violation", err)
+	}
+
+	levels, err := repos.EducationLevel.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(levels) != 2 {
+		t.Fatalf("education levels = %d, want 2", len(levels))
+	}
+
+	// the id follows the fixtures
+	level := domain.EducationLevel{EducationLevelID: 2, EducationLevelName: "Специалитет"}
+	if err := repos.EducationLevel.Put(ctx, level); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	got, err := repos.EducationLevel.GetByID(ctx, level.EducationLevelID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got != level {
+		t.Errorf("education level after Put() = %+v, want %+v", got, level)
+	}
+	if err := repos.EducationLevel.Patch(ctx, level.EducationLevelID, map[string]interface{}{"education_level_name": "Аспирантура"}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	if got, err := repos.EducationLevel.GetByID(ctx, level.EducationLevelID); err != nil || got.EducationLevelName != "Аспирантура" {
+		t.Errorf("education level after Patch() = %+v, error = %v", got, err)
+	}
+
+	if err := repos.EducationLevel.Delete(ctx, level.EducationLevelID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.EducationLevel.GetByID(ctx, level.EducationLevelID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted level error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,14 +20,14 @@ func NewEducationTypeRepo(db *pgxpool.Pool) *EducationTypeRepo {
 func (r *EducationTypeRepo) Create(ctx context.Context, educationType domain.EducationType) error {
 	query := `INSERT INTO educationTypes (education_type_name)
               VALUES ($1)`
-	_, err := r.db.Exec(ctx, query, educationType.EducationTypeName)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, educationType.EducationTypeName)
 
 	return err
 }
 
 func (r *EducationTypeRepo) Put(ctx context.Context, educationType domain.EducationType) error {
 	query := `UPDATE educationTypes SET education_type_name=$1 WHERE education_type_id=$2`
-	_, err := r.db.Exec(ctx, query, educationType.EducationTypeName, educationType.EducationTypeID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, educationType.EducationTypeName, educationType.EducationTypeID)
 
 	return err
 }
@@ -45,14 +46,14 @@ func (r *EducationTypeRepo) Patch(ctx context.Context, educationTypeID int64, up
 	query = query[:len(query)-1]
 	query += " WHERE education_type_id = $" + strconv.Itoa(argsCounter)
 	args = append(args, educationTypeID)
-	_, err := r.db.Exec(ctx, query, args...)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, args...)
 
 	return err
 }
 
 func (r *EducationTypeRepo) Delete(ctx context.Context, educationTypeID int64) error {
 	query := `DELETE FROM educationTypes WHERE education_type_id = $1`
-	_, err := r.db.Exec(ctx, query, educationTypeID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, educationTypeID)
 
 	return err
 }
@@ -61,7 +62,7 @@ func (r *EducationTypeRepo) GetByID(ctx context.Context, educationTypeID int64)
 	query := `SELECT education_type_id, education_type_name FROM educationTypes WHERE education_type_id = $1`
 
 	educationType := domain.EducationType{}
-	err := r.db.QueryRow(ctx, query, educationTypeID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, educationTypeID).Scan(
 		&educationType.EducationTypeID,
 		&educationType.EducationTypeName,
 	)
@@ -73,7 +74,7 @@ func (r *EducationTypeRepo) GetByName(ctx context.Context, educationTypeName str
 	query := `SELECT education_type_id, education_type_name FROM educationTypes WHERE education_type_name = $1`
 
 	educationType := domain.EducationType{}
-	err := r.db.QueryRow(ctx, query, educationTypeName).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, educationTypeName).Scan(
 		&educationType.EducationTypeID,
 		&educationType.EducationTypeName,
 	)
@@ -84,7 +85,7 @@ func (r *EducationTypeRepo) GetByName(ctx context.Context, educationTypeName str
 func (r *EducationTypeRepo) GetAll(ctx context.Context) ([]domain.EducationType, error) {
 	query := `SELECT education_type_id, education_type_name FROM educationTypes`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -117,7 +118,7 @@ func (r *EducationTypeRepo) GetAll(ctx context.Context) ([]domain.EducationType,
 
 func (r *EducationTypeRepo) getCountEducationTypes(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM educationTypes;`
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return 0, err
 	}
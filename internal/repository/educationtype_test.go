@@ -0,0 +1,60 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestEducationTypeRepo(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	if err := repos.EducationType.Create(ctx, domain.EducationType{EducationTypeName: "Заочная"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repos.EducationType.Create(ctx, domain.EducationType{EducationTypeName: "Очная"}); !isViolation(err, "23505") {
+		t.Errorf("Create() of a duplicate name error = %v, want a unique violation", err)
+	}
+
+	created, err := repos.EducationType.GetByName(ctx, "Заочная")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if created.EducationTypeID != 2 {
+		t.Errorf("id of the created type = %d, want 2", created.EducationTypeID)
+	}
+
+	created.EducationTypeName = "Очно-заочная"
+	if err := repos.EducationType.Put(ctx, created); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	got, err := repos.EducationType.GetByID(ctx, created.EducationTypeID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got != created {
+		t.Errorf("education type after Put() = %+v, want %+v", got, created)
+	}
+	if err := repos.EducationType.Patch(ctx, created.EducationTypeID, map[string]interface{}{"education_type_name": "Очная"}); !isViolation(err, "23505") {
+		t.Errorf("Patch() to a taken name error = %v, want a unique violation", err)
+	}
+
+	all, err := repos.EducationType.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("education types = %d, want 2", len(all))
+	}
+
+	if err := repos.EducationType.Delete(ctx, created.EducationTypeID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.EducationType.GetByID(ctx, created.EducationTypeID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted type error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,14 +20,14 @@ func NewFacultyRepo(db *pgxpool.Pool) *FacultyRepo {
 func (r *FacultyRepo) Create(ctx context.Context, faculty domain.Faculty) error {
 	query := `INSERT INTO faculties (university_id, faculty_name, head_last_name, head_first_name, head_middle_name, faculty_email)
               VALUES ($1, $2, $3, $4, $5, $6)`
-	_, err := r.db.Exec(ctx, query, faculty.UniversityID, faculty.FacultyName, faculty.HeadLastName, faculty.HeadFirstName, faculty.HeadMiddleName, faculty.FacultyEmail)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, faculty.UniversityID, faculty.FacultyName, faculty.HeadLastName, faculty.HeadFirstName, faculty.HeadMiddleName, faculty.FacultyEmail)
 
 	return err
 }
 
 func (r *FacultyRepo) Put(ctx context.Context, faculty domain.Faculty) error {
 	query := `UPDATE faculties SET university_id=$1, faculty_name=$2, head_last_name=$3, head_first_name=$4, head_middle_name=$5, faculty_email=$6 WHERE faculty_id=$7`
-	_, err := r.db.Exec(ctx, query, faculty.UniversityID, faculty.FacultyName, faculty.HeadLastName, faculty.HeadFirstName, faculty.HeadMiddleName, faculty.FacultyEmail, faculty.FacultyID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, faculty.UniversityID, faculty.FacultyName, faculty.HeadLastName, faculty.HeadFirstName, faculty.HeadMiddleName, faculty.FacultyEmail, faculty.FacultyID)
 
 	return err
 }
@@ -45,14 +46,14 @@ func (r *FacultyRepo) Patch(ctx context.Context, facultyID int64, updates map[st
 	query = query[:len(query)-1]
 	query += " WHERE faculty_id = $" + strconv.Itoa(argsCounter)
 	args = append(args, facultyID)
-	_, err := r.db.Exec(ctx, query, args...)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, args...)
 
 	return err
 }
 
 func (r *FacultyRepo) Delete(ctx context.Context, facultyID int64) error {
 	query := `DELETE FROM faculties WHERE faculty_id = $1`
-	_, err := r.db.Exec(ctx, query, facultyID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, facultyID)
 
 	return err
 }
@@ -68,7 +69,7 @@ func (r *FacultyRepo) GetByID(ctx context.Context, facultyID int64) (domain.Facu
 		WHERE f.faculty_id = $1`
 
 	facultyInfo := domain.FacultyInfo{}
-	err := r.db.QueryRow(ctx, query, facultyID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, facultyID).Scan(
 		&facultyInfo.Faculty.FacultyID,
 		&facultyInfo.Faculty.UniversityID,
 		&facultyInfo.Faculty.FacultyName,
@@ -93,7 +94,7 @@ func (r *FacultyRepo) GetByName(ctx context.Context, facultyName string) (domain
 		WHERE f.faculty_name = $1`
 
 	facultyInfo := domain.FacultyInfo{}
-	err := r.db.QueryRow(ctx, query, facultyName).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, facultyName).Scan(
 		&facultyInfo.Faculty.FacultyID,
 		&facultyInfo.Faculty.UniversityID,
 		&facultyInfo.Faculty.FacultyName,
@@ -116,7 +117,7 @@ func (r *FacultyRepo) GetAll(ctx context.Context) ([]domain.FacultyInfo, error)
 		LEFT JOIN 
 			university u ON f.university_id = u.university_id`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -159,7 +160,7 @@ func (r *FacultyRepo) GetAllByUniversityID(ctx context.Context, universityID int
 			university u ON f.university_id = u.university_id
 		WHERE f.university_id = $1`
 
-	rows, err := r.db.Query(ctx, query, universityID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, universityID)
 	if err != nil {
 		return nil, err
 	}
@@ -194,7 +195,7 @@ func (r *FacultyRepo) GetAllByUniversityID(ctx context.Context, universityID int
 
 func (r *FacultyRepo) getCountFaculties(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM faculties;`
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -213,7 +214,7 @@ func (r *FacultyRepo) getCountFaculties(ctx context.Context) (int64, error) {
 
 func (r *FacultyRepo) getCountFacultiesByUniversityID(ctx context.Context, universityID int64) (int64, error) {
 	query := `SELECT COUNT(*) FROM faculties WHERE university_id = $1;`
-	rows, err := r.db.Query(ctx, query, universityID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, universityID)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,70 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestFacultyRepo(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	faculty := domain.Faculty{UniversityID: 1, FacultyName: "Агрономический", HeadLastName: "Ломов", HeadFirstName: "Игорь", HeadMiddleName: "Юрьевич", FacultyEmail: "agro-faculty@omgau.org"}
+	if err := repos.Faculty.Create(ctx, faculty); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repos.Faculty.Create(ctx, faculty); !isViolation(err, "23505") {
+		t.Errorf("Create() of a duplicate name error = %v, want a unique violation", err)
+	}
+
+	created, err := repos.Faculty.GetByName(ctx, faculty.FacultyName)
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if created.Faculty.FacultyID != 2 || created.FacultySub.UniversityName != "Омский ГАУ" {
+		t.Errorf("created faculty = %+v", created)
+	}
+
+	put := created.Faculty
+	put.HeadLastName = "Волков"
+	if err := repos.Faculty.Put(ctx, put); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := repos.Faculty.Patch(ctx, put.FacultyID, map[string]interface{}{"faculty_email": "agronomy@omgau.org"}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	got, err := repos.Faculty.GetByID(ctx, put.FacultyID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Faculty.HeadLastName != "Волков" || got.Faculty.FacultyEmail != "agronomy@omgau.org" {
+		t.Errorf("faculty after Put() and Patch() = %+v", got.Faculty)
+	}
+
+	all, err := repos.Faculty.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	byUniversity, err := repos.Faculty.GetAllByUniversityID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetAllByUniversityID() error = %v", err)
+	}
+	other, err := repos.Faculty.GetAllByUniversityID(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetAllByUniversityID() error = %v", err)
+	}
+	if len(all) != 2 || len(byUniversity) != 2 || len(other) != 0 {
+		t.Errorf("faculties = %d, of the university = %d, of a missing one = %d, want 2, 2 and 0", len(all), len(byUniversity), len(other))
+	}
+
+	if err := repos.Faculty.Delete(ctx, put.FacultyID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Faculty.GetByID(ctx, put.FacultyID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted faculty error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
@@ -0,0 +1,289 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type GradebookRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewGradebookRepo(db *pgxpool.Pool) *GradebookRepo {
+	return &GradebookRepo{db: db}
+}
+
+// SetMark puts the mark of the student for the lesson, a second mark for the same lesson replaces the first
+func (r *GradebookRepo) SetMark(ctx context.Context, mark domain.Mark) (int64, error) {
+	query := `INSERT INTO marks (schedule_id, student_id, lesson_date, mark, comment, teacher_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (schedule_id, student_id, lesson_date)
+		DO UPDATE SET mark = EXCLUDED.mark, comment = EXCLUDED.comment, teacher_id = EXCLUDED.teacher_id, created_at = now()
+		RETURNING mark_id`
+
+	var markID int64
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query,
+		mark.ScheduleID, mark.StudentID, mark.LessonDate, mark.Mark, mark.Comment, mark.TeacherID,
+	).Scan(&markID)
+	return markID, err
+}
+
+func (r *GradebookRepo) DeleteMark(ctx context.Context, markID int64) error {
+	query := `DELETE FROM marks WHERE mark_id = $1`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, markID)
+	return err
+}
+
+func (r *GradebookRepo) GetMarkByID(ctx context.Context, markID int64) (domain.Mark, error) {
+	query := `SELECT mark_id, schedule_id, student_id, lesson_date, mark, comment, teacher_id
+	FROM marks
+	WHERE mark_id = $1`
+
+	var mark domain.Mark
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, markID).Scan(
+		&mark.MarkID,
+		&mark.ScheduleID,
+		&mark.StudentID,
+		&mark.LessonDate,
+		&mark.Mark,
+		&mark.Comment,
+		&mark.TeacherID,
+	)
+	return mark, err
+}
+
+// GetStudentMarks returns the marks of the student in the semester ordered by lesson date,
+// semester 0 returns all semesters
+func (r *GradebookRepo) GetStudentMarks(ctx context.Context, studentID int64, semester int) ([]domain.MarkInfo, error) {
+	query := `SELECT sc.discipline_id, sc.semester,
+		m.mark_id, m.schedule_id, m.student_id, m.lesson_date, m.mark, m.comment, m.teacher_id
+	FROM marks m
+	INNER JOIN schedules sc ON sc.schedule_id = m.schedule_id
+	WHERE m.student_id = $1 AND ($2 = 0 OR sc.semester = $2)
+	ORDER BY m.lesson_date, m.mark_id`
+
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, studentID, semester)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	marks := make([]domain.MarkInfo, 0)
+	for rows.Next() {
+		var mark domain.MarkInfo
+		err := rows.Scan(
+			&mark.MarkSub.DisciplineID,
+			&mark.MarkSub.Semester,
+			&mark.Mark.MarkID,
+			&mark.Mark.ScheduleID,
+			&mark.Mark.StudentID,
+			&mark.Mark.LessonDate,
+			&mark.Mark.Mark,
+			&mark.Mark.Comment,
+			&mark.Mark.TeacherID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		marks = append(marks, mark)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return marks, nil
+}
+
+func (r *GradebookRepo) CreateControlPoint(ctx context.Context, point domain.ControlPoint) (int64, error) {
+	query := `INSERT INTO control_points (group_id, discipline_id, semester, control_point_name, max_score, due_date)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING control_point_id`
+
+	var controlPointID int64
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query,
+		point.GroupID, point.DisciplineID, point.Semester, point.Name, point.MaxScore, point.DueDate,
+	).Scan(&controlPointID)
+	return controlPointID, err
+}
+
+func (r *GradebookRepo) DeleteControlPoint(ctx context.Context, controlPointID int64) error {
+	query := `DELETE FROM control_points WHERE control_point_id = $1`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, controlPointID)
+	return err
+}
+
+const controlPointQuery = `SELECT cp.control_point_id, cp.group_id, cp.discipline_id, cp.semester, cp.control_point_name, cp.max_score, cp.due_date
+	FROM control_points cp`
+
+func (r *GradebookRepo) GetControlPointByID(ctx context.Context, controlPointID int64) (domain.ControlPoint, error) {
+	query := controlPointQuery + `
+	WHERE cp.control_point_id = $1`
+	return scanControlPoint(postgres.Conn(ctx, r.db).QueryRow(ctx, query, controlPointID))
+}
+
+// GetControlPoints returns the control points of the discipline for the group in the semester
+func (r *GradebookRepo) GetControlPoints(ctx context.Context, groupID string, disciplineID int64, semester int) ([]domain.ControlPoint, error) {
+	query := controlPointQuery + `
+	WHERE cp.group_id = $1 AND cp.discipline_id = $2 AND cp.semester = $3
+	ORDER BY cp.due_date NULLS LAST, cp.control_point_name`
+
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, groupID, disciplineID, semester)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	points := make([]domain.ControlPoint, 0)
+	for rows.Next() {
+		point, err := scanControlPoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+// SetControlPointResult puts the score of the student for the control point, replacing the previous one
+func (r *GradebookRepo) SetControlPointResult(ctx context.Context, result domain.ControlPointResult) error {
+	query := `INSERT INTO control_point_results (control_point_id, student_id, score, teacher_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (control_point_id, student_id)
+		DO UPDATE SET score = EXCLUDED.score, teacher_id = EXCLUDED.teacher_id, graded_at = now()`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, result.ControlPointID, result.StudentID, result.Score, result.TeacherID)
+	return err
+}
+
+// GetStudentControlPoints returns the control points of the student's group with the student's scores,
+// semester 0 returns all semesters
+func (r *GradebookRepo) GetStudentControlPoints(ctx context.Context, studentID int64, semester int) ([]domain.ControlPointScore, error) {
+	query := `SELECT cp.control_point_id, cp.group_id, cp.discipline_id, cp.semester, cp.control_point_name, cp.max_score, cp.due_date,
+		cpr.score
+	FROM control_points cp
+	INNER JOIN students st ON st.group_id = cp.group_id
+	LEFT JOIN control_point_results cpr ON cpr.control_point_id = cp.control_point_id AND cpr.student_id = st.student_id
+	WHERE st.student_id = $1 AND ($2 = 0 OR cp.semester = $2)
+	ORDER BY cp.due_date NULLS LAST, cp.control_point_name`
+
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, studentID, semester)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	points := make([]domain.ControlPointScore, 0)
+	for rows.Next() {
+		var point domain.ControlPointScore
+		err := rows.Scan(
+			&point.ControlPoint.ControlPointID,
+			&point.ControlPoint.GroupID,
+			&point.ControlPoint.DisciplineID,
+			&point.ControlPoint.Semester,
+			&point.ControlPoint.Name,
+			&point.ControlPoint.MaxScore,
+			&point.ControlPoint.DueDate,
+			&point.Score,
+		)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+// SetFinalResult puts the grade of the student for the credit or the exam, a new grade replaces the previous one
+func (r *GradebookRepo) SetFinalResult(ctx context.Context, result domain.FinalResult) (int64, error) {
+	query := `INSERT INTO final_results (student_id, discipline_id, semester, control_type, grade, teacher_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (student_id, discipline_id, semester, control_type)
+		DO UPDATE SET grade = EXCLUDED.grade, teacher_id = EXCLUDED.teacher_id, graded_on = CURRENT_DATE
+		RETURNING final_result_id`
+
+	var finalResultID int64
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query,
+		result.StudentID, result.DisciplineID, result.Semester, result.ControlType, result.Grade, result.TeacherID,
+	).Scan(&finalResultID)
+	return finalResultID, err
+}
+
+// GetStudentFinalResults returns the credits and exams of the student, semester 0 returns all semesters
+func (r *GradebookRepo) GetStudentFinalResults(ctx context.Context, studentID int64, semester int) ([]domain.FinalResult, error) {
+	query := `SELECT final_result_id, student_id, discipline_id, semester, control_type, grade, teacher_id, graded_on
+	FROM final_results
+	WHERE student_id = $1 AND ($2 = 0 OR semester = $2)
+	ORDER BY semester, graded_on`
+
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, studentID, semester)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]domain.FinalResult, 0)
+	for rows.Next() {
+		var result domain.FinalResult
+		err := rows.Scan(
+			&result.FinalResultID,
+			&result.StudentID,
+			&result.DisciplineID,
+			&result.Semester,
+			&result.ControlType,
+			&result.Grade,
+			&result.TeacherID,
+			&result.GradedOn,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// GetStudentAttendance counts the lessons of the discipline in the semester the student attended
+// out of the lessons with a marked attendance
+func (r *GradebookRepo) GetStudentAttendance(ctx context.Context, studentID int64, disciplineID int64, semester int) (domain.DisciplineAttendance, error) {
+	query := `SELECT COUNT(*) FILTER (WHERE a.presence), COUNT(*)
+	FROM attendance a
+	INNER JOIN schedules sc ON sc.schedule_id = a.schedule_id
+	WHERE a.student_id = $1 AND sc.discipline_id = $2 AND sc.semester = $3`
+
+	var attendance domain.DisciplineAttendance
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, studentID, disciplineID, semester).Scan(&attendance.Visits, &attendance.Total)
+	return attendance, err
+}
+
+func scanControlPoint(row pgx.Row) (domain.ControlPoint, error) {
+	var point domain.ControlPoint
+	err := row.Scan(
+		&point.ControlPointID,
+		&point.GroupID,
+		&point.DisciplineID,
+		&point.Semester,
+		&point.Name,
+		&point.MaxScore,
+		&point.DueDate,
+	)
+	return point, err
+}
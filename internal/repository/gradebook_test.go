@@ -0,0 +1,208 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestGradebookRepoMarks(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	teacherID := int64(1)
+	mark := domain.Mark{ScheduleID: 1, StudentID: 2, LessonDate: date("2024-09-02"), Mark: 3, TeacherID: &teacherID}
+	id, err := repos.Gradebook.SetMark(ctx, mark)
+	if err != nil {
+		t.Fatalf("SetMark() error = %v", err)
+	}
+
+	// a second mark for the lesson replaces the first one
+	comment := "исправил"
+	mark.Mark, mark.Comment = 5, &comment
+	replaced, err := repos.Gradebook.SetMark(ctx, mark)
+	if err != nil {
+		t.Fatalf("SetMark() error = %v", err)
+	}
+	if replaced != id {
+		t.Errorf("id of the replaced mark = %d, want %d", replaced, id)
+	}
+	got, err := repos.Gradebook.GetMarkByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetMarkByID() error = %v", err)
+	}
+	if got.Mark != 5 || got.Comment == nil || *got.Comment != comment || !got.LessonDate.Equal(mark.LessonDate) || got.TeacherID == nil || *got.TeacherID != teacherID {
+		t.Errorf("mark = %+v", got)
+	}
+
+	if _, err := repos.Gradebook.SetMark(ctx, domain.Mark{ScheduleID: 2, StudentID: 2, LessonDate: date("2024-09-02"), Mark: 4}); err != nil {
+		t.Fatalf("SetMark() error = %v", err)
+	}
+	if _, err := repos.Gradebook.SetMark(ctx, domain.Mark{ScheduleID: 1, StudentID: 2, LessonDate: date("2024-09-16"), Mark: 6}); !isViolation(err, "23514") {
+		t.Errorf("SetMark() of 6 error = %v, want a check violation", err)
+	}
+
+	marks, err := repos.Gradebook.GetStudentMarks(ctx, 2, 1)
+	if err != nil {
+		t.Fatalf("GetStudentMarks() error = %v", err)
+	}
+	if len(marks) != 2 || marks[0].Mark.MarkID != id || marks[0].MarkSub.DisciplineID != 1 || marks[1].MarkSub.DisciplineID != 2 || marks[1].MarkSub.Semester != 1 {
+		t.Errorf("marks = %+v", marks)
+	}
+	other, err := repos.Gradebook.GetStudentMarks(ctx, 2, 2)
+	if err != nil {
+		t.Fatalf("GetStudentMarks() error = %v", err)
+	}
+	all, err := repos.Gradebook.GetStudentMarks(ctx, 2, 0)
+	if err != nil {
+		t.Fatalf("GetStudentMarks() error = %v", err)
+	}
+	if len(other) != 0 || len(all) != 2 {
+		t.Errorf("marks of semester 2 = %d, of every semester = %d, want 0 and 2", len(other), len(all))
+	}
+
+	if err := repos.Gradebook.DeleteMark(ctx, id); err != nil {
+		t.Fatalf("DeleteMark() error = %v", err)
+	}
+	if _, err := repos.Gradebook.GetMarkByID(ctx, id); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetMarkByID() of the deleted mark error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
+
+func TestGradebookRepoControlPoints(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	due := date("2024-10-15")
+	points := []domain.ControlPoint{
+		{GroupID: firstGroup, DisciplineID: 1, Semester: 1, Name: "Коллоквиум", MaxScore: 10},
+		{GroupID: firstGroup, DisciplineID: 1, Semester: 1, Name: "Контрольная работа", MaxScore: 20, DueDate: &due},
+		{GroupID: secondGroup, DisciplineID: 1, Semester: 1, Name: "Контрольная работа", MaxScore: 20},
+	}
+	ids := make([]int64, 0, len(points))
+	for _, point := range points {
+		id, err := repos.Gradebook.CreateControlPoint(ctx, point)
+		if err != nil {
+			t.Fatalf("CreateControlPoint(%+v) error = %v", point, err)
+		}
+		ids = append(ids, id)
+	}
+	if _, err := repos.Gradebook.CreateControlPoint(ctx, points[0]); !isViolation(err, "23505") {
+		t.Errorf("CreateControlPoint() of a duplicate error = %v, want a unique violation", err)
+	}
+
+	// the points with a due date go first
+	group, err := repos.Gradebook.GetControlPoints(ctx, firstGroup, 1, 1)
+	if err != nil {
+		t.Fatalf("GetControlPoints() error = %v", err)
+	}
+	if len(group) != 2 || group[0].ControlPointID != ids[1] || group[1].ControlPointID != ids[0] || group[0].DueDate == nil || !group[0].DueDate.Equal(due) {
+		t.Errorf("control points = %+v", group)
+	}
+
+	if err := repos.Gradebook.SetControlPointResult(ctx, domain.ControlPointResult{ControlPointID: ids[1], StudentID: 2, Score: 12}); err != nil {
+		t.Fatalf("SetControlPointResult() error = %v", err)
+	}
+	if err := repos.Gradebook.SetControlPointResult(ctx, domain.ControlPointResult{ControlPointID: ids[1], StudentID: 2, Score: 18}); err != nil {
+		t.Fatalf("SetControlPointResult() error = %v", err)
+	}
+	scores, err := repos.Gradebook.GetStudentControlPoints(ctx, 2, 1)
+	if err != nil {
+		t.Fatalf("GetStudentControlPoints() error = %v", err)
+	}
+	if len(scores) != 2 || scores[0].Score == nil || *scores[0].Score != 18 || scores[1].Score != nil {
+		t.Errorf("scores = %+v, want 18 for the test and none for the colloquium", scores)
+	}
+
+	if err := repos.Gradebook.DeleteControlPoint(ctx, ids[1]); err != nil {
+		t.Fatalf("DeleteControlPoint() error = %v", err)
+	}
+	if _, err := repos.Gradebook.GetControlPointByID(ctx, ids[1]); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetControlPointByID() of the deleted point error = %v, want %v", err, pgx.ErrNoRows)
+	}
+	point, err := repos.Gradebook.GetControlPointByID(ctx, ids[2])
+	if err != nil {
+		t.Fatalf("GetControlPointByID() error = %v", err)
+	}
+	if point.GroupID != secondGroup || point.Name != "Контрольная работа" || point.MaxScore != 20 || point.DueDate != nil {
+		t.Errorf("control point = %+v", point)
+	}
+}
+
+func TestGradebookRepoFinalResults(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	credit := domain.FinalResult{StudentID: 2, DisciplineID: 2, Semester: 1, ControlType: domain.ControlTypeCredit, Grade: "не зачтено"}
+	id, err := repos.Gradebook.SetFinalResult(ctx, credit)
+	if err != nil {
+		t.Fatalf("SetFinalResult() error = %v", err)
+	}
+	credit.Grade = "зачтено"
+	if retake, err := repos.Gradebook.SetFinalResult(ctx, credit); err != nil || retake != id {
+		t.Fatalf("SetFinalResult() of the retake = %d, %v, want %d", retake, err, id)
+	}
+	exam := domain.FinalResult{StudentID: 2, DisciplineID: 1, Semester: 1, ControlType: domain.ControlTypeExam, Grade: "хорошо"}
+	if _, err := repos.Gradebook.SetFinalResult(ctx, exam); err != nil {
+		t.Fatalf("SetFinalResult() error = %v", err)
+	}
+
+	wrong := domain.FinalResult{StudentID: 2, DisciplineID: 1, Semester: 2, ControlType: domain.ControlTypeExam, Grade: "зачтено"}
+	if _, err := repos.Gradebook.SetFinalResult(ctx, wrong); !isViolation(err, "23514") {
+		t.Errorf("SetFinalResult() with a grade of a credit for an exam error = %v, want a check violation", err)
+	}
+
+	results, err := repos.Gradebook.GetStudentFinalResults(ctx, 2, 1)
+	if err != nil {
+		t.Fatalf("GetStudentFinalResults() error = %v", err)
+	}
+	grades := make(map[string]string, len(results))
+	for _, result := range results {
+		grades[result.ControlType] = result.Grade
+	}
+	if len(results) != 2 || grades[domain.ControlTypeCredit] != "зачтено" || grades[domain.ControlTypeExam] != "хорошо" {
+		t.Errorf("final results = %+v", results)
+	}
+	if results[0].GradedOn.IsZero() {
+		t.Errorf("graded on = %v, want the current date", results[0].GradedOn)
+	}
+}
+
+func TestGradebookRepoGetStudentAttendance(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		studentID    int64
+		disciplineID int64
+		semester     int
+		want         domain.DisciplineAttendance
+	}{
+		{name: "a pass", studentID: 2, disciplineID: 1, semester: 1, want: domain.DisciplineAttendance{Visits: 1, Total: 2}},
+		{name: "in both groups", studentID: 4, disciplineID: 1, semester: 1, want: domain.DisciplineAttendance{Visits: 3, Total: 3}},
+		{name: "laboratory works", studentID: 1, disciplineID: 2, semester: 1, want: domain.DisciplineAttendance{Visits: 1, Total: 1}},
+		{name: "another semester", studentID: 1, disciplineID: 1, semester: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repos.Gradebook.GetStudentAttendance(ctx, tt.studentID, tt.disciplineID, tt.semester)
+			if err != nil {
+				t.Fatalf("GetStudentAttendance() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("attendance = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// isViolation reports whether err is a PostgreSQL error with the code
+func isViolation(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,14 +20,14 @@ func NewGroupRepo(db *pgxpool.Pool) *GroupRepo {
 func (r *GroupRepo) Create(ctx context.Context, group domain.Group) error {
 	query := `INSERT INTO groups (group_id, profile_id)
               VALUES ($1, $2)`
-	_, err := r.db.Exec(ctx, query, group.GroupID, group.ProfileID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, group.GroupID, group.ProfileID)
 
 	return err
 }
 
 func (r *GroupRepo) Put(ctx context.Context, group domain.Group) error {
 	query := `UPDATE groups SET profile_id=$1 WHERE group_id=$2`
-	_, err := r.db.Exec(ctx, query, group.ProfileID, group.GroupID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, group.ProfileID, group.GroupID)
 
 	return err
 }
@@ -45,14 +46,14 @@ func (r *GroupRepo) Patch(ctx context.Context, groupID string, updates map[strin
 	query = query[:len(query)-1]
 	query += " WHERE group_id = $" + strconv.Itoa(argsCounter)
 	args = append(args, groupID)
-	_, err := r.db.Exec(ctx, query, args...)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, args...)
 
 	return err
 }
 
 func (r *GroupRepo) Delete(ctx context.Context, groupID string) error {
 	query := `DELETE FROM groups WHERE group_id = $1`
-	_, err := r.db.Exec(ctx, query, groupID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, groupID)
 
 	return err
 }
@@ -68,7 +69,7 @@ func (r *GroupRepo) GetByID(ctx context.Context, groupID string) (domain.GroupIn
 		WHERE g.group_id = $1`
 
 	groupInfo := domain.GroupInfo{}
-	err := r.db.QueryRow(ctx, query, groupID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, groupID).Scan(
 		&groupInfo.Group.GroupID,
 		&groupInfo.Group.ProfileID,
 		&groupInfo.GroupSub.ProfileName,
@@ -88,7 +89,7 @@ func (r *GroupRepo) GetByName(ctx context.Context, profileName string) (domain.G
 		WHERE p.profile_name = $1`
 
 	groupInfo := domain.GroupInfo{}
-	err := r.db.QueryRow(ctx, query, profileName).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, profileName).Scan(
 		&groupInfo.Group.GroupID,
 		&groupInfo.Group.ProfileID,
 		&groupInfo.GroupSub.ProfileName,
@@ -106,7 +107,7 @@ func (r *GroupRepo) GetAll(ctx context.Context) ([]domain.GroupInfo, error) {
 		LEFT JOIN 
 			profiles p ON g.profile_id = p.profile_id`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +149,7 @@ func (r *GroupRepo) GetAllByProfileID(ctx context.Context, profileID int64) ([]d
 			profiles p ON g.profile_id = p.profile_id
 		WHERE g.profile_id = $1`
 
-	rows, err := r.db.Query(ctx, query, profileID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, profileID)
 	if err != nil {
 		return nil, err
 	}
@@ -182,7 +183,7 @@ func (r *GroupRepo) GetAllByProfileID(ctx context.Context, profileID int64) ([]d
 
 func (r *GroupRepo) getCountGroups(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM groups;`
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -201,7 +202,7 @@ func (r *GroupRepo) getCountGroups(ctx context.Context) (int64, error) {
 
 func (r *GroupRepo) getCountGroupsByProfileID(ctx context.Context, profileID int64) (int64, error) {
 	query := `SELECT COUNT(*) FROM groups WHERE profile_id = $1;`
-	rows, err := r.db.Query(ctx, query, profileID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, profileID)
 	if err != nil {
 		return 0, err
 	}
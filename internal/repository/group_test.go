@@ -0,0 +1,70 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestGroupRepo(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	if err := repos.Profile.Create(ctx, domain.Profile{SpecialtyCode: "35.03.06", EducationTypeID: 1, ProfileName: "Электрооборудование"}); err != nil {
+		t.Fatalf("Create() of the profile error = %v", err)
+	}
+
+	group := domain.Group{GroupID: "2024-35.03.06-1", ProfileID: 1}
+	if err := repos.Group.Create(ctx, group); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repos.Group.Create(ctx, domain.Group{GroupID: firstGroup, ProfileID: 1}); !isViolation(err, "23505") {
+		t.Errorf("Create() of a duplicate group error = %v, want a unique violation", err)
+	}
+
+	created, err := repos.Group.GetByID(ctx, group.GroupID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if created.Group != group || created.GroupSub.ProfileName != "Технический сервис" {
+		t.Errorf("created group = %+v", created)
+	}
+
+	group.ProfileID = 2
+	if err := repos.Group.Put(ctx, group); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	// GetByName looks the group up by the name of its profile
+	byName, err := repos.Group.GetByName(ctx, "Электрооборудование")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if byName.Group != group {
+		t.Errorf("group of the profile = %+v, want %+v", byName.Group, group)
+	}
+
+	if err := repos.Group.Patch(ctx, secondGroup, map[string]interface{}{"profile_id": int64(2)}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	byProfile, err := repos.Group.GetAllByProfileID(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetAllByProfileID() error = %v", err)
+	}
+	all, err := repos.Group.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(byProfile) != 2 || len(all) != 3 {
+		t.Errorf("groups of the profile = %d, all = %d, want 2 and 3", len(byProfile), len(all))
+	}
+
+	if err := repos.Group.Delete(ctx, group.GroupID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Group.GetByID(ctx, group.GroupID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted group error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
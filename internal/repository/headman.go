@@ -3,8 +3,10 @@ package repository
 import (
 	"context"
 	"strconv"
+	"time"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -17,16 +19,16 @@ func NewHeadmanRepo(db *pgxpool.Pool) *HeadmanRepo {
 }
 
 func (r *HeadmanRepo) Create(ctx context.Context, headman domain.Headman) error {
-	query := `INSERT INTO headmans (student_id, group_id)
-              VALUES ($1, $2)`
-	_, err := r.db.Exec(ctx, query, headman.StudentID, headman.GroupID)
+	query := `INSERT INTO headmans (student_id, group_id, term_start, term_end, is_deputy)
+              VALUES ($1, $2, $3, $4, $5)`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, headman.StudentID, headman.GroupID, headman.TermStart, headman.TermEnd, headman.IsDeputy)
 
 	return err
 }
 
 func (r *HeadmanRepo) Put(ctx context.Context, headman domain.Headman) error {
-	query := `UPDATE headmans SET student_id=$1, group_id=$2 WHERE headman_id=$3`
-	_, err := r.db.Exec(ctx, query, headman.StudentID, headman.GroupID, headman.HeadmanID)
+	query := `UPDATE headmans SET student_id=$1, group_id=$2, term_start=$3, term_end=$4, is_deputy=$5 WHERE headman_id=$6`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, headman.StudentID, headman.GroupID, headman.TermStart, headman.TermEnd, headman.IsDeputy, headman.HeadmanID)
 
 	return err
 }
@@ -45,21 +47,21 @@ func (r *HeadmanRepo) Patch(ctx context.Context, headmanID int64, updates map[st
 	query = query[:len(query)-1]
 	query += " WHERE headman_id = $" + strconv.Itoa(argsCounter)
 	args = append(args, headmanID)
-	_, err := r.db.Exec(ctx, query, args...)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, args...)
 
 	return err
 }
 
 func (r *HeadmanRepo) Delete(ctx context.Context, headmanID int64) error {
 	query := `DELETE FROM headmans WHERE headman_id = $1`
-	_, err := r.db.Exec(ctx, query, headmanID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, headmanID)
 
 	return err
 }
 
 func (r *HeadmanRepo) GetByID(ctx context.Context, headmanID int64) (domain.HeadmanInfo, error) {
 	query := `SELECT 
-			h.headman_id, h.student_id, h.group_id,
+			h.headman_id, h.student_id, h.group_id, h.term_start, h.term_end, h.is_deputy,
 			s.last_name, s.first_name, s.middle_name, 
 			g.group_name
 		FROM 
@@ -71,10 +73,13 @@ func (r *HeadmanRepo) GetByID(ctx context.Context, headmanID int64) (domain.Head
 		WHERE h.headman_id = $1`
 
 	headmanInfo := domain.HeadmanInfo{}
-	err := r.db.QueryRow(ctx, query, headmanID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, headmanID).Scan(
 		&headmanInfo.Headman.HeadmanID,
 		&headmanInfo.Headman.StudentID,
 		&headmanInfo.Headman.GroupID,
+		&headmanInfo.Headman.TermStart,
+		&headmanInfo.Headman.TermEnd,
+		&headmanInfo.Headman.IsDeputy,
 		&headmanInfo.HeadmanSub.Student.LastName,
 		&headmanInfo.HeadmanSub.Student.FirstName,
 		&headmanInfo.HeadmanSub.Student.MiddleName,
@@ -86,7 +91,7 @@ func (r *HeadmanRepo) GetByID(ctx context.Context, headmanID int64) (domain.Head
 
 func (r *HeadmanRepo) GetByStudentID(ctx context.Context, studentID int64) (domain.HeadmanInfo, error) {
 	query := `SELECT 
-			h.headman_id, h.student_id, h.group_id,
+			h.headman_id, h.student_id, h.group_id, h.term_start, h.term_end, h.is_deputy,
 			s.last_name, s.first_name, s.middle_name, 
 			g.group_name
 		FROM 
@@ -95,13 +100,18 @@ func (r *HeadmanRepo) GetByStudentID(ctx context.Context, studentID int64) (doma
 			students s ON h.student_id = s.student_id
 		LEFT JOIN 
 			groups g ON h.group_id = g.group_id
-		WHERE h.student_id = $1`
+		WHERE h.student_id = $1
+		ORDER BY h.term_start DESC
+		LIMIT 1`
 
 	headmanInfo := domain.HeadmanInfo{}
-	err := r.db.QueryRow(ctx, query, studentID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, studentID).Scan(
 		&headmanInfo.Headman.HeadmanID,
 		&headmanInfo.Headman.StudentID,
 		&headmanInfo.Headman.GroupID,
+		&headmanInfo.Headman.TermStart,
+		&headmanInfo.Headman.TermEnd,
+		&headmanInfo.Headman.IsDeputy,
 		&headmanInfo.HeadmanSub.Student.LastName,
 		&headmanInfo.HeadmanSub.Student.FirstName,
 		&headmanInfo.HeadmanSub.Student.MiddleName,
@@ -113,7 +123,7 @@ func (r *HeadmanRepo) GetByStudentID(ctx context.Context, studentID int64) (doma
 
 func (r *HeadmanRepo) GetAll(ctx context.Context) ([]domain.HeadmanInfo, error) {
 	query := `SELECT 
-			h.headman_id, h.student_id, h.group_id,
+			h.headman_id, h.student_id, h.group_id, h.term_start, h.term_end, h.is_deputy,
 			s.last_name, s.first_name, s.middle_name, 
 			g.group_name
 		FROM 
@@ -123,7 +133,7 @@ func (r *HeadmanRepo) GetAll(ctx context.Context) ([]domain.HeadmanInfo, error)
 		LEFT JOIN 
 			groups g ON h.group_id = g.group_id`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +151,9 @@ func (r *HeadmanRepo) GetAll(ctx context.Context) ([]domain.HeadmanInfo, error)
 			&headmanInfo.Headman.HeadmanID,
 			&headmanInfo.Headman.StudentID,
 			&headmanInfo.Headman.GroupID,
+			&headmanInfo.Headman.TermStart,
+			&headmanInfo.Headman.TermEnd,
+			&headmanInfo.Headman.IsDeputy,
 			&headmanInfo.HeadmanSub.Student.LastName,
 			&headmanInfo.HeadmanSub.Student.FirstName,
 			&headmanInfo.HeadmanSub.Student.MiddleName,
@@ -159,9 +172,133 @@ func (r *HeadmanRepo) GetAll(ctx context.Context) ([]domain.HeadmanInfo, error)
 	return headmans, nil
 }
 
+// GetAllByGroupID returns every term of the headmen and the deputies of a group
+func (r *HeadmanRepo) GetAllByGroupID(ctx context.Context, groupID string) ([]domain.HeadmanInfo, error) {
+	return r.getAllBy(ctx, "h.group_id = $1", groupID)
+}
+
+// GetAllByStudentID returns every term of a student in any group
+func (r *HeadmanRepo) GetAllByStudentID(ctx context.Context, studentID int64) ([]domain.HeadmanInfo, error) {
+	return r.getAllBy(ctx, "h.student_id = $1", studentID)
+}
+
+func (r *HeadmanRepo) getAllBy(ctx context.Context, condition string, arg interface{}) ([]domain.HeadmanInfo, error) {
+	query := `SELECT 
+			h.headman_id, h.student_id, h.group_id, h.term_start, h.term_end, h.is_deputy,
+			s.last_name, s.first_name, s.middle_name, 
+			g.group_name
+		FROM 
+			headmans h
+		LEFT JOIN 
+			students s ON h.student_id = s.student_id
+		LEFT JOIN 
+			groups g ON h.group_id = g.group_id
+		WHERE ` + condition + `
+		ORDER BY h.term_start, h.headman_id`
+
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	headmans := make([]domain.HeadmanInfo, 0)
+	for rows.Next() {
+		var headmanInfo domain.HeadmanInfo
+		err := rows.Scan(
+			&headmanInfo.Headman.HeadmanID,
+			&headmanInfo.Headman.StudentID,
+			&headmanInfo.Headman.GroupID,
+			&headmanInfo.Headman.TermStart,
+			&headmanInfo.Headman.TermEnd,
+			&headmanInfo.Headman.IsDeputy,
+			&headmanInfo.HeadmanSub.Student.LastName,
+			&headmanInfo.HeadmanSub.Student.FirstName,
+			&headmanInfo.HeadmanSub.Student.MiddleName,
+			&headmanInfo.HeadmanSub.GroupName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		headmans = append(headmans, headmanInfo)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return headmans, nil
+}
+
+// IsActive reports whether the student is the headman or the deputy of the group on the date
+func (r *HeadmanRepo) IsActive(ctx context.Context, studentID int64, groupID string, date time.Time) (bool, error) {
+	query := `SELECT EXISTS (
+			SELECT 1 FROM headmans
+			WHERE student_id = $1 AND group_id = $2
+			AND term_start <= $3 AND (term_end IS NULL OR $3 <= term_end)
+		)`
+
+	var active bool
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, studentID, groupID, date).Scan(&active)
+
+	return active, err
+}
+
+// SyncRoles switches accounts of students whose term has ended back to the student
+// role and gives the headman role to students whose term is active on the date
+func (r *HeadmanRepo) SyncRoles(ctx context.Context, date time.Time) (demoted int64, promoted int64, err error) {
+	tx, err := postgres.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	demoteQuery := `UPDATE users u
+		SET user_role = 'Студент', student_id = h.student_id, headman_id = NULL
+		FROM headmans h
+		WHERE u.headman_id = h.headman_id AND u.user_role = 'Староста'
+		AND NOT (h.term_start <= $1 AND (h.term_end IS NULL OR $1 <= h.term_end))
+		AND NOT EXISTS (SELECT 1 FROM users o WHERE o.student_id = h.student_id)`
+	tag, err := tx.Exec(ctx, demoteQuery, date)
+	if err != nil {
+		return 0, 0, err
+	}
+	demoted = tag.RowsAffected()
+
+	promoteQuery := `UPDATE users u
+		SET user_role = 'Староста', headman_id = h.headman_id, student_id = NULL
+		FROM headmans h
+		WHERE u.student_id = h.student_id AND u.user_role = 'Студент'
+		AND h.term_start <= $1 AND (h.term_end IS NULL OR $1 <= h.term_end)
+		AND NOT EXISTS (SELECT 1 FROM users o WHERE o.headman_id = h.headman_id)`
+	tag, err = tx.Exec(ctx, promoteQuery, date)
+	if err != nil {
+		return 0, 0, err
+	}
+	promoted = tag.RowsAffected()
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, 0, err
+	}
+
+	return demoted, promoted, nil
+}
+
+// Demote switches the account holding the term back to the student role
+func (r *HeadmanRepo) Demote(ctx context.Context, headmanID int64) error {
+	query := `UPDATE users u
+		SET user_role = 'Студент', student_id = h.student_id, headman_id = NULL
+		FROM headmans h
+		WHERE u.headman_id = h.headman_id AND h.headman_id = $1 AND u.user_role = 'Староста'
+		AND NOT EXISTS (SELECT 1 FROM users o WHERE o.student_id = h.student_id)`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, headmanID)
+
+	return err
+}
+
 func (r *HeadmanRepo) getCountHeadmans(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM headmans;`
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,190 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func headmanIDs(headmen []domain.HeadmanInfo) []int64 {
+	ids := make([]int64, 0, len(headmen))
+	for _, headman := range headmen {
+		ids = append(ids, headman.Headman.HeadmanID)
+	}
+	return ids
+}
+
+func TestHeadmanRepoTerms(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	// the migration dropped the unique constraints of the group and the student,
+	// the deputy gets term 2 and the headman a later term 3
+	terms := []domain.Headman{
+		{StudentID: 2, GroupID: firstGroup, TermStart: date("2024-09-01"), IsDeputy: true},
+		{StudentID: 1, GroupID: firstGroup, TermStart: date("2025-09-01")},
+	}
+	for _, term := range terms {
+		if err := repos.Headman.Create(ctx, term); err != nil {
+			t.Fatalf("Create(%+v) error = %v", term, err)
+		}
+	}
+
+	byGroup, err := repos.Headman.GetAllByGroupID(ctx, firstGroup)
+	if err != nil {
+		t.Fatalf("GetAllByGroupID() error = %v", err)
+	}
+	if ids := headmanIDs(byGroup); !equalIDs(ids, []int64{1, 2, 3}) {
+		t.Fatalf("terms of the group = %v, want [1 2 3]", ids)
+	}
+	deputy := byGroup[1]
+	if !deputy.Headman.IsDeputy || deputy.Headman.TermEnd != nil || deputy.HeadmanSub.GroupName != firstGroup || deputy.HeadmanSub.Student.LastName != "Борисов" {
+		t.Errorf("deputy = %+v", deputy)
+	}
+
+	byStudent, err := repos.Headman.GetAllByStudentID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetAllByStudentID() error = %v", err)
+	}
+	if ids := headmanIDs(byStudent); !equalIDs(ids, []int64{1, 3}) {
+		t.Errorf("terms of the student = %v, want [1 3]", ids)
+	}
+	latest, err := repos.Headman.GetByStudentID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetByStudentID() error = %v", err)
+	}
+	if latest.Headman.HeadmanID != 3 {
+		t.Errorf("latest term = %d, want 3", latest.Headman.HeadmanID)
+	}
+
+	end := date("2024-12-31")
+	if err := repos.Headman.Patch(ctx, 2, map[string]interface{}{"term_end": end}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	patched, err := repos.Headman.GetByID(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if patched.Headman.TermEnd == nil || !patched.Headman.TermEnd.Equal(end) {
+		t.Errorf("term end = %v, want %v", patched.Headman.TermEnd, end)
+	}
+
+	active := []struct {
+		studentID int64
+		groupID   string
+		date      string
+		want      bool
+	}{
+		{studentID: 1, groupID: firstGroup, date: "2024-10-01", want: true},
+		{studentID: 1, groupID: firstGroup, date: "2024-08-31"},
+		{studentID: 1, groupID: secondGroup, date: "2024-10-01"},
+		{studentID: 2, groupID: firstGroup, date: "2024-12-31", want: true},
+		{studentID: 2, groupID: firstGroup, date: "2025-01-01"},
+	}
+	for _, tt := range active {
+		got, err := repos.Headman.IsActive(ctx, tt.studentID, tt.groupID, date(tt.date))
+		if err != nil {
+			t.Fatalf("IsActive() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("IsActive(%d, %s, %s) = %v, want %v", tt.studentID, tt.groupID, tt.date, got, tt.want)
+		}
+	}
+
+	// the term start check of the migration
+	if err := repos.Headman.Patch(ctx, 2, map[string]interface{}{"term_end": date("2024-08-01")}); err == nil {
+		t.Errorf("Patch() with the end before the start error = nil")
+	}
+
+	put := latest.Headman
+	put.IsDeputy = true
+	put.GroupID = secondGroup
+	if err := repos.Headman.Put(ctx, put); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	got, err := repos.Headman.GetByID(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !got.Headman.IsDeputy || got.Headman.GroupID != secondGroup || got.HeadmanSub.GroupName != secondGroup {
+		t.Errorf("term after Put() = %+v", got)
+	}
+
+	if err := repos.Headman.Delete(ctx, 3); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Headman.GetByID(ctx, 3); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted term error = %v, want %v", err, pgx.ErrNoRows)
+	}
+	all, err := repos.Headman.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("terms = %d, want 2", len(all))
+	}
+}
+
+func TestHeadmanRepoSyncRoles(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	if err := repos.Headman.Create(ctx, domain.Headman{StudentID: 2, GroupID: firstGroup, TermStart: date("2024-09-01"), IsDeputy: true}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	demoted, promoted, err := repos.Headman.SyncRoles(ctx, date("2024-10-01"))
+	if err != nil {
+		t.Fatalf("SyncRoles() error = %v", err)
+	}
+	if demoted != 0 || promoted != 1 {
+		t.Errorf("demoted, promoted = %d, %d, want 0, 1", demoted, promoted)
+	}
+	deputy, err := repos.User.GetByHeadmanID(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetByHeadmanID() error = %v", err)
+	}
+	if deputy.User.UserID != studentUserID || deputy.User.Role != "Староста" || deputy.User.StudentID != nil {
+		t.Errorf("deputy = %+v, want the headman role", deputy.User)
+	}
+
+	if err := repos.Headman.Patch(ctx, 1, map[string]interface{}{"term_end": date("2024-09-30")}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	demoted, promoted, err = repos.Headman.SyncRoles(ctx, date("2024-10-01"))
+	if err != nil {
+		t.Fatalf("SyncRoles() error = %v", err)
+	}
+	if demoted != 1 || promoted != 0 {
+		t.Errorf("demoted, promoted = %d, %d, want 1, 0", demoted, promoted)
+	}
+	headman, err := repos.User.GetByID(ctx, headmanUserID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if headman.User.Role != "Студент" || headman.User.HeadmanID != nil || headman.User.StudentID == nil || *headman.User.StudentID != 1 {
+		t.Errorf("headman after the term = %+v, want the student role", headman.User)
+	}
+}
+
+func TestHeadmanRepoDemote(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	if err := repos.Headman.Demote(ctx, 1); err != nil {
+		t.Fatalf("Demote() error = %v", err)
+	}
+	if err := repos.Headman.Delete(ctx, 1); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	user, err := repos.User.GetByID(ctx, headmanUserID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if user.User.Role != "Студент" || user.User.HeadmanID != nil || user.User.StudentID == nil || *user.User.StudentID != 1 {
+		t.Errorf("user = %+v, want the student role", user.User)
+	}
+}
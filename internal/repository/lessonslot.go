@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LessonSlotRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewLessonSlotRepo(db *pgxpool.Pool) *LessonSlotRepo {
+	return &LessonSlotRepo{db: db}
+}
+
+func (r *LessonSlotRepo) Create(ctx context.Context, slot domain.LessonSlot) (int64, error) {
+	query := `INSERT INTO lesson_slots (university_id, slot_number, start_time, end_time, break_minutes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING slot_id`
+
+	var slotID int64
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query,
+		slot.UniversityID, slot.SlotNumber, slot.StartTime, slot.EndTime, slot.BreakMinutes,
+	).Scan(&slotID)
+	return slotID, err
+}
+
+// Put updates the slot and moves the actual schedules of the slot to its new start time
+func (r *LessonSlotRepo) Put(ctx context.Context, slot domain.LessonSlot) error {
+	tx, err := postgres.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE lesson_slots SET university_id = $1, slot_number = $2, start_time = $3, end_time = $4, break_minutes = $5
+		WHERE slot_id = $6`
+	if _, err := tx.Exec(ctx, query,
+		slot.UniversityID, slot.SlotNumber, slot.StartTime, slot.EndTime, slot.BreakMinutes, slot.SlotID); err != nil {
+		return err
+	}
+
+	query = `UPDATE schedules SET start_time = $1 WHERE slot_id = $2`
+	if _, err := tx.Exec(ctx, query, slot.StartTime, slot.SlotID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *LessonSlotRepo) Delete(ctx context.Context, slotID int64) error {
+	query := `DELETE FROM lesson_slots WHERE slot_id = $1`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, slotID)
+	return err
+}
+
+func (r *LessonSlotRepo) GetByID(ctx context.Context, slotID int64) (domain.LessonSlot, error) {
+	query := `SELECT slot_id, university_id, slot_number, start_time, end_time, break_minutes
+		FROM lesson_slots
+		WHERE slot_id = $1`
+	return scanLessonSlot(postgres.Conn(ctx, r.db).QueryRow(ctx, query, slotID))
+}
+
+func (r *LessonSlotRepo) GetByUniversityID(ctx context.Context, universityID int64) ([]domain.LessonSlot, error) {
+	query := `SELECT slot_id, university_id, slot_number, start_time, end_time, break_minutes
+		FROM lesson_slots
+		WHERE university_id = $1
+		ORDER BY slot_number`
+	return r.getAll(ctx, query, universityID)
+}
+
+// GetByGroupID returns the bell schedule of the university of the group
+func (r *LessonSlotRepo) GetByGroupID(ctx context.Context, groupID string) ([]domain.LessonSlot, error) {
+	query := `SELECT slot_id, university_id, slot_number, start_time, end_time, break_minutes
+		FROM lesson_slots
+		WHERE university_id = group_university_id($1)
+		ORDER BY slot_number`
+	return r.getAll(ctx, query, groupID)
+}
+
+func (r *LessonSlotRepo) getAll(ctx context.Context, query string, args ...interface{}) ([]domain.LessonSlot, error) {
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	slots := make([]domain.LessonSlot, 0)
+	for rows.Next() {
+		slot, err := scanLessonSlot(rows)
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, slot)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return slots, nil
+}
+
+func scanLessonSlot(row pgx.Row) (domain.LessonSlot, error) {
+	var slot domain.LessonSlot
+	err := row.Scan(
+		&slot.SlotID,
+		&slot.UniversityID,
+		&slot.SlotNumber,
+		&slot.StartTime,
+		&slot.EndTime,
+		&slot.BreakMinutes,
+	)
+	return slot, err
+}
@@ -0,0 +1,94 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestLessonSlotRepoBellSchedule(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	byUniversity, err := repos.LessonSlot.GetByUniversityID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetByUniversityID() error = %v", err)
+	}
+	byGroup, err := repos.LessonSlot.GetByGroupID(ctx, secondGroup)
+	if err != nil {
+		t.Fatalf("GetByGroupID() error = %v", err)
+	}
+	for _, slots := range [][]domain.LessonSlot{byUniversity, byGroup} {
+		if len(slots) != 2 || slots[0].SlotNumber != 1 || slots[1].SlotNumber != 2 || !slots[1].StartTime.Equal(clock("10:10")) || slots[1].BreakMinutes != 10 {
+			t.Errorf("slots = %+v", slots)
+		}
+	}
+
+	id, err := repos.LessonSlot.Create(ctx, domain.LessonSlot{UniversityID: 1, SlotNumber: 3, StartTime: clock("11:50"), EndTime: clock("13:20")})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	slot, err := repos.LessonSlot.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if slot.SlotNumber != 3 || !slot.StartTime.Equal(clock("11:50")) || !slot.EndTime.Equal(clock("13:20")) || slot.BreakMinutes != 0 {
+		t.Errorf("created slot = %+v", slot)
+	}
+
+	wrong := []struct {
+		name string
+		slot domain.LessonSlot
+		code string
+	}{
+		{name: "number", slot: domain.LessonSlot{UniversityID: 1, SlotNumber: 2, StartTime: clock("14:00"), EndTime: clock("15:30")}, code: "23505"},
+		{name: "start time", slot: domain.LessonSlot{UniversityID: 1, SlotNumber: 4, StartTime: clock("10:10"), EndTime: clock("11:40")}, code: "23505"},
+		{name: "end time", slot: domain.LessonSlot{UniversityID: 1, SlotNumber: 4, StartTime: clock("14:00"), EndTime: clock("13:00")}, code: "23514"},
+	}
+	for _, tt := range wrong {
+		if _, err := repos.LessonSlot.Create(ctx, tt.slot); !isViolation(err, tt.code) {
+			t.Errorf("Create() with a wrong %s error = %v, want %s", tt.name, err, tt.code)
+		}
+	}
+
+	if err := repos.LessonSlot.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.LessonSlot.GetByID(ctx, id); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted slot error = %v, want %v", err, pgx.ErrNoRows)
+	}
+	// the schedules keep the slot they are in
+	if err := repos.LessonSlot.Delete(ctx, 1); !isViolation(err, "23503") {
+		t.Errorf("Delete() of a slot with schedules error = %v, want a foreign key violation", err)
+	}
+}
+
+func TestLessonSlotRepoPutMovesSchedules(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	slot := domain.LessonSlot{SlotID: 1, UniversityID: 1, SlotNumber: 1, StartTime: clock("08:00"), EndTime: clock("09:30"), BreakMinutes: 10}
+	if err := repos.LessonSlot.Put(ctx, slot); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	for _, id := range []int64{1, 3, 4} {
+		schedule, err := repos.Schedule.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if !schedule.Schedule.StartTime.Equal(clock("08:00")) {
+			t.Errorf("start time of schedule %d = %v, want 08:00", id, schedule.Schedule.StartTime)
+		}
+	}
+	other, err := repos.Schedule.GetByID(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !other.Schedule.StartTime.Equal(clock("10:10")) {
+		t.Errorf("start time of a schedule of another slot = %v, want 10:10", other.Schedule.StartTime)
+	}
+}
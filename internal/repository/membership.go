@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	reasonEnrollment = "enrollment"
+	reasonTransfer   = "transfer"
+	reasonPromotion  = "promotion"
+)
+
+type MembershipRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewMembershipRepo(db *pgxpool.Pool) *MembershipRepo {
+	return &MembershipRepo{db: db}
+}
+
+func (r *MembershipRepo) GetByStudentID(ctx context.Context, studentID int64) ([]domain.GroupMembership, error) {
+	query := `SELECT history_id, student_id, group_id, valid_from, valid_to, reason
+		FROM student_group_history
+		WHERE student_id = $1
+		ORDER BY valid_from, history_id`
+
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	memberships := make([]domain.GroupMembership, 0)
+	for rows.Next() {
+		var membership domain.GroupMembership
+		err := rows.Scan(
+			&membership.HistoryID,
+			&membership.StudentID,
+			&membership.GroupID,
+			&membership.ValidFrom,
+			&membership.ValidTo,
+			&membership.Reason,
+		)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, membership)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return memberships, nil
+}
+
+// Transfer moves the student to another group starting from the transfer date
+func (r *MembershipRepo) Transfer(ctx context.Context, transfer domain.StudentTransfer) error {
+	tx, err := postgres.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	reason := transfer.Reason
+	if reason == "" {
+		reason = reasonTransfer
+	}
+	if err := moveStudents(ctx, tx, []int64{transfer.StudentID}, transfer.GroupID, transfer.Date, reason); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Promote creates the missing groups and moves the students of every source group
+// to its target group. Students are selected before any move, so chains like
+// A -> B, B -> C work in any order
+func (r *MembershipRepo) Promote(ctx context.Context, promotions []domain.GroupPromotion, groups []domain.Group, date time.Time) error {
+	tx, err := postgres.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	groupQuery := `INSERT INTO groups (group_id, profile_id) VALUES ($1, $2)`
+	for _, group := range groups {
+		if _, err := tx.Exec(ctx, groupQuery, group.GroupID, group.ProfileID); err != nil {
+			return err
+		}
+	}
+
+	students := make([][]int64, len(promotions))
+	for i, promotion := range promotions {
+		rows, err := tx.Query(ctx, `SELECT student_id FROM students WHERE group_id = $1 FOR UPDATE`, promotion.FromGroupID)
+		if err != nil {
+			return err
+		}
+		students[i], err = pgx.CollectRows(rows, pgx.RowTo[int64])
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, promotion := range promotions {
+		if err := moveStudents(ctx, tx, students[i], promotion.ToGroupID, date, reasonPromotion); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// moveStudents closes the current memberships of the students at the date and opens
+// memberships in the group. Students without history get it from their current group
+func moveStudents(ctx context.Context, tx pgx.Tx, studentIDs []int64, groupID string, date time.Time, reason string) error {
+	if len(studentIDs) == 0 {
+		return nil
+	}
+
+	queries := []struct {
+		sql  string
+		args []interface{}
+	}{
+		{
+			sql: `INSERT INTO student_group_history (student_id, group_id, valid_from)
+				SELECT s.student_id, s.group_id, DATE '1970-01-01' FROM students s
+				WHERE s.student_id = ANY($1)
+				AND NOT EXISTS (SELECT 1 FROM student_group_history h WHERE h.student_id = s.student_id)`,
+			args: []interface{}{studentIDs},
+		},
+		{
+			sql:  `UPDATE student_group_history SET valid_to = $2 WHERE student_id = ANY($1) AND valid_to IS NULL`,
+			args: []interface{}{studentIDs, date},
+		},
+		{
+			sql: `INSERT INTO student_group_history (student_id, group_id, valid_from, reason)
+				SELECT student_id, $2, $3, $4 FROM UNNEST($1::BIGINT[]) AS student_id`,
+			args: []interface{}{studentIDs, groupID, date, reason},
+		},
+		{
+			sql:  `UPDATE students SET group_id = $2 WHERE student_id = ANY($1)`,
+			args: []interface{}{studentIDs, groupID},
+		},
+	}
+
+	for _, query := range queries {
+		if _, err := tx.Exec(ctx, query.sql, query.args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changeGroup keeps the history when a student is moved by editing the student,
+// the move takes effect today
+func changeGroup(ctx context.Context, tx pgx.Tx, studentID int64, groupID string) error {
+	var currentGroupID string
+	err := tx.QueryRow(ctx, `SELECT group_id FROM students WHERE student_id = $1 FOR UPDATE`, studentID).Scan(&currentGroupID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if currentGroupID == groupID {
+		return nil
+	}
+
+	return moveStudents(ctx, tx, []int64{studentID}, groupID, time.Now(), reasonTransfer)
+}
+
+// startMembership records the first group of a new student
+func startMembership(ctx context.Context, tx pgx.Tx, studentID int64, groupID string) error {
+	query := `INSERT INTO student_group_history (student_id, group_id, valid_from, reason) VALUES ($1, $2, CURRENT_DATE, $3)`
+	_, err := tx.Exec(ctx, query, studentID, groupID, reasonEnrollment)
+
+	return err
+}
@@ -0,0 +1,102 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+func studentIDs(students []domain.Student) []int64 {
+	ids := make([]int64, 0, len(students))
+	for _, student := range students {
+		ids = append(ids, student.StudentID)
+	}
+	sortIDs(ids)
+	return ids
+}
+
+func TestMembershipRepoTransfer(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	history, err := repos.Membership.GetByStudentID(ctx, 4)
+	if err != nil {
+		t.Fatalf("GetByStudentID() error = %v", err)
+	}
+	if len(history) != 2 || history[0].GroupID != firstGroup || history[0].ValidTo == nil || !history[0].ValidTo.Equal(date("2024-09-15")) ||
+		history[1].GroupID != secondGroup || history[1].ValidTo != nil || history[1].Reason == nil || *history[1].Reason != "transfer" {
+		t.Fatalf("history of the transferred student = %+v", history)
+	}
+
+	transfer := domain.StudentTransfer{StudentID: 3, GroupID: secondGroup, Date: date("2024-10-01")}
+	if err := repos.Membership.Transfer(ctx, transfer); err != nil {
+		t.Fatalf("Transfer() error = %v", err)
+	}
+
+	history, err = repos.Membership.GetByStudentID(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetByStudentID() error = %v", err)
+	}
+	if len(history) != 2 || history[0].ValidTo == nil || !history[0].ValidTo.Equal(transfer.Date) ||
+		history[1].GroupID != secondGroup || !history[1].ValidFrom.Equal(transfer.Date) || history[1].ValidTo != nil ||
+		history[1].Reason == nil || *history[1].Reason != "transfer" {
+		t.Errorf("history = %+v, want the closed and the new membership", history)
+	}
+	student, err := repos.Student.GetByID(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if student.GroupID != secondGroup {
+		t.Errorf("group of the student = %s, want %s", student.GroupID, secondGroup)
+	}
+}
+
+func TestMembershipRepoPromote(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	const nextGroup = "2024-35.03.06-1"
+	// the students of the first group are selected before the second group moves into it
+	promotions := []domain.GroupPromotion{
+		{FromGroupID: secondGroup, ToGroupID: firstGroup},
+		{FromGroupID: firstGroup, ToGroupID: nextGroup},
+	}
+	groups := []domain.Group{{GroupID: nextGroup, ProfileID: 1}}
+	if err := repos.Membership.Promote(ctx, promotions, groups, date("2025-09-01")); err != nil {
+		t.Fatalf("Promote() error = %v", err)
+	}
+
+	want := map[string][]int64{nextGroup: {1, 2, 3}, firstGroup: {4}, secondGroup: {}}
+	for groupID, ids := range want {
+		students, err := repos.Student.GetAllByGroupID(ctx, groupID)
+		if err != nil {
+			t.Fatalf("GetAllByGroupID() error = %v", err)
+		}
+		if got := studentIDs(students); !equalIDs(got, ids) {
+			t.Errorf("students of %s = %v, want %v", groupID, got, ids)
+		}
+	}
+
+	history, err := repos.Membership.GetByStudentID(ctx, 4)
+	if err != nil {
+		t.Fatalf("GetByStudentID() error = %v", err)
+	}
+	last := history[len(history)-1]
+	if len(history) != 3 || last.GroupID != firstGroup || last.Reason == nil || *last.Reason != "promotion" || !last.ValidFrom.Equal(date("2025-09-01")) {
+		t.Errorf("history = %+v, want the promotion last", history)
+	}
+
+	// a group that exists already rolls the whole promotion back
+	err = repos.Membership.Promote(ctx, []domain.GroupPromotion{{FromGroupID: firstGroup, ToGroupID: secondGroup}}, []domain.Group{{GroupID: secondGroup, ProfileID: 1}}, date("2026-09-01"))
+	if !isViolation(err, "23505") {
+		t.Fatalf("Promote() into an existing group error = %v, want a unique violation", err)
+	}
+	students, err := repos.Student.GetAllByGroupID(ctx, firstGroup)
+	if err != nil {
+		t.Fatalf("GetAllByGroupID() error = %v", err)
+	}
+	if got := studentIDs(students); !equalIDs(got, []int64{4}) {
+		t.Errorf("students of %s after the failed promotion = %v, want [4]", firstGroup, got)
+	}
+}
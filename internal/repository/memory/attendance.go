@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type AttendanceRepo struct {
+	s *store
+}
+
+func (r *AttendanceRepo) Create(ctx context.Context, attendance domain.Attendance) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	attendance.AttendanceID = r.s.next("attendance")
+	r.s.attendance.insert(attendance)
+	return nil
+}
+
+// Put changes the marks of the attendance, the student, the schedule and the time are kept
+func (r *AttendanceRepo) Put(ctx context.Context, attendance domain.Attendance) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.attendance, r.byID(attendance.AttendanceID), func(row *domain.Attendance) error {
+		row.Presence = attendance.Presence
+		row.LateArrival = attendance.LateArrival
+		row.Respectfulness = attendance.Respectfulness
+		row.Reason = attendance.Reason
+		return nil
+	}, noUnique[domain.Attendance])
+}
+
+func (r *AttendanceRepo) Patch(ctx context.Context, attendanceID int64, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.attendance, r.byID(attendanceID), func(row *domain.Attendance) error {
+		return patch(row, updates)
+	}, noUnique[domain.Attendance])
+}
+
+func (r *AttendanceRepo) Delete(ctx context.Context, attendanceID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.attendance.remove(r.byID(attendanceID))
+	return nil
+}
+
+func (r *AttendanceRepo) GetByID(ctx context.Context, attendanceID int64) (domain.AttendanceInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	attendance, err := getRow(&r.s.attendance, r.byID(attendanceID))
+	if err != nil {
+		return domain.AttendanceInfo{}, err
+	}
+	return r.s.attendanceInfo(attendance), nil
+}
+
+func (r *AttendanceRepo) GetByStudentID(ctx context.Context, studentID int64) ([]domain.AttendanceInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.attendance.filter(func(a domain.Attendance) bool { return a.StudentID == studentID })), nil
+}
+
+func (r *AttendanceRepo) GetAll(ctx context.Context) ([]domain.AttendanceInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.attendance.all()), nil
+}
+
+// GetAllByGroupIDAndCreated returns a row for every student of the group attending the schedule
+// with the attendance marked at the time, the attendance fields are nil for unmarked students
+func (r *AttendanceRepo) GetAllByGroupIDAndCreated(ctx context.Context, groupID string, scheduleID int64, created time.Time) ([]domain.GroupAttendanceInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	schedule, scheduled := r.s.schedule(scheduleID)
+	infos := make([]domain.GroupAttendanceInfo, 0)
+	for _, student := range r.s.students.rows {
+		if student.GroupID != groupID || (scheduled && !r.s.attends(schedule, student.StudentID)) {
+			continue
+		}
+		sub := domain.GroupAttendanceSub{Student: domain.AttendanceStudent{
+			GroupID:    student.GroupID,
+			LastName:   student.LastName,
+			FirstName:  student.FirstName,
+			MiddleName: student.MiddleName,
+		}}
+
+		marked := false
+		if scheduled {
+			for _, attendance := range r.s.attendance.rows {
+				if attendance.StudentID != student.StudentID || attendance.ScheduleID != scheduleID || !attendance.Created.Equal(created) {
+					continue
+				}
+				marked = true
+				attendanceID, studentID, scheduleID, created := attendance.AttendanceID, student.StudentID, schedule.ScheduleID, attendance.Created
+				infos = append(infos, domain.GroupAttendanceInfo{
+					AttendanceSub: sub,
+					Attendance: domain.GroupAttendance{
+						AttendanceID:   &attendanceID,
+						StudentID:      &studentID,
+						ScheduleID:     &scheduleID,
+						Presence:       attendance.Presence,
+						LateArrival:    attendance.LateArrival,
+						Respectfulness: attendance.Respectfulness,
+						Reason:         attendance.Reason,
+						Created:        &created,
+					},
+				})
+			}
+		}
+		if !marked {
+			studentID := student.StudentID
+			infos = append(infos, domain.GroupAttendanceInfo{
+				AttendanceSub: sub,
+				Attendance:    domain.GroupAttendance{StudentID: &studentID},
+			})
+		}
+	}
+	return infos, nil
+}
+
+func (r *AttendanceRepo) ExistsByGroupID(ctx context.Context, groupID string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, attendance := range r.s.attendance.rows {
+		if schedule, ok := r.s.schedule(attendance.ScheduleID); ok && schedule.GroupID == groupID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *AttendanceRepo) infos(attendances []domain.Attendance) []domain.AttendanceInfo {
+	infos := make([]domain.AttendanceInfo, 0, len(attendances))
+	for _, attendance := range attendances {
+		infos = append(infos, r.s.attendanceInfo(attendance))
+	}
+	return infos
+}
+
+func (r *AttendanceRepo) byID(attendanceID int64) func(domain.Attendance) bool {
+	return func(a domain.Attendance) bool { return a.AttendanceID == attendanceID }
+}
+
+func (s *store) attendanceInfo(attendance domain.Attendance) domain.AttendanceInfo {
+	info := domain.AttendanceInfo{Attendance: attendance}
+	if student, ok := s.student(attendance.StudentID); ok {
+		info.AttendanceSub.Student = domain.StudentFullName{
+			LastName:   student.LastName,
+			FirstName:  student.FirstName,
+			MiddleName: student.MiddleName,
+		}
+	}
+	return info
+}
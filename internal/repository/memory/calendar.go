@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type CalendarRepo struct {
+	s *store
+}
+
+func (r *CalendarRepo) Create(ctx context.Context, period domain.CalendarPeriod) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	period = periodDates(period)
+	period.PeriodID = r.s.next("academic_calendar")
+	r.s.calendar.insert(period)
+	return period.PeriodID, nil
+}
+
+func (r *CalendarRepo) Put(ctx context.Context, period domain.CalendarPeriod) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.calendar, r.byID(period.PeriodID), func(row *domain.CalendarPeriod) error {
+		*row = periodDates(period)
+		return nil
+	}, noUnique[domain.CalendarPeriod])
+}
+
+func (r *CalendarRepo) Delete(ctx context.Context, periodID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.calendar.remove(r.byID(periodID))
+	return nil
+}
+
+func (r *CalendarRepo) GetByID(ctx context.Context, periodID int64) (domain.CalendarPeriod, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return getRow(&r.s.calendar, r.byID(periodID))
+}
+
+// GetByUniversityID returns the periods intersecting the range
+func (r *CalendarRepo) GetByUniversityID(ctx context.Context, universityID int64, from, to time.Time) ([]domain.CalendarPeriod, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	from, to = date(from), date(to)
+	periods := r.s.calendar.filter(func(p domain.CalendarPeriod) bool {
+		return p.UniversityID == universityID && !p.StartDate.After(to) && !p.EndDate.Before(from)
+	})
+	sort.SliceStable(periods, func(i, j int) bool {
+		a, b := periods[i], periods[j]
+		if !a.StartDate.Equal(b.StartDate) {
+			return a.StartDate.Before(b.StartDate)
+		}
+		return a.PeriodID < b.PeriodID
+	})
+	return periods, nil
+}
+
+func (r *CalendarRepo) IsTeachingDay(ctx context.Context, groupID string, date time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.isTeachingDay(groupID, date), nil
+}
+
+// GetTeachingDays returns the teaching days of the semester periods with the number
+func (r *CalendarRepo) GetTeachingDays(ctx context.Context, groupID string, semester int) ([]time.Time, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	days := make([]time.Time, 0)
+	universityID, ok := r.s.groupUniversityID(groupID)
+	if !ok {
+		return days, nil
+	}
+
+	seen := make(map[time.Time]bool)
+	for _, period := range r.s.calendar.rows {
+		if period.UniversityID != universityID || period.Kind != domain.PeriodSemester ||
+			period.Semester == nil || *period.Semester != semester {
+			continue
+		}
+		for day := period.StartDate; !day.After(period.EndDate); day = day.AddDate(0, 0, 1) {
+			if !seen[day] && r.s.isTeachingDayAt(universityID, day) {
+				seen[day] = true
+				days = append(days, day)
+			}
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return days, nil
+}
+
+func (r *CalendarRepo) byID(periodID int64) func(domain.CalendarPeriod) bool {
+	return func(p domain.CalendarPeriod) bool { return p.PeriodID == periodID }
+}
+
+func periodDates(period domain.CalendarPeriod) domain.CalendarPeriod {
+	period.StartDate = date(period.StartDate)
+	period.EndDate = date(period.EndDate)
+	return period
+}
+
+// isTeachingDay is is_teaching_day(group_university_id(group), date) of the database,
+// a group without a university has no calendar and teaches every day
+func (s *store) isTeachingDay(groupID string, day time.Time) bool {
+	universityID, ok := s.groupUniversityID(groupID)
+	if !ok {
+		return true
+	}
+	return s.isTeachingDayAt(universityID, day)
+}
+
+// isTeachingDayAt is the is_teaching_day function of the database
+func (s *store) isTeachingDayAt(universityID int64, day time.Time) bool {
+	hasSemesters, inSemester := false, false
+	for _, period := range s.calendar.rows {
+		if period.UniversityID != universityID {
+			continue
+		}
+		if period.Kind != domain.PeriodSemester {
+			if period.Covers(day) {
+				return false
+			}
+			continue
+		}
+		hasSemesters = true
+		if period.Covers(day) {
+			inSemester = true
+		}
+	}
+	return !hasSemesters || inSemester
+}
@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type ClassroomRepo struct {
+	s *store
+}
+
+func (r *ClassroomRepo) Create(ctx context.Context, classroom domain.Classroom) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	classroom.ClassroomID = 0
+	classroom.Features = classroomFeatures(classroom.Features)
+	if err := r.unique(classroom); err != nil {
+		return err
+	}
+	classroom.ClassroomID = r.s.next("classrooms")
+	r.s.classrooms.insert(classroom)
+	return nil
+}
+
+func (r *ClassroomRepo) Put(ctx context.Context, classroom domain.Classroom) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.classrooms, r.byID(classroom.ClassroomID), func(row *domain.Classroom) error {
+		*row = classroom
+		row.Features = classroomFeatures(classroom.Features)
+		return nil
+	}, r.unique)
+}
+
+func (r *ClassroomRepo) Patch(ctx context.Context, classroomID int64, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.classrooms, r.byID(classroomID), func(row *domain.Classroom) error {
+		if err := patch(row, updates); err != nil {
+			return err
+		}
+		row.Features = classroomFeatures(row.Features)
+		return nil
+	}, r.unique)
+}
+
+func (r *ClassroomRepo) unique(classroom domain.Classroom) error {
+	if r.s.classrooms.exists(func(c domain.Classroom) bool {
+		return c.ClassroomID != classroom.ClassroomID && c.ClassroomName == classroom.ClassroomName
+	}) {
+		return uniqueViolation("U_classrooms_classroom_name")
+	}
+	return nil
+}
+
+func (r *ClassroomRepo) Delete(ctx context.Context, classroomID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.classrooms.remove(r.byID(classroomID))
+	return nil
+}
+
+func (r *ClassroomRepo) GetByID(ctx context.Context, classroomID int64) (domain.Classroom, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return getRow(&r.s.classrooms, r.byID(classroomID))
+}
+
+func (r *ClassroomRepo) GetByName(ctx context.Context, classroomName string) (domain.Classroom, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return getRow(&r.s.classrooms, func(c domain.Classroom) bool { return c.ClassroomName == classroomName })
+}
+
+func (r *ClassroomRepo) GetAll(ctx context.Context) ([]domain.Classroom, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.classrooms.all(), nil
+}
+
+func (r *ClassroomRepo) GetFree(ctx context.Context, filter domain.FreeClassroomFilter) ([]domain.Classroom, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	startTime := clock(filter.StartTime)
+	classrooms := r.s.classrooms.filter(func(c domain.Classroom) bool {
+		if c.Capacity < filter.MinCapacity || !containsAll(c.Features, filter.Features) {
+			return false
+		}
+		return !r.s.schedules.exists(func(s domain.Schedule) bool {
+			return s.ClassroomID == c.ClassroomID && isActual(s) &&
+				s.Semester == filter.Semester && s.WeekType == filter.WeekType &&
+				s.DayOfWeek == filter.DayOfWeek && s.StartTime.Equal(startTime)
+		})
+	})
+	sort.SliceStable(classrooms, func(i, j int) bool {
+		a, b := classrooms[i], classrooms[j]
+		if a.Capacity != b.Capacity {
+			return a.Capacity < b.Capacity
+		}
+		if a.Building != b.Building {
+			return a.Building < b.Building
+		}
+		return a.ClassroomName < b.ClassroomName
+	})
+	return classrooms, nil
+}
+
+func (r *ClassroomRepo) byID(classroomID int64) func(domain.Classroom) bool {
+	return func(c domain.Classroom) bool { return c.ClassroomID == classroomID }
+}
+
+func (s *store) classroomName(classroomID int64) (string, bool) {
+	classroom, ok := s.classrooms.find(func(c domain.Classroom) bool { return c.ClassroomID == classroomID })
+	if !ok {
+		return "", false
+	}
+	return classroom.ClassroomName, true
+}
+
+// classroomFeatures keeps the features an empty array instead of NULL
+func classroomFeatures(features []string) []string {
+	if features == nil {
+		return []string{}
+	}
+	return append([]string{}, features...)
+}
+
+// containsAll is the @> operator of arrays
+func containsAll(values, subset []string) bool {
+	for _, want := range subset {
+		found := false
+		for _, value := range values {
+			if value == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
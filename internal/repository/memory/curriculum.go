@@ -0,0 +1,183 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type CurriculumRepo struct {
+	s *store
+}
+
+func (r *CurriculumRepo) Create(ctx context.Context, item domain.CurriculumItem) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	item.CurriculumID = 0
+	if err := r.unique(item); err != nil {
+		return 0, err
+	}
+	item.CurriculumID = r.s.next("curriculum")
+	r.s.curriculum.insert(item)
+	return item.CurriculumID, nil
+}
+
+func (r *CurriculumRepo) Put(ctx context.Context, item domain.CurriculumItem) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.curriculum, r.byID(item.CurriculumID), func(row *domain.CurriculumItem) error {
+		*row = item
+		return nil
+	}, r.unique)
+}
+
+func (r *CurriculumRepo) unique(item domain.CurriculumItem) error {
+	if r.s.curriculum.exists(func(c domain.CurriculumItem) bool {
+		return c.CurriculumID != item.CurriculumID && c.ProfileID == item.ProfileID && c.Semester == item.Semester &&
+			c.DisciplineID == item.DisciplineID && c.DisciplineTypeID == item.DisciplineTypeID
+	}) {
+		return uniqueViolation("U_curriculum_item")
+	}
+	return nil
+}
+
+func (r *CurriculumRepo) Delete(ctx context.Context, curriculumID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.curriculum.remove(r.byID(curriculumID))
+	return nil
+}
+
+func (r *CurriculumRepo) GetByID(ctx context.Context, curriculumID int64) (domain.CurriculumItemInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	infos := r.infos(r.byID(curriculumID))
+	if len(infos) == 0 {
+		return domain.CurriculumItemInfo{}, errNoRows()
+	}
+	return infos[0], nil
+}
+
+func (r *CurriculumRepo) GetByProfileID(ctx context.Context, profileID int64) ([]domain.CurriculumItemInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	infos := r.infos(func(c domain.CurriculumItem) bool { return c.ProfileID == profileID })
+	sort.SliceStable(infos, func(i, j int) bool {
+		if infos[i].CurriculumItem.Semester != infos[j].CurriculumItem.Semester {
+			return infos[i].CurriculumItem.Semester < infos[j].CurriculumItem.Semester
+		}
+		return curriculumLess(infos[i], infos[j])
+	})
+	return infos, nil
+}
+
+func (r *CurriculumRepo) GetByGroupAndSemester(ctx context.Context, groupID string, semester int) ([]domain.CurriculumItemInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	group, ok := r.s.groups.find(byGroupID(groupID))
+	if !ok {
+		return []domain.CurriculumItemInfo{}, nil
+	}
+	infos := r.infos(func(c domain.CurriculumItem) bool { return c.ProfileID == group.ProfileID && c.Semester == semester })
+	sort.SliceStable(infos, func(i, j int) bool { return curriculumLess(infos[i], infos[j]) })
+	return infos, nil
+}
+
+// CountHeldLessons counts the days with attendance of every schedule of the group
+// in the semester by the discipline type and the subgroup
+func (r *CurriculumRepo) CountHeldLessons(ctx context.Context, groupID string, semester int) ([]domain.HeldLessons, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	type lesson struct {
+		scheduleID int64
+		day        time.Time
+	}
+	held := make([]domain.HeldLessons, 0)
+	index := make(map[[3]int64]int)
+	seen := make(map[lesson]bool)
+	for _, schedule := range r.s.schedules.rows {
+		if schedule.GroupID != groupID || schedule.Semester != semester {
+			continue
+		}
+		disciplineName, ok := r.s.disciplineName(schedule.DisciplineID)
+		if !ok {
+			continue
+		}
+		disciplineTypeName, ok := r.s.disciplineTypeName(schedule.DisciplineTypeID)
+		if !ok {
+			continue
+		}
+		for _, attendance := range r.s.attendance.rows {
+			if attendance.ScheduleID != schedule.ScheduleID {
+				continue
+			}
+			day := lesson{schedule.ScheduleID, date(attendance.Created)}
+			if seen[day] {
+				continue
+			}
+			seen[day] = true
+
+			key := [3]int64{schedule.DisciplineID, schedule.DisciplineTypeID, -1}
+			if schedule.SubgroupID != nil {
+				key[2] = *schedule.SubgroupID
+			}
+			i, ok := index[key]
+			if !ok {
+				i = len(held)
+				index[key] = i
+				held = append(held, domain.HeldLessons{
+					DisciplineID:       schedule.DisciplineID,
+					DisciplineName:     disciplineName,
+					DisciplineTypeID:   schedule.DisciplineTypeID,
+					DisciplineTypeName: disciplineTypeName,
+					SubgroupID:         schedule.SubgroupID,
+				})
+			}
+			held[i].Lessons++
+		}
+	}
+	return held, nil
+}
+
+// infos joins the names of the discipline and the type, items without them are skipped like by INNER JOIN
+func (r *CurriculumRepo) infos(match func(domain.CurriculumItem) bool) []domain.CurriculumItemInfo {
+	infos := make([]domain.CurriculumItemInfo, 0)
+	for _, item := range r.s.curriculum.filter(match) {
+		disciplineName, ok := r.s.disciplineName(item.DisciplineID)
+		if !ok {
+			continue
+		}
+		disciplineTypeName, ok := r.s.disciplineTypeName(item.DisciplineTypeID)
+		if !ok {
+			continue
+		}
+		infos = append(infos, domain.CurriculumItemInfo{
+			CurriculumItem: item,
+			CurriculumItemSub: domain.CurriculumItemSub{
+				DisciplineName:     disciplineName,
+				DisciplineTypeName: disciplineTypeName,
+			},
+		})
+	}
+	return infos
+}
+
+func (r *CurriculumRepo) byID(curriculumID int64) func(domain.CurriculumItem) bool {
+	return func(c domain.CurriculumItem) bool { return c.CurriculumID == curriculumID }
+}
+
+func curriculumLess(a, b domain.CurriculumItemInfo) bool {
+	if a.CurriculumItemSub.DisciplineName != b.CurriculumItemSub.DisciplineName {
+		return a.CurriculumItemSub.DisciplineName < b.CurriculumItemSub.DisciplineName
+	}
+	return a.CurriculumItemSub.DisciplineTypeName < b.CurriculumItemSub.DisciplineTypeName
+}
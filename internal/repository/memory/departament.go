@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type DepartamentRepo struct {
+	s *store
+}
+
+func (r *DepartamentRepo) Create(ctx context.Context, departament domain.Departament) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	departament.DepartamentID = 0
+	if err := r.unique(departament); err != nil {
+		return err
+	}
+	departament.DepartamentID = r.s.next("departaments")
+	r.s.departaments.insert(departament)
+	return nil
+}
+
+func (r *DepartamentRepo) Put(ctx context.Context, departament domain.Departament) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.departaments, r.byID(departament.DepartamentID), func(row *domain.Departament) error {
+		*row = departament
+		return nil
+	}, r.unique)
+}
+
+func (r *DepartamentRepo) Patch(ctx context.Context, departamentID int64, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.departaments, r.byID(departamentID), func(row *domain.Departament) error {
+		return patch(row, updates)
+	}, r.unique)
+}
+
+func (r *DepartamentRepo) unique(departament domain.Departament) error {
+	if r.s.departaments.exists(func(d domain.Departament) bool {
+		return d.DepartamentID != departament.DepartamentID && d.DepartamentName == departament.DepartamentName
+	}) {
+		return uniqueViolation("U_departaments_departament_name")
+	}
+	return nil
+}
+
+func (r *DepartamentRepo) Delete(ctx context.Context, departamentID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.departaments.remove(r.byID(departamentID))
+	return nil
+}
+
+func (r *DepartamentRepo) GetByID(ctx context.Context, departamentID int64) (domain.DepartamentInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	departament, err := getRow(&r.s.departaments, r.byID(departamentID))
+	return r.s.departamentInfo(departament), err
+}
+
+func (r *DepartamentRepo) GetByName(ctx context.Context, departamentName string) (domain.DepartamentInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	departament, err := getRow(&r.s.departaments, func(d domain.Departament) bool { return d.DepartamentName == departamentName })
+	return r.s.departamentInfo(departament), err
+}
+
+func (r *DepartamentRepo) GetAll(ctx context.Context) ([]domain.DepartamentInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.departaments.all()), nil
+}
+
+func (r *DepartamentRepo) GetAllByFacultyID(ctx context.Context, facultyID int64) ([]domain.DepartamentInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.departaments.filter(func(d domain.Departament) bool { return d.FacultyID == facultyID })), nil
+}
+
+func (r *DepartamentRepo) infos(departaments []domain.Departament) []domain.DepartamentInfo {
+	infos := make([]domain.DepartamentInfo, 0, len(departaments))
+	for _, departament := range departaments {
+		infos = append(infos, r.s.departamentInfo(departament))
+	}
+	return infos
+}
+
+func (r *DepartamentRepo) byID(departamentID int64) func(domain.Departament) bool {
+	return func(d domain.Departament) bool { return d.DepartamentID == departamentID }
+}
+
+func (s *store) departamentInfo(departament domain.Departament) domain.DepartamentInfo {
+	info := domain.DepartamentInfo{Departament: departament}
+	if faculty, ok := s.faculties.find(func(f domain.Faculty) bool { return f.FacultyID == departament.FacultyID }); ok {
+		info.DepartamentSub.FacultyName = faculty.FacultyName
+	}
+	return info
+}
+
+func (s *store) departamentName(departamentID int64) string {
+	if departament, ok := s.departaments.find(func(d domain.Departament) bool { return d.DepartamentID == departamentID }); ok {
+		return departament.DepartamentName
+	}
+	return ""
+}
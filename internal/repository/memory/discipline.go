@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type DisciplineRepo struct {
+	s *store
+}
+
+func (r *DisciplineRepo) Create(ctx context.Context, discipline domain.Discipline) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	discipline.DisciplineID = 0
+	if err := r.unique(discipline); err != nil {
+		return err
+	}
+	discipline.DisciplineID = r.s.next("disciplines")
+	r.s.disciplines.insert(discipline)
+	return nil
+}
+
+func (r *DisciplineRepo) Put(ctx context.Context, discipline domain.Discipline) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.disciplines, r.byID(discipline.DisciplineID), func(row *domain.Discipline) error {
+		*row = discipline
+		return nil
+	}, r.unique)
+}
+
+func (r *DisciplineRepo) Patch(ctx context.Context, disciplineID int64, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.disciplines, r.byID(disciplineID), func(row *domain.Discipline) error {
+		return patch(row, updates)
+	}, r.unique)
+}
+
+func (r *DisciplineRepo) unique(discipline domain.Discipline) error {
+	if r.s.disciplines.exists(func(d domain.Discipline) bool {
+		return d.DisciplineID != discipline.DisciplineID && d.DisciplineName == discipline.DisciplineName
+	}) {
+		return uniqueViolation("U_disciplines_discipline_name")
+	}
+	return nil
+}
+
+func (r *DisciplineRepo) Delete(ctx context.Context, disciplineID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.disciplines.remove(r.byID(disciplineID))
+	return nil
+}
+
+func (r *DisciplineRepo) GetByID(ctx context.Context, disciplineID int64) (domain.DisciplineInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	discipline, err := getRow(&r.s.disciplines, r.byID(disciplineID))
+	return r.s.disciplineInfo(discipline), err
+}
+
+func (r *DisciplineRepo) GetByName(ctx context.Context, disciplineName string) (domain.DisciplineInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	discipline, err := getRow(&r.s.disciplines, func(d domain.Discipline) bool { return d.DisciplineName == disciplineName })
+	return r.s.disciplineInfo(discipline), err
+}
+
+func (r *DisciplineRepo) GetAll(ctx context.Context) ([]domain.DisciplineInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.disciplines.all()), nil
+}
+
+func (r *DisciplineRepo) GetAllByDepartamentID(ctx context.Context, departamentID int64) ([]domain.DisciplineInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.disciplines.filter(func(d domain.Discipline) bool { return d.DepartamentID == departamentID })), nil
+}
+
+func (r *DisciplineRepo) infos(disciplines []domain.Discipline) []domain.DisciplineInfo {
+	infos := make([]domain.DisciplineInfo, 0, len(disciplines))
+	for _, discipline := range disciplines {
+		infos = append(infos, r.s.disciplineInfo(discipline))
+	}
+	return infos
+}
+
+func (r *DisciplineRepo) byID(disciplineID int64) func(domain.Discipline) bool {
+	return func(d domain.Discipline) bool { return d.DisciplineID == disciplineID }
+}
+
+func (s *store) disciplineInfo(discipline domain.Discipline) domain.DisciplineInfo {
+	return domain.DisciplineInfo{
+		Discipline:    discipline,
+		DisciplineSub: domain.DisciplineSub{DepartamentName: s.departamentName(discipline.DepartamentID)},
+	}
+}
+
+func (s *store) disciplineName(disciplineID int64) (string, bool) {
+	discipline, ok := s.disciplines.find(func(d domain.Discipline) bool { return d.DisciplineID == disciplineID })
+	if !ok {
+		return "", false
+	}
+	return discipline.DisciplineName, true
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type DisciplineTypeRepo struct {
+	s *store
+}
+
+func (r *DisciplineTypeRepo) Create(ctx context.Context, disciplineType domain.DisciplineType) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	disciplineType.DisciplineTypeID = 0
+	if err := r.unique(disciplineType); err != nil {
+		return err
+	}
+	disciplineType.DisciplineTypeID = r.s.next("disciplineTypes")
+	r.s.disciplineTypes.insert(disciplineType)
+	return nil
+}
+
+func (r *DisciplineTypeRepo) Put(ctx context.Context, disciplineType domain.DisciplineType) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.disciplineTypes, r.byID(disciplineType.DisciplineTypeID), func(row *domain.DisciplineType) error {
+		*row = disciplineType
+		return nil
+	}, r.unique)
+}
+
+func (r *DisciplineTypeRepo) Patch(ctx context.Context, disciplineTypeID int64, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.disciplineTypes, r.byID(disciplineTypeID), func(row *domain.DisciplineType) error {
+		return patch(row, updates)
+	}, r.unique)
+}
+
+func (r *DisciplineTypeRepo) unique(disciplineType domain.DisciplineType) error {
+	if r.s.disciplineTypes.exists(func(d domain.DisciplineType) bool {
+		return d.DisciplineTypeID != disciplineType.DisciplineTypeID && d.DisciplineTypeName == disciplineType.DisciplineTypeName
+	}) {
+		return uniqueViolation("U_disciplineTypes_discipline_type_name")
+	}
+	return nil
+}
+
+func (r *DisciplineTypeRepo) Delete(ctx context.Context, disciplineTypeID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.disciplineTypes.remove(r.byID(disciplineTypeID))
+	return nil
+}
+
+func (r *DisciplineTypeRepo) GetByID(ctx context.Context, disciplineTypeID int64) (domain.DisciplineType, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return getRow(&r.s.disciplineTypes, r.byID(disciplineTypeID))
+}
+
+func (r *DisciplineTypeRepo) GetByName(ctx context.Context, disciplineTypeName string) (domain.DisciplineType, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return getRow(&r.s.disciplineTypes, func(d domain.DisciplineType) bool { return d.DisciplineTypeName == disciplineTypeName })
+}
+
+func (r *DisciplineTypeRepo) GetAll(ctx context.Context) ([]domain.DisciplineType, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.disciplineTypes.all(), nil
+}
+
+func (r *DisciplineTypeRepo) byID(disciplineTypeID int64) func(domain.DisciplineType) bool {
+	return func(d domain.DisciplineType) bool { return d.DisciplineTypeID == disciplineTypeID }
+}
+
+func (s *store) disciplineTypeName(disciplineTypeID int64) (string, bool) {
+	disciplineType, ok := s.disciplineTypes.find(func(d domain.DisciplineType) bool { return d.DisciplineTypeID == disciplineTypeID })
+	if !ok {
+		return "", false
+	}
+	return disciplineType.DisciplineTypeName, true
+}
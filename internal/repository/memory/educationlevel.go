@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type EducationLevelRepo struct {
+	s *store
+}
+
+func (r *EducationLevelRepo) Create(ctx context.Context, educationLevel domain.EducationLevel) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	educationLevel.EducationLevelID = 0
+	if err := r.unique(educationLevel); err != nil {
+		return err
+	}
+	educationLevel.EducationLevelID = r.s.next("educationLevels")
+	r.s.educationLevels.insert(educationLevel)
+	return nil
+}
+
+func (r *EducationLevelRepo) Put(ctx context.Context, educationLevel domain.EducationLevel) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.educationLevels, r.byID(educationLevel.EducationLevelID), func(row *domain.EducationLevel) error {
+		*row = educationLevel
+		return nil
+	}, r.unique)
+}
+
+func (r *EducationLevelRepo) Patch(ctx context.Context, educationLevelID int64, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.educationLevels, r.byID(educationLevelID), func(row *domain.EducationLevel) error {
+		return patch(row, updates)
+	}, r.unique)
+}
+
+func (r *EducationLevelRepo) unique(educationLevel domain.EducationLevel) error {
+	if r.s.educationLevels.exists(func(e domain.EducationLevel) bool {
+		return e.EducationLevelID != educationLevel.EducationLevelID && e.EducationLevelName == educationLevel.EducationLevelName
+	}) {
+		return uniqueViolation("U_educationLevels_education_level_name")
+	}
+	return nil
+}
+
+func (r *EducationLevelRepo) Delete(ctx context.Context, educationLevelID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.educationLevels.remove(r.byID(educationLevelID))
+	return nil
+}
+
+func (r *EducationLevelRepo) GetByID(ctx context.Context, educationLevelID int64) (domain.EducationLevel, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return getRow(&r.s.educationLevels, r.byID(educationLevelID))
+}
+
+func (r *EducationLevelRepo) GetAll(ctx context.Context) ([]domain.EducationLevel, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.educationLevels.all(), nil
+}
+
+func (r *EducationLevelRepo) byID(educationLevelID int64) func(domain.EducationLevel) bool {
+	return func(e domain.EducationLevel) bool { return e.EducationLevelID == educationLevelID }
+}
+
+func (s *store) educationLevelName(educationLevelID int64) string {
+	if educationLevel, ok := s.educationLevels.find(func(e domain.EducationLevel) bool { return e.EducationLevelID == educationLevelID }); ok {
+		return educationLevel.EducationLevelName
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type EducationTypeRepo struct {
+	s *store
+}
+
+func (r *EducationTypeRepo) Create(ctx context.Context, educationType domain.EducationType) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	educationType.EducationTypeID = 0
+	if err := r.unique(educationType); err != nil {
+		return err
+	}
+	educationType.EducationTypeID = r.s.next("educationTypes")
+	r.s.educationTypes.insert(educationType)
+	return nil
+}
+
+func (r *EducationTypeRepo) Put(ctx context.Context, educationType domain.EducationType) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.educationTypes, r.byID(educationType.EducationTypeID), func(row *domain.EducationType) error {
+		*row = educationType
+		return nil
+	}, r.unique)
+}
+
+func (r *EducationTypeRepo) Patch(ctx context.Context, educationTypeID int64, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.educationTypes, r.byID(educationTypeID), func(row *domain.EducationType) error {
+		return patch(row, updates)
+	}, r.unique)
+}
+
+func (r *EducationTypeRepo) unique(educationType domain.EducationType) error {
+	if r.s.educationTypes.exists(func(e domain.EducationType) bool {
+		return e.EducationTypeID != educationType.EducationTypeID && e.EducationTypeName == educationType.EducationTypeName
+	}) {
+		return uniqueViolation("U_educationTypes_education_type_name")
+	}
+	return nil
+}
+
+func (r *EducationTypeRepo) Delete(ctx context.Context, educationTypeID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.educationTypes.remove(r.byID(educationTypeID))
+	return nil
+}
+
+func (r *EducationTypeRepo) GetByID(ctx context.Context, educationTypeID int64) (domain.EducationType, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return getRow(&r.s.educationTypes, r.byID(educationTypeID))
+}
+
+func (r *EducationTypeRepo) GetByName(ctx context.Context, educationTypeName string) (domain.EducationType, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return getRow(&r.s.educationTypes, func(e domain.EducationType) bool { return e.EducationTypeName == educationTypeName })
+}
+
+func (r *EducationTypeRepo) GetAll(ctx context.Context) ([]domain.EducationType, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.educationTypes.all(), nil
+}
+
+func (r *EducationTypeRepo) byID(educationTypeID int64) func(domain.EducationType) bool {
+	return func(e domain.EducationType) bool { return e.EducationTypeID == educationTypeID }
+}
+
+func (s *store) educationTypeName(educationTypeID int64) string {
+	if educationType, ok := s.educationTypes.find(func(e domain.EducationType) bool { return e.EducationTypeID == educationTypeID }); ok {
+		return educationType.EducationTypeName
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type FacultyRepo struct {
+	s *store
+}
+
+func (r *FacultyRepo) Create(ctx context.Context, faculty domain.Faculty) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	faculty.FacultyID = 0
+	if err := r.unique(faculty); err != nil {
+		return err
+	}
+	faculty.FacultyID = r.s.next("faculties")
+	r.s.faculties.insert(faculty)
+	return nil
+}
+
+func (r *FacultyRepo) Put(ctx context.Context, faculty domain.Faculty) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.faculties, r.byID(faculty.FacultyID), func(row *domain.Faculty) error {
+		*row = faculty
+		return nil
+	}, r.unique)
+}
+
+func (r *FacultyRepo) Patch(ctx context.Context, facultyID int64, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.faculties, r.byID(facultyID), func(row *domain.Faculty) error {
+		return patch(row, updates)
+	}, r.unique)
+}
+
+func (r *FacultyRepo) unique(faculty domain.Faculty) error {
+	if r.s.faculties.exists(func(f domain.Faculty) bool {
+		return f.FacultyID != faculty.FacultyID && f.FacultyName == faculty.FacultyName
+	}) {
+		return uniqueViolation("U_faculties_faculty_name")
+	}
+	return nil
+}
+
+func (r *FacultyRepo) Delete(ctx context.Context, facultyID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.faculties.remove(r.byID(facultyID))
+	return nil
+}
+
+func (r *FacultyRepo) GetByID(ctx context.Context, facultyID int64) (domain.FacultyInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	faculty, err := getRow(&r.s.faculties, r.byID(facultyID))
+	return r.s.facultyInfo(faculty), err
+}
+
+func (r *FacultyRepo) GetByName(ctx context.Context, facultyName string) (domain.FacultyInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	faculty, err := getRow(&r.s.faculties, func(f domain.Faculty) bool { return f.FacultyName == facultyName })
+	return r.s.facultyInfo(faculty), err
+}
+
+func (r *FacultyRepo) GetAll(ctx context.Context) ([]domain.FacultyInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.faculties.all()), nil
+}
+
+func (r *FacultyRepo) GetAllByUniversityID(ctx context.Context, universityID int64) ([]domain.FacultyInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.faculties.filter(func(f domain.Faculty) bool { return f.UniversityID == universityID })), nil
+}
+
+func (r *FacultyRepo) infos(faculties []domain.Faculty) []domain.FacultyInfo {
+	infos := make([]domain.FacultyInfo, 0, len(faculties))
+	for _, faculty := range faculties {
+		infos = append(infos, r.s.facultyInfo(faculty))
+	}
+	return infos
+}
+
+func (r *FacultyRepo) byID(facultyID int64) func(domain.Faculty) bool {
+	return func(f domain.Faculty) bool { return f.FacultyID == facultyID }
+}
+
+func (s *store) facultyInfo(faculty domain.Faculty) domain.FacultyInfo {
+	info := domain.FacultyInfo{Faculty: faculty}
+	if university, ok := s.universities.find(func(u domain.University) bool { return u.UniversityID == faculty.UniversityID }); ok {
+		info.FacultySub.UniversityName = university.UniversityName
+	}
+	return info
+}
@@ -0,0 +1,237 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type GradebookRepo struct {
+	s *store
+}
+
+// SetMark replaces the mark of the student for the same lesson
+func (r *GradebookRepo) SetMark(ctx context.Context, mark domain.Mark) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	mark.LessonDate = date(mark.LessonDate)
+	if existing, ok := r.s.marks.find(func(m domain.Mark) bool {
+		return m.ScheduleID == mark.ScheduleID && m.StudentID == mark.StudentID && m.LessonDate.Equal(mark.LessonDate)
+	}); ok {
+		existing.Mark = mark.Mark
+		existing.Comment = mark.Comment
+		existing.TeacherID = mark.TeacherID
+		return existing.MarkID, nil
+	}
+	mark.MarkID = r.s.next("marks")
+	r.s.marks.insert(mark)
+	return mark.MarkID, nil
+}
+
+func (r *GradebookRepo) DeleteMark(ctx context.Context, markID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.marks.remove(func(m domain.Mark) bool { return m.MarkID == markID })
+	return nil
+}
+
+func (r *GradebookRepo) GetMarkByID(ctx context.Context, markID int64) (domain.Mark, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return getRow(&r.s.marks, func(m domain.Mark) bool { return m.MarkID == markID })
+}
+
+func (r *GradebookRepo) GetStudentMarks(ctx context.Context, studentID int64, semester int) ([]domain.MarkInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	marks := make([]domain.MarkInfo, 0)
+	for _, mark := range r.s.marks.rows {
+		if mark.StudentID != studentID {
+			continue
+		}
+		schedule, ok := r.s.schedule(mark.ScheduleID)
+		if !ok || (semester != 0 && schedule.Semester != semester) {
+			continue
+		}
+		marks = append(marks, domain.MarkInfo{
+			Mark:    mark,
+			MarkSub: domain.MarkSub{DisciplineID: schedule.DisciplineID, Semester: schedule.Semester},
+		})
+	}
+	sort.SliceStable(marks, func(i, j int) bool {
+		a, b := marks[i].Mark, marks[j].Mark
+		if !a.LessonDate.Equal(b.LessonDate) {
+			return a.LessonDate.Before(b.LessonDate)
+		}
+		return a.MarkID < b.MarkID
+	})
+	return marks, nil
+}
+
+func (r *GradebookRepo) CreateControlPoint(ctx context.Context, point domain.ControlPoint) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if r.s.controlPoints.exists(func(cp domain.ControlPoint) bool {
+		return cp.GroupID == point.GroupID && cp.DisciplineID == point.DisciplineID &&
+			cp.Semester == point.Semester && cp.Name == point.Name
+	}) {
+		return 0, uniqueViolation("U_control_points_name")
+	}
+	point.DueDate = optionalDate(point.DueDate)
+	point.ControlPointID = r.s.next("control_points")
+	r.s.controlPoints.insert(point)
+	return point.ControlPointID, nil
+}
+
+func (r *GradebookRepo) DeleteControlPoint(ctx context.Context, controlPointID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.controlPoints.remove(r.controlPointByID(controlPointID))
+	r.s.controlPointResults.remove(func(cpr domain.ControlPointResult) bool { return cpr.ControlPointID == controlPointID })
+	return nil
+}
+
+func (r *GradebookRepo) GetControlPointByID(ctx context.Context, controlPointID int64) (domain.ControlPoint, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return getRow(&r.s.controlPoints, r.controlPointByID(controlPointID))
+}
+
+func (r *GradebookRepo) GetControlPoints(ctx context.Context, groupID string, disciplineID int64, semester int) ([]domain.ControlPoint, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	points := r.s.controlPoints.filter(func(cp domain.ControlPoint) bool {
+		return cp.GroupID == groupID && cp.DisciplineID == disciplineID && cp.Semester == semester
+	})
+	sort.SliceStable(points, func(i, j int) bool { return controlPointLess(points[i], points[j]) })
+	return points, nil
+}
+
+// SetControlPointResult replaces the previous score of the student
+func (r *GradebookRepo) SetControlPointResult(ctx context.Context, result domain.ControlPointResult) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if existing, ok := r.s.controlPointResults.find(func(cpr domain.ControlPointResult) bool {
+		return cpr.ControlPointID == result.ControlPointID && cpr.StudentID == result.StudentID
+	}); ok {
+		existing.Score = result.Score
+		existing.TeacherID = result.TeacherID
+		return nil
+	}
+	r.s.controlPointResults.insert(result)
+	return nil
+}
+
+func (r *GradebookRepo) GetStudentControlPoints(ctx context.Context, studentID int64, semester int) ([]domain.ControlPointScore, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	points := make([]domain.ControlPointScore, 0)
+	student, ok := r.s.student(studentID)
+	if !ok {
+		return points, nil
+	}
+	controlPoints := r.s.controlPoints.filter(func(cp domain.ControlPoint) bool {
+		return cp.GroupID == student.GroupID && (semester == 0 || cp.Semester == semester)
+	})
+	sort.SliceStable(controlPoints, func(i, j int) bool { return controlPointLess(controlPoints[i], controlPoints[j]) })
+
+	for _, point := range controlPoints {
+		score := domain.ControlPointScore{ControlPoint: point}
+		if result, ok := r.s.controlPointResults.find(func(cpr domain.ControlPointResult) bool {
+			return cpr.ControlPointID == point.ControlPointID && cpr.StudentID == studentID
+		}); ok {
+			value := result.Score
+			score.Score = &value
+		}
+		points = append(points, score)
+	}
+	return points, nil
+}
+
+// SetFinalResult replaces the previous grade of the student for the credit or the exam
+func (r *GradebookRepo) SetFinalResult(ctx context.Context, result domain.FinalResult) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	today := date(time.Now())
+	if existing, ok := r.s.finalResults.find(func(f domain.FinalResult) bool {
+		return f.StudentID == result.StudentID && f.DisciplineID == result.DisciplineID &&
+			f.Semester == result.Semester && f.ControlType == result.ControlType
+	}); ok {
+		existing.Grade = result.Grade
+		existing.TeacherID = result.TeacherID
+		existing.GradedOn = today
+		return existing.FinalResultID, nil
+	}
+	result.FinalResultID = r.s.next("final_results")
+	result.GradedOn = today
+	r.s.finalResults.insert(result)
+	return result.FinalResultID, nil
+}
+
+func (r *GradebookRepo) GetStudentFinalResults(ctx context.Context, studentID int64, semester int) ([]domain.FinalResult, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	results := r.s.finalResults.filter(func(f domain.FinalResult) bool {
+		return f.StudentID == studentID && (semester == 0 || f.Semester == semester)
+	})
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Semester != b.Semester {
+			return a.Semester < b.Semester
+		}
+		return a.GradedOn.Before(b.GradedOn)
+	})
+	return results, nil
+}
+
+func (r *GradebookRepo) GetStudentAttendance(ctx context.Context, studentID int64, disciplineID int64, semester int) (domain.DisciplineAttendance, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var attendance domain.DisciplineAttendance
+	for _, a := range r.s.attendance.rows {
+		if a.StudentID != studentID {
+			continue
+		}
+		schedule, ok := r.s.schedule(a.ScheduleID)
+		if !ok || schedule.DisciplineID != disciplineID || schedule.Semester != semester {
+			continue
+		}
+		attendance.Total++
+		if a.Presence != nil && *a.Presence {
+			attendance.Visits++
+		}
+	}
+	return attendance, nil
+}
+
+func (r *GradebookRepo) controlPointByID(controlPointID int64) func(domain.ControlPoint) bool {
+	return func(cp domain.ControlPoint) bool { return cp.ControlPointID == controlPointID }
+}
+
+// controlPointLess orders by the due date with the points without it last, then by the name
+func controlPointLess(a, b domain.ControlPoint) bool {
+	switch {
+	case a.DueDate == nil && b.DueDate != nil:
+		return false
+	case a.DueDate != nil && b.DueDate == nil:
+		return true
+	case a.DueDate != nil && !a.DueDate.Equal(*b.DueDate):
+		return a.DueDate.Before(*b.DueDate)
+	}
+	return a.Name < b.Name
+}
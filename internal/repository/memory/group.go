@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type GroupRepo struct {
+	s *store
+}
+
+func (r *GroupRepo) Create(ctx context.Context, group domain.Group) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.createGroup(group)
+}
+
+func (r *GroupRepo) Put(ctx context.Context, group domain.Group) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.groups, byGroupID(group.GroupID), func(row *domain.Group) error {
+		row.ProfileID = group.ProfileID
+		return nil
+	}, r.unique(group.GroupID))
+}
+
+func (r *GroupRepo) Patch(ctx context.Context, groupID string, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.groups, byGroupID(groupID), func(row *domain.Group) error {
+		return patch(row, updates)
+	}, r.unique(groupID))
+}
+
+// unique checks the id of the updated row, it may change through a patch
+func (r *GroupRepo) unique(groupID string) func(domain.Group) error {
+	return func(group domain.Group) error {
+		if group.GroupID != groupID && r.s.groups.exists(byGroupID(group.GroupID)) {
+			return uniqueViolation("PK_groups")
+		}
+		return nil
+	}
+}
+
+func (r *GroupRepo) Delete(ctx context.Context, groupID string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.groups.remove(byGroupID(groupID))
+	return nil
+}
+
+func (r *GroupRepo) GetByID(ctx context.Context, groupID string) (domain.GroupInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	group, err := getRow(&r.s.groups, byGroupID(groupID))
+	return r.s.groupInfo(group), err
+}
+
+func (r *GroupRepo) GetByName(ctx context.Context, profileName string) (domain.GroupInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	group, err := getRow(&r.s.groups, func(g domain.Group) bool { return r.s.profileName(g.ProfileID) == profileName })
+	return r.s.groupInfo(group), err
+}
+
+func (r *GroupRepo) GetAll(ctx context.Context) ([]domain.GroupInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.groups.all()), nil
+}
+
+func (r *GroupRepo) GetAllByProfileID(ctx context.Context, profileID int64) ([]domain.GroupInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.groups.filter(func(g domain.Group) bool { return g.ProfileID == profileID })), nil
+}
+
+func (r *GroupRepo) infos(groups []domain.Group) []domain.GroupInfo {
+	infos := make([]domain.GroupInfo, 0, len(groups))
+	for _, group := range groups {
+		infos = append(infos, r.s.groupInfo(group))
+	}
+	return infos
+}
+
+func byGroupID(groupID string) func(domain.Group) bool {
+	return func(g domain.Group) bool { return g.GroupID == groupID }
+}
+
+func (s *store) createGroup(group domain.Group) error {
+	if s.groups.exists(byGroupID(group.GroupID)) {
+		return uniqueViolation("PK_groups")
+	}
+	s.groups.insert(group)
+	return nil
+}
+
+func (s *store) groupInfo(group domain.Group) domain.GroupInfo {
+	return domain.GroupInfo{
+		Group:    group,
+		GroupSub: domain.GroupSub{ProfileName: s.profileName(group.ProfileID)},
+	}
+}
+
+// groupUniversityID is the group_university_id function of the database
+func (s *store) groupUniversityID(groupID string) (int64, bool) {
+	group, ok := s.groups.find(byGroupID(groupID))
+	if !ok {
+		return 0, false
+	}
+	profile, ok := s.profiles.find(func(p domain.Profile) bool { return p.ProfileID == group.ProfileID })
+	if !ok {
+		return 0, false
+	}
+	specialty, ok := s.specialties.find(func(sp domain.Specialty) bool { return sp.SpecialtyCode == profile.SpecialtyCode })
+	if !ok {
+		return 0, false
+	}
+	departament, ok := s.departaments.find(func(d domain.Departament) bool { return d.DepartamentID == specialty.DepartamentID })
+	if !ok {
+		return 0, false
+	}
+	faculty, ok := s.faculties.find(func(f domain.Faculty) bool { return f.FacultyID == departament.FacultyID })
+	if !ok {
+		return 0, false
+	}
+	return faculty.UniversityID, true
+}
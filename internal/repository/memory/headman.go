@@ -0,0 +1,222 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+const (
+	roleStudent = "Студент"
+	roleHeadman = "Староста"
+)
+
+type HeadmanRepo struct {
+	s *store
+}
+
+func (r *HeadmanRepo) Create(ctx context.Context, headman domain.Headman) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	headman.HeadmanID = r.s.next("headmans")
+	r.s.headmen.insert(headmanDates(headman))
+	return nil
+}
+
+func (r *HeadmanRepo) Put(ctx context.Context, headman domain.Headman) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.headmen, r.byID(headman.HeadmanID), func(row *domain.Headman) error {
+		*row = headmanDates(headman)
+		return nil
+	}, noUnique[domain.Headman])
+}
+
+func (r *HeadmanRepo) Patch(ctx context.Context, headmanID int64, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.headmen, r.byID(headmanID), func(row *domain.Headman) error {
+		if err := patch(row, updates); err != nil {
+			return err
+		}
+		*row = headmanDates(*row)
+		return nil
+	}, noUnique[domain.Headman])
+}
+
+func (r *HeadmanRepo) Delete(ctx context.Context, headmanID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.headmen.remove(r.byID(headmanID))
+	return nil
+}
+
+func (r *HeadmanRepo) GetByID(ctx context.Context, headmanID int64) (domain.HeadmanInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	headman, err := getRow(&r.s.headmen, r.byID(headmanID))
+	return r.s.headmanInfo(headman), err
+}
+
+// GetByStudentID returns the latest term of the student
+func (r *HeadmanRepo) GetByStudentID(ctx context.Context, studentID int64) (domain.HeadmanInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	headmen := r.sorted(func(h domain.Headman) bool { return h.StudentID == studentID })
+	if len(headmen) == 0 {
+		return domain.HeadmanInfo{}, errNoRows()
+	}
+	return r.s.headmanInfo(headmen[len(headmen)-1]), nil
+}
+
+func (r *HeadmanRepo) GetAll(ctx context.Context) ([]domain.HeadmanInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.headmen.all()), nil
+}
+
+func (r *HeadmanRepo) GetAllByGroupID(ctx context.Context, groupID string) ([]domain.HeadmanInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.sorted(func(h domain.Headman) bool { return h.GroupID == groupID })), nil
+}
+
+func (r *HeadmanRepo) GetAllByStudentID(ctx context.Context, studentID int64) ([]domain.HeadmanInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.sorted(func(h domain.Headman) bool { return h.StudentID == studentID })), nil
+}
+
+func (r *HeadmanRepo) IsActive(ctx context.Context, studentID int64, groupID string, date time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.headmen.exists(func(h domain.Headman) bool {
+		return h.StudentID == studentID && h.GroupID == groupID && h.IsActiveOn(date)
+	}), nil
+}
+
+// SyncRoles demotes the accounts of ended terms before promoting the accounts of active ones
+func (r *HeadmanRepo) Demote(ctx context.Context, headmanID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	headman, ok := r.s.headmen.find(r.byID(headmanID))
+	if !ok || r.s.users.exists(func(u domain.User) bool { return u.StudentID != nil && *u.StudentID == headman.StudentID }) {
+		return nil
+	}
+	for i := range r.s.users.rows {
+		user := &r.s.users.rows[i]
+		if user.HeadmanID == nil || *user.HeadmanID != headmanID || user.Role != roleHeadman {
+			continue
+		}
+		studentID := headman.StudentID
+		user.Role = roleStudent
+		user.StudentID = &studentID
+		user.HeadmanID = nil
+	}
+	return nil
+}
+
+func (r *HeadmanRepo) SyncRoles(ctx context.Context, date time.Time) (demoted int64, promoted int64, err error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for i := range r.s.users.rows {
+		user := &r.s.users.rows[i]
+		if user.HeadmanID == nil || user.Role != roleHeadman {
+			continue
+		}
+		headman, ok := r.s.headmen.find(r.byID(*user.HeadmanID))
+		if !ok || headman.IsActiveOn(date) || r.s.users.exists(func(u domain.User) bool {
+			return u.StudentID != nil && *u.StudentID == headman.StudentID
+		}) {
+			continue
+		}
+		studentID := headman.StudentID
+		user.Role = roleStudent
+		user.StudentID = &studentID
+		user.HeadmanID = nil
+		demoted++
+	}
+
+	for i := range r.s.users.rows {
+		user := &r.s.users.rows[i]
+		if user.StudentID == nil || user.Role != roleStudent {
+			continue
+		}
+		studentID := *user.StudentID
+		headman, ok := r.s.headmen.find(func(h domain.Headman) bool {
+			return h.StudentID == studentID && h.IsActiveOn(date) && !r.s.users.exists(func(u domain.User) bool {
+				return u.HeadmanID != nil && *u.HeadmanID == h.HeadmanID
+			})
+		})
+		if !ok {
+			continue
+		}
+		headmanID := headman.HeadmanID
+		user.Role = roleHeadman
+		user.HeadmanID = &headmanID
+		user.StudentID = nil
+		promoted++
+	}
+	return demoted, promoted, nil
+}
+
+// sorted returns the matching terms ordered by the start of the term
+func (r *HeadmanRepo) sorted(match func(domain.Headman) bool) []domain.Headman {
+	headmen := r.s.headmen.filter(match)
+	sort.SliceStable(headmen, func(i, j int) bool {
+		a, b := headmen[i], headmen[j]
+		if !a.TermStart.Equal(b.TermStart) {
+			return a.TermStart.Before(b.TermStart)
+		}
+		return a.HeadmanID < b.HeadmanID
+	})
+	return headmen
+}
+
+func (r *HeadmanRepo) infos(headmen []domain.Headman) []domain.HeadmanInfo {
+	infos := make([]domain.HeadmanInfo, 0, len(headmen))
+	for _, headman := range headmen {
+		infos = append(infos, r.s.headmanInfo(headman))
+	}
+	return infos
+}
+
+func (r *HeadmanRepo) byID(headmanID int64) func(domain.Headman) bool {
+	return func(h domain.Headman) bool { return h.HeadmanID == headmanID }
+}
+
+// headmanDates keeps the days of the term like the DATE columns
+func headmanDates(headman domain.Headman) domain.Headman {
+	headman.TermStart = date(headman.TermStart)
+	headman.TermEnd = optionalDate(headman.TermEnd)
+	return headman
+}
+
+func (s *store) headmanInfo(headman domain.Headman) domain.HeadmanInfo {
+	info := domain.HeadmanInfo{Headman: headman}
+	if student, ok := s.student(headman.StudentID); ok {
+		info.HeadmanSub.Student = domain.StudentFullName{
+			LastName:   student.LastName,
+			FirstName:  student.FirstName,
+			MiddleName: student.MiddleName,
+		}
+	}
+	if s.groups.exists(byGroupID(headman.GroupID)) {
+		info.HeadmanSub.GroupName = headman.GroupID
+	}
+	return info
+}
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type LessonSlotRepo struct {
+	s *store
+}
+
+func (r *LessonSlotRepo) Create(ctx context.Context, slot domain.LessonSlot) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	slot = slotColumns(slot)
+	slot.SlotID = 0
+	if err := r.unique(slot); err != nil {
+		return 0, err
+	}
+	slot.SlotID = r.s.next("lesson_slots")
+	r.s.slots.insert(slot)
+	return slot.SlotID, nil
+}
+
+// Put updates the slot and moves the schedules of the slot to its new start time
+func (r *LessonSlotRepo) Put(ctx context.Context, slot domain.LessonSlot) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	slot = slotColumns(slot)
+	err := updateRow(&r.s.slots, r.byID(slot.SlotID), func(row *domain.LessonSlot) error {
+		*row = slot
+		return nil
+	}, r.unique)
+	if err != nil {
+		return err
+	}
+	for i := range r.s.schedules.rows {
+		schedule := &r.s.schedules.rows[i]
+		if schedule.SlotID != nil && *schedule.SlotID == slot.SlotID {
+			schedule.StartTime = slot.StartTime
+		}
+	}
+	return nil
+}
+
+func (r *LessonSlotRepo) unique(slot domain.LessonSlot) error {
+	others := func(match func(domain.LessonSlot) bool) bool {
+		return r.s.slots.exists(func(l domain.LessonSlot) bool {
+			return l.SlotID != slot.SlotID && l.UniversityID == slot.UniversityID && match(l)
+		})
+	}
+	if others(func(l domain.LessonSlot) bool { return l.SlotNumber == slot.SlotNumber }) {
+		return uniqueViolation("U_lesson_slots_number")
+	}
+	if others(func(l domain.LessonSlot) bool { return l.StartTime.Equal(slot.StartTime) }) {
+		return uniqueViolation("U_lesson_slots_start_time")
+	}
+	return nil
+}
+
+func (r *LessonSlotRepo) Delete(ctx context.Context, slotID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.slots.remove(r.byID(slotID))
+	return nil
+}
+
+func (r *LessonSlotRepo) GetByID(ctx context.Context, slotID int64) (domain.LessonSlot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return getRow(&r.s.slots, r.byID(slotID))
+}
+
+func (r *LessonSlotRepo) GetByUniversityID(ctx context.Context, universityID int64) ([]domain.LessonSlot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.byUniversity(universityID), nil
+}
+
+func (r *LessonSlotRepo) GetByGroupID(ctx context.Context, groupID string) ([]domain.LessonSlot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	universityID, ok := r.s.groupUniversityID(groupID)
+	if !ok {
+		return []domain.LessonSlot{}, nil
+	}
+	return r.byUniversity(universityID), nil
+}
+
+func (r *LessonSlotRepo) byUniversity(universityID int64) []domain.LessonSlot {
+	slots := r.s.slots.filter(func(l domain.LessonSlot) bool { return l.UniversityID == universityID })
+	sort.SliceStable(slots, func(i, j int) bool { return slots[i].SlotNumber < slots[j].SlotNumber })
+	return slots
+}
+
+func (r *LessonSlotRepo) byID(slotID int64) func(domain.LessonSlot) bool {
+	return func(l domain.LessonSlot) bool { return l.SlotID == slotID }
+}
+
+func slotColumns(slot domain.LessonSlot) domain.LessonSlot {
+	slot.StartTime = clock(slot.StartTime)
+	slot.EndTime = clock(slot.EndTime)
+	return slot
+}
@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+const (
+	reasonEnrollment = "enrollment"
+	reasonTransfer   = "transfer"
+	reasonPromotion  = "promotion"
+)
+
+type MembershipRepo struct {
+	s *store
+}
+
+func (r *MembershipRepo) GetByStudentID(ctx context.Context, studentID int64) ([]domain.GroupMembership, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	memberships := r.s.memberships.filter(func(m domain.GroupMembership) bool { return m.StudentID == studentID })
+	sort.SliceStable(memberships, func(i, j int) bool {
+		a, b := memberships[i], memberships[j]
+		if !a.ValidFrom.Equal(b.ValidFrom) {
+			return a.ValidFrom.Before(b.ValidFrom)
+		}
+		return a.HistoryID < b.HistoryID
+	})
+	return memberships, nil
+}
+
+func (r *MembershipRepo) Transfer(ctx context.Context, transfer domain.StudentTransfer) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	reason := transfer.Reason
+	if reason == "" {
+		reason = reasonTransfer
+	}
+	r.s.moveStudents([]int64{transfer.StudentID}, transfer.GroupID, transfer.Date, reason)
+	return nil
+}
+
+// Promote checks the new groups before any change, the students are selected before any move
+func (r *MembershipRepo) Promote(ctx context.Context, promotions []domain.GroupPromotion, groups []domain.Group, date time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	created := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		if created[group.GroupID] || r.s.groups.exists(byGroupID(group.GroupID)) {
+			return uniqueViolation("PK_groups")
+		}
+		created[group.GroupID] = true
+	}
+	for _, group := range groups {
+		r.s.groups.insert(group)
+	}
+
+	students := make([][]int64, len(promotions))
+	for i, promotion := range promotions {
+		for _, student := range r.s.students.filter(func(s domain.Student) bool { return s.GroupID == promotion.FromGroupID }) {
+			students[i] = append(students[i], student.StudentID)
+		}
+	}
+	for i, promotion := range promotions {
+		r.s.moveStudents(students[i], promotion.ToGroupID, date, reasonPromotion)
+	}
+	return nil
+}
+
+// moveStudents closes the current memberships of the students at the date and opens
+// memberships in the group. Students without history get it from their current group
+func (s *store) moveStudents(studentIDs []int64, groupID string, at time.Time, reason string) {
+	day := date(at)
+	for _, studentID := range studentIDs {
+		student, ok := s.students.find(byStudentID(studentID))
+		if !ok {
+			continue
+		}
+		if !s.memberships.exists(func(m domain.GroupMembership) bool { return m.StudentID == studentID }) {
+			s.memberships.insert(domain.GroupMembership{
+				HistoryID: s.next("student_group_history"),
+				StudentID: studentID,
+				GroupID:   student.GroupID,
+				ValidFrom: time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+			})
+		}
+		for i := range s.memberships.rows {
+			membership := &s.memberships.rows[i]
+			if membership.StudentID == studentID && membership.ValidTo == nil {
+				validTo := day
+				membership.ValidTo = &validTo
+			}
+		}
+		newReason := reason
+		s.memberships.insert(domain.GroupMembership{
+			HistoryID: s.next("student_group_history"),
+			StudentID: studentID,
+			GroupID:   groupID,
+			ValidFrom: day,
+			Reason:    &newReason,
+		})
+		student.GroupID = groupID
+	}
+}
+
+// changeGroup keeps the history when a student is moved by editing the student,
+// the move takes effect today
+func (s *store) changeGroup(studentID int64, groupID string) {
+	student, ok := s.students.find(byStudentID(studentID))
+	if !ok || student.GroupID == groupID {
+		return
+	}
+	s.moveStudents([]int64{studentID}, groupID, time.Now(), reasonTransfer)
+}
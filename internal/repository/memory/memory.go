@@ -0,0 +1,356 @@
+// Package memory implements the repositories in memory for tests. The repositories share one store
+// and keep the semantics of the Postgres ones: the lookups of a single row return pgx.ErrNoRows,
+// inserts and updates breaking a unique constraint return a *pgconn.PgError with the code 23505,
+// updates and deletes of missing rows do nothing. Foreign keys are not checked.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// store holds the tables of all repositories, every repository method locks it once
+type store struct {
+	mu  sync.Mutex
+	ids map[string]int64
+
+	universities        table[domain.University]
+	faculties           table[domain.Faculty]
+	departaments        table[domain.Departament]
+	teachers            table[domain.Teacher]
+	disciplines         table[domain.Discipline]
+	disciplineTypes     table[domain.DisciplineType]
+	classrooms          table[domain.Classroom]
+	educationLevels     table[domain.EducationLevel]
+	educationTypes      table[domain.EducationType]
+	specialties         table[domain.Specialty]
+	profiles            table[domain.Profile]
+	groups              table[domain.Group]
+	students            table[domain.Student]
+	memberships         table[domain.GroupMembership]
+	headmen             table[domain.Headman]
+	users               table[domain.User]
+	resetTokens         table[domain.PasswordResetToken]
+	schedules           table[domain.Schedule]
+	exceptions          table[domain.ScheduleException]
+	attendance          table[domain.Attendance]
+	calendar            table[domain.CalendarPeriod]
+	slots               table[domain.LessonSlot]
+	subgroups           table[domain.Subgroup]
+	curriculum          table[domain.CurriculumItem]
+	marks               table[domain.Mark]
+	controlPoints       table[domain.ControlPoint]
+	controlPointResults table[domain.ControlPointResult]
+	finalResults        table[domain.FinalResult]
+}
+
+// NewRepositories returns empty repositories sharing one store
+func NewRepositories() *repository.Repositories {
+	s := &store{ids: make(map[string]int64)}
+	return &repository.Repositories{
+		Transactor:        &Transactor{s},
+		Student:           &StudentRepo{s},
+		Schedule:          &ScheduleRepo{s},
+		Headman:           &HeadmanRepo{s},
+		Attendance:        &AttendanceRepo{s},
+		User:              &UserRepo{s},
+		University:        &UniversityRepo{s},
+		Faculty:           &FacultyRepo{s},
+		Departament:       &DepartamentRepo{s},
+		Teacher:           &TeacherRepo{s},
+		Discipline:        &DisciplineRepo{s},
+		DisciplineType:    &DisciplineTypeRepo{s},
+		Classroom:         &ClassroomRepo{s},
+		EducationLevel:    &EducationLevelRepo{s},
+		Specialty:         &SpecialtyRepo{s},
+		Profile:           &ProfileRepo{s},
+		Group:             &GroupRepo{s},
+		EducationType:     &EducationTypeRepo{s},
+		Report:            &ReportRepo{s},
+		PasswordReset:     &PasswordResetRepo{s},
+		Membership:        &MembershipRepo{s},
+		ScheduleException: &ScheduleExceptionRepo{s},
+		Calendar:          &CalendarRepo{s},
+		LessonSlot:        &LessonSlotRepo{s},
+		Subgroup:          &SubgroupRepo{s},
+		Curriculum:        &CurriculumRepo{s},
+		Workload:          &WorkloadRepo{s},
+		Gradebook:         &GradebookRepo{s},
+	}
+}
+
+// Transactor restores the store when the function of a transaction fails. The store isn't
+// locked while the function runs, so it gives atomicity without isolation: a rollback also
+// undoes the changes other goroutines made meanwhile. Nested calls work like savepoints
+type Transactor struct {
+	s *store
+}
+
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	saved := t.s.snapshot()
+	if err := fn(ctx); err != nil {
+		t.s.restore(saved)
+		return err
+	}
+	return nil
+}
+
+// snapshot copies the rows of the store, the sequences aren't rolled back like in Postgres
+func (s *store) snapshot() *store {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := &store{}
+	copyTables(saved, s)
+	return saved
+}
+
+func (s *store) restore(saved *store) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copyTables(s, saved)
+}
+
+// copyTables copies the rows of every table, the rows are updated in place so they can't be shared
+func copyTables(dst, src *store) {
+	dst.universities.rows = slices.Clone(src.universities.rows)
+	dst.faculties.rows = slices.Clone(src.faculties.rows)
+	dst.departaments.rows = slices.Clone(src.departaments.rows)
+	dst.teachers.rows = slices.Clone(src.teachers.rows)
+	dst.disciplines.rows = slices.Clone(src.disciplines.rows)
+	dst.disciplineTypes.rows = slices.Clone(src.disciplineTypes.rows)
+	dst.classrooms.rows = slices.Clone(src.classrooms.rows)
+	dst.educationLevels.rows = slices.Clone(src.educationLevels.rows)
+	dst.educationTypes.rows = slices.Clone(src.educationTypes.rows)
+	dst.specialties.rows = slices.Clone(src.specialties.rows)
+	dst.profiles.rows = slices.Clone(src.profiles.rows)
+	dst.groups.rows = slices.Clone(src.groups.rows)
+	dst.students.rows = slices.Clone(src.students.rows)
+	dst.memberships.rows = slices.Clone(src.memberships.rows)
+	dst.headmen.rows = slices.Clone(src.headmen.rows)
+	dst.users.rows = slices.Clone(src.users.rows)
+	dst.resetTokens.rows = slices.Clone(src.resetTokens.rows)
+	dst.schedules.rows = slices.Clone(src.schedules.rows)
+	dst.exceptions.rows = slices.Clone(src.exceptions.rows)
+	dst.attendance.rows = slices.Clone(src.attendance.rows)
+	dst.calendar.rows = slices.Clone(src.calendar.rows)
+	dst.slots.rows = slices.Clone(src.slots.rows)
+	dst.subgroups.rows = slices.Clone(src.subgroups.rows)
+	dst.curriculum.rows = slices.Clone(src.curriculum.rows)
+	dst.marks.rows = slices.Clone(src.marks.rows)
+	dst.controlPoints.rows = slices.Clone(src.controlPoints.rows)
+	dst.controlPointResults.rows = slices.Clone(src.controlPointResults.rows)
+	dst.finalResults.rows = slices.Clone(src.finalResults.rows)
+}
+
+// next returns the next value of the sequence like BIGSERIAL does
+func (s *store) next(sequence string) int64 {
+	s.ids[sequence]++
+	return s.ids[sequence]
+}
+
+// table is the rows of a table in the order of insertion
+type table[T any] struct {
+	rows []T
+}
+
+func (t *table[T]) insert(row T) {
+	t.rows = append(t.rows, row)
+}
+
+// find returns the first matching row for an update in place
+func (t *table[T]) find(match func(T) bool) (*T, bool) {
+	for i := range t.rows {
+		if match(t.rows[i]) {
+			return &t.rows[i], true
+		}
+	}
+	return nil, false
+}
+
+func (t *table[T]) exists(match func(T) bool) bool {
+	_, ok := t.find(match)
+	return ok
+}
+
+func (t *table[T]) filter(match func(T) bool) []T {
+	rows := make([]T, 0)
+	for _, row := range t.rows {
+		if match(row) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func (t *table[T]) all() []T {
+	return t.filter(func(T) bool { return true })
+}
+
+func (t *table[T]) remove(match func(T) bool) {
+	rows := t.rows[:0]
+	for _, row := range t.rows {
+		if !match(row) {
+			rows = append(rows, row)
+		}
+	}
+	t.rows = rows
+}
+
+// getRow returns the first matching row or pgx.ErrNoRows
+func getRow[T any](t *table[T], match func(T) bool) (T, error) {
+	row, ok := t.find(match)
+	if !ok {
+		var zero T
+		return zero, errNoRows()
+	}
+	return *row, nil
+}
+
+// errNoRows is the error of a single row lookup without rows
+func errNoRows() error {
+	return pgx.ErrNoRows
+}
+
+// updateRow changes the first matching row when the changed row keeps the unique constraints,
+// a missing row is not an error like an UPDATE of no rows
+func updateRow[T any](t *table[T], match func(T) bool, change func(row *T) error, unique func(row T) error) error {
+	row, ok := t.find(match)
+	if !ok {
+		return nil
+	}
+	updated := *row
+	if err := change(&updated); err != nil {
+		return err
+	}
+	if err := unique(updated); err != nil {
+		return err
+	}
+	*row = updated
+	return nil
+}
+
+// uniqueViolation is the error Postgres returns for a duplicate key
+func uniqueViolation(constraint string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           "23505",
+		Message:        fmt.Sprintf("duplicate key value violates unique constraint %q", constraint),
+		ConstraintName: constraint,
+	}
+}
+
+// patch sets the fields of the row named by the columns of the updates, a column matches
+// the json tag of a field or the snake case of its name
+func patch(row interface{}, updates map[string]interface{}) error {
+	value := reflect.ValueOf(row).Elem()
+	for column, update := range updates {
+		field, ok := fieldByColumn(value, column)
+		if !ok {
+			return &pgconn.PgError{Severity: "ERROR", Code: "42703", Message: fmt.Sprintf("column %q does not exist", column)}
+		}
+		if err := assign(field, update); err != nil {
+			return fmt.Errorf("column %q: %w", column, err)
+		}
+	}
+	return nil
+}
+
+func fieldByColumn(value reflect.Value, column string) (reflect.Value, bool) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == column || snakeCase(field.Name) == column {
+			return value.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// assign stores the update into the field, a nil update clears a pointer field
+// and a plain value is stored behind the pointer
+func assign(field reflect.Value, update interface{}) error {
+	if update == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	value := reflect.ValueOf(update)
+	if value.Kind() == reflect.Pointer && field.Kind() != reflect.Pointer {
+		if value.IsNil() {
+			field.Set(reflect.Zero(field.Type()))
+			return nil
+		}
+		value = value.Elem()
+	}
+	if field.Kind() == reflect.Pointer && value.Kind() != reflect.Pointer {
+		if !value.Type().ConvertibleTo(field.Type().Elem()) {
+			return fmt.Errorf("can't assign %s to %s", value.Type(), field.Type())
+		}
+		pointer := reflect.New(field.Type().Elem())
+		pointer.Elem().Set(value.Convert(field.Type().Elem()))
+		field.Set(pointer)
+		return nil
+	}
+	if !value.Type().ConvertibleTo(field.Type()) {
+		return fmt.Errorf("can't assign %s to %s", value.Type(), field.Type())
+	}
+	field.Set(value.Convert(field.Type()))
+	return nil
+}
+
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// date keeps the day like a DATE column
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// clock keeps the time of day like a TIME column, pgx reads it on January 1, 2000
+func clock(t time.Time) time.Time {
+	return time.Date(2000, time.January, 1, t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+func optionalDate(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	d := date(*t)
+	return &d
+}
+
+func optionalClock(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := clock(*t)
+	return &c
+}
+
+func fullName(lastName, firstName, middleName string) string {
+	return lastName + " " + firstName + " " + middleName
+}
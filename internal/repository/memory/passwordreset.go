@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/google/uuid"
+)
+
+type PasswordResetRepo struct {
+	s *store
+}
+
+func (r *PasswordResetRepo) Create(ctx context.Context, token domain.PasswordResetToken) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if r.s.resetTokens.exists(func(t domain.PasswordResetToken) bool { return t.TokenHash == token.TokenHash }) {
+		return uniqueViolation("U_password_reset_tokens_token_hash")
+	}
+	token.TokenID = r.s.next("password_reset_tokens")
+	token.UsedAt = nil
+	token.Created = time.Now()
+	r.s.resetTokens.insert(token)
+	return nil
+}
+
+func (r *PasswordResetRepo) Consume(ctx context.Context, tokenHash string, now time.Time) (uuid.UUID, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	token, ok := r.s.resetTokens.find(func(t domain.PasswordResetToken) bool {
+		return t.TokenHash == tokenHash && t.UsedAt == nil && t.ExpiresAt.After(now)
+	})
+	if !ok {
+		return uuid.Nil, errNoRows()
+	}
+	usedAt := now
+	token.UsedAt = &usedAt
+	return token.UserID, nil
+}
+
+func (r *PasswordResetRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.resetTokens.remove(func(t domain.PasswordResetToken) bool { return t.UserID == userID })
+	return nil
+}
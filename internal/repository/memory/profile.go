@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type ProfileRepo struct {
+	s *store
+}
+
+func (r *ProfileRepo) Create(ctx context.Context, profile domain.Profile) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	profile.ProfileID = 0
+	if err := r.unique(profile); err != nil {
+		return err
+	}
+	profile.ProfileID = r.s.next("profiles")
+	r.s.profiles.insert(profile)
+	return nil
+}
+
+func (r *ProfileRepo) Put(ctx context.Context, profile domain.Profile) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.profiles, r.byID(profile.ProfileID), func(row *domain.Profile) error {
+		*row = profile
+		return nil
+	}, r.unique)
+}
+
+func (r *ProfileRepo) Patch(ctx context.Context, profileID int64, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.profiles, r.byID(profileID), func(row *domain.Profile) error {
+		return patch(row, updates)
+	}, r.unique)
+}
+
+func (r *ProfileRepo) unique(profile domain.Profile) error {
+	if r.s.profiles.exists(func(p domain.Profile) bool {
+		return p.ProfileID != profile.ProfileID && p.ProfileName == profile.ProfileName
+	}) {
+		return uniqueViolation("U_profiles_profile_name")
+	}
+	return nil
+}
+
+func (r *ProfileRepo) Delete(ctx context.Context, profileID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.profiles.remove(r.byID(profileID))
+	return nil
+}
+
+func (r *ProfileRepo) GetByID(ctx context.Context, profileID int64) (domain.ProfileInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	profile, err := getRow(&r.s.profiles, r.byID(profileID))
+	return r.s.profileInfo(profile), err
+}
+
+func (r *ProfileRepo) GetByName(ctx context.Context, profileName string) (domain.ProfileInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	profile, err := getRow(&r.s.profiles, func(p domain.Profile) bool { return p.ProfileName == profileName })
+	return r.s.profileInfo(profile), err
+}
+
+func (r *ProfileRepo) GetAll(ctx context.Context) ([]domain.ProfileInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.profiles.all()), nil
+}
+
+func (r *ProfileRepo) GetAllBySpecialtyCode(ctx context.Context, specialtyCode string) ([]domain.ProfileInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.profiles.filter(func(p domain.Profile) bool { return p.SpecialtyCode == specialtyCode })), nil
+}
+
+func (r *ProfileRepo) GetByEducationTypeID(ctx context.Context, educationTypeID int64) ([]domain.ProfileInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.profiles.filter(func(p domain.Profile) bool { return p.EducationTypeID == educationTypeID })), nil
+}
+
+func (r *ProfileRepo) infos(profiles []domain.Profile) []domain.ProfileInfo {
+	infos := make([]domain.ProfileInfo, 0, len(profiles))
+	for _, profile := range profiles {
+		infos = append(infos, r.s.profileInfo(profile))
+	}
+	return infos
+}
+
+func (r *ProfileRepo) byID(profileID int64) func(domain.Profile) bool {
+	return func(p domain.Profile) bool { return p.ProfileID == profileID }
+}
+
+func (s *store) profileInfo(profile domain.Profile) domain.ProfileInfo {
+	return domain.ProfileInfo{
+		Profile:    profile,
+		ProfileSub: domain.ProfileSub{EducationTypeName: s.educationTypeName(profile.EducationTypeID)},
+	}
+}
+
+func (s *store) profileName(profileID int64) string {
+	if profile, ok := s.profiles.find(func(p domain.Profile) bool { return p.ProfileID == profileID }); ok {
+		return profile.ProfileName
+	}
+	return ""
+}
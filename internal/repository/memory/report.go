@@ -0,0 +1,232 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type ReportRepo struct {
+	s *store
+}
+
+// GetActualReportByGroupIDCreated returns the attendance of the students who belonged to the group
+// on the day of the lesson, only the teaching days in the range are reported
+func (r *ReportRepo) GetActualReportByGroupIDCreated(ctx context.Context, groupID string, startRange time.Time, endRange time.Time) (*domain.AttendanceReport, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	head, ok := r.head(groupID)
+	if !ok {
+		return nil, errNoRows()
+	}
+
+	// the totals count the same lessons as the rows of the report
+	reported := func(attendance domain.Attendance) bool {
+		if attendance.Created.Before(startRange) || attendance.Created.After(endRange) ||
+			!r.s.isTeachingDay(groupID, attendance.Created) || !r.s.memberOn(attendance.StudentID, groupID, attendance.Created) {
+			return false
+		}
+		schedule, ok := r.s.schedule(attendance.ScheduleID)
+		return ok && r.s.attends(schedule, attendance.StudentID)
+	}
+
+	type counts struct {
+		passes, visits, total int64
+	}
+	subatt := make(map[[2]int64]*counts)
+	for _, attendance := range r.s.attendance.rows {
+		if attendance.Presence == nil || !reported(attendance) {
+			continue
+		}
+		key := [2]int64{attendance.StudentID, attendance.ScheduleID}
+		c, ok := subatt[key]
+		if !ok {
+			c = &counts{}
+			subatt[key] = c
+		}
+		c.total++
+		if *attendance.Presence {
+			c.visits++
+		} else {
+			c.passes++
+		}
+	}
+
+	data := make([]domain.ReportData, 0)
+	lastNames := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, attendance := range r.s.attendance.rows {
+		if !reported(attendance) {
+			continue
+		}
+		c, ok := subatt[[2]int64{attendance.StudentID, attendance.ScheduleID}]
+		if !ok {
+			continue
+		}
+		row, lastName, ok := r.row(attendance)
+		if !ok {
+			continue
+		}
+		row.Visits, row.Passes, row.Total = c.visits, c.passes, c.total
+		if c.total > 0 {
+			row.PercentageOfVisits = math.Round(float64(c.visits)*100/float64(c.total)*100) / 100
+		}
+
+		key := reportRowKey(row)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		data = append(data, row)
+		lastNames = append(lastNames, lastName)
+	}
+
+	order := make([]int, len(data))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return lastNames[order[i]] < lastNames[order[j]] })
+	reportData := make([]domain.ReportData, 0, len(data))
+	for _, i := range order {
+		reportData = append(reportData, data[i])
+	}
+
+	return &domain.AttendanceReport{ReportHead: head, ReportData: reportData}, nil
+}
+
+// row joins the schedule and the names of the attendance, an attendance missing any of them
+// is skipped like by INNER JOIN, a lesson of a subgroup is reported only for its students
+func (r *ReportRepo) row(attendance domain.Attendance) (domain.ReportData, string, bool) {
+	student, ok := r.s.student(attendance.StudentID)
+	if !ok {
+		return domain.ReportData{}, "", false
+	}
+	schedule, ok := r.s.schedule(attendance.ScheduleID)
+	if !ok || !r.s.attends(schedule, attendance.StudentID) {
+		return domain.ReportData{}, "", false
+	}
+	disciplineTypeName, ok := r.s.disciplineTypeName(schedule.DisciplineTypeID)
+	if !ok {
+		return domain.ReportData{}, "", false
+	}
+	classroomName, ok := r.s.classroomName(schedule.ClassroomID)
+	if !ok {
+		return domain.ReportData{}, "", false
+	}
+	disciplineName, ok := r.s.disciplineName(schedule.DisciplineID)
+	if !ok {
+		return domain.ReportData{}, "", false
+	}
+	teacher, ok := r.s.teacher(schedule.TeacherID)
+	if !ok {
+		return domain.ReportData{}, "", false
+	}
+
+	return domain.ReportData{
+		Semester:           int64(schedule.Semester),
+		WeekType:           schedule.WeekType,
+		DayOfWeek:          schedule.DayOfWeek,
+		DisciplineName:     disciplineName,
+		DisciplineTypeName: disciplineTypeName,
+		StartTime:          schedule.StartTime,
+		ClassroomName:      classroomName,
+		TeacherName:        fullName(teacher.LastName, teacher.FirstName, teacher.MiddleName),
+		StudentName:        fullName(student.LastName, student.FirstName, student.MiddleName),
+		Presence:           attendance.Presence,
+		LateArrival:        attendance.LateArrival,
+		Respectfulness:     attendance.Respectfulness,
+		Reason:             attendance.Reason,
+		Created:            attendance.Created,
+	}, student.LastName, true
+}
+
+// head joins the group up to the university, nothing is returned when a link is missing
+func (r *ReportRepo) head(groupID string) (domain.ReportHead, bool) {
+	group, ok := r.s.groups.find(byGroupID(groupID))
+	if !ok {
+		return domain.ReportHead{}, false
+	}
+	profile, ok := r.s.profiles.find(func(p domain.Profile) bool { return p.ProfileID == group.ProfileID })
+	if !ok {
+		return domain.ReportHead{}, false
+	}
+	educationType, ok := r.s.educationTypes.find(func(e domain.EducationType) bool { return e.EducationTypeID == profile.EducationTypeID })
+	if !ok {
+		return domain.ReportHead{}, false
+	}
+	specialty, ok := r.s.specialties.find(func(sp domain.Specialty) bool { return sp.SpecialtyCode == profile.SpecialtyCode })
+	if !ok {
+		return domain.ReportHead{}, false
+	}
+	educationLevel, ok := r.s.educationLevels.find(func(e domain.EducationLevel) bool { return e.EducationLevelID == specialty.EducationLevelID })
+	if !ok {
+		return domain.ReportHead{}, false
+	}
+	departament, ok := r.s.departaments.find(func(d domain.Departament) bool { return d.DepartamentID == specialty.DepartamentID })
+	if !ok {
+		return domain.ReportHead{}, false
+	}
+	faculty, ok := r.s.faculties.find(func(f domain.Faculty) bool { return f.FacultyID == departament.FacultyID })
+	if !ok {
+		return domain.ReportHead{}, false
+	}
+	university, ok := r.s.universities.find(func(u domain.University) bool { return u.UniversityID == faculty.UniversityID })
+	if !ok {
+		return domain.ReportHead{}, false
+	}
+
+	return domain.ReportHead{
+		UniversityName:     university.UniversityName,
+		UniversityHead:     fullName(university.HeadLastName, university.HeadFirstName, university.HeadMiddleName),
+		FacultyName:        faculty.FacultyName,
+		FacultyHead:        fullName(faculty.HeadLastName, faculty.HeadFirstName, faculty.HeadMiddleName),
+		DepartamentName:    departament.DepartamentName,
+		DepartamentHead:    fullName(departament.HeadLastName, departament.HeadFirstName, departament.HeadMiddleName),
+		GroupID:            group.GroupID,
+		SpecialtyName:      specialty.SpecialtyName,
+		EducationLevelName: educationLevel.EducationLevelName,
+		ProfileName:        profile.ProfileName,
+		EducationTypeName:  educationType.EducationTypeName,
+	}, true
+}
+
+// memberOn reports whether the student belonged to the group on the day, a student
+// without history belongs to the current group
+func (s *store) memberOn(studentID int64, groupID string, at time.Time) bool {
+	day := date(at)
+	hasHistory := false
+	for _, membership := range s.memberships.rows {
+		if membership.StudentID != studentID {
+			continue
+		}
+		hasHistory = true
+		if membership.GroupID == groupID && !membership.ValidFrom.After(day) &&
+			(membership.ValidTo == nil || day.Before(*membership.ValidTo)) {
+			return true
+		}
+	}
+	if hasHistory {
+		return false
+	}
+	student, ok := s.student(studentID)
+	return ok && student.GroupID == groupID
+}
+
+// reportRowKey is the GROUP BY of the report query, equal rows are reported once
+func reportRowKey(row domain.ReportData) string {
+	values := fmt.Sprint(deref(row.Presence), deref(row.LateArrival), deref(row.Respectfulness), deref(row.Reason))
+	row.Presence, row.LateArrival, row.Respectfulness, row.Reason = nil, nil, nil, nil
+	return fmt.Sprintf("%v|%s|%d", row, values, row.Created.UnixNano())
+}
+
+func deref[T any](value *T) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
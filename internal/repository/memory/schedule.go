@@ -0,0 +1,315 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type ScheduleRepo struct {
+	s *store
+}
+
+func (r *ScheduleRepo) Create(ctx context.Context, schedule domain.Schedule) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.createSchedule(schedule)
+	return nil
+}
+
+// CreateMany takes the slots from the bell schedule of the group's university,
+// the start of studies and the subgroups are not stored like in the database
+func (r *ScheduleRepo) CreateMany(ctx context.Context, schedules []domain.Schedule) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, schedule := range schedules {
+		schedule.BeginStudies = time.Time{}
+		schedule.SubgroupID = nil
+		schedule.SlotID = r.s.slotByStartTime(schedule.GroupID, schedule)
+		r.s.createSchedule(schedule)
+	}
+	return nil
+}
+
+func (r *ScheduleRepo) Rollover(ctx context.Context, semester int, schedules []domain.Schedule) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	archived := false
+	for i := range r.s.schedules.rows {
+		schedule := &r.s.schedules.rows[i]
+		if schedule.Semester == semester && isActual(*schedule) {
+			schedule.IsActual = &archived
+		}
+	}
+	for _, schedule := range schedules {
+		schedule.SlotID = r.s.slotByStartTime(schedule.GroupID, schedule)
+		r.s.createSchedule(schedule)
+	}
+	return nil
+}
+
+func (r *ScheduleRepo) Put(ctx context.Context, schedule domain.Schedule) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.schedules, r.byID(schedule.ScheduleID), func(row *domain.Schedule) error {
+		*row = scheduleColumns(schedule)
+		return nil
+	}, noUnique[domain.Schedule])
+}
+
+func (r *ScheduleRepo) Patch(ctx context.Context, scheduleID int64, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.schedules, r.byID(scheduleID), func(row *domain.Schedule) error {
+		if err := patch(row, updates); err != nil {
+			return err
+		}
+		*row = scheduleColumns(*row)
+		return nil
+	}, noUnique[domain.Schedule])
+}
+
+func (r *ScheduleRepo) Delete(ctx context.Context, scheduleID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.delete(scheduleID)
+	return nil
+}
+
+func (r *ScheduleRepo) DeleteByGroupID(ctx context.Context, groupID string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, schedule := range r.s.schedules.filter(func(s domain.Schedule) bool { return s.GroupID == groupID }) {
+		r.delete(schedule.ScheduleID)
+	}
+	return nil
+}
+
+// delete removes the schedule with its exceptions and marks like the foreign keys do
+func (r *ScheduleRepo) delete(scheduleID int64) {
+	r.s.schedules.remove(r.byID(scheduleID))
+	r.s.exceptions.remove(func(e domain.ScheduleException) bool { return e.ScheduleID == scheduleID })
+	r.s.marks.remove(func(m domain.Mark) bool { return m.ScheduleID == scheduleID })
+}
+
+func (r *ScheduleRepo) GetByID(ctx context.Context, scheduleID int64) (domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	schedule, err := getRow(&r.s.schedules, r.byID(scheduleID))
+	if err != nil {
+		return domain.ScheduleInfo{}, err
+	}
+	return r.s.scheduleInfo(schedule), nil
+}
+
+func (r *ScheduleRepo) GetAll(ctx context.Context) ([]domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.list(func(domain.Schedule) bool { return true }), nil
+}
+
+func (r *ScheduleRepo) GetByGroupID(ctx context.Context, groupID string) ([]domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.list(func(s domain.Schedule) bool { return s.GroupID == groupID }), nil
+}
+
+func (r *ScheduleRepo) GetByTeacherID(ctx context.Context, teacherID int64) ([]domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.list(func(s domain.Schedule) bool { return s.TeacherID == teacherID }), nil
+}
+
+func (r *ScheduleRepo) GetByGroupAndWeekType(ctx context.Context, groupID, weekType string) ([]domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.list(func(s domain.Schedule) bool { return s.GroupID == groupID && s.WeekType == weekType }), nil
+}
+
+func (r *ScheduleRepo) GetByTeacherAndWeekType(ctx context.Context, teacherID int64, weekType string) ([]domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.list(func(s domain.Schedule) bool { return s.TeacherID == teacherID && s.WeekType == weekType }), nil
+}
+
+func (r *ScheduleRepo) GetByGroupWeekTypeAndDay(ctx context.Context, groupID, weekType, dayOfWeek string) ([]domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.list(func(s domain.Schedule) bool {
+		return s.GroupID == groupID && s.WeekType == weekType && s.DayOfWeek == dayOfWeek
+	}), nil
+}
+
+func (r *ScheduleRepo) GetByTeacherWeekTypeAndDay(ctx context.Context, teacherID int64, weekType, dayOfWeek string) ([]domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.list(func(s domain.Schedule) bool {
+		return s.TeacherID == teacherID && s.WeekType == weekType && s.DayOfWeek == dayOfWeek
+	}), nil
+}
+
+func (r *ScheduleRepo) GetActualByGroupID(ctx context.Context, groupID string) ([]domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.list(func(s domain.Schedule) bool { return s.GroupID == groupID && isActual(s) }), nil
+}
+
+func (r *ScheduleRepo) GetActualByTeacherID(ctx context.Context, teacherID int64) ([]domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.list(func(s domain.Schedule) bool { return s.TeacherID == teacherID && isActual(s) }), nil
+}
+
+func (r *ScheduleRepo) GetActualByTeacherWeekTypeAndDay(ctx context.Context, teacherID int64, weekType, dayOfWeek string) ([]domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.list(func(s domain.Schedule) bool {
+		return s.TeacherID == teacherID && s.WeekType == weekType && s.DayOfWeek == dayOfWeek && isActual(s)
+	}), nil
+}
+
+func (r *ScheduleRepo) GetActualByTeacherAndWeekType(ctx context.Context, teacherID int64, weekType string) ([]domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.list(func(s domain.Schedule) bool {
+		return s.TeacherID == teacherID && s.WeekType == weekType && isActual(s)
+	}), nil
+}
+
+func (r *ScheduleRepo) GetActualByGroupWeekTypeAndDay(ctx context.Context, groupID, weekType, dayOfWeek string) ([]domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.list(func(s domain.Schedule) bool {
+		return s.GroupID == groupID && s.WeekType == weekType && s.DayOfWeek == dayOfWeek && isActual(s)
+	}), nil
+}
+
+func (r *ScheduleRepo) GetActualByGroupAndWeekType(ctx context.Context, groupID string, weekType string) ([]domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.list(func(s domain.Schedule) bool {
+		return s.GroupID == groupID && s.WeekType == weekType && isActual(s)
+	}), nil
+}
+
+func (r *ScheduleRepo) GetActualByGroupAndDay(ctx context.Context, groupID, dayOfWeek string) ([]domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.actualByDay(func(s domain.Schedule) bool { return s.GroupID == groupID }, dayOfWeek), nil
+}
+
+func (r *ScheduleRepo) GetActualByTeacherAndDay(ctx context.Context, teacherID int64, dayOfWeek string) ([]domain.ScheduleInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.actualByDay(func(s domain.Schedule) bool { return s.TeacherID == teacherID }, dayOfWeek), nil
+}
+
+func (r *ScheduleRepo) list(match func(domain.Schedule) bool) []domain.ScheduleInfo {
+	schedules := r.s.schedules.filter(match)
+	infos := make([]domain.ScheduleInfo, 0, len(schedules))
+	for _, schedule := range schedules {
+		infos = append(infos, r.s.scheduleInfo(schedule))
+	}
+	return infos
+}
+
+func (r *ScheduleRepo) actualByDay(match func(domain.Schedule) bool, dayOfWeek string) []domain.ScheduleInfo {
+	schedules := r.s.schedules.filter(func(s domain.Schedule) bool {
+		return match(s) && s.DayOfWeek == dayOfWeek && isActual(s)
+	})
+	sort.SliceStable(schedules, func(i, j int) bool { return schedules[i].StartTime.Before(schedules[j].StartTime) })
+
+	infos := make([]domain.ScheduleInfo, 0, len(schedules))
+	for _, schedule := range schedules {
+		infos = append(infos, r.s.scheduleInfo(schedule))
+	}
+	return infos
+}
+
+func (r *ScheduleRepo) byID(scheduleID int64) func(domain.Schedule) bool {
+	return func(s domain.Schedule) bool { return s.ScheduleID == scheduleID }
+}
+
+func isActual(schedule domain.Schedule) bool {
+	return schedule.IsActual != nil && *schedule.IsActual
+}
+
+// scheduleColumns keeps the start of studies as a DATE and the start time as a TIME
+func scheduleColumns(schedule domain.Schedule) domain.Schedule {
+	if !schedule.BeginStudies.IsZero() {
+		schedule.BeginStudies = date(schedule.BeginStudies)
+	}
+	schedule.StartTime = clock(schedule.StartTime)
+	return schedule
+}
+
+func (s *store) createSchedule(schedule domain.Schedule) {
+	schedule = scheduleColumns(schedule)
+	schedule.ScheduleID = s.next("schedules")
+	s.schedules.insert(schedule)
+}
+
+func (s *store) schedule(scheduleID int64) (domain.Schedule, bool) {
+	schedule, ok := s.schedules.find(func(sc domain.Schedule) bool { return sc.ScheduleID == scheduleID })
+	if !ok {
+		return domain.Schedule{}, false
+	}
+	return *schedule, true
+}
+
+// slotByStartTime finds the slot of the bell schedule of the group's university by the start time
+func (s *store) slotByStartTime(groupID string, schedule domain.Schedule) *int64 {
+	universityID, ok := s.groupUniversityID(groupID)
+	if !ok {
+		return nil
+	}
+	startTime := clock(schedule.StartTime)
+	slot, ok := s.slots.find(func(l domain.LessonSlot) bool {
+		return l.UniversityID == universityID && l.StartTime.Equal(startTime)
+	})
+	if !ok {
+		return nil
+	}
+	slotID := slot.SlotID
+	return &slotID
+}
+
+func (s *store) scheduleInfo(schedule domain.Schedule) domain.ScheduleInfo {
+	info := domain.ScheduleInfo{Schedule: schedule}
+	info.ScheduleSub.DisciplineName, _ = s.disciplineName(schedule.DisciplineID)
+	info.ScheduleSub.DisciplineTypeName, _ = s.disciplineTypeName(schedule.DisciplineTypeID)
+	info.ScheduleSub.ClassroomName, _ = s.classroomName(schedule.ClassroomID)
+	if teacher, ok := s.teacher(schedule.TeacherID); ok {
+		info.ScheduleSub.TeacherFullName = domain.TeacherFullName{
+			LastName:   teacher.LastName,
+			FirstName:  teacher.FirstName,
+			MiddleName: teacher.MiddleName,
+		}
+	}
+	return info
+}
@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type ScheduleExceptionRepo struct {
+	s *store
+}
+
+func (r *ScheduleExceptionRepo) Create(ctx context.Context, exception domain.ScheduleException) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	exception = exceptionColumns(exception)
+	exception.ExceptionID = 0
+	if err := r.unique(exception); err != nil {
+		return 0, err
+	}
+	exception.ExceptionID = r.s.next("schedule_exceptions")
+	exception.CreatedAt = time.Now()
+	r.s.exceptions.insert(exception)
+	return exception.ExceptionID, nil
+}
+
+func (r *ScheduleExceptionRepo) Put(ctx context.Context, exception domain.ScheduleException) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.exceptions, r.byID(exception.ExceptionID), func(row *domain.ScheduleException) error {
+		createdAt := row.CreatedAt
+		*row = exceptionColumns(exception)
+		row.CreatedAt = createdAt
+		return nil
+	}, r.unique)
+}
+
+func (r *ScheduleExceptionRepo) unique(exception domain.ScheduleException) error {
+	if r.s.exceptions.exists(func(e domain.ScheduleException) bool {
+		return e.ExceptionID != exception.ExceptionID && e.ScheduleID == exception.ScheduleID && e.LessonDate.Equal(exception.LessonDate)
+	}) {
+		return uniqueViolation("U_schedule_exceptions_lesson")
+	}
+	return nil
+}
+
+func (r *ScheduleExceptionRepo) Delete(ctx context.Context, exceptionID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.exceptions.remove(r.byID(exceptionID))
+	return nil
+}
+
+func (r *ScheduleExceptionRepo) GetByID(ctx context.Context, exceptionID int64) (domain.ScheduleExceptionInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	exception, err := getRow(&r.s.exceptions, r.byID(exceptionID))
+	if err != nil {
+		return domain.ScheduleExceptionInfo{}, err
+	}
+	return r.s.exceptionInfo(exception), nil
+}
+
+func (r *ScheduleExceptionRepo) GetByScheduleID(ctx context.Context, scheduleID int64) ([]domain.ScheduleExceptionInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.sorted(func(e domain.ScheduleException) bool { return e.ScheduleID == scheduleID }), nil
+}
+
+func (r *ScheduleExceptionRepo) GetByScheduleAndDate(ctx context.Context, scheduleID int64, at time.Time) ([]domain.ScheduleExceptionInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	day := date(at)
+	return r.sorted(func(e domain.ScheduleException) bool { return e.ScheduleID == scheduleID && heldOrPlannedOn(e, day) }), nil
+}
+
+func (r *ScheduleExceptionRepo) GetByDate(ctx context.Context, at time.Time) ([]domain.ScheduleExceptionInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	day := date(at)
+	return r.sorted(func(e domain.ScheduleException) bool { return heldOrPlannedOn(e, day) }), nil
+}
+
+// sorted returns the matching exceptions ordered by the schedule and the lesson date
+func (r *ScheduleExceptionRepo) sorted(match func(domain.ScheduleException) bool) []domain.ScheduleExceptionInfo {
+	exceptions := r.s.exceptions.filter(match)
+	sort.SliceStable(exceptions, func(i, j int) bool {
+		a, b := exceptions[i], exceptions[j]
+		if a.ScheduleID != b.ScheduleID {
+			return a.ScheduleID < b.ScheduleID
+		}
+		return a.LessonDate.Before(b.LessonDate)
+	})
+
+	infos := make([]domain.ScheduleExceptionInfo, 0, len(exceptions))
+	for _, exception := range exceptions {
+		infos = append(infos, r.s.exceptionInfo(exception))
+	}
+	return infos
+}
+
+func (r *ScheduleExceptionRepo) byID(exceptionID int64) func(domain.ScheduleException) bool {
+	return func(e domain.ScheduleException) bool { return e.ExceptionID == exceptionID }
+}
+
+func heldOrPlannedOn(exception domain.ScheduleException, day time.Time) bool {
+	return exception.LessonDate.Equal(day) || (exception.MovedToDate != nil && exception.MovedToDate.Equal(day))
+}
+
+func exceptionColumns(exception domain.ScheduleException) domain.ScheduleException {
+	exception.LessonDate = date(exception.LessonDate)
+	exception.MovedToDate = optionalDate(exception.MovedToDate)
+	exception.StartTime = optionalClock(exception.StartTime)
+	return exception
+}
+
+func (s *store) exceptionInfo(exception domain.ScheduleException) domain.ScheduleExceptionInfo {
+	info := domain.ScheduleExceptionInfo{ScheduleException: exception}
+	if exception.TeacherID != nil {
+		if teacher, ok := s.teacher(*exception.TeacherID); ok {
+			info.ScheduleExceptionSub.TeacherFullName = &domain.TeacherFullName{
+				LastName:   teacher.LastName,
+				FirstName:  teacher.FirstName,
+				MiddleName: teacher.MiddleName,
+			}
+		}
+	}
+	if exception.ClassroomID != nil {
+		if classroomName, ok := s.classroomName(*exception.ClassroomID); ok {
+			info.ScheduleExceptionSub.ClassroomName = &classroomName
+		}
+	}
+	return info
+}
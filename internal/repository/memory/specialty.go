@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type SpecialtyRepo struct {
+	s *store
+}
+
+func (r *SpecialtyRepo) Create(ctx context.Context, specialty domain.Specialty) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if r.s.specialties.exists(r.byCode(specialty.SpecialtyCode)) {
+		return uniqueViolation("PK_specialties")
+	}
+	r.s.specialties.insert(specialty)
+	return nil
+}
+
+func (r *SpecialtyRepo) Put(ctx context.Context, specialty domain.Specialty) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.specialties, r.byCode(specialty.SpecialtyCode), func(row *domain.Specialty) error {
+		*row = specialty
+		return nil
+	}, r.unique(specialty.SpecialtyCode))
+}
+
+func (r *SpecialtyRepo) Patch(ctx context.Context, specialtyCode string, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.specialties, r.byCode(specialtyCode), func(row *domain.Specialty) error {
+		return patch(row, updates)
+	}, r.unique(specialtyCode))
+}
+
+// unique checks the code of the updated row, it may change through a patch
+func (r *SpecialtyRepo) unique(specialtyCode string) func(domain.Specialty) error {
+	return func(specialty domain.Specialty) error {
+		if specialty.SpecialtyCode != specialtyCode && r.s.specialties.exists(r.byCode(specialty.SpecialtyCode)) {
+			return uniqueViolation("PK_specialties")
+		}
+		return nil
+	}
+}
+
+func (r *SpecialtyRepo) Delete(ctx context.Context, specialtyCode string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.specialties.remove(r.byCode(specialtyCode))
+	return nil
+}
+
+func (r *SpecialtyRepo) GetByCode(ctx context.Context, specialtyCode string) (domain.SpecialtyInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	specialty, err := getRow(&r.s.specialties, r.byCode(specialtyCode))
+	return r.s.specialtyInfo(specialty), err
+}
+
+func (r *SpecialtyRepo) GetByName(ctx context.Context, specialtyName string) (domain.SpecialtyInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	specialty, err := getRow(&r.s.specialties, func(s domain.Specialty) bool { return s.SpecialtyName == specialtyName })
+	return r.s.specialtyInfo(specialty), err
+}
+
+func (r *SpecialtyRepo) GetAll(ctx context.Context) ([]domain.SpecialtyInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.specialties.all()), nil
+}
+
+func (r *SpecialtyRepo) GetAllByDepartamentID(ctx context.Context, departamentID int64) ([]domain.SpecialtyInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.specialties.filter(func(s domain.Specialty) bool { return s.DepartamentID == departamentID })), nil
+}
+
+func (r *SpecialtyRepo) infos(specialties []domain.Specialty) []domain.SpecialtyInfo {
+	infos := make([]domain.SpecialtyInfo, 0, len(specialties))
+	for _, specialty := range specialties {
+		infos = append(infos, r.s.specialtyInfo(specialty))
+	}
+	return infos
+}
+
+func (r *SpecialtyRepo) byCode(specialtyCode string) func(domain.Specialty) bool {
+	return func(s domain.Specialty) bool { return s.SpecialtyCode == specialtyCode }
+}
+
+func (s *store) specialtyInfo(specialty domain.Specialty) domain.SpecialtyInfo {
+	return domain.SpecialtyInfo{
+		Specialty: specialty,
+		SpecialtySub: domain.SpecialtySub{
+			DepartamentName:    s.departamentName(specialty.DepartamentID),
+			EducationLevelName: s.educationLevelName(specialty.EducationLevelID),
+		},
+	}
+}
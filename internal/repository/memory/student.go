@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/google/uuid"
+)
+
+type StudentRepo struct {
+	s *store
+}
+
+func (r *StudentRepo) Create(ctx context.Context, student domain.Student) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.createStudent(student), nil
+}
+
+// CreateWithAccounts checks the usernames before any insert, so nothing is stored
+// when one of them is taken like in the transaction of the database
+func (r *StudentRepo) CreateWithAccounts(ctx context.Context, accounts []domain.StudentAccount) ([]int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	usernames := make(map[string]bool, len(accounts))
+	for _, account := range accounts {
+		if account.User == nil {
+			continue
+		}
+		username := account.User.Username
+		if usernames[username] || r.s.users.exists(func(u domain.User) bool { return u.Username == username }) {
+			return nil, uniqueViolation("U_users_username")
+		}
+		usernames[username] = true
+	}
+
+	studentIDs := make([]int64, 0, len(accounts))
+	for _, account := range accounts {
+		studentID := r.s.createStudent(account.Student)
+		studentIDs = append(studentIDs, studentID)
+		if account.User == nil {
+			continue
+		}
+		user := *account.User
+		user.UserID = uuid.New()
+		user.HeadmanID = nil
+		user.StudentID = &studentID
+		user.TeacherID = nil
+		user.TokenVersion = 0
+		r.s.users.insert(user)
+	}
+	return studentIDs, nil
+}
+
+func (r *StudentRepo) Put(ctx context.Context, student domain.Student) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.changeGroup(student.StudentID, student.GroupID)
+	return updateRow(&r.s.students, byStudentID(student.StudentID), func(row *domain.Student) error {
+		*row = student
+		return nil
+	}, noUnique[domain.Student])
+}
+
+func (r *StudentRepo) Patch(ctx context.Context, studentID int64, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.students.find(byStudentID(studentID))
+	if !ok {
+		return nil
+	}
+	updated := *row
+	if err := patch(&updated, updates); err != nil {
+		return err
+	}
+	if groupID, ok := updates["group_id"].(string); ok {
+		r.s.changeGroup(studentID, groupID)
+	}
+	*row = updated
+	return nil
+}
+
+func (r *StudentRepo) Delete(ctx context.Context, studentID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.students.remove(byStudentID(studentID))
+	r.s.memberships.remove(func(m domain.GroupMembership) bool { return m.StudentID == studentID })
+	return nil
+}
+
+func (r *StudentRepo) GetByID(ctx context.Context, studentID int64) (domain.Student, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return getRow(&r.s.students, byStudentID(studentID))
+}
+
+func (r *StudentRepo) GetByName(ctx context.Context, lastName, firstName, middleName string) (domain.Student, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return getRow(&r.s.students, func(s domain.Student) bool {
+		return s.LastName == lastName && s.FirstName == firstName && s.MiddleName == middleName
+	})
+}
+
+func (r *StudentRepo) GetAll(ctx context.Context) ([]domain.Student, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.students.all(), nil
+}
+
+func (r *StudentRepo) GetAllByGroupID(ctx context.Context, groupID string) ([]domain.Student, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.students.filter(func(s domain.Student) bool { return s.GroupID == groupID }), nil
+}
+
+func byStudentID(studentID int64) func(domain.Student) bool {
+	return func(s domain.Student) bool { return s.StudentID == studentID }
+}
+
+// noUnique is the unique check of a table without unique columns besides the key
+func noUnique[T any](T) error {
+	return nil
+}
+
+func (s *store) student(studentID int64) (domain.Student, bool) {
+	student, ok := s.students.find(byStudentID(studentID))
+	if !ok {
+		return domain.Student{}, false
+	}
+	return *student, true
+}
+
+// createStudent inserts the student and records the first group
+func (s *store) createStudent(student domain.Student) int64 {
+	student.StudentID = s.next("students")
+	s.students.insert(student)
+
+	reason := reasonEnrollment
+	s.memberships.insert(domain.GroupMembership{
+		HistoryID: s.next("student_group_history"),
+		StudentID: student.StudentID,
+		GroupID:   student.GroupID,
+		ValidFrom: date(time.Now()),
+		Reason:    &reason,
+	})
+	return student.StudentID
+}
@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type SubgroupRepo struct {
+	s *store
+}
+
+func (r *SubgroupRepo) Create(ctx context.Context, subgroup domain.Subgroup) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	subgroup.SubgroupID = 0
+	subgroup.StudentIDs = subgroupStudents(subgroup.StudentIDs)
+	if err := r.unique(subgroup); err != nil {
+		return 0, err
+	}
+	subgroup.SubgroupID = r.s.next("subgroups")
+	r.s.subgroups.insert(subgroup)
+	return subgroup.SubgroupID, nil
+}
+
+// Put replaces the name and the students of the subgroup
+func (r *SubgroupRepo) Put(ctx context.Context, subgroup domain.Subgroup) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.subgroups, r.byID(subgroup.SubgroupID), func(row *domain.Subgroup) error {
+		*row = subgroup
+		row.StudentIDs = subgroupStudents(subgroup.StudentIDs)
+		return nil
+	}, r.unique)
+}
+
+func (r *SubgroupRepo) unique(subgroup domain.Subgroup) error {
+	if r.s.subgroups.exists(func(sg domain.Subgroup) bool {
+		return sg.SubgroupID != subgroup.SubgroupID && sg.GroupID == subgroup.GroupID && sg.Name == subgroup.Name
+	}) {
+		return uniqueViolation("U_subgroups_name")
+	}
+	return nil
+}
+
+func (r *SubgroupRepo) Delete(ctx context.Context, subgroupID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.subgroups.remove(r.byID(subgroupID))
+	return nil
+}
+
+func (r *SubgroupRepo) GetByID(ctx context.Context, subgroupID int64) (domain.Subgroup, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	subgroup, err := getRow(&r.s.subgroups, r.byID(subgroupID))
+	subgroup.StudentIDs = append([]int64{}, subgroup.StudentIDs...)
+	return subgroup, err
+}
+
+func (r *SubgroupRepo) GetByGroupID(ctx context.Context, groupID string) ([]domain.Subgroup, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	subgroups := r.s.subgroups.filter(func(sg domain.Subgroup) bool { return sg.GroupID == groupID })
+	for i := range subgroups {
+		subgroups[i].StudentIDs = append([]int64{}, subgroups[i].StudentIDs...)
+	}
+	sort.SliceStable(subgroups, func(i, j int) bool { return subgroups[i].Name < subgroups[j].Name })
+	return subgroups, nil
+}
+
+func (r *SubgroupRepo) HasStudent(ctx context.Context, subgroupID int64, studentID int64) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.inSubgroup(subgroupID, studentID), nil
+}
+
+func (r *SubgroupRepo) byID(subgroupID int64) func(domain.Subgroup) bool {
+	return func(sg domain.Subgroup) bool { return sg.SubgroupID == subgroupID }
+}
+
+func (s *store) inSubgroup(subgroupID int64, studentID int64) bool {
+	subgroup, ok := s.subgroups.find(func(sg domain.Subgroup) bool { return sg.SubgroupID == subgroupID })
+	if !ok {
+		return false
+	}
+	for _, id := range subgroup.StudentIDs {
+		if id == studentID {
+			return true
+		}
+	}
+	return false
+}
+
+// attends reports whether the student attends the lessons of the schedule,
+// a schedule without a subgroup is attended by the whole group
+func (s *store) attends(schedule domain.Schedule, studentID int64) bool {
+	return schedule.SubgroupID == nil || s.inSubgroup(*schedule.SubgroupID, studentID)
+}
+
+// subgroupStudents keeps the students like the rows of subgroup_students
+// aggregated in the order of the ids
+func subgroupStudents(studentIDs []int64) []int64 {
+	unique := make([]int64, 0, len(studentIDs))
+	seen := make(map[int64]bool, len(studentIDs))
+	for _, id := range studentIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i] < unique[j] })
+	return unique
+}
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type TeacherRepo struct {
+	s *store
+}
+
+func (r *TeacherRepo) Create(ctx context.Context, teacher domain.Teacher) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	teacher.TeacherID = 0
+	if err := r.unique(teacher); err != nil {
+		return err
+	}
+	teacher.TeacherID = r.s.next("teachers")
+	r.s.teachers.insert(teacher)
+	return nil
+}
+
+func (r *TeacherRepo) Put(ctx context.Context, teacher domain.Teacher) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.teachers, r.byID(teacher.TeacherID), func(row *domain.Teacher) error {
+		*row = teacher
+		return nil
+	}, r.unique)
+}
+
+func (r *TeacherRepo) Patch(ctx context.Context, teacherID int64, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.teachers, r.byID(teacherID), func(row *domain.Teacher) error {
+		return patch(row, updates)
+	}, r.unique)
+}
+
+func (r *TeacherRepo) unique(teacher domain.Teacher) error {
+	if r.s.teachers.exists(func(t domain.Teacher) bool {
+		return t.TeacherID != teacher.TeacherID && t.TeacherEmail == teacher.TeacherEmail
+	}) {
+		return uniqueViolation("U_teachers_teacher_email")
+	}
+	return nil
+}
+
+func (r *TeacherRepo) Delete(ctx context.Context, teacherID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.teachers.remove(r.byID(teacherID))
+	return nil
+}
+
+func (r *TeacherRepo) GetByID(ctx context.Context, teacherID int64) (domain.TeacherInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	teacher, err := getRow(&r.s.teachers, r.byID(teacherID))
+	return r.s.teacherInfo(teacher), err
+}
+
+func (r *TeacherRepo) GetByEmail(ctx context.Context, teacherEmail string) (domain.TeacherInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	teacher, err := getRow(&r.s.teachers, func(t domain.Teacher) bool { return t.TeacherEmail == teacherEmail })
+	return r.s.teacherInfo(teacher), err
+}
+
+func (r *TeacherRepo) GetAllByLastName(ctx context.Context, lastName string) ([]domain.TeacherInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.teachers.filter(func(t domain.Teacher) bool { return t.LastName == lastName })), nil
+}
+
+func (r *TeacherRepo) GetAll(ctx context.Context) ([]domain.TeacherInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.teachers.all()), nil
+}
+
+func (r *TeacherRepo) GetAllByDepartamentID(ctx context.Context, departamentID int64) ([]domain.TeacherInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.teachers.filter(func(t domain.Teacher) bool { return t.DepartamentID == departamentID })), nil
+}
+
+func (r *TeacherRepo) infos(teachers []domain.Teacher) []domain.TeacherInfo {
+	infos := make([]domain.TeacherInfo, 0, len(teachers))
+	for _, teacher := range teachers {
+		infos = append(infos, r.s.teacherInfo(teacher))
+	}
+	return infos
+}
+
+func (r *TeacherRepo) byID(teacherID int64) func(domain.Teacher) bool {
+	return func(t domain.Teacher) bool { return t.TeacherID == teacherID }
+}
+
+func (s *store) teacherInfo(teacher domain.Teacher) domain.TeacherInfo {
+	return domain.TeacherInfo{
+		Teacher:    teacher,
+		TeacherSub: domain.TeacherSub{DepartamentName: s.departamentName(teacher.DepartamentID)},
+	}
+}
+
+func (s *store) teacher(teacherID int64) (domain.Teacher, bool) {
+	teacher, ok := s.teachers.find(func(t domain.Teacher) bool { return t.TeacherID == teacherID })
+	if !ok {
+		return domain.Teacher{}, false
+	}
+	return *teacher, true
+}
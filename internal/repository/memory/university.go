@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type UniversityRepo struct {
+	s *store
+}
+
+func (r *UniversityRepo) Create(ctx context.Context, university domain.University) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	university.UniversityID = 0
+	if err := r.unique(university); err != nil {
+		return err
+	}
+	university.UniversityID = r.s.next("university")
+	r.s.universities.insert(university)
+	return nil
+}
+
+func (r *UniversityRepo) Put(ctx context.Context, university domain.University) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.universities, r.byID(university.UniversityID), func(row *domain.University) error {
+		*row = university
+		return nil
+	}, r.unique)
+}
+
+func (r *UniversityRepo) Patch(ctx context.Context, universityID int64, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.universities, r.byID(universityID), func(row *domain.University) error {
+		return patch(row, updates)
+	}, r.unique)
+}
+
+func (r *UniversityRepo) unique(university domain.University) error {
+	if r.s.universities.exists(func(u domain.University) bool {
+		return u.UniversityID != university.UniversityID && u.UniversityName == university.UniversityName
+	}) {
+		return uniqueViolation("U_university_name")
+	}
+	return nil
+}
+
+func (r *UniversityRepo) Delete(ctx context.Context, universityID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.universities.remove(r.byID(universityID))
+	r.s.calendar.remove(func(p domain.CalendarPeriod) bool { return p.UniversityID == universityID })
+	r.s.slots.remove(func(l domain.LessonSlot) bool { return l.UniversityID == universityID })
+	return nil
+}
+
+func (r *UniversityRepo) GetByID(ctx context.Context, universityID int64) (domain.University, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return getRow(&r.s.universities, r.byID(universityID))
+}
+
+func (r *UniversityRepo) GetByName(ctx context.Context, universityName string) (domain.University, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return getRow(&r.s.universities, func(u domain.University) bool { return u.UniversityName == universityName })
+}
+
+func (r *UniversityRepo) GetAll(ctx context.Context) ([]domain.University, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.universities.all(), nil
+}
+
+func (r *UniversityRepo) byID(universityID int64) func(domain.University) bool {
+	return func(u domain.University) bool { return u.UniversityID == universityID }
+}
@@ -0,0 +1,224 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/google/uuid"
+)
+
+type UserRepo struct {
+	s *store
+}
+
+func (r *UserRepo) Create(ctx context.Context, user domain.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	user.UserID = uuid.Nil
+	if err := r.unique(user); err != nil {
+		return err
+	}
+	user.UserID = uuid.New()
+	user.TokenVersion = 0
+	r.s.users.insert(user)
+	return nil
+}
+
+func (r *UserRepo) Put(ctx context.Context, user domain.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.users, r.byID(user.UserID), func(row *domain.User) error {
+		tokenVersion := row.TokenVersion
+		*row = user
+		row.TokenVersion = tokenVersion
+		return nil
+	}, r.unique)
+}
+
+func (r *UserRepo) Patch(ctx context.Context, userID uuid.UUID, updates map[string]interface{}) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.users, r.byID(userID), func(row *domain.User) error {
+		return patch(row, updates)
+	}, r.unique)
+}
+
+func (r *UserRepo) UpdatePassword(ctx context.Context, userID uuid.UUID, password string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.users, r.byID(userID), func(row *domain.User) error {
+		row.Password = password
+		row.TokenVersion++
+		return nil
+	}, r.unique)
+}
+
+func (r *UserRepo) RevokeTokens(ctx context.Context, userID uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return updateRow(&r.s.users, r.byID(userID), func(row *domain.User) error {
+		row.TokenVersion++
+		return nil
+	}, r.unique)
+}
+
+func (r *UserRepo) unique(user domain.User) error {
+	if r.s.users.exists(func(u domain.User) bool {
+		return u.UserID != user.UserID && u.Username == user.Username
+	}) {
+		return uniqueViolation("U_users_username")
+	}
+	return nil
+}
+
+func (r *UserRepo) Delete(ctx context.Context, userID uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.users.remove(r.byID(userID))
+	r.s.resetTokens.remove(func(t domain.PasswordResetToken) bool { return t.UserID == userID })
+	return nil
+}
+
+// GetByID takes the group of the student, the other lookups take the group of the headman
+func (r *UserRepo) GetByID(ctx context.Context, userID uuid.UUID) (domain.UserInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	user, err := getRow(&r.s.users, r.byID(userID))
+	if err != nil {
+		return domain.UserInfo{}, err
+	}
+	info := r.s.userInfo(user)
+	info.UserSub.GroupID = nil
+	if student, ok := r.s.userStudent(user); ok {
+		groupID := student.GroupID
+		info.UserSub.GroupID = &groupID
+	}
+	return info, nil
+}
+
+func (r *UserRepo) GetByName(ctx context.Context, username string) (domain.UserInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.get(func(u domain.User) bool { return u.Username == username })
+}
+
+func (r *UserRepo) GetByStudentID(ctx context.Context, studentID int64) (domain.UserInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.get(func(u domain.User) bool {
+		student, ok := r.s.userStudent(u)
+		return ok && student.StudentID == studentID
+	})
+}
+
+func (r *UserRepo) GetByTeacherID(ctx context.Context, teacherID int64) (domain.UserInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.get(func(u domain.User) bool {
+		_, ok := r.s.teacher(teacherID)
+		return ok && u.TeacherID != nil && *u.TeacherID == teacherID
+	})
+}
+
+func (r *UserRepo) GetByHeadmanID(ctx context.Context, headmanID int64) (domain.UserInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.get(func(u domain.User) bool {
+		headman, ok := r.s.userHeadman(u)
+		return ok && headman.HeadmanID == headmanID
+	})
+}
+
+func (r *UserRepo) GetAllByRole(ctx context.Context, role string) ([]domain.UserInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.users.filter(func(u domain.User) bool { return u.Role == role })), nil
+}
+
+func (r *UserRepo) GetAll(ctx context.Context) ([]domain.UserInfo, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.infos(r.s.users.all()), nil
+}
+
+func (r *UserRepo) get(match func(domain.User) bool) (domain.UserInfo, error) {
+	user, err := getRow(&r.s.users, match)
+	if err != nil {
+		return domain.UserInfo{}, err
+	}
+	return r.s.userInfo(user), nil
+}
+
+func (r *UserRepo) infos(users []domain.User) []domain.UserInfo {
+	infos := make([]domain.UserInfo, 0, len(users))
+	for _, user := range users {
+		infos = append(infos, r.s.userInfo(user))
+	}
+	return infos
+}
+
+func (r *UserRepo) byID(userID uuid.UUID) func(domain.User) bool {
+	return func(u domain.User) bool { return u.UserID == userID }
+}
+
+func (s *store) userHeadman(user domain.User) (domain.Headman, bool) {
+	if user.HeadmanID == nil {
+		return domain.Headman{}, false
+	}
+	headman, ok := s.headmen.find(func(h domain.Headman) bool { return h.HeadmanID == *user.HeadmanID })
+	if !ok {
+		return domain.Headman{}, false
+	}
+	return *headman, true
+}
+
+// userStudent is the student of the account itself or of its headman term
+func (s *store) userStudent(user domain.User) (domain.Student, bool) {
+	if user.StudentID != nil {
+		if student, ok := s.student(*user.StudentID); ok {
+			return student, true
+		}
+	}
+	if headman, ok := s.userHeadman(user); ok {
+		return s.student(headman.StudentID)
+	}
+	return domain.Student{}, false
+}
+
+func (s *store) userInfo(user domain.User) domain.UserInfo {
+	info := domain.UserInfo{User: user}
+	if student, ok := s.userStudent(user); ok {
+		info.UserSub.StudentFullName = &domain.StudentFullName{
+			LastName:   student.LastName,
+			FirstName:  student.FirstName,
+			MiddleName: student.MiddleName,
+		}
+	}
+	if user.TeacherID != nil {
+		if teacher, ok := s.teacher(*user.TeacherID); ok {
+			info.UserSub.TeacherFullName = &domain.TeacherFullName{
+				LastName:   teacher.LastName,
+				FirstName:  teacher.FirstName,
+				MiddleName: teacher.MiddleName,
+			}
+		}
+	}
+	if headman, ok := s.userHeadman(user); ok {
+		groupID := headman.GroupID
+		info.UserSub.GroupID = &groupID
+	}
+	return info
+}
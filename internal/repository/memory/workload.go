@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+type WorkloadRepo struct {
+	s *store
+}
+
+// CountDeliveredLessons counts the days with attendance of the actual schedules by the teacher
+// who held the lesson, a substitute teacher of an exception replaces the teacher of the schedule
+func (r *WorkloadRepo) CountDeliveredLessons(ctx context.Context, teacherIDs []int64, semester int) ([]domain.DeliveredLessons, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	teachers := make(map[int64]bool, len(teacherIDs))
+	for _, teacherID := range teacherIDs {
+		teachers[teacherID] = true
+	}
+
+	type lesson struct {
+		teacherID  int64
+		scheduleID int64
+	}
+	type day struct {
+		lesson lesson
+		day    time.Time
+	}
+	delivered := make([]domain.DeliveredLessons, 0)
+	index := make(map[lesson]int)
+	seen := make(map[day]bool)
+	for _, attendance := range r.s.attendance.rows {
+		schedule, ok := r.s.schedule(attendance.ScheduleID)
+		if !ok || !isActual(schedule) || (semester != 0 && schedule.Semester != semester) {
+			continue
+		}
+		created := date(attendance.Created)
+		teacherID := schedule.TeacherID
+		if exception, ok := r.s.exceptions.find(func(e domain.ScheduleException) bool {
+			return e.ScheduleID == schedule.ScheduleID && !e.IsCancelled && e.HeldOn().Equal(created)
+		}); ok && exception.TeacherID != nil {
+			teacherID = *exception.TeacherID
+		}
+		if !teachers[teacherID] {
+			continue
+		}
+
+		key := lesson{teacherID, schedule.ScheduleID}
+		if seen[day{key, created}] {
+			continue
+		}
+		seen[day{key, created}] = true
+		i, ok := index[key]
+		if !ok {
+			i = len(delivered)
+			index[key] = i
+			delivered = append(delivered, domain.DeliveredLessons{TeacherID: teacherID, ScheduleID: schedule.ScheduleID})
+		}
+		delivered[i].Lessons++
+	}
+	return delivered, nil
+}
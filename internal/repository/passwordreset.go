@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PasswordResetRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPasswordResetRepo(db *pgxpool.Pool) *PasswordResetRepo {
+	return &PasswordResetRepo{db: db}
+}
+
+func (r *PasswordResetRepo) Create(ctx context.Context, token domain.PasswordResetToken) error {
+	query := `INSERT INTO password_reset_tokens (user_id, token_hash, expires_at)
+              VALUES ($1, $2, $3)`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, token.UserID, token.TokenHash, token.ExpiresAt)
+
+	return err
+}
+
+// Consume marks an unused and unexpired token as used and returns its user.
+// pgx.ErrNoRows is returned when the token is unknown, expired or already used
+func (r *PasswordResetRepo) Consume(ctx context.Context, tokenHash string, now time.Time) (uuid.UUID, error) {
+	query := `UPDATE password_reset_tokens SET used_at = $2
+              WHERE token_hash = $1 AND used_at IS NULL AND expires_at > $2
+              RETURNING user_id`
+
+	var userID uuid.UUID
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, tokenHash, now).Scan(&userID)
+
+	return userID, err
+}
+
+func (r *PasswordResetRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM password_reset_tokens WHERE user_id = $1`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, userID)
+
+	return err
+}
@@ -0,0 +1,48 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestPasswordResetRepoConsume(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	tokens := []domain.PasswordResetToken{
+		{UserID: studentUserID, TokenHash: "valid", ExpiresAt: now.Add(time.Hour)},
+		{UserID: studentUserID, TokenHash: "expired", ExpiresAt: now.Add(-time.Minute)},
+		{UserID: teacherUserID, TokenHash: "deleted", ExpiresAt: now.Add(time.Hour)},
+	}
+	for _, token := range tokens {
+		if err := repos.PasswordReset.Create(ctx, token); err != nil {
+			t.Fatalf("Create(%s) error = %v", token.TokenHash, err)
+		}
+	}
+	if err := repos.PasswordReset.Create(ctx, tokens[0]); !isViolation(err, "23505") {
+		t.Errorf("Create() of a duplicate hash error = %v, want a unique violation", err)
+	}
+	if err := repos.PasswordReset.DeleteByUserID(ctx, teacherUserID); err != nil {
+		t.Fatalf("DeleteByUserID() error = %v", err)
+	}
+
+	userID, err := repos.PasswordReset.Consume(ctx, "valid", now)
+	if err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+	if userID != studentUserID {
+		t.Errorf("user of the token = %s, want %s", userID, studentUserID)
+	}
+
+	for _, hash := range []string{"valid", "expired", "deleted", "unknown"} {
+		if _, err := repos.PasswordReset.Consume(ctx, hash, now); !errors.Is(err, pgx.ErrNoRows) {
+			t.Errorf("Consume(%s) error = %v, want %v", hash, err, pgx.ErrNoRows)
+		}
+	}
+}
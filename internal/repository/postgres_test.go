@@ -0,0 +1,269 @@
+package repository_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/BeRebornBng/OsauAmsApi/migrations"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// The integration tests run the repositories against a disposable PostgreSQL.
+// TEST_DATABASE_URL points them to a server where the user may create databases,
+// otherwise a temporary cluster is started from the binaries of a local installation:
+// the directory in POSTGRES_BIN, the one of postgres in PATH or /usr/lib/postgresql/*/bin.
+// The cluster listens on a unix socket only. Without a server the tests are skipped.
+//
+// The migrations, starting from the baseline tables, and the fixtures are applied
+// once to a template database, every test gets a fresh copy of it.
+
+const templateDatabase = "osau_template"
+
+var (
+	admin      *pgxpool.Pool
+	skipReason string
+	databases  atomic.Int64
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	if testing.Short() {
+		skipReason = "integration tests are skipped in short mode"
+		return m.Run()
+	}
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		server, err := startServer()
+		if err != nil {
+			skipReason = err.Error()
+			return m.Run()
+		}
+		defer server.stop()
+		url = server.url
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var err error
+	admin, err = connect(ctx, url, "")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "connect to the test server:", err)
+		return 1
+	}
+	defer admin.Close()
+
+	if err := createTemplate(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "create the template database:", err)
+		return 1
+	}
+	defer admin.Exec(context.Background(), `DROP DATABASE IF EXISTS `+templateName())
+
+	return m.Run()
+}
+
+// newRepos returns the repositories of a fresh database with the fixtures
+func newRepos(t *testing.T) (*repository.Repositories, *pgxpool.Pool) {
+	t.Helper()
+	if admin == nil {
+		t.Skip(skipReason)
+	}
+	ctx := context.Background()
+
+	name := fmt.Sprintf("osau_test_%d_%d", os.Getpid(), databases.Add(1))
+	if _, err := admin.Exec(ctx, `CREATE DATABASE `+name+` TEMPLATE `+templateName()); err != nil {
+		t.Fatalf("create database: %v", err)
+	}
+	db, err := connect(ctx, admin.Config().ConnString(), name)
+	if err != nil {
+		t.Fatalf("connect to %s: %v", name, err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		if _, err := admin.Exec(context.Background(), `DROP DATABASE `+name); err != nil {
+			t.Errorf("drop database %s: %v", name, err)
+		}
+	})
+
+	return repository.NewRepositories(db), db
+}
+
+// templateName keeps parallel runs against one server apart
+func templateName() string {
+	return fmt.Sprintf("%s_%d", templateDatabase, os.Getpid())
+}
+
+func createTemplate(ctx context.Context) error {
+	if _, err := admin.Exec(ctx, `CREATE DATABASE `+templateName()); err != nil {
+		return err
+	}
+	db, err := connect(ctx, admin.Config().ConnString(), templateName())
+	if err != nil {
+		return err
+	}
+	// a template can't be copied while somebody is connected to it
+	defer db.Close()
+
+	if _, err := postgres.Migrate(ctx, db, migrations.FS); err != nil {
+		return err
+	}
+	return execFile(ctx, db, "testdata/fixtures.sql")
+}
+
+func execFile(ctx context.Context, db *pgxpool.Pool, name string) error {
+	sql, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, string(sql)); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// connect opens a pool to the database of the server, an empty database keeps the one of url
+func connect(ctx context.Context, url, database string) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		return nil, err
+	}
+	if database != "" {
+		config.ConnConfig.Database = database
+	}
+	db, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+type server struct {
+	dir string
+	url string
+	cmd *exec.Cmd
+}
+
+func startServer() (*server, error) {
+	bin, err := postgresBin()
+	if err != nil {
+		return nil, err
+	}
+	if os.Geteuid() == 0 {
+		return nil, errors.New("postgres can't run as root, set TEST_DATABASE_URL to run the integration tests")
+	}
+
+	dir, err := os.MkdirTemp("", "osau-pg-")
+	if err != nil {
+		return nil, err
+	}
+	data := filepath.Join(dir, "data")
+
+	initdb := exec.Command(filepath.Join(bin, "initdb"), "-D", data, "-U", "postgres", "-A", "trust", "-E", "UTF8", "--locale=C", "--no-sync")
+	if out, err := initdb.CombinedOutput(); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("initdb: %w: %s", err, out)
+	}
+
+	var log bytes.Buffer
+	cmd := exec.Command(filepath.Join(bin, "postgres"), "-D", data, "-k", dir, "-p", "5432", "-F",
+		"-c", "listen_addresses=", "-c", "timezone=UTC")
+	cmd.Stdout = &log
+	cmd.Stderr = &log
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	s := &server{
+		dir: dir,
+		url: fmt.Sprintf("host=%s port=5432 user=postgres dbname=postgres sslmode=disable", dir),
+		cmd: cmd,
+	}
+	if err := s.wait(30 * time.Second); err != nil {
+		s.stop()
+		return nil, fmt.Errorf("postgres didn't start: %w: %s", err, log.String())
+	}
+	return s, nil
+}
+
+// wait polls the server until it accepts connections
+func (s *server) wait(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for {
+		db, err := connect(ctx, s.url, "")
+		if err == nil {
+			db.Close()
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func (s *server) stop() {
+	// SIGINT is the fast shutdown, it doesn't wait for the clients
+	if err := s.cmd.Process.Signal(os.Interrupt); err == nil {
+		s.cmd.Wait()
+	}
+	os.RemoveAll(s.dir)
+}
+
+// postgresBin finds the directory with the initdb and postgres binaries
+func postgresBin() (string, error) {
+	candidates := make([]string, 0)
+	if bin := os.Getenv("POSTGRES_BIN"); bin != "" {
+		candidates = append(candidates, bin)
+	}
+	if path, err := exec.LookPath("postgres"); err == nil {
+		candidates = append(candidates, filepath.Dir(path))
+	}
+	// the newest version of Debian and Ubuntu packages goes first
+	installed, _ := filepath.Glob("/usr/lib/postgresql/*/bin")
+	sort.Slice(installed, func(i, j int) bool { return majorVersion(installed[i]) > majorVersion(installed[j]) })
+	candidates = append(candidates, installed...)
+
+	for _, bin := range candidates {
+		if isFile(filepath.Join(bin, "initdb")) && isFile(filepath.Join(bin, "postgres")) {
+			return bin, nil
+		}
+	}
+	return "", errors.New("no PostgreSQL binaries found, set POSTGRES_BIN or TEST_DATABASE_URL to run the integration tests")
+}
+
+// majorVersion reads the version from /usr/lib/postgresql/<version>/bin
+func majorVersion(bin string) float64 {
+	version, _ := strconv.ParseFloat(filepath.Base(filepath.Dir(bin)), 64)
+	return version
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
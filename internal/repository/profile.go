@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,14 +20,14 @@ func NewProfileRepo(db *pgxpool.Pool) *ProfileRepo {
 func (r *ProfileRepo) Create(ctx context.Context, profile domain.Profile) error {
 	query := `INSERT INTO profiles (specialty_code, education_type_id, profile_name)
               VALUES ($1, $2, $3)`
-	_, err := r.db.Exec(ctx, query, profile.SpecialtyCode, profile.EducationTypeID, profile.ProfileName)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, profile.SpecialtyCode, profile.EducationTypeID, profile.ProfileName)
 
 	return err
 }
 
 func (r *ProfileRepo) Put(ctx context.Context, profile domain.Profile) error {
 	query := `UPDATE profiles SET specialty_code=$1, education_type_id=$2, profile_name=$3 WHERE profile_id=$4`
-	_, err := r.db.Exec(ctx, query, profile.SpecialtyCode, profile.EducationTypeID, profile.ProfileName, profile.ProfileID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, profile.SpecialtyCode, profile.EducationTypeID, profile.ProfileName, profile.ProfileID)
 
 	return err
 }
@@ -45,14 +46,14 @@ func (r *ProfileRepo) Patch(ctx context.Context, profileID int64, updates map[st
 	query = query[:len(query)-1]
 	query += " WHERE profile_id = $" + strconv.Itoa(argsCounter)
 	args = append(args, profileID)
-	_, err := r.db.Exec(ctx, query, args...)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, args...)
 
 	return err
 }
 
 func (r *ProfileRepo) Delete(ctx context.Context, profileID int64) error {
 	query := `DELETE FROM profiles WHERE profile_id = $1`
-	_, err := r.db.Exec(ctx, query, profileID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, profileID)
 
 	return err
 }
@@ -68,7 +69,7 @@ func (r *ProfileRepo) GetByID(ctx context.Context, profileID int64) (domain.Prof
 		WHERE p.profile_id = $1`
 
 	profileInfo := domain.ProfileInfo{}
-	err := r.db.QueryRow(ctx, query, profileID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, profileID).Scan(
 		&profileInfo.Profile.ProfileID,
 		&profileInfo.Profile.SpecialtyCode,
 		&profileInfo.Profile.EducationTypeID,
@@ -90,7 +91,7 @@ func (r *ProfileRepo) GetByName(ctx context.Context, profileName string) (domain
 		WHERE p.profile_name = $1`
 
 	profileInfo := domain.ProfileInfo{}
-	err := r.db.QueryRow(ctx, query, profileName).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, profileName).Scan(
 		&profileInfo.Profile.ProfileID,
 		&profileInfo.Profile.SpecialtyCode,
 		&profileInfo.Profile.EducationTypeID,
@@ -110,7 +111,7 @@ func (r *ProfileRepo) GetAll(ctx context.Context) ([]domain.ProfileInfo, error)
 		LEFT JOIN 
 			educationTypes et ON p.education_type_id = et.education_type_id`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -154,7 +155,7 @@ func (r *ProfileRepo) GetAllBySpecialtyCode(ctx context.Context, specialtyCode s
 			educationTypes et ON p.education_type_id = et.education_type_id
 		WHERE p.specialty_code = $1`
 
-	rows, err := r.db.Query(ctx, query, specialtyCode)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, specialtyCode)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +199,7 @@ func (r *ProfileRepo) GetByEducationTypeID(ctx context.Context, educationTypeID
 			educationTypes et ON p.education_type_id = et.education_type_id
 		WHERE p.education_type_id = $1`
 
-	rows, err := r.db.Query(ctx, query, educationTypeID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, educationTypeID)
 	if err != nil {
 		return nil, err
 	}
@@ -234,7 +235,7 @@ func (r *ProfileRepo) GetByEducationTypeID(ctx context.Context, educationTypeID
 
 func (r *ProfileRepo) getCountProfiles(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM profiles;`
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -253,7 +254,7 @@ func (r *ProfileRepo) getCountProfiles(ctx context.Context) (int64, error) {
 
 func (r *ProfileRepo) getCountProfilesBySpecialtyCode(ctx context.Context, specialtyCode string) (int64, error) {
 	query := `SELECT COUNT(*) FROM profiles WHERE specialty_code = $1;`
-	rows, err := r.db.Query(ctx, query, specialtyCode)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, specialtyCode)
 	if err != nil {
 		return 0, err
 	}
@@ -272,7 +273,7 @@ func (r *ProfileRepo) getCountProfilesBySpecialtyCode(ctx context.Context, speci
 
 func (r *ProfileRepo) getCountProfilesByEducationTypeID(ctx context.Context, educationTypeID int64) (int64, error) {
 	query := `SELECT COUNT(*) FROM profiles WHERE education_type_id = $1;`
-	rows, err := r.db.Query(ctx, query, educationTypeID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, educationTypeID)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,81 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestProfileRepo(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	if err := repos.EducationType.Create(ctx, domain.EducationType{EducationTypeName: "Заочная"}); err != nil {
+		t.Fatalf("Create() of the education type error = %v", err)
+	}
+	profile := domain.Profile{SpecialtyCode: "35.03.06", EducationTypeID: 2, ProfileName: "Электрооборудование"}
+	if err := repos.Profile.Create(ctx, profile); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repos.Profile.Create(ctx, profile); !isViolation(err, "23505") {
+		t.Errorf("Create() of a duplicate name error = %v, want a unique violation", err)
+	}
+
+	created, err := repos.Profile.GetByName(ctx, profile.ProfileName)
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if created.Profile.ProfileID != 2 || created.ProfileSub.EducationTypeName != "Заочная" {
+		t.Errorf("created profile = %+v", created)
+	}
+
+	put := created.Profile
+	put.ProfileName = "Электрооборудование и электротехнологии"
+	if err := repos.Profile.Put(ctx, put); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	got, err := repos.Profile.GetByID(ctx, put.ProfileID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Profile != put {
+		t.Errorf("profile after Put() = %+v, want %+v", got.Profile, put)
+	}
+
+	byType, err := repos.Profile.GetByEducationTypeID(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetByEducationTypeID() error = %v", err)
+	}
+	if len(byType) != 1 || byType[0].Profile.ProfileID != put.ProfileID {
+		t.Errorf("profiles of the education type = %+v", byType)
+	}
+	// the profile moves to the full-time education
+	if err := repos.Profile.Patch(ctx, put.ProfileID, map[string]interface{}{"education_type_id": int64(1)}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	byType, err = repos.Profile.GetByEducationTypeID(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetByEducationTypeID() error = %v", err)
+	}
+	bySpecialty, err := repos.Profile.GetAllBySpecialtyCode(ctx, "35.03.06")
+	if err != nil {
+		t.Fatalf("GetAllBySpecialtyCode() error = %v", err)
+	}
+	all, err := repos.Profile.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(byType) != 0 || len(bySpecialty) != 2 || len(all) != 2 {
+		t.Errorf("profiles of the part-time education = %d, of the specialty = %d, all = %d, want 0, 2 and 2", len(byType), len(bySpecialty), len(all))
+	}
+
+	if err := repos.Profile.Delete(ctx, put.ProfileID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Profile.GetByID(ctx, put.ProfileID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted profile error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
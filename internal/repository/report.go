@@ -5,9 +5,15 @@ import (
 	"time"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const tracerName = "github.com/BeRebornBng/OsauAmsApi/internal/repository"
+
 type ReportRepo struct {
 	db *pgxpool.Pool
 }
@@ -44,15 +50,19 @@ func (r *ReportRepo) GetActualReportByGroupIDCreated(ctx context.Context, groupI
 	ON et.education_type_id = p.education_type_id
 	INNER JOIN groups g
 	ON g.profile_id = p.profile_id AND g.group_id = $1`
+	headCtx, headSpan := otel.Tracer(tracerName).Start(ctx, "ReportRepo.ReportHead")
 	var reportHead domain.ReportHead = domain.ReportHead{}
-	if err := r.db.QueryRow(ctx, query, groupID).Scan(&reportHead.UniversityName, &reportHead.UniversityHead,
+	if err := postgres.Conn(headCtx, r.db).QueryRow(headCtx, query, groupID).Scan(&reportHead.UniversityName, &reportHead.UniversityHead,
 		&reportHead.FacultyName, &reportHead.FacultyHead,
 		&reportHead.DepartamentName, &reportHead.DepartamentHead,
 		&reportHead.GroupID, &reportHead.SpecialtyName,
 		&reportHead.EducationLevelName, &reportHead.ProfileName,
 		&reportHead.EducationTypeName); err != nil {
+		endSpan(headSpan, err)
 		return nil, err
 	}
+	headSpan.End()
+
 	query = `WITH subatt AS (
 		SELECT
 			t.student_id,
@@ -62,6 +72,25 @@ func (r *ReportRepo) GetActualReportByGroupIDCreated(ctx context.Context, groupI
 			COUNT(t.presence) AS total,
 			ROUND(CAST(COUNT(CASE t.presence WHEN true THEN 1 END) * 100.0 / COUNT(t.presence) AS NUMERIC), 2) AS percentage_of_visits
 		FROM attendance t
+		INNER JOIN students s ON s.student_id = t.student_id
+		INNER JOIN schedules ts ON ts.schedule_id = t.schedule_id
+		WHERE t.created >= $2 AND t.created <= $3
+		AND is_teaching_day(group_university_id($1), t.created::date)
+		AND (
+			EXISTS (
+				SELECT 1 FROM student_group_history h
+				WHERE h.student_id = t.student_id AND h.group_id = $1
+				AND h.valid_from <= t.created::date AND (h.valid_to IS NULL OR t.created::date < h.valid_to)
+			)
+			OR (
+				s.group_id = $1
+				AND NOT EXISTS (SELECT 1 FROM student_group_history h WHERE h.student_id = t.student_id)
+			)
+		)
+		AND (
+			ts.subgroup_id IS NULL
+			OR EXISTS (SELECT 1 FROM subgroup_students ss WHERE ss.subgroup_id = ts.subgroup_id AND ss.student_id = t.student_id)
+		)
 		GROUP BY t.student_id, t.schedule_id
 	)
 	SELECT
@@ -84,7 +113,7 @@ func (r *ReportRepo) GetActualReportByGroupIDCreated(ctx context.Context, groupI
 		COALESCE(subatt.percentage_of_visits, 0) AS percentage_of_visits,
 		at.created
 	FROM attendance at
-	INNER JOIN students st ON st.student_id = at.student_id AND st.group_id = $1
+	INNER JOIN students st ON st.student_id = at.student_id
 	INNER JOIN schedules sch ON at.schedule_id = sch.schedule_id
 	INNER JOIN disciplineTypes dtype ON sch.discipline_type_id = dtype.discipline_type_id
 	INNER JOIN classrooms cr ON sch.classroom_id = cr.classroom_id
@@ -92,6 +121,22 @@ func (r *ReportRepo) GetActualReportByGroupIDCreated(ctx context.Context, groupI
 	INNER JOIN teachers teach ON teach.teacher_id = sch.teacher_id
 	INNER JOIN subatt ON at.student_id = subatt.student_id AND at.schedule_id = subatt.schedule_id
 	WHERE at.created >= $2 and at.created <= $3
+	AND is_teaching_day(group_university_id($1), at.created::date)
+	AND (
+		EXISTS (
+			SELECT 1 FROM student_group_history h
+			WHERE h.student_id = at.student_id AND h.group_id = $1
+			AND h.valid_from <= at.created::date AND (h.valid_to IS NULL OR at.created::date < h.valid_to)
+		)
+		OR (
+			st.group_id = $1
+			AND NOT EXISTS (SELECT 1 FROM student_group_history h WHERE h.student_id = at.student_id)
+		)
+	)
+	AND (
+		sch.subgroup_id IS NULL
+		OR EXISTS (SELECT 1 FROM subgroup_students ss WHERE ss.subgroup_id = sch.subgroup_id AND ss.student_id = at.student_id)
+	)
 	GROUP BY
 		sch.semester,
 		sch.week_type,
@@ -112,8 +157,10 @@ func (r *ReportRepo) GetActualReportByGroupIDCreated(ctx context.Context, groupI
 		subatt.percentage_of_visits,
 		at.created
 	ORDER BY st.last_name ASC;`
-	rows, err := r.db.Query(ctx, query, groupID, startRange, endRange)
+	dataCtx, dataSpan := otel.Tracer(tracerName).Start(ctx, "ReportRepo.ReportData")
+	rows, err := postgres.Conn(dataCtx, r.db).Query(dataCtx, query, groupID, startRange, endRange)
 	if err != nil {
+		endSpan(dataSpan, err)
 		return nil, err
 	}
 	defer rows.Close()
@@ -142,16 +189,27 @@ func (r *ReportRepo) GetActualReportByGroupIDCreated(ctx context.Context, groupI
 			&report.Created,
 		)
 		if err != nil {
+			endSpan(dataSpan, err)
 			return nil, err
 		}
 		reportData = append(reportData, report)
 
 	}
 	if err = rows.Err(); err != nil {
+		endSpan(dataSpan, err)
 		return nil, err
 	}
+	dataSpan.End()
+
 	var attendanceReport domain.AttendanceReport = domain.AttendanceReport{}
 	attendanceReport.ReportHead = reportHead
 	attendanceReport.ReportData = reportData
 	return &attendanceReport, nil
 }
+
+// endSpan ends the span and marks it failed with the error
+func endSpan(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	span.End()
+}
@@ -0,0 +1,96 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func TestReportRepoGetActualReportByGroupIDCreated(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+	start, end := date("2024-09-01"), time.Date(2024, 11, 30, 23, 59, 59, 0, time.UTC)
+
+	report, err := repos.Report.GetActualReportByGroupIDCreated(ctx, firstGroup, start, end)
+	if err != nil {
+		t.Fatalf("GetActualReportByGroupIDCreated() error = %v", err)
+	}
+
+	head := report.ReportHead
+	if head.UniversityName != "Омский ГАУ" || head.UniversityHead != "Шумакова Оксана Викторовна" || head.GroupID != firstGroup {
+		t.Errorf("report head = %+v", head)
+	}
+	if head.SpecialtyName != "Агроинженерия" || head.ProfileName != "Технический сервис" || head.EducationTypeName != "Очная" || head.EducationLevelName != "Бакалавриат" {
+		t.Errorf("report head = %+v", head)
+	}
+
+	// the holiday, the laboratory work of another subgroup and the lessons
+	// of the transferred student in the second group are left out
+	want := map[string]int{
+		"Алексеев Иван Иванович":    3,
+		"Борисов Пётр Петрович":     2,
+		"Васильев Олег Олегович":    1,
+		"Григорьев Денис Денисович": 1,
+	}
+	got := make(map[string]int)
+	previous := ""
+	for _, row := range report.ReportData {
+		got[row.StudentName]++
+		if row.StudentName < previous {
+			t.Errorf("rows aren't ordered by the student: %q after %q", row.StudentName, previous)
+		}
+		previous = row.StudentName
+
+		if !row.Created.Before(date("2024-09-15")) && row.StudentName == "Григорьев Денис Денисович" {
+			t.Errorf("row of the transferred student after the transfer: %+v", row)
+		}
+		if row.Created.Equal(date("2024-11-04")) {
+			t.Errorf("row of the holiday: %+v", row)
+		}
+	}
+	if len(got) != len(want) || len(report.ReportData) != 7 {
+		t.Fatalf("rows = %v, want %v", got, want)
+	}
+	for student, rows := range want {
+		if got[student] != rows {
+			t.Errorf("rows of %s = %d, want %d", student, got[student], rows)
+		}
+	}
+
+	for _, row := range report.ReportData {
+		// the totals leave out the lessons after the transfer like the rows do
+		if row.StudentName == "Григорьев Денис Денисович" && (row.Visits != 1 || row.Total != 1) {
+			t.Errorf("totals of the transferred student = %d %d, want 1 1", row.Visits, row.Total)
+		}
+		if row.StudentName != "Борисов Пётр Петрович" || !row.Created.Equal(date("2024-09-02")) {
+			continue
+		}
+		if row.Presence == nil || *row.Presence || row.Reason == nil || *row.Reason != "болезнь" {
+			t.Errorf("marks = %v %v", row.Presence, row.Reason)
+		}
+		if row.Visits != 1 || row.Passes != 1 || row.Total != 2 || row.PercentageOfVisits != 50 {
+			t.Errorf("totals = %d %d %d %v, want 1 1 2 50", row.Visits, row.Passes, row.Total, row.PercentageOfVisits)
+		}
+		if row.DisciplineName != "Математика" || row.TeacherName != "Морозов Павел Андреевич" || !row.StartTime.Equal(clock("08:30")) {
+			t.Errorf("lesson = %+v", row)
+		}
+	}
+
+	// the totals count only the lessons of the range
+	september, err := repos.Report.GetActualReportByGroupIDCreated(ctx, firstGroup, start, date("2024-09-10"))
+	if err != nil {
+		t.Fatalf("GetActualReportByGroupIDCreated() error = %v", err)
+	}
+	for _, row := range september.ReportData {
+		if row.StudentName == "Борисов Пётр Петрович" && (row.Passes != 1 || row.Total != 1) {
+			t.Errorf("totals in the range = %d %d, want 1 1", row.Passes, row.Total)
+		}
+	}
+
+	if _, err := repos.Report.GetActualReportByGroupIDCreated(ctx, "2023-00.00.00-1", start, end); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("report of a missing group error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
@@ -5,12 +5,20 @@ import (
 	"time"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// ITransactor runs fn in one transaction, the repositories called with the context
+// passed to fn take part in it
+type ITransactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type IStudent interface {
-	Create(ctx context.Context, student domain.Student) error
+	Create(ctx context.Context, student domain.Student) (int64, error)
+	CreateWithAccounts(ctx context.Context, accounts []domain.StudentAccount) ([]int64, error)
 	Put(ctx context.Context, student domain.Student) error
 	Patch(ctx context.Context, studentID int64, updates map[string]interface{}) error
 	Delete(ctx context.Context, studentID int64) error
@@ -22,9 +30,12 @@ type IStudent interface {
 
 type ISchedule interface {
 	Create(ctx context.Context, schedule domain.Schedule) error
+	CreateMany(ctx context.Context, schedules []domain.Schedule) error
+	Rollover(ctx context.Context, semester int, schedules []domain.Schedule) error
 	Put(ctx context.Context, schedule domain.Schedule) error
 	Patch(ctx context.Context, scheduleID int64, updates map[string]interface{}) error
 	Delete(ctx context.Context, scheduleID int64) error
+	DeleteByGroupID(ctx context.Context, groupID string) error
 	GetByID(ctx context.Context, scheduleID int64) (domain.ScheduleInfo, error)
 	GetAll(ctx context.Context) ([]domain.ScheduleInfo, error)
 	GetByGroupID(ctx context.Context, groupID string) ([]domain.ScheduleInfo, error)
@@ -39,6 +50,56 @@ type ISchedule interface {
 	GetActualByTeacherAndWeekType(ctx context.Context, teacherID int64, weekType string) ([]domain.ScheduleInfo, error)
 	GetActualByGroupWeekTypeAndDay(ctx context.Context, groupID, weekType, dayOfWeek string) ([]domain.ScheduleInfo, error)
 	GetActualByGroupAndWeekType(ctx context.Context, groupID string, weekType string) ([]domain.ScheduleInfo, error)
+	GetActualByGroupAndDay(ctx context.Context, groupID, dayOfWeek string) ([]domain.ScheduleInfo, error)
+	GetActualByTeacherAndDay(ctx context.Context, teacherID int64, dayOfWeek string) ([]domain.ScheduleInfo, error)
+}
+
+type ILessonSlot interface {
+	Create(ctx context.Context, slot domain.LessonSlot) (int64, error)
+	Put(ctx context.Context, slot domain.LessonSlot) error
+	Delete(ctx context.Context, slotID int64) error
+	GetByID(ctx context.Context, slotID int64) (domain.LessonSlot, error)
+	GetByUniversityID(ctx context.Context, universityID int64) ([]domain.LessonSlot, error)
+	GetByGroupID(ctx context.Context, groupID string) ([]domain.LessonSlot, error)
+}
+
+type ISubgroup interface {
+	Create(ctx context.Context, subgroup domain.Subgroup) (int64, error)
+	Put(ctx context.Context, subgroup domain.Subgroup) error
+	Delete(ctx context.Context, subgroupID int64) error
+	GetByID(ctx context.Context, subgroupID int64) (domain.Subgroup, error)
+	GetByGroupID(ctx context.Context, groupID string) ([]domain.Subgroup, error)
+	HasStudent(ctx context.Context, subgroupID int64, studentID int64) (bool, error)
+}
+
+type ICalendar interface {
+	Create(ctx context.Context, period domain.CalendarPeriod) (int64, error)
+	Put(ctx context.Context, period domain.CalendarPeriod) error
+	Delete(ctx context.Context, periodID int64) error
+	GetByID(ctx context.Context, periodID int64) (domain.CalendarPeriod, error)
+	GetByUniversityID(ctx context.Context, universityID int64, from, to time.Time) ([]domain.CalendarPeriod, error)
+	IsTeachingDay(ctx context.Context, groupID string, date time.Time) (bool, error)
+	GetTeachingDays(ctx context.Context, groupID string, semester int) ([]time.Time, error)
+}
+
+type ICurriculum interface {
+	Create(ctx context.Context, item domain.CurriculumItem) (int64, error)
+	Put(ctx context.Context, item domain.CurriculumItem) error
+	Delete(ctx context.Context, curriculumID int64) error
+	GetByID(ctx context.Context, curriculumID int64) (domain.CurriculumItemInfo, error)
+	GetByProfileID(ctx context.Context, profileID int64) ([]domain.CurriculumItemInfo, error)
+	GetByGroupAndSemester(ctx context.Context, groupID string, semester int) ([]domain.CurriculumItemInfo, error)
+	CountHeldLessons(ctx context.Context, groupID string, semester int) ([]domain.HeldLessons, error)
+}
+
+type IScheduleException interface {
+	Create(ctx context.Context, exception domain.ScheduleException) (int64, error)
+	Put(ctx context.Context, exception domain.ScheduleException) error
+	Delete(ctx context.Context, exceptionID int64) error
+	GetByID(ctx context.Context, exceptionID int64) (domain.ScheduleExceptionInfo, error)
+	GetByScheduleID(ctx context.Context, scheduleID int64) ([]domain.ScheduleExceptionInfo, error)
+	GetByScheduleAndDate(ctx context.Context, scheduleID int64, date time.Time) ([]domain.ScheduleExceptionInfo, error)
+	GetByDate(ctx context.Context, date time.Time) ([]domain.ScheduleExceptionInfo, error)
 }
 
 type IAttendance interface {
@@ -50,12 +111,15 @@ type IAttendance interface {
 	GetByStudentID(ctx context.Context, studentID int64) ([]domain.AttendanceInfo, error)
 	GetAll(ctx context.Context) ([]domain.AttendanceInfo, error)
 	GetAllByGroupIDAndCreated(ctx context.Context, groupID string, scheduleID int64, created time.Time) ([]domain.GroupAttendanceInfo, error)
+	ExistsByGroupID(ctx context.Context, groupID string) (bool, error)
 }
 
 type IUser interface {
 	Create(ctx context.Context, user domain.User) error
 	Put(ctx context.Context, user domain.User) error
 	Patch(ctx context.Context, userID uuid.UUID, updates map[string]interface{}) error
+	UpdatePassword(ctx context.Context, userID uuid.UUID, password string) error
+	RevokeTokens(ctx context.Context, userID uuid.UUID) error
 	Delete(ctx context.Context, userID uuid.UUID) error
 	GetByID(ctx context.Context, userID uuid.UUID) (domain.UserInfo, error)
 	GetByName(ctx context.Context, username string) (domain.UserInfo, error)
@@ -66,6 +130,12 @@ type IUser interface {
 	GetAll(ctx context.Context) ([]domain.UserInfo, error)
 }
 
+type IPasswordReset interface {
+	Create(ctx context.Context, token domain.PasswordResetToken) error
+	Consume(ctx context.Context, tokenHash string, now time.Time) (uuid.UUID, error)
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
 type IHeadman interface {
 	Create(ctx context.Context, headman domain.Headman) error
 	Put(ctx context.Context, headman domain.Headman) error
@@ -74,6 +144,11 @@ type IHeadman interface {
 	GetByID(ctx context.Context, headmanID int64) (domain.HeadmanInfo, error)
 	GetByStudentID(ctx context.Context, studentID int64) (domain.HeadmanInfo, error)
 	GetAll(ctx context.Context) ([]domain.HeadmanInfo, error)
+	GetAllByGroupID(ctx context.Context, groupID string) ([]domain.HeadmanInfo, error)
+	GetAllByStudentID(ctx context.Context, studentID int64) ([]domain.HeadmanInfo, error)
+	IsActive(ctx context.Context, studentID int64, groupID string, date time.Time) (bool, error)
+	SyncRoles(ctx context.Context, date time.Time) (demoted int64, promoted int64, err error)
+	Demote(ctx context.Context, headmanID int64) error
 }
 
 type IUniversity interface {
@@ -115,6 +190,7 @@ type ITeacher interface {
 	Delete(ctx context.Context, teacherID int64) error
 	GetByID(ctx context.Context, teacherID int64) (domain.TeacherInfo, error)
 	GetByEmail(ctx context.Context, teacherEmail string) (domain.TeacherInfo, error)
+	GetAllByLastName(ctx context.Context, lastName string) ([]domain.TeacherInfo, error)
 	GetAll(ctx context.Context) ([]domain.TeacherInfo, error)
 	GetAllByDepartamentID(ctx context.Context, departamentID int64) ([]domain.TeacherInfo, error)
 }
@@ -136,6 +212,7 @@ type IDisciplineType interface {
 	Patch(ctx context.Context, disciplineTypeID int64, updates map[string]interface{}) error
 	Delete(ctx context.Context, disciplineTypeID int64) error
 	GetByID(ctx context.Context, disciplineTypeID int64) (domain.DisciplineType, error)
+	GetByName(ctx context.Context, disciplineTypeName string) (domain.DisciplineType, error)
 	GetAll(ctx context.Context) ([]domain.DisciplineType, error)
 }
 
@@ -145,7 +222,9 @@ type IClassroom interface {
 	Patch(ctx context.Context, classroomID int64, updates map[string]interface{}) error
 	Delete(ctx context.Context, classroomID int64) error
 	GetByID(ctx context.Context, classroomID int64) (domain.Classroom, error)
+	GetByName(ctx context.Context, classroomName string) (domain.Classroom, error)
 	GetAll(ctx context.Context) ([]domain.Classroom, error)
+	GetFree(ctx context.Context, filter domain.FreeClassroomFilter) ([]domain.Classroom, error)
 }
 
 type IEducationLevel interface {
@@ -201,50 +280,96 @@ type IEducationType interface {
 	GetAll(ctx context.Context) ([]domain.EducationType, error)
 }
 
+type IMembership interface {
+	GetByStudentID(ctx context.Context, studentID int64) ([]domain.GroupMembership, error)
+	Transfer(ctx context.Context, transfer domain.StudentTransfer) error
+	Promote(ctx context.Context, promotions []domain.GroupPromotion, groups []domain.Group, date time.Time) error
+}
+
+type IGradebook interface {
+	SetMark(ctx context.Context, mark domain.Mark) (int64, error)
+	DeleteMark(ctx context.Context, markID int64) error
+	GetMarkByID(ctx context.Context, markID int64) (domain.Mark, error)
+	GetStudentMarks(ctx context.Context, studentID int64, semester int) ([]domain.MarkInfo, error)
+	CreateControlPoint(ctx context.Context, point domain.ControlPoint) (int64, error)
+	DeleteControlPoint(ctx context.Context, controlPointID int64) error
+	GetControlPointByID(ctx context.Context, controlPointID int64) (domain.ControlPoint, error)
+	GetControlPoints(ctx context.Context, groupID string, disciplineID int64, semester int) ([]domain.ControlPoint, error)
+	SetControlPointResult(ctx context.Context, result domain.ControlPointResult) error
+	GetStudentControlPoints(ctx context.Context, studentID int64, semester int) ([]domain.ControlPointScore, error)
+	SetFinalResult(ctx context.Context, result domain.FinalResult) (int64, error)
+	GetStudentFinalResults(ctx context.Context, studentID int64, semester int) ([]domain.FinalResult, error)
+	GetStudentAttendance(ctx context.Context, studentID int64, disciplineID int64, semester int) (domain.DisciplineAttendance, error)
+}
+
+type IWorkload interface {
+	CountDeliveredLessons(ctx context.Context, teacherIDs []int64, semester int) ([]domain.DeliveredLessons, error)
+}
+
 type IReport interface {
 	GetActualReportByGroupIDCreated(ctx context.Context, groupID string, startRange time.Time, endRange time.Time) (*domain.AttendanceReport, error)
 }
 
 type Repositories struct {
-	Student        IStudent
-	Schedule       ISchedule
-	Headman        IHeadman
-	Attendance     IAttendance
-	User           IUser
-	University     IUniversity
-	Faculty        IFaculty
-	Departament    IDepartament
-	Teacher        ITeacher
-	Discipline     IDiscipline
-	DisciplineType IDisciplineType
-	Classroom      IClassroom
-	EducationLevel IEducationLevel
-	Specialty      ISpecialty
-	Profile        IProfile
-	Group          IGroup
-	EducationType  IEducationType
-	Report         IReport
+	Transactor        ITransactor
+	Student           IStudent
+	Schedule          ISchedule
+	Headman           IHeadman
+	Attendance        IAttendance
+	User              IUser
+	University        IUniversity
+	Faculty           IFaculty
+	Departament       IDepartament
+	Teacher           ITeacher
+	Discipline        IDiscipline
+	DisciplineType    IDisciplineType
+	Classroom         IClassroom
+	EducationLevel    IEducationLevel
+	Specialty         ISpecialty
+	Profile           IProfile
+	Group             IGroup
+	EducationType     IEducationType
+	Report            IReport
+	PasswordReset     IPasswordReset
+	Membership        IMembership
+	ScheduleException IScheduleException
+	Calendar          ICalendar
+	LessonSlot        ILessonSlot
+	Subgroup          ISubgroup
+	Curriculum        ICurriculum
+	Workload          IWorkload
+	Gradebook         IGradebook
 }
 
 func NewRepositories(db *pgxpool.Pool) *Repositories {
 	return &Repositories{
-		Student:        NewStudentRepo(db),
-		Schedule:       NewScheduleRepo(db),
-		Headman:        NewHeadmanRepo(db),
-		Attendance:     NewAttendanceRepo(db),
-		User:           NewUserRepo(db),
-		University:     NewUniversityRepo(db),
-		Faculty:        NewFacultyRepo(db),
-		Departament:    NewDepartamentRepo(db),
-		Teacher:        NewTeacherRepo(db),
-		Discipline:     NewDisciplineRepo(db),
-		DisciplineType: NewDisciplineTypeRepo(db),
-		Classroom:      NewClassroomRepo(db),
-		EducationLevel: NewEducationLevelRepo(db),
-		Specialty:      NewSpecialtyRepo(db),
-		Profile:        NewProfileRepo(db),
-		Group:          NewGroupRepo(db),
-		EducationType:  NewEducationTypeRepo(db),
-		Report:         NewReportRepo(db),
+		Transactor:        postgres.NewTxManager(db),
+		Student:           NewStudentRepo(db),
+		Schedule:          NewScheduleRepo(db),
+		Headman:           NewHeadmanRepo(db),
+		Attendance:        NewAttendanceRepo(db),
+		User:              NewUserRepo(db),
+		University:        NewUniversityRepo(db),
+		Faculty:           NewFacultyRepo(db),
+		Departament:       NewDepartamentRepo(db),
+		Teacher:           NewTeacherRepo(db),
+		Discipline:        NewDisciplineRepo(db),
+		DisciplineType:    NewDisciplineTypeRepo(db),
+		Classroom:         NewClassroomRepo(db),
+		EducationLevel:    NewEducationLevelRepo(db),
+		Specialty:         NewSpecialtyRepo(db),
+		Profile:           NewProfileRepo(db),
+		Group:             NewGroupRepo(db),
+		EducationType:     NewEducationTypeRepo(db),
+		Report:            NewReportRepo(db),
+		PasswordReset:     NewPasswordResetRepo(db),
+		Membership:        NewMembershipRepo(db),
+		ScheduleException: NewScheduleExceptionRepo(db),
+		Calendar:          NewCalendarRepo(db),
+		LessonSlot:        NewLessonSlotRepo(db),
+		Subgroup:          NewSubgroupRepo(db),
+		Curriculum:        NewCurriculumRepo(db),
+		Workload:          NewWorkloadRepo(db),
+		Gradebook:         NewGradebookRepo(db),
 	}
 }
@@ -2,10 +2,14 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,19 +23,101 @@ func NewScheduleRepo(db *pgxpool.Pool) *ScheduleRepo {
 
 func (r *ScheduleRepo) Create(ctx context.Context, schedule domain.Schedule) error {
 	query := `INSERT INTO schedules (
-		group_id, discipline_id, teacher_id, discipline_type_id, classroom_id, semester, week_type, day_of_week, start_time, is_actual
-	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
-	_, err := r.db.Exec(ctx, query,
-		schedule.GroupID, schedule.DisciplineID, schedule.TeacherID, schedule.DisciplineTypeID, schedule.ClassroomID, schedule.Semester, schedule.WeekType, schedule.DayOfWeek, schedule.StartTime, schedule.IsActual)
+		group_id, discipline_id, teacher_id, discipline_type_id, classroom_id, semester, begin_studies, week_type, day_of_week, start_time, slot_id, subgroup_id, is_actual
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query,
+		schedule.GroupID, schedule.DisciplineID, schedule.TeacherID, schedule.DisciplineTypeID, schedule.ClassroomID, schedule.Semester, beginStudies(schedule), schedule.WeekType, schedule.DayOfWeek, schedule.StartTime, schedule.SlotID, schedule.SubgroupID, schedule.IsActual)
 	return err
 }
 
+// slotByStartTime finds the slot of the bell schedule of the group's university by the start time
+const slotByStartTime = `(SELECT ls.slot_id FROM lesson_slots ls WHERE ls.university_id = group_university_id($1) AND ls.start_time = $%d)`
+
+// beginStudies stores an unknown start of studies as NULL
+func beginStudies(schedule domain.Schedule) *time.Time {
+	if schedule.BeginStudies.IsZero() {
+		return nil
+	}
+	return &schedule.BeginStudies
+}
+
+// nullDate scans a nullable date, NULL leaves the zero time like an unknown start of studies
+type nullDate struct {
+	date *time.Time
+}
+
+func (n nullDate) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*n.date = time.Time{}
+	case time.Time:
+		*n.date = value
+	default:
+		return fmt.Errorf("can't scan %T into a date", src)
+	}
+	return nil
+}
+
+// CreateMany inserts all schedules in one transaction
+func (r *ScheduleRepo) CreateMany(ctx context.Context, schedules []domain.Schedule) error {
+	tx, err := postgres.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO schedules (
+		group_id, discipline_id, teacher_id, discipline_type_id, classroom_id, semester, week_type, day_of_week, start_time, slot_id, is_actual
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, ` + fmt.Sprintf(slotByStartTime, 9) + `, $10)`
+
+	batch := &pgx.Batch{}
+	for _, schedule := range schedules {
+		batch.Queue(query,
+			schedule.GroupID, schedule.DisciplineID, schedule.TeacherID, schedule.DisciplineTypeID, schedule.ClassroomID, schedule.Semester, schedule.WeekType, schedule.DayOfWeek, schedule.StartTime, schedule.IsActual)
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Rollover archives the actual schedules of the semester and inserts the schedules
+// of the next semester in one transaction
+func (r *ScheduleRepo) Rollover(ctx context.Context, semester int, schedules []domain.Schedule) error {
+	tx, err := postgres.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	archiveQuery := `UPDATE schedules SET is_actual = false WHERE semester = $1 AND is_actual`
+	if _, err := tx.Exec(ctx, archiveQuery, semester); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO schedules (
+		group_id, discipline_id, teacher_id, discipline_type_id, classroom_id, semester, begin_studies, week_type, day_of_week, start_time, slot_id, subgroup_id, is_actual
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, ` + fmt.Sprintf(slotByStartTime, 10) + `, $11, $12)`
+
+	batch := &pgx.Batch{}
+	for _, schedule := range schedules {
+		batch.Queue(query,
+			schedule.GroupID, schedule.DisciplineID, schedule.TeacherID, schedule.DisciplineTypeID, schedule.ClassroomID, schedule.Semester, schedule.BeginStudies, schedule.WeekType, schedule.DayOfWeek, schedule.StartTime, schedule.SubgroupID, schedule.IsActual)
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
 func (r *ScheduleRepo) Put(ctx context.Context, schedule domain.Schedule) error {
 	query := `UPDATE schedules SET 
-		group_id=$1, discipline_id=$2, teacher_id=$3, discipline_type_id=$4, classroom_id=$5, semester=$6, week_type=$7, day_of_week=$8, start_time=$9, is_actual=$10
-		WHERE schedule_id=$11`
-	_, err := r.db.Exec(ctx, query,
-		schedule.GroupID, schedule.DisciplineID, schedule.TeacherID, schedule.DisciplineTypeID, schedule.ClassroomID, schedule.Semester, schedule.WeekType, schedule.DayOfWeek, schedule.StartTime, schedule.IsActual, schedule.ScheduleID)
+		group_id=$1, discipline_id=$2, teacher_id=$3, discipline_type_id=$4, classroom_id=$5, semester=$6, begin_studies=$7, week_type=$8, day_of_week=$9, start_time=$10, slot_id=$11, subgroup_id=$12, is_actual=$13
+		WHERE schedule_id=$14`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query,
+		schedule.GroupID, schedule.DisciplineID, schedule.TeacherID, schedule.DisciplineTypeID, schedule.ClassroomID, schedule.Semester, beginStudies(schedule), schedule.WeekType, schedule.DayOfWeek, schedule.StartTime, schedule.SlotID, schedule.SubgroupID, schedule.IsActual, schedule.ScheduleID)
 	return err
 }
 
@@ -49,19 +135,25 @@ func (r *ScheduleRepo) Patch(ctx context.Context, scheduleID int64, updates map[
 	query = query[:len(query)-1]
 	query += " WHERE schedule_id = $" + strconv.Itoa(argsCounter)
 	args = append(args, scheduleID)
-	_, err := r.db.Exec(ctx, query, args...)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, args...)
 	return err
 }
 
 func (r *ScheduleRepo) Delete(ctx context.Context, scheduleID int64) error {
 	query := `DELETE FROM schedules WHERE schedule_id = $1`
-	_, err := r.db.Exec(ctx, query, scheduleID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, scheduleID)
+	return err
+}
+
+func (r *ScheduleRepo) DeleteByGroupID(ctx context.Context, groupID string) error {
+	query := `DELETE FROM schedules WHERE group_id = $1`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, groupID)
 	return err
 }
 
 func (r *ScheduleRepo) GetByID(ctx context.Context, scheduleID int64) (domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -71,13 +163,19 @@ func (r *ScheduleRepo) GetByID(ctx context.Context, scheduleID int64) (domain.Sc
 	WHERE s.schedule_id = $1`
 
 	scheduleInfo := domain.ScheduleInfo{}
-	err := r.db.QueryRow(ctx, query, scheduleID).Scan(
-		&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+	var beginStudies *time.Time
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, scheduleID).Scan(
+		&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &beginStudies, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 		&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
+	if beginStudies != nil {
+		scheduleInfo.Schedule.BeginStudies = *beginStudies
+	}
 
 	return scheduleInfo, err
 }
 
+// getCountSchedules runs on the pool even in a transaction: the count only sizes the result
+// and runs alongside the main query, which a transaction's single connection can't do
 func (r *ScheduleRepo) getCountSchedules(ctx context.Context, query string, args ...interface{}) (int64, error) {
 	row := r.db.QueryRow(ctx, query, args...)
 	var count int64
@@ -87,7 +185,7 @@ func (r *ScheduleRepo) getCountSchedules(ctx context.Context, query string, args
 
 func (r *ScheduleRepo) GetAll(ctx context.Context) ([]domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -108,7 +206,7 @@ func (r *ScheduleRepo) GetAll(ctx context.Context) ([]domain.ScheduleInfo, error
 		count, countErr = r.getCountSchedules(ctx, countQuery)
 	}()
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +223,7 @@ func (r *ScheduleRepo) GetAll(ctx context.Context) ([]domain.ScheduleInfo, error
 	for rows.Next() {
 		var scheduleInfo domain.ScheduleInfo
 		err := rows.Scan(
-			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, nullDate{&scheduleInfo.Schedule.BeginStudies}, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
 		if err != nil {
 			return nil, err
@@ -142,7 +240,7 @@ func (r *ScheduleRepo) GetAll(ctx context.Context) ([]domain.ScheduleInfo, error
 
 func (r *ScheduleRepo) GetByGroupID(ctx context.Context, groupID string) ([]domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -151,7 +249,7 @@ func (r *ScheduleRepo) GetByGroupID(ctx context.Context, groupID string) ([]doma
 	LEFT JOIN classrooms c ON s.classroom_id = c.classroom_id
 	WHERE s.group_id = $1`
 
-	rows, err := r.db.Query(ctx, query, groupID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, groupID)
 	if err != nil {
 		return nil, err
 	}
@@ -162,7 +260,7 @@ func (r *ScheduleRepo) GetByGroupID(ctx context.Context, groupID string) ([]doma
 	for rows.Next() {
 		var scheduleInfo domain.ScheduleInfo
 		err := rows.Scan(
-			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, nullDate{&scheduleInfo.Schedule.BeginStudies}, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
 		if err != nil {
 			return nil, err
@@ -179,7 +277,7 @@ func (r *ScheduleRepo) GetByGroupID(ctx context.Context, groupID string) ([]doma
 
 func (r *ScheduleRepo) GetByTeacherID(ctx context.Context, teacherID int64) ([]domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -188,7 +286,7 @@ func (r *ScheduleRepo) GetByTeacherID(ctx context.Context, teacherID int64) ([]d
 	LEFT JOIN classrooms c ON s.classroom_id = c.classroom_id
 	WHERE s.teacher_id = $1`
 
-	rows, err := r.db.Query(ctx, query, teacherID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, teacherID)
 	if err != nil {
 		return nil, err
 	}
@@ -199,7 +297,7 @@ func (r *ScheduleRepo) GetByTeacherID(ctx context.Context, teacherID int64) ([]d
 	for rows.Next() {
 		var scheduleInfo domain.ScheduleInfo
 		err := rows.Scan(
-			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, nullDate{&scheduleInfo.Schedule.BeginStudies}, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
 		if err != nil {
 			return nil, err
@@ -216,7 +314,7 @@ func (r *ScheduleRepo) GetByTeacherID(ctx context.Context, teacherID int64) ([]d
 
 func (r *ScheduleRepo) GetByGroupAndWeekType(ctx context.Context, groupID string, weekType string) ([]domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -225,7 +323,7 @@ func (r *ScheduleRepo) GetByGroupAndWeekType(ctx context.Context, groupID string
 	LEFT JOIN classrooms c ON s.classroom_id = c.classroom_id
 	WHERE s.group_id = $1 AND s.week_type = $2`
 
-	rows, err := r.db.Query(ctx, query, groupID, weekType)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, groupID, weekType)
 	if err != nil {
 		return nil, err
 	}
@@ -236,7 +334,7 @@ func (r *ScheduleRepo) GetByGroupAndWeekType(ctx context.Context, groupID string
 	for rows.Next() {
 		var scheduleInfo domain.ScheduleInfo
 		err := rows.Scan(
-			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, nullDate{&scheduleInfo.Schedule.BeginStudies}, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
 		if err != nil {
 			return nil, err
@@ -253,7 +351,7 @@ func (r *ScheduleRepo) GetByGroupAndWeekType(ctx context.Context, groupID string
 
 func (r *ScheduleRepo) GetByTeacherAndWeekType(ctx context.Context, teacherID int64, weekType string) ([]domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -262,7 +360,7 @@ func (r *ScheduleRepo) GetByTeacherAndWeekType(ctx context.Context, teacherID in
 	LEFT JOIN classrooms c ON s.classroom_id = c.classroom_id
 	WHERE s.teacher_id = $1 AND s.week_type = $2`
 
-	rows, err := r.db.Query(ctx, query, teacherID, weekType)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, teacherID, weekType)
 	if err != nil {
 		return nil, err
 	}
@@ -273,7 +371,7 @@ func (r *ScheduleRepo) GetByTeacherAndWeekType(ctx context.Context, teacherID in
 	for rows.Next() {
 		var scheduleInfo domain.ScheduleInfo
 		err := rows.Scan(
-			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, nullDate{&scheduleInfo.Schedule.BeginStudies}, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
 		if err != nil {
 			return nil, err
@@ -290,7 +388,7 @@ func (r *ScheduleRepo) GetByTeacherAndWeekType(ctx context.Context, teacherID in
 
 func (r *ScheduleRepo) GetByGroupWeekTypeAndDay(ctx context.Context, groupID, weekType, dayOfWeek string) ([]domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -299,7 +397,7 @@ func (r *ScheduleRepo) GetByGroupWeekTypeAndDay(ctx context.Context, groupID, we
 	LEFT JOIN classrooms c ON s.classroom_id = c.classroom_id
 	WHERE s.group_id = $1 AND s.week_type = $2 AND s.day_of_week = $3`
 
-	rows, err := r.db.Query(ctx, query, groupID, weekType, dayOfWeek)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, groupID, weekType, dayOfWeek)
 	if err != nil {
 		return nil, err
 	}
@@ -310,7 +408,7 @@ func (r *ScheduleRepo) GetByGroupWeekTypeAndDay(ctx context.Context, groupID, we
 	for rows.Next() {
 		var scheduleInfo domain.ScheduleInfo
 		err := rows.Scan(
-			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, nullDate{&scheduleInfo.Schedule.BeginStudies}, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
 		if err != nil {
 			return nil, err
@@ -327,7 +425,7 @@ func (r *ScheduleRepo) GetByGroupWeekTypeAndDay(ctx context.Context, groupID, we
 
 func (r *ScheduleRepo) GetByTeacherWeekTypeAndDay(ctx context.Context, teacherID int64, weekType, dayOfWeek string) ([]domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -336,7 +434,7 @@ func (r *ScheduleRepo) GetByTeacherWeekTypeAndDay(ctx context.Context, teacherID
 	LEFT JOIN classrooms c ON s.classroom_id = c.classroom_id
 	WHERE s.teacher_id = $1 AND s.week_type = $2 AND s.day_of_week = $3`
 
-	rows, err := r.db.Query(ctx, query, teacherID, weekType, dayOfWeek)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, teacherID, weekType, dayOfWeek)
 	if err != nil {
 		return nil, err
 	}
@@ -347,7 +445,7 @@ func (r *ScheduleRepo) GetByTeacherWeekTypeAndDay(ctx context.Context, teacherID
 	for rows.Next() {
 		var scheduleInfo domain.ScheduleInfo
 		err := rows.Scan(
-			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, nullDate{&scheduleInfo.Schedule.BeginStudies}, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
 		if err != nil {
 			return nil, err
@@ -364,7 +462,7 @@ func (r *ScheduleRepo) GetByTeacherWeekTypeAndDay(ctx context.Context, teacherID
 
 func (r *ScheduleRepo) GetActualByGroupID(ctx context.Context, groupID string) ([]domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -373,7 +471,7 @@ func (r *ScheduleRepo) GetActualByGroupID(ctx context.Context, groupID string) (
 	LEFT JOIN classrooms c ON s.classroom_id = c.classroom_id
 	WHERE s.group_id = $1 AND s.is_actual = TRUE`
 
-	rows, err := r.db.Query(ctx, query, groupID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, groupID)
 	if err != nil {
 		return nil, err
 	}
@@ -384,7 +482,7 @@ func (r *ScheduleRepo) GetActualByGroupID(ctx context.Context, groupID string) (
 	for rows.Next() {
 		var scheduleInfo domain.ScheduleInfo
 		err := rows.Scan(
-			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, nullDate{&scheduleInfo.Schedule.BeginStudies}, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
 		if err != nil {
 			return nil, err
@@ -401,7 +499,7 @@ func (r *ScheduleRepo) GetActualByGroupID(ctx context.Context, groupID string) (
 
 func (r *ScheduleRepo) GetActualByTeacherID(ctx context.Context, teacherID int64) ([]domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -410,7 +508,7 @@ func (r *ScheduleRepo) GetActualByTeacherID(ctx context.Context, teacherID int64
 	LEFT JOIN classrooms c ON s.classroom_id = c.classroom_id
 	WHERE s.teacher_id = $1 AND s.is_actual = TRUE`
 
-	rows, err := r.db.Query(ctx, query, teacherID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, teacherID)
 	if err != nil {
 		return nil, err
 	}
@@ -421,7 +519,7 @@ func (r *ScheduleRepo) GetActualByTeacherID(ctx context.Context, teacherID int64
 	for rows.Next() {
 		var scheduleInfo domain.ScheduleInfo
 		err := rows.Scan(
-			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, nullDate{&scheduleInfo.Schedule.BeginStudies}, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
 		if err != nil {
 			return nil, err
@@ -440,7 +538,7 @@ func (r *ScheduleRepo) GetActualByTeacherID(ctx context.Context, teacherID int64
 
 func (r *ScheduleRepo) GetGroupedByGroupID(ctx context.Context, groupID string) (map[int]map[string]map[string][]domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -450,7 +548,7 @@ func (r *ScheduleRepo) GetGroupedByGroupID(ctx context.Context, groupID string)
 	WHERE s.group_id = $1
 	ORDER BY s.semester, s.week_type, s.day_of_week`
 
-	rows, err := r.db.Query(ctx, query, groupID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, groupID)
 	if err != nil {
 		return nil, err
 	}
@@ -460,7 +558,7 @@ func (r *ScheduleRepo) GetGroupedByGroupID(ctx context.Context, groupID string)
 	for rows.Next() {
 		var scheduleInfo domain.ScheduleInfo
 		err := rows.Scan(
-			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, nullDate{&scheduleInfo.Schedule.BeginStudies}, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
 		if err != nil {
 			return nil, err
@@ -484,7 +582,7 @@ func (r *ScheduleRepo) GetGroupedByGroupID(ctx context.Context, groupID string)
 
 func (r *ScheduleRepo) GetGroupedByTeacherID(ctx context.Context, teacherID int64) (map[int]map[string]map[string][]domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -494,7 +592,7 @@ func (r *ScheduleRepo) GetGroupedByTeacherID(ctx context.Context, teacherID int6
 	WHERE s.teacher_id = $1
 	ORDER BY s.semester, s.week_type, s.day_of_week`
 
-	rows, err := r.db.Query(ctx, query, teacherID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, teacherID)
 	if err != nil {
 		return nil, err
 	}
@@ -504,7 +602,7 @@ func (r *ScheduleRepo) GetGroupedByTeacherID(ctx context.Context, teacherID int6
 	for rows.Next() {
 		var scheduleInfo domain.ScheduleInfo
 		err := rows.Scan(
-			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, nullDate{&scheduleInfo.Schedule.BeginStudies}, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
 		if err != nil {
 			return nil, err
@@ -528,7 +626,7 @@ func (r *ScheduleRepo) GetGroupedByTeacherID(ctx context.Context, teacherID int6
 
 func (r *ScheduleRepo) GetActualByGroupAndWeekType(ctx context.Context, groupID string, weekType string) ([]domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -537,7 +635,7 @@ func (r *ScheduleRepo) GetActualByGroupAndWeekType(ctx context.Context, groupID
 	LEFT JOIN classrooms c ON s.classroom_id = c.classroom_id
 	WHERE s.group_id = $1 AND s.week_type = $2 AND s.is_actual = TRUE`
 
-	rows, err := r.db.Query(ctx, query, groupID, weekType)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, groupID, weekType)
 	if err != nil {
 		return nil, err
 	}
@@ -548,7 +646,7 @@ func (r *ScheduleRepo) GetActualByGroupAndWeekType(ctx context.Context, groupID
 	for rows.Next() {
 		var scheduleInfo domain.ScheduleInfo
 		err := rows.Scan(
-			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, nullDate{&scheduleInfo.Schedule.BeginStudies}, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
 		if err != nil {
 			return nil, err
@@ -565,7 +663,7 @@ func (r *ScheduleRepo) GetActualByGroupAndWeekType(ctx context.Context, groupID
 
 func (r *ScheduleRepo) GetActualByTeacherAndWeekType(ctx context.Context, teacherID int64, weekType string) ([]domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -574,7 +672,7 @@ func (r *ScheduleRepo) GetActualByTeacherAndWeekType(ctx context.Context, teache
 	LEFT JOIN classrooms c ON s.classroom_id = c.classroom_id
 	WHERE s.teacher_id = $1 AND s.week_type = $2 AND s.is_actual = TRUE`
 
-	rows, err := r.db.Query(ctx, query, teacherID, weekType)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, teacherID, weekType)
 	if err != nil {
 		return nil, err
 	}
@@ -585,7 +683,7 @@ func (r *ScheduleRepo) GetActualByTeacherAndWeekType(ctx context.Context, teache
 	for rows.Next() {
 		var scheduleInfo domain.ScheduleInfo
 		err := rows.Scan(
-			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, nullDate{&scheduleInfo.Schedule.BeginStudies}, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
 		if err != nil {
 			return nil, err
@@ -602,7 +700,7 @@ func (r *ScheduleRepo) GetActualByTeacherAndWeekType(ctx context.Context, teache
 
 func (r *ScheduleRepo) GetActualByGroupWeekTypeAndDay(ctx context.Context, groupID, weekType, dayOfWeek string) ([]domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -611,7 +709,7 @@ func (r *ScheduleRepo) GetActualByGroupWeekTypeAndDay(ctx context.Context, group
 	LEFT JOIN classrooms c ON s.classroom_id = c.classroom_id
 	WHERE s.group_id = $1 AND s.week_type = $2 AND s.day_of_week = $3 AND s.is_actual = TRUE`
 
-	rows, err := r.db.Query(ctx, query, groupID, weekType, dayOfWeek)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, groupID, weekType, dayOfWeek)
 	if err != nil {
 		return nil, err
 	}
@@ -622,7 +720,7 @@ func (r *ScheduleRepo) GetActualByGroupWeekTypeAndDay(ctx context.Context, group
 	for rows.Next() {
 		var scheduleInfo domain.ScheduleInfo
 		err := rows.Scan(
-			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, nullDate{&scheduleInfo.Schedule.BeginStudies}, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
 		if err != nil {
 			return nil, err
@@ -639,7 +737,7 @@ func (r *ScheduleRepo) GetActualByGroupWeekTypeAndDay(ctx context.Context, group
 
 func (r *ScheduleRepo) GetActualByTeacherWeekTypeAndDay(ctx context.Context, teacherID int64, weekType, dayOfWeek string) ([]domain.ScheduleInfo, error) {
 	query := `SELECT 
-		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.week_type, s.day_of_week, s.start_time, s.is_actual,
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
 		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
 	FROM schedules s
 	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
@@ -648,7 +746,7 @@ func (r *ScheduleRepo) GetActualByTeacherWeekTypeAndDay(ctx context.Context, tea
 	LEFT JOIN classrooms c ON s.classroom_id = c.classroom_id
 	WHERE s.teacher_id = $1 AND s.week_type = $2 AND s.day_of_week = $3 AND s.is_actual = TRUE`
 
-	rows, err := r.db.Query(ctx, query, teacherID, weekType, dayOfWeek)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, teacherID, weekType, dayOfWeek)
 	if err != nil {
 		return nil, err
 	}
@@ -659,7 +757,7 @@ func (r *ScheduleRepo) GetActualByTeacherWeekTypeAndDay(ctx context.Context, tea
 	for rows.Next() {
 		var scheduleInfo domain.ScheduleInfo
 		err := rows.Scan(
-			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, nullDate{&scheduleInfo.Schedule.BeginStudies}, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
 			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
 		if err != nil {
 			return nil, err
@@ -673,3 +771,56 @@ func (r *ScheduleRepo) GetActualByTeacherWeekTypeAndDay(ctx context.Context, tea
 
 	return schedules, nil
 }
+
+// GetActualByGroupAndDay returns the actual schedules of the group on the day of the week
+// of both week types with the start of studies
+func (r *ScheduleRepo) GetActualByGroupAndDay(ctx context.Context, groupID, dayOfWeek string) ([]domain.ScheduleInfo, error) {
+	return r.getActualByDay(ctx, `s.group_id = $1`, groupID, dayOfWeek)
+}
+
+// GetActualByTeacherAndDay returns the actual schedules of the teacher on the day of the week
+// of both week types with the start of studies
+func (r *ScheduleRepo) GetActualByTeacherAndDay(ctx context.Context, teacherID int64, dayOfWeek string) ([]domain.ScheduleInfo, error) {
+	return r.getActualByDay(ctx, `s.teacher_id = $1`, teacherID, dayOfWeek)
+}
+
+func (r *ScheduleRepo) getActualByDay(ctx context.Context, condition string, arg interface{}, dayOfWeek string) ([]domain.ScheduleInfo, error) {
+	query := `SELECT 
+		s.schedule_id, s.group_id, s.discipline_id, s.teacher_id, s.discipline_type_id, s.classroom_id, s.semester, s.begin_studies, s.week_type, s.day_of_week, s.start_time, s.slot_id, s.subgroup_id, s.is_actual,
+		d.discipline_name, t.last_name, t.first_name, t.middle_name, dt.discipline_type_name, c.classroom_name
+	FROM schedules s
+	LEFT JOIN disciplines d ON s.discipline_id = d.discipline_id
+	LEFT JOIN teachers t ON s.teacher_id = t.teacher_id
+	LEFT JOIN disciplineTypes dt ON s.discipline_type_id = dt.discipline_type_id
+	LEFT JOIN classrooms c ON s.classroom_id = c.classroom_id
+	WHERE ` + condition + ` AND s.day_of_week = $2 AND s.is_actual = TRUE
+	ORDER BY s.start_time`
+
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, arg, dayOfWeek)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := make([]domain.ScheduleInfo, 0)
+	for rows.Next() {
+		var scheduleInfo domain.ScheduleInfo
+		var beginStudies *time.Time
+		err := rows.Scan(
+			&scheduleInfo.Schedule.ScheduleID, &scheduleInfo.Schedule.GroupID, &scheduleInfo.Schedule.DisciplineID, &scheduleInfo.Schedule.TeacherID, &scheduleInfo.Schedule.DisciplineTypeID, &scheduleInfo.Schedule.ClassroomID, &scheduleInfo.Schedule.Semester, &beginStudies, &scheduleInfo.Schedule.WeekType, &scheduleInfo.Schedule.DayOfWeek, &scheduleInfo.Schedule.StartTime, &scheduleInfo.Schedule.SlotID, &scheduleInfo.Schedule.SubgroupID, &scheduleInfo.Schedule.IsActual,
+			&scheduleInfo.ScheduleSub.DisciplineName, &scheduleInfo.ScheduleSub.TeacherFullName.LastName, &scheduleInfo.ScheduleSub.TeacherFullName.FirstName, &scheduleInfo.ScheduleSub.TeacherFullName.MiddleName, &scheduleInfo.ScheduleSub.DisciplineTypeName, &scheduleInfo.ScheduleSub.ClassroomName)
+		if err != nil {
+			return nil, err
+		}
+		if beginStudies != nil {
+			scheduleInfo.Schedule.BeginStudies = *beginStudies
+		}
+		schedules = append(schedules, scheduleInfo)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
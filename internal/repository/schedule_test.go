@@ -0,0 +1,362 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	firstGroup  = "2023-35.03.06-1"
+	secondGroup = "2023-35.03.06-2"
+)
+
+func date(value string) time.Time {
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// clock is a time of day the way a TIME column scans
+func clock(value string) time.Time {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		panic(err)
+	}
+	return time.Date(2000, 1, 1, t.Hour(), t.Minute(), 0, 0, time.UTC)
+}
+
+func scheduleIDs(schedules []domain.ScheduleInfo) []int64 {
+	ids := make([]int64, 0, len(schedules))
+	for _, schedule := range schedules {
+		ids = append(ids, schedule.Schedule.ScheduleID)
+	}
+	return ids
+}
+
+func sortIDs(ids []int64) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+}
+
+func equalIDs(got, want []int64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestScheduleRepoGetByID(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	schedule, err := repos.Schedule.GetByID(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	got := schedule.Schedule
+	if got.GroupID != firstGroup || got.Semester != 1 || got.WeekType != "Верхняя" || got.DayOfWeek != "Понедельник" {
+		t.Errorf("schedule = %+v", got)
+	}
+	if !got.StartTime.Equal(clock("10:10")) || !got.BeginStudies.Equal(date("2024-09-02")) {
+		t.Errorf("start time = %v, begin studies = %v", got.StartTime, got.BeginStudies)
+	}
+	if got.SlotID == nil || *got.SlotID != 2 || got.SubgroupID == nil || *got.SubgroupID != 1 || got.IsActual == nil || !*got.IsActual {
+		t.Errorf("slot = %v, subgroup = %v, actual = %v", got.SlotID, got.SubgroupID, got.IsActual)
+	}
+	sub := schedule.ScheduleSub
+	if sub.DisciplineName != "Физика" || sub.DisciplineTypeName != "Лабораторная работа" || sub.ClassroomName != "202" || sub.TeacherFullName.LastName != "Новикова" {
+		t.Errorf("names = %+v", sub)
+	}
+
+	archived, err := repos.Schedule.GetByID(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !archived.Schedule.BeginStudies.IsZero() {
+		t.Errorf("unknown begin studies = %v, want the zero time", archived.Schedule.BeginStudies)
+	}
+
+	if _, err := repos.Schedule.GetByID(ctx, 100); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of a missing schedule error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
+
+// fixtureSlots holds the lesson slots of the fixture schedules
+var fixtureSlots = map[int64]int64{1: 1, 2: 2, 3: 1, 4: 1}
+
+func TestScheduleRepoLists(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		get   func() ([]domain.ScheduleInfo, error)
+		want  []int64
+		exact bool
+	}{
+		{
+			name: "all",
+			get:  func() ([]domain.ScheduleInfo, error) { return repos.Schedule.GetAll(ctx) },
+			want: []int64{1, 2, 3, 4},
+		},
+		{
+			name: "group",
+			get:  func() ([]domain.ScheduleInfo, error) { return repos.Schedule.GetByGroupID(ctx, firstGroup) },
+			want: []int64{1, 2, 3},
+		},
+		{
+			name: "actual of the group",
+			get:  func() ([]domain.ScheduleInfo, error) { return repos.Schedule.GetActualByGroupID(ctx, firstGroup) },
+			want: []int64{1, 2},
+		},
+		{
+			name: "teacher",
+			get:  func() ([]domain.ScheduleInfo, error) { return repos.Schedule.GetByTeacherID(ctx, 1) },
+			want: []int64{1, 3, 4},
+		},
+		{
+			name: "actual of the teacher",
+			get:  func() ([]domain.ScheduleInfo, error) { return repos.Schedule.GetActualByTeacherID(ctx, 1) },
+			want: []int64{1, 4},
+		},
+		{
+			name: "group and week type",
+			get: func() ([]domain.ScheduleInfo, error) {
+				return repos.Schedule.GetByGroupAndWeekType(ctx, firstGroup, "Нижняя")
+			},
+			want: []int64{3},
+		},
+		{
+			name: "actual of the group and week type",
+			get: func() ([]domain.ScheduleInfo, error) {
+				return repos.Schedule.GetActualByGroupAndWeekType(ctx, firstGroup, "Нижняя")
+			},
+			want: []int64{},
+		},
+		{
+			name: "teacher and week type",
+			get: func() ([]domain.ScheduleInfo, error) {
+				return repos.Schedule.GetByTeacherAndWeekType(ctx, 1, "Нижняя")
+			},
+			want: []int64{3},
+		},
+		{
+			name: "actual of the teacher and week type",
+			get: func() ([]domain.ScheduleInfo, error) {
+				return repos.Schedule.GetActualByTeacherAndWeekType(ctx, 1, "Верхняя")
+			},
+			want: []int64{1, 4},
+		},
+		{
+			name: "group on the day",
+			get: func() ([]domain.ScheduleInfo, error) {
+				return repos.Schedule.GetByGroupWeekTypeAndDay(ctx, firstGroup, "Нижняя", "Вторник")
+			},
+			want: []int64{3},
+		},
+		{
+			name: "actual of the group on the day",
+			get: func() ([]domain.ScheduleInfo, error) {
+				return repos.Schedule.GetActualByGroupWeekTypeAndDay(ctx, firstGroup, "Верхняя", "Понедельник")
+			},
+			want: []int64{1, 2},
+		},
+		{
+			name: "teacher on the day",
+			get: func() ([]domain.ScheduleInfo, error) {
+				return repos.Schedule.GetByTeacherWeekTypeAndDay(ctx, 2, "Верхняя", "Понедельник")
+			},
+			want: []int64{2},
+		},
+		{
+			name: "actual of the teacher on the day",
+			get: func() ([]domain.ScheduleInfo, error) {
+				return repos.Schedule.GetActualByTeacherWeekTypeAndDay(ctx, 1, "Верхняя", "Понедельник")
+			},
+			want: []int64{1, 4},
+		},
+		{
+			name: "actual of the group on the day by start time",
+			get: func() ([]domain.ScheduleInfo, error) {
+				return repos.Schedule.GetActualByGroupAndDay(ctx, firstGroup, "Понедельник")
+			},
+			want:  []int64{1, 2},
+			exact: true,
+		},
+		{
+			name: "actual of the teacher on the day of both week types",
+			get: func() ([]domain.ScheduleInfo, error) {
+				return repos.Schedule.GetActualByTeacherAndDay(ctx, 1, "Вторник")
+			},
+			want: []int64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedules, err := tt.get()
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			got := scheduleIDs(schedules)
+			if !tt.exact {
+				sortIDs(got)
+			}
+			if !equalIDs(got, tt.want) {
+				t.Fatalf("schedules = %v, want %v", got, tt.want)
+			}
+			for _, schedule := range schedules {
+				if slot := schedule.Schedule.SlotID; slot == nil || *slot != fixtureSlots[schedule.Schedule.ScheduleID] {
+					t.Errorf("slot of schedule %d = %v, want %d", schedule.Schedule.ScheduleID, slot, fixtureSlots[schedule.Schedule.ScheduleID])
+				}
+			}
+		})
+	}
+}
+
+func TestScheduleRepoCreate(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+	actual := true
+	slotID := int64(1)
+
+	schedule := domain.Schedule{GroupID: secondGroup, DisciplineID: 2, TeacherID: 2, DisciplineTypeID: 1, ClassroomID: 2, Semester: 1, BeginStudies: date("2024-09-06"), WeekType: "Верхняя", DayOfWeek: "Пятница", StartTime: clock("08:30"), SlotID: &slotID, IsActual: &actual}
+	if err := repos.Schedule.Create(ctx, schedule); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	created, err := repos.Schedule.GetActualByGroupAndDay(ctx, secondGroup, "Пятница")
+	if err != nil {
+		t.Fatalf("GetActualByGroupAndDay() error = %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("created schedules = %d, want 1", len(created))
+	}
+	got := created[0].Schedule
+	if got.ScheduleID != 5 || !got.BeginStudies.Equal(schedule.BeginStudies) || got.SlotID == nil || *got.SlotID != slotID || got.SubgroupID != nil {
+		t.Errorf("created schedule = %+v", got)
+	}
+}
+
+func TestScheduleRepoCreateMany(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+	actual := true
+
+	schedules := []domain.Schedule{
+		{GroupID: secondGroup, DisciplineID: 2, TeacherID: 2, DisciplineTypeID: 1, ClassroomID: 2, Semester: 1, WeekType: "Нижняя", DayOfWeek: "Среда", StartTime: clock("10:10"), IsActual: &actual},
+		{GroupID: secondGroup, DisciplineID: 2, TeacherID: 2, DisciplineTypeID: 1, ClassroomID: 2, Semester: 1, WeekType: "Нижняя", DayOfWeek: "Среда", StartTime: clock("12:00"), IsActual: &actual},
+	}
+	if err := repos.Schedule.CreateMany(ctx, schedules); err != nil {
+		t.Fatalf("CreateMany() error = %v", err)
+	}
+
+	created, err := repos.Schedule.GetActualByGroupAndDay(ctx, secondGroup, "Среда")
+	if err != nil {
+		t.Fatalf("GetActualByGroupAndDay() error = %v", err)
+	}
+	if len(created) != 2 {
+		t.Fatalf("created schedules = %d, want 2", len(created))
+	}
+	// the lesson at 10:10 takes the second slot of the bell schedule, 12:00 is outside of it
+	if slot := created[0].Schedule.SlotID; slot == nil || *slot != 2 {
+		t.Errorf("slot of the lesson at 10:10 = %v, want 2", slot)
+	}
+	if slot := created[1].Schedule.SlotID; slot != nil {
+		t.Errorf("slot of the lesson at 12:00 = %v, want none", *slot)
+	}
+
+	broken := append(schedules[:1:1], domain.Schedule{GroupID: secondGroup, DisciplineID: 100, TeacherID: 2, DisciplineTypeID: 1, ClassroomID: 2, Semester: 1, WeekType: "Нижняя", DayOfWeek: "Четверг", StartTime: clock("08:30")})
+	if err := repos.Schedule.CreateMany(ctx, broken); err == nil {
+		t.Fatal("CreateMany() with a missing discipline error = nil")
+	}
+	all, err := repos.Schedule.GetByGroupID(ctx, secondGroup)
+	if err != nil {
+		t.Fatalf("GetByGroupID() error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("schedules after the failed batch = %d, want 3", len(all))
+	}
+}
+
+func TestScheduleRepoRollover(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+	actual := true
+
+	next := []domain.Schedule{
+		{GroupID: firstGroup, DisciplineID: 2, TeacherID: 2, DisciplineTypeID: 1, ClassroomID: 1, Semester: 2, BeginStudies: date("2025-02-03"), WeekType: "Верхняя", DayOfWeek: "Пятница", StartTime: clock("08:30"), IsActual: &actual},
+	}
+	if err := repos.Schedule.Rollover(ctx, 1, next); err != nil {
+		t.Fatalf("Rollover() error = %v", err)
+	}
+
+	schedules, err := repos.Schedule.GetActualByGroupID(ctx, firstGroup)
+	if err != nil {
+		t.Fatalf("GetActualByGroupID() error = %v", err)
+	}
+	if len(schedules) != 1 || schedules[0].Schedule.Semester != 2 || !schedules[0].Schedule.BeginStudies.Equal(date("2025-02-03")) {
+		t.Fatalf("actual schedules = %+v, want the schedule of the next semester", schedules)
+	}
+	inserted, err := repos.Schedule.GetByID(ctx, schedules[0].Schedule.ScheduleID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if inserted.Schedule.SlotID == nil || *inserted.Schedule.SlotID != 1 {
+		t.Errorf("slot = %v, want 1", inserted.Schedule.SlotID)
+	}
+	// the semester is archived for every group
+	if other, err := repos.Schedule.GetActualByGroupID(ctx, secondGroup); err != nil || len(other) != 0 {
+		t.Errorf("actual schedules of another group = %d, error = %v, want none", len(other), err)
+	}
+}
+
+func TestScheduleRepoUpdate(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	if err := repos.Schedule.Patch(ctx, 1, map[string]interface{}{"classroom_id": int64(2), "week_type": "Нижняя"}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	patched, err := repos.Schedule.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if patched.Schedule.ClassroomID != 2 || patched.ScheduleSub.ClassroomName != "202" || patched.Schedule.WeekType != "Нижняя" {
+		t.Errorf("patched schedule = %+v", patched)
+	}
+
+	schedule := patched.Schedule
+	schedule.BeginStudies = time.Time{}
+	schedule.StartTime = clock("10:10")
+	slot := int64(2)
+	schedule.SlotID = &slot
+	if err := repos.Schedule.Put(ctx, schedule); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	put, err := repos.Schedule.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !put.Schedule.BeginStudies.IsZero() || !put.Schedule.StartTime.Equal(clock("10:10")) || put.Schedule.SlotID == nil || *put.Schedule.SlotID != 2 {
+		t.Errorf("schedule after Put() = %+v", put.Schedule)
+	}
+
+	if err := repos.Schedule.Delete(ctx, 3); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Schedule.GetByID(ctx, 3); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted schedule error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const scheduleExceptionSelect = `SELECT
+		e.exception_id, e.schedule_id, e.lesson_date, e.is_cancelled, e.teacher_id, e.classroom_id, e.moved_to_date, e.start_time, e.reason, e.created_at,
+		t.last_name, t.first_name, t.middle_name, c.classroom_name
+	FROM schedule_exceptions e
+	LEFT JOIN teachers t ON e.teacher_id = t.teacher_id
+	LEFT JOIN classrooms c ON e.classroom_id = c.classroom_id`
+
+type ScheduleExceptionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewScheduleExceptionRepo(db *pgxpool.Pool) *ScheduleExceptionRepo {
+	return &ScheduleExceptionRepo{db: db}
+}
+
+func (r *ScheduleExceptionRepo) Create(ctx context.Context, exception domain.ScheduleException) (int64, error) {
+	query := `INSERT INTO schedule_exceptions (
+		schedule_id, lesson_date, is_cancelled, teacher_id, classroom_id, moved_to_date, start_time, reason
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING exception_id`
+
+	var exceptionID int64
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query,
+		exception.ScheduleID, exception.LessonDate, exception.IsCancelled, exception.TeacherID, exception.ClassroomID, exception.MovedToDate, exception.StartTime, exception.Reason,
+	).Scan(&exceptionID)
+	return exceptionID, err
+}
+
+func (r *ScheduleExceptionRepo) Put(ctx context.Context, exception domain.ScheduleException) error {
+	query := `UPDATE schedule_exceptions SET
+		schedule_id = $1, lesson_date = $2, is_cancelled = $3, teacher_id = $4, classroom_id = $5, moved_to_date = $6, start_time = $7, reason = $8
+	WHERE exception_id = $9`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query,
+		exception.ScheduleID, exception.LessonDate, exception.IsCancelled, exception.TeacherID, exception.ClassroomID, exception.MovedToDate, exception.StartTime, exception.Reason, exception.ExceptionID)
+	return err
+}
+
+func (r *ScheduleExceptionRepo) Delete(ctx context.Context, exceptionID int64) error {
+	query := `DELETE FROM schedule_exceptions WHERE exception_id = $1`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, exceptionID)
+	return err
+}
+
+func (r *ScheduleExceptionRepo) GetByID(ctx context.Context, exceptionID int64) (domain.ScheduleExceptionInfo, error) {
+	query := scheduleExceptionSelect + ` WHERE e.exception_id = $1`
+	return scanScheduleException(postgres.Conn(ctx, r.db).QueryRow(ctx, query, exceptionID))
+}
+
+func (r *ScheduleExceptionRepo) GetByScheduleID(ctx context.Context, scheduleID int64) ([]domain.ScheduleExceptionInfo, error) {
+	query := scheduleExceptionSelect + ` WHERE e.schedule_id = $1 ORDER BY e.lesson_date`
+	return r.getAll(ctx, query, scheduleID)
+}
+
+// GetByScheduleAndDate returns the exceptions of the lesson on the date and the exception
+// that moves the lesson to the date from another day
+func (r *ScheduleExceptionRepo) GetByScheduleAndDate(ctx context.Context, scheduleID int64, date time.Time) ([]domain.ScheduleExceptionInfo, error) {
+	query := scheduleExceptionSelect + ` WHERE e.schedule_id = $1 AND (e.lesson_date = $2 OR e.moved_to_date = $2)
+		ORDER BY e.lesson_date`
+	return r.getAll(ctx, query, scheduleID, date)
+}
+
+// GetByDate returns the exceptions of the lessons on the date and of the lessons moved to the date
+func (r *ScheduleExceptionRepo) GetByDate(ctx context.Context, date time.Time) ([]domain.ScheduleExceptionInfo, error) {
+	query := scheduleExceptionSelect + ` WHERE e.lesson_date = $1 OR e.moved_to_date = $1
+		ORDER BY e.schedule_id, e.lesson_date`
+	return r.getAll(ctx, query, date)
+}
+
+func (r *ScheduleExceptionRepo) getAll(ctx context.Context, query string, args ...interface{}) ([]domain.ScheduleExceptionInfo, error) {
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exceptions := make([]domain.ScheduleExceptionInfo, 0)
+	for rows.Next() {
+		exception, err := scanScheduleException(rows)
+		if err != nil {
+			return nil, err
+		}
+		exceptions = append(exceptions, exception)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return exceptions, nil
+}
+
+func scanScheduleException(row pgx.Row) (domain.ScheduleExceptionInfo, error) {
+	var info domain.ScheduleExceptionInfo
+	var lastName, firstName, middleName *string
+	e := &info.ScheduleException
+	err := row.Scan(
+		&e.ExceptionID, &e.ScheduleID, &e.LessonDate, &e.IsCancelled, &e.TeacherID, &e.ClassroomID, &e.MovedToDate, &e.StartTime, &e.Reason, &e.CreatedAt,
+		&lastName, &firstName, &middleName, &info.ScheduleExceptionSub.ClassroomName)
+	if err != nil {
+		return info, err
+	}
+	if lastName != nil {
+		info.ScheduleExceptionSub.TeacherFullName = &domain.TeacherFullName{LastName: *lastName}
+		if firstName != nil {
+			info.ScheduleExceptionSub.TeacherFullName.FirstName = *firstName
+		}
+		if middleName != nil {
+			info.ScheduleExceptionSub.TeacherFullName.MiddleName = *middleName
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,145 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func exceptionIDs(exceptions []domain.ScheduleExceptionInfo) []int64 {
+	ids := make([]int64, 0, len(exceptions))
+	for _, exception := range exceptions {
+		ids = append(ids, exception.ScheduleException.ExceptionID)
+	}
+	return ids
+}
+
+func TestScheduleExceptionRepo(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	teacherID, classroomID := int64(2), int64(2)
+	reason := "командировка"
+	movedTo, startTime := date("2024-09-24"), clock("10:10")
+	exceptions := []domain.ScheduleException{
+		{ScheduleID: 1, LessonDate: date("2024-09-09"), TeacherID: &teacherID, ClassroomID: &classroomID, Reason: &reason},
+		{ScheduleID: 1, LessonDate: date("2024-09-23"), MovedToDate: &movedTo, StartTime: &startTime},
+		{ScheduleID: 4, LessonDate: date("2024-09-09"), IsCancelled: true},
+	}
+	ids := make([]int64, 0, len(exceptions))
+	for _, exception := range exceptions {
+		id, err := repos.ScheduleException.Create(ctx, exception)
+		if err != nil {
+			t.Fatalf("Create(%+v) error = %v", exception, err)
+		}
+		ids = append(ids, id)
+	}
+
+	wrong := []struct {
+		name      string
+		exception domain.ScheduleException
+		code      string
+	}{
+		{name: "second exception of the lesson", exception: domain.ScheduleException{ScheduleID: 1, LessonDate: date("2024-09-09"), IsCancelled: true}, code: "23505"},
+		{name: "cancelled with a substitute", exception: domain.ScheduleException{ScheduleID: 1, LessonDate: date("2024-09-30"), IsCancelled: true, TeacherID: &teacherID}, code: "23514"},
+	}
+	for _, tt := range wrong {
+		if _, err := repos.ScheduleException.Create(ctx, tt.exception); !isViolation(err, tt.code) {
+			t.Errorf("Create() of a %s error = %v, want %s", tt.name, err, tt.code)
+		}
+	}
+
+	substitute, err := repos.ScheduleException.GetByID(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	sub := substitute.ScheduleExceptionSub
+	if sub.TeacherFullName == nil || sub.TeacherFullName.LastName != "Новикова" || sub.TeacherFullName.MiddleName != "Игоревна" || sub.ClassroomName == nil || *sub.ClassroomName != "202" {
+		t.Errorf("names of the substitute = %+v", sub)
+	}
+	if substitute.ScheduleException.Reason == nil || *substitute.ScheduleException.Reason != reason || substitute.ScheduleException.CreatedAt.IsZero() {
+		t.Errorf("exception = %+v", substitute.ScheduleException)
+	}
+
+	lists := []struct {
+		name string
+		get  func() ([]domain.ScheduleExceptionInfo, error)
+		want []int64
+	}{
+		{
+			name: "schedule",
+			get: func() ([]domain.ScheduleExceptionInfo, error) {
+				return repos.ScheduleException.GetByScheduleID(ctx, 1)
+			},
+			want: []int64{ids[0], ids[1]},
+		},
+		{
+			name: "lesson date",
+			get: func() ([]domain.ScheduleExceptionInfo, error) {
+				return repos.ScheduleException.GetByScheduleAndDate(ctx, 1, date("2024-09-09"))
+			},
+			want: []int64{ids[0]},
+		},
+		{
+			name: "moved to date",
+			get: func() ([]domain.ScheduleExceptionInfo, error) {
+				return repos.ScheduleException.GetByScheduleAndDate(ctx, 1, movedTo)
+			},
+			want: []int64{ids[1]},
+		},
+		{
+			name: "date",
+			get: func() ([]domain.ScheduleExceptionInfo, error) {
+				return repos.ScheduleException.GetByDate(ctx, date("2024-09-09"))
+			},
+			want: []int64{ids[0], ids[2]},
+		},
+		{
+			name: "moved lessons of the date",
+			get: func() ([]domain.ScheduleExceptionInfo, error) {
+				return repos.ScheduleException.GetByDate(ctx, movedTo)
+			},
+			want: []int64{ids[1]},
+		},
+		{
+			name: "date without exceptions",
+			get: func() ([]domain.ScheduleExceptionInfo, error) {
+				return repos.ScheduleException.GetByDate(ctx, date("2024-09-02"))
+			},
+			want: []int64{},
+		},
+	}
+	for _, tt := range lists {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.get()
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if ids := exceptionIDs(got); !equalIDs(ids, tt.want) {
+				t.Errorf("exceptions = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+
+	cancelled := domain.ScheduleException{ExceptionID: ids[0], ScheduleID: 1, LessonDate: date("2024-09-09"), IsCancelled: true, Reason: &reason}
+	if err := repos.ScheduleException.Put(ctx, cancelled); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	got, err := repos.ScheduleException.GetByID(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !got.ScheduleException.IsCancelled || got.ScheduleExceptionSub.TeacherFullName != nil || got.ScheduleExceptionSub.ClassroomName != nil {
+		t.Errorf("exception after Put() = %+v", got)
+	}
+
+	if err := repos.ScheduleException.Delete(ctx, ids[1]); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.ScheduleException.GetByID(ctx, ids[1]); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted exception error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
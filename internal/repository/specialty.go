@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,14 +20,14 @@ func NewSpecialtyRepo(db *pgxpool.Pool) *SpecialtyRepo {
 func (r *SpecialtyRepo) Create(ctx context.Context, specialty domain.Specialty) error {
 	query := `INSERT INTO specialties (specialty_code, specialty_name, departament_id, education_level_id)
               VALUES ($1, $2, $3, $4)`
-	_, err := r.db.Exec(ctx, query, specialty.SpecialtyCode, specialty.SpecialtyName, specialty.DepartamentID, specialty.EducationLevelID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, specialty.SpecialtyCode, specialty.SpecialtyName, specialty.DepartamentID, specialty.EducationLevelID)
 
 	return err
 }
 
 func (r *SpecialtyRepo) Put(ctx context.Context, specialty domain.Specialty) error {
 	query := `UPDATE specialties SET specialty_name=$1, departament_id=$2, education_level_id=$3 WHERE specialty_code=$4`
-	_, err := r.db.Exec(ctx, query, specialty.SpecialtyName, specialty.DepartamentID, specialty.EducationLevelID, specialty.SpecialtyCode)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, specialty.SpecialtyName, specialty.DepartamentID, specialty.EducationLevelID, specialty.SpecialtyCode)
 
 	return err
 }
@@ -45,14 +46,14 @@ func (r *SpecialtyRepo) Patch(ctx context.Context, specialtyCode string, updates
 	query = query[:len(query)-1]
 	query += " WHERE specialty_code = $" + strconv.Itoa(argsCounter)
 	args = append(args, specialtyCode)
-	_, err := r.db.Exec(ctx, query, args...)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, args...)
 
 	return err
 }
 
 func (r *SpecialtyRepo) Delete(ctx context.Context, specialtyCode string) error {
 	query := `DELETE FROM specialties WHERE specialty_code = $1`
-	_, err := r.db.Exec(ctx, query, specialtyCode)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, specialtyCode)
 
 	return err
 }
@@ -70,7 +71,7 @@ func (r *SpecialtyRepo) GetByCode(ctx context.Context, specialtyCode string) (do
 		WHERE s.specialty_code = $1`
 
 	specialtyInfo := domain.SpecialtyInfo{}
-	err := r.db.QueryRow(ctx, query, specialtyCode).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, specialtyCode).Scan(
 		&specialtyInfo.Specialty.SpecialtyCode,
 		&specialtyInfo.Specialty.SpecialtyName,
 		&specialtyInfo.Specialty.DepartamentID,
@@ -95,7 +96,7 @@ func (r *SpecialtyRepo) GetByName(ctx context.Context, specialtyName string) (do
 		WHERE s.specialty_name = $1`
 
 	specialtyInfo := domain.SpecialtyInfo{}
-	err := r.db.QueryRow(ctx, query, specialtyName).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, specialtyName).Scan(
 		&specialtyInfo.Specialty.SpecialtyCode,
 		&specialtyInfo.Specialty.SpecialtyName,
 		&specialtyInfo.Specialty.DepartamentID,
@@ -118,7 +119,7 @@ func (r *SpecialtyRepo) GetAll(ctx context.Context) ([]domain.SpecialtyInfo, err
 		LEFT JOIN 
 			educationLevels e ON s.education_level_id = e.education_level_id`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -165,7 +166,7 @@ func (r *SpecialtyRepo) GetAllByDepartamentID(ctx context.Context, departamentID
 			educationLevels e ON s.education_level_id = e.education_level_id
 		WHERE s.departament_id = $1`
 
-	rows, err := r.db.Query(ctx, query, departamentID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, departamentID)
 	if err != nil {
 		return nil, err
 	}
@@ -202,7 +203,7 @@ func (r *SpecialtyRepo) GetAllByDepartamentID(ctx context.Context, departamentID
 
 func (r *SpecialtyRepo) getCountSpecialties(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM specialties;`
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -221,7 +222,7 @@ func (r *SpecialtyRepo) getCountSpecialties(ctx context.Context) (int64, error)
 
 // func (r *SpecialtyRepo) getCountSpecialtiesByDepartamentID(ctx context.Context, departamentID int64) (int64, error) {
 // 	query := `SELECT COUNT(*) FROM specialties WHERE departament_id = $1;`
-// 	rows, err := r.db.Query(ctx, query, departamentID)
+// 	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, departamentID)
 // 	if err != nil {
 // 		return 0, err
 // 	}
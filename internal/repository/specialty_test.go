@@ -0,0 +1,65 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestSpecialtyRepo(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	specialty := domain.Specialty{SpecialtyCode: "35.04.06", SpecialtyName: "Агроинженерия (магистратура)", DepartamentID: 1, EducationLevelID: 1}
+	if err := repos.Specialty.Create(ctx, specialty); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repos.Specialty.Create(ctx, specialty); !isViolation(err, "23505") {
+		t.Errorf("Create() of a duplicate code error = %v, want a unique violation", err)
+	}
+
+	created, err := repos.Specialty.GetByName(ctx, specialty.SpecialtyName)
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if created.Specialty != specialty || created.SpecialtySub.DepartamentName != "Агроинженерия" || created.SpecialtySub.EducationLevelName != "Бакалавриат" {
+		t.Errorf("created specialty = %+v", created)
+	}
+
+	specialty.SpecialtyName = "Технологии и средства механизации"
+	if err := repos.Specialty.Put(ctx, specialty); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := repos.Specialty.Patch(ctx, specialty.SpecialtyCode, map[string]interface{}{"departament_id": int64(1)}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	got, err := repos.Specialty.GetByCode(ctx, specialty.SpecialtyCode)
+	if err != nil {
+		t.Fatalf("GetByCode() error = %v", err)
+	}
+	if got.Specialty != specialty {
+		t.Errorf("specialty after Put() and Patch() = %+v, want %+v", got.Specialty, specialty)
+	}
+
+	all, err := repos.Specialty.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	byDepartament, err := repos.Specialty.GetAllByDepartamentID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetAllByDepartamentID() error = %v", err)
+	}
+	if len(all) != 2 || len(byDepartament) != 2 {
+		t.Errorf("specialties = %d, of the departament = %d, want 2", len(all), len(byDepartament))
+	}
+
+	if err := repos.Specialty.Delete(ctx, specialty.SpecialtyCode); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Specialty.GetByCode(ctx, specialty.SpecialtyCode); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByCode() of the deleted specialty error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
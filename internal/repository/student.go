@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -16,19 +17,91 @@ func NewStudentRepo(db *pgxpool.Pool) *StudentRepo {
 	return &StudentRepo{db: db}
 }
 
-func (r *StudentRepo) Create(ctx context.Context, student domain.Student) error {
+func (r *StudentRepo) Create(ctx context.Context, student domain.Student) (int64, error) {
+	tx, err := postgres.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `INSERT INTO students (group_id, last_name, first_name, middle_name)
-              VALUES ($1, $2, $3, $4)`
-	_, err := r.db.Exec(ctx, query, student.GroupID, student.LastName, student.FirstName, student.MiddleName)
+              VALUES ($1, $2, $3, $4) RETURNING student_id`
+	var studentID int64
+	err = tx.QueryRow(ctx, query, student.GroupID, student.LastName, student.FirstName, student.MiddleName).Scan(&studentID)
+	if err != nil {
+		return 0, err
+	}
 
-	return err
+	if err := startMembership(ctx, tx, studentID, student.GroupID); err != nil {
+		return 0, err
+	}
+
+	return studentID, tx.Commit(ctx)
+}
+
+// CreateWithAccounts inserts students and their user accounts in one transaction,
+// nothing is stored if any insert fails
+func (r *StudentRepo) CreateWithAccounts(ctx context.Context, accounts []domain.StudentAccount) ([]int64, error) {
+	tx, err := postgres.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	studentQuery := `INSERT INTO students (group_id, last_name, first_name, middle_name)
+              VALUES ($1, $2, $3, $4) RETURNING student_id`
+	userQuery := `INSERT INTO users (username, password, user_role, headman_id, student_id, teacher_id)
+              VALUES ($1, $2, $3, $4, $5, $6)`
+
+	studentIDs := make([]int64, 0, len(accounts))
+	for _, account := range accounts {
+		student := account.Student
+		var studentID int64
+		err := tx.QueryRow(ctx, studentQuery, student.GroupID, student.LastName, student.FirstName, student.MiddleName).Scan(&studentID)
+		if err != nil {
+			return nil, err
+		}
+		studentIDs = append(studentIDs, studentID)
+
+		if err := startMembership(ctx, tx, studentID, student.GroupID); err != nil {
+			return nil, err
+		}
+
+		if account.User == nil {
+			continue
+		}
+		user := account.User
+		_, err = tx.Exec(ctx, userQuery, user.Username, user.Password, user.Role, nil, studentID, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return studentIDs, nil
 }
 
 func (r *StudentRepo) Put(ctx context.Context, student domain.Student) error {
+	tx, err := postgres.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := changeGroup(ctx, tx, student.StudentID, student.GroupID); err != nil {
+		return err
+	}
+
 	query := `UPDATE students SET group_id=$1, last_name=$2, first_name=$3, middle_name=$4 WHERE student_id=$5`
-	_, err := r.db.Exec(ctx, query, student.GroupID, student.LastName, student.FirstName, student.MiddleName, student.StudentID)
+	_, err = tx.Exec(ctx, query, student.GroupID, student.LastName, student.FirstName, student.MiddleName, student.StudentID)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return tx.Commit(ctx)
 }
 
 func (r *StudentRepo) Patch(ctx context.Context, studentID int64, updates map[string]interface{}) error {
@@ -45,14 +118,29 @@ func (r *StudentRepo) Patch(ctx context.Context, studentID int64, updates map[st
 	query = query[:len(query)-1]
 	query += " WHERE student_id = $" + strconv.Itoa(argsCounter)
 	args = append(args, studentID)
-	_, err := r.db.Exec(ctx, query, args...)
 
-	return err
+	tx, err := postgres.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if groupID, ok := updates["group_id"].(string); ok {
+		if err := changeGroup(ctx, tx, studentID, groupID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (r *StudentRepo) Delete(ctx context.Context, studentID int64) error {
 	query := `DELETE FROM students WHERE student_id = $1`
-	_, err := r.db.Exec(ctx, query, studentID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, studentID)
 
 	return err
 }
@@ -61,7 +149,7 @@ func (r *StudentRepo) GetByID(ctx context.Context, studentID int64) (domain.Stud
 	query := `SELECT student_id, group_id, last_name, first_name, middle_name FROM students WHERE student_id = $1`
 
 	student := domain.Student{}
-	err := r.db.QueryRow(ctx, query, studentID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, studentID).Scan(
 		&student.StudentID,
 		&student.GroupID,
 		&student.LastName,
@@ -76,7 +164,7 @@ func (r *StudentRepo) GetByName(ctx context.Context, lastName, firstName, middle
 	query := `SELECT student_id, group_id, last_name, first_name, middle_name FROM students WHERE last_name = $1 AND first_name = $2 AND middle_name = $3`
 
 	student := domain.Student{}
-	err := r.db.QueryRow(ctx, query, lastName, firstName, middleName).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, lastName, firstName, middleName).Scan(
 		&student.StudentID,
 		&student.GroupID,
 		&student.LastName,
@@ -90,7 +178,7 @@ func (r *StudentRepo) GetByName(ctx context.Context, lastName, firstName, middle
 func (r *StudentRepo) GetAll(ctx context.Context) ([]domain.Student, error) {
 	query := `SELECT student_id, group_id, last_name, first_name, middle_name FROM students`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +215,7 @@ func (r *StudentRepo) GetAll(ctx context.Context) ([]domain.Student, error) {
 func (r *StudentRepo) GetAllByGroupID(ctx context.Context, groupID string) ([]domain.Student, error) {
 	query := `SELECT student_id, group_id, last_name, first_name, middle_name FROM students WHERE group_id = $1`
 
-	rows, err := r.db.Query(ctx, query, groupID)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, groupID)
 	if err != nil {
 		return nil, err
 	}
@@ -163,7 +251,7 @@ func (r *StudentRepo) GetAllByGroupID(ctx context.Context, groupID string) ([]do
 
 func (r *StudentRepo) getCountStudents(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM students;`
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -182,7 +270,7 @@ func (r *StudentRepo) getCountStudents(ctx context.Context) (int64, error) {
 
 // func (r *StudentRepo) getCountStudentsByGroupID(ctx context.Context, groupID string) (int64, error) {
 // 	query := `SELECT COUNT(*) FROM students WHERE group_id = $1;`
-// 	rows, err := r.db.Query(ctx, query, groupID)
+// 	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, groupID)
 // 	if err != nil {
 // 		return 0, err
 // 	}
@@ -0,0 +1,157 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestStudentRepo(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	student := domain.Student{GroupID: secondGroup, LastName: "Дмитриев", FirstName: "Антон", MiddleName: "Антонович"}
+	id, err := repos.Student.Create(ctx, student)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	student.StudentID = id
+	if got, err := repos.Student.GetByID(ctx, id); err != nil || got != student {
+		t.Errorf("created student = %+v, error = %v, want %+v", got, err, student)
+	}
+	// a new student is enrolled in the group
+	history, err := repos.Membership.GetByStudentID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByStudentID() error = %v", err)
+	}
+	if len(history) != 1 || history[0].GroupID != secondGroup || history[0].ValidTo != nil || history[0].Reason == nil || *history[0].Reason != "enrollment" {
+		t.Errorf("history of the new student = %+v", history)
+	}
+
+	byName, err := repos.Student.GetByName(ctx, "Алексеев", "Иван", "Иванович")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if byName.StudentID != 1 || byName.GroupID != firstGroup {
+		t.Errorf("student by name = %+v", byName)
+	}
+
+	tests := []struct {
+		name      string
+		studentID int64
+		update    func() error
+		want      domain.Student
+	}{
+		{
+			name:      "put",
+			studentID: 3,
+			update: func() error {
+				return repos.Student.Put(ctx, domain.Student{StudentID: 3, GroupID: secondGroup, LastName: "Васильев", FirstName: "Олег", MiddleName: "Игоревич"})
+			},
+			want: domain.Student{StudentID: 3, GroupID: secondGroup, LastName: "Васильев", FirstName: "Олег", MiddleName: "Игоревич"},
+		},
+		{
+			name:      "patch",
+			studentID: 2,
+			update: func() error {
+				return repos.Student.Patch(ctx, 2, map[string]interface{}{"group_id": secondGroup, "last_name": "Борисенко"})
+			},
+			want: domain.Student{StudentID: 2, GroupID: secondGroup, LastName: "Борисенко", FirstName: "Пётр", MiddleName: "Петрович"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.update(); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			got, err := repos.Student.GetByID(ctx, tt.studentID)
+			if err != nil {
+				t.Fatalf("GetByID() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("student = %+v, want %+v", got, tt.want)
+			}
+			// the change of the group is a transfer
+			history, err := repos.Membership.GetByStudentID(ctx, tt.studentID)
+			if err != nil {
+				t.Fatalf("GetByStudentID() error = %v", err)
+			}
+			last := history[len(history)-1]
+			if len(history) != 2 || history[0].ValidTo == nil || last.GroupID != secondGroup || last.Reason == nil || *last.Reason != "transfer" {
+				t.Errorf("history = %+v, want the transfer last", history)
+			}
+		})
+	}
+
+	all, err := repos.Student.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != 5 {
+		t.Errorf("students = %d, want 5", len(all))
+	}
+
+	if err := repos.Student.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Student.GetByID(ctx, id); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted student error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
+
+func TestStudentRepoCreateWithAccounts(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	accounts := []domain.StudentAccount{
+		{
+			Student: domain.Student{GroupID: secondGroup, LastName: "Дмитриев", FirstName: "Антон", MiddleName: "Антонович"},
+			User:    &domain.User{Username: "dmitrievanton", Password: "hash", Role: "Студент"},
+		},
+		{
+			Student: domain.Student{GroupID: secondGroup, LastName: "Егоров", FirstName: "Максим", MiddleName: "Максимович"},
+		},
+	}
+	ids, err := repos.Student.CreateWithAccounts(ctx, accounts)
+	if err != nil {
+		t.Fatalf("CreateWithAccounts() error = %v", err)
+	}
+	if !equalIDs(ids, []int64{5, 6}) {
+		t.Fatalf("ids = %v, want [5 6]", ids)
+	}
+	user, err := repos.User.GetByStudentID(ctx, 5)
+	if err != nil {
+		t.Fatalf("GetByStudentID() error = %v", err)
+	}
+	if user.User.Username != "dmitrievanton" || user.User.Role != "Студент" {
+		t.Errorf("account = %+v", user.User)
+	}
+	if _, err := repos.User.GetByStudentID(ctx, 6); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByStudentID() of the student without an account error = %v, want %v", err, pgx.ErrNoRows)
+	}
+	students, err := repos.Student.GetAllByGroupID(ctx, secondGroup)
+	if err != nil {
+		t.Fatalf("GetAllByGroupID() error = %v", err)
+	}
+	if got := studentIDs(students); !equalIDs(got, []int64{4, 5, 6}) {
+		t.Errorf("students of the group = %v, want [4 5 6]", got)
+	}
+
+	// a taken username rolls the whole batch back
+	broken := []domain.StudentAccount{
+		{Student: domain.Student{GroupID: secondGroup, LastName: "Жуков", FirstName: "Роман", MiddleName: "Романович"}},
+		{
+			Student: domain.Student{GroupID: secondGroup, LastName: "Зуев", FirstName: "Кирилл", MiddleName: "Кириллович"},
+			User:    &domain.User{Username: "studentuser", Password: "hash", Role: "Студент"},
+		},
+	}
+	if _, err := repos.Student.CreateWithAccounts(ctx, broken); !isViolation(err, "23505") {
+		t.Fatalf("CreateWithAccounts() with a taken username error = %v, want a unique violation", err)
+	}
+	if _, err := repos.Student.GetByName(ctx, "Жуков", "Роман", "Романович"); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByName() of the rolled back student error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SubgroupRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSubgroupRepo(db *pgxpool.Pool) *SubgroupRepo {
+	return &SubgroupRepo{db: db}
+}
+
+// subgroupQuery selects the subgroups with the ids of their students
+const subgroupQuery = `SELECT sg.subgroup_id, sg.group_id, sg.subgroup_name,
+		COALESCE(array_agg(ss.student_id ORDER BY ss.student_id) FILTER (WHERE ss.student_id IS NOT NULL), '{}')
+	FROM subgroups sg
+	LEFT JOIN subgroup_students ss ON ss.subgroup_id = sg.subgroup_id`
+
+// Create inserts the subgroup with its students in one transaction
+func (r *SubgroupRepo) Create(ctx context.Context, subgroup domain.Subgroup) (int64, error) {
+	tx, err := postgres.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO subgroups (group_id, subgroup_name) VALUES ($1, $2) RETURNING subgroup_id`
+	var subgroupID int64
+	if err := tx.QueryRow(ctx, query, subgroup.GroupID, subgroup.Name).Scan(&subgroupID); err != nil {
+		return 0, err
+	}
+
+	if err := insertSubgroupStudents(ctx, tx, subgroupID, subgroup.StudentIDs); err != nil {
+		return 0, err
+	}
+
+	return subgroupID, tx.Commit(ctx)
+}
+
+// Put updates the subgroup and replaces its students in one transaction
+func (r *SubgroupRepo) Put(ctx context.Context, subgroup domain.Subgroup) error {
+	tx, err := postgres.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE subgroups SET group_id = $1, subgroup_name = $2 WHERE subgroup_id = $3`
+	if _, err := tx.Exec(ctx, query, subgroup.GroupID, subgroup.Name, subgroup.SubgroupID); err != nil {
+		return err
+	}
+
+	query = `DELETE FROM subgroup_students WHERE subgroup_id = $1`
+	if _, err := tx.Exec(ctx, query, subgroup.SubgroupID); err != nil {
+		return err
+	}
+
+	if err := insertSubgroupStudents(ctx, tx, subgroup.SubgroupID, subgroup.StudentIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func insertSubgroupStudents(ctx context.Context, tx pgx.Tx, subgroupID int64, studentIDs []int64) error {
+	query := `INSERT INTO subgroup_students (subgroup_id, student_id)
+		SELECT $1, unnest($2::bigint[])
+		ON CONFLICT DO NOTHING`
+	_, err := tx.Exec(ctx, query, subgroupID, studentIDs)
+	return err
+}
+
+func (r *SubgroupRepo) Delete(ctx context.Context, subgroupID int64) error {
+	query := `DELETE FROM subgroups WHERE subgroup_id = $1`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, subgroupID)
+	return err
+}
+
+func (r *SubgroupRepo) GetByID(ctx context.Context, subgroupID int64) (domain.Subgroup, error) {
+	query := subgroupQuery + `
+	WHERE sg.subgroup_id = $1
+	GROUP BY sg.subgroup_id`
+	return scanSubgroup(postgres.Conn(ctx, r.db).QueryRow(ctx, query, subgroupID))
+}
+
+func (r *SubgroupRepo) GetByGroupID(ctx context.Context, groupID string) ([]domain.Subgroup, error) {
+	query := subgroupQuery + `
+	WHERE sg.group_id = $1
+	GROUP BY sg.subgroup_id
+	ORDER BY sg.subgroup_name`
+
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subgroups := make([]domain.Subgroup, 0)
+	for rows.Next() {
+		subgroup, err := scanSubgroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		subgroups = append(subgroups, subgroup)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subgroups, nil
+}
+
+// HasStudent reports whether the student belongs to the subgroup
+func (r *SubgroupRepo) HasStudent(ctx context.Context, subgroupID int64, studentID int64) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM subgroup_students WHERE subgroup_id = $1 AND student_id = $2)`
+	var exists bool
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, subgroupID, studentID).Scan(&exists)
+	return exists, err
+}
+
+func scanSubgroup(row pgx.Row) (domain.Subgroup, error) {
+	var subgroup domain.Subgroup
+	err := row.Scan(
+		&subgroup.SubgroupID,
+		&subgroup.GroupID,
+		&subgroup.Name,
+		&subgroup.StudentIDs,
+	)
+	return subgroup, err
+}
@@ -0,0 +1,86 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestSubgroupRepo(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	id, err := repos.Subgroup.Create(ctx, domain.Subgroup{GroupID: firstGroup, Name: "2", StudentIDs: []int64{3, 3}})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := repos.Subgroup.Create(ctx, domain.Subgroup{GroupID: firstGroup, Name: "1"}); !isViolation(err, "23505") {
+		t.Errorf("Create() of a duplicate name error = %v, want a unique violation", err)
+	}
+
+	subgroups, err := repos.Subgroup.GetByGroupID(ctx, firstGroup)
+	if err != nil {
+		t.Fatalf("GetByGroupID() error = %v", err)
+	}
+	if len(subgroups) != 2 || subgroups[0].Name != "1" || !equalIDs(subgroups[0].StudentIDs, []int64{1, 2}) ||
+		subgroups[1].SubgroupID != id || !equalIDs(subgroups[1].StudentIDs, []int64{3}) {
+		t.Errorf("subgroups = %+v", subgroups)
+	}
+
+	if err := repos.Subgroup.Put(ctx, domain.Subgroup{SubgroupID: id, GroupID: firstGroup, Name: "Вторая", StudentIDs: []int64{2, 3}}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	subgroup, err := repos.Subgroup.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if subgroup.Name != "Вторая" || !equalIDs(subgroup.StudentIDs, []int64{2, 3}) {
+		t.Errorf("subgroup after Put() = %+v", subgroup)
+	}
+
+	membership := []struct {
+		subgroupID int64
+		studentID  int64
+		want       bool
+	}{
+		{subgroupID: 1, studentID: 1, want: true},
+		{subgroupID: 1, studentID: 3},
+		{subgroupID: id, studentID: 3, want: true},
+		{subgroupID: id, studentID: 1},
+	}
+	for _, tt := range membership {
+		got, err := repos.Subgroup.HasStudent(ctx, tt.subgroupID, tt.studentID)
+		if err != nil {
+			t.Fatalf("HasStudent() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("HasStudent(%d, %d) = %v, want %v", tt.subgroupID, tt.studentID, got, tt.want)
+		}
+	}
+
+	// an empty subgroup has no students rather than a null
+	if err := repos.Subgroup.Put(ctx, domain.Subgroup{SubgroupID: id, GroupID: firstGroup, Name: "Вторая"}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	empty, err := repos.Subgroup.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if empty.StudentIDs == nil || len(empty.StudentIDs) != 0 {
+		t.Errorf("students of an empty subgroup = %#v, want an empty slice", empty.StudentIDs)
+	}
+
+	if err := repos.Subgroup.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Subgroup.GetByID(ctx, id); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted subgroup error = %v, want %v", err, pgx.ErrNoRows)
+	}
+	// the laboratory works of the first subgroup keep it
+	if err := repos.Subgroup.Delete(ctx, 1); !isViolation(err, "23503") {
+		t.Errorf("Delete() of a subgroup with schedules error = %v, want a foreign key violation", err)
+	}
+}
@@ -0,0 +1,75 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestTeacherRepo(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	teacher := domain.Teacher{DepartamentID: 1, LastName: "Морозов", FirstName: "Илья", MiddleName: "Сергеевич", TeacherEmail: "morozov.is@omgau.org"}
+	if err := repos.Teacher.Create(ctx, teacher); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repos.Teacher.Create(ctx, teacher); !isViolation(err, "23505") {
+		t.Errorf("Create() of a duplicate email error = %v, want a unique violation", err)
+	}
+
+	created, err := repos.Teacher.GetByEmail(ctx, teacher.TeacherEmail)
+	if err != nil {
+		t.Fatalf("GetByEmail() error = %v", err)
+	}
+	if created.Teacher.TeacherID != 3 || created.TeacherSub.DepartamentName != "Агроинженерия" {
+		t.Errorf("created teacher = %+v", created)
+	}
+
+	// the namesakes are found together
+	namesakes, err := repos.Teacher.GetAllByLastName(ctx, "Морозов")
+	if err != nil {
+		t.Fatalf("GetAllByLastName() error = %v", err)
+	}
+	if len(namesakes) != 2 {
+		t.Errorf("teachers named Морозов = %d, want 2", len(namesakes))
+	}
+
+	put := created.Teacher
+	put.MiddleName = "Андреевич"
+	if err := repos.Teacher.Put(ctx, put); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := repos.Teacher.Patch(ctx, put.TeacherID, map[string]interface{}{"last_name": "Морозко"}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	got, err := repos.Teacher.GetByID(ctx, put.TeacherID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Teacher.MiddleName != "Андреевич" || got.Teacher.LastName != "Морозко" || got.Teacher.TeacherEmail != teacher.TeacherEmail {
+		t.Errorf("teacher after Put() and Patch() = %+v", got.Teacher)
+	}
+
+	all, err := repos.Teacher.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	byDepartament, err := repos.Teacher.GetAllByDepartamentID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetAllByDepartamentID() error = %v", err)
+	}
+	if len(all) != 3 || len(byDepartament) != 3 {
+		t.Errorf("teachers = %d, of the departament = %d, want 3", len(all), len(byDepartament))
+	}
+
+	if err := repos.Teacher.Delete(ctx, put.TeacherID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Teacher.GetByID(ctx, put.TeacherID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted teacher error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
@@ -0,0 +1,113 @@
+-- One university with two groups of the same profile. Student 4 studied in the
+-- first group until 2024-09-15 and was transferred to the second one, student 3
+-- isn't in subgroup 1 of the first group. 2024-11-04 is a holiday.
+
+INSERT INTO university (university_id, university_name, head_last_name, head_first_name, head_middle_name, university_email) VALUES
+    (1, 'Омский ГАУ', 'Шумакова', 'Оксана', 'Викторовна', 'adm@omgau.org');
+
+INSERT INTO faculties (faculty_id, university_id, faculty_name, head_last_name, head_first_name, head_middle_name, faculty_email) VALUES
+    (1, 1, 'Инженерный', 'Кузнецов', 'Сергей', 'Петрович', 'engineering@omgau.org');
+
+INSERT INTO departaments (departament_id, faculty_id, departament_name, head_last_name, head_first_name, head_middle_name, departament_email) VALUES
+    (1, 1, 'Агроинженерия', 'Соколов', 'Андрей', 'Николаевич', 'agro@omgau.org');
+
+INSERT INTO teachers (teacher_id, departament_id, last_name, first_name, middle_name, teacher_email) VALUES
+    (1, 1, 'Морозов', 'Павел', 'Андреевич', 'morozov@omgau.org'),
+    (2, 1, 'Новикова', 'Елена', 'Игоревна', 'novikova@omgau.org');
+
+INSERT INTO disciplines (discipline_id, departament_id, discipline_name) VALUES
+    (1, 1, 'Математика'),
+    (2, 1, 'Физика');
+
+INSERT INTO disciplineTypes (discipline_type_id, discipline_type_name) VALUES
+    (1, 'Лекция'),
+    (2, 'Лабораторная работа');
+
+INSERT INTO classrooms (classroom_id, classroom_name, capacity, building) VALUES
+    (1, '101', 60, 'Главный'),
+    (2, '202', 20, 'Главный');
+
+INSERT INTO educationLevels (education_level_id, education_level_name) VALUES (1, 'Бакалавриат');
+
+INSERT INTO educationTypes (education_type_id, education_type_name) VALUES (1, 'Очная');
+
+INSERT INTO specialties (specialty_code, specialty_name, departament_id, education_level_id) VALUES
+    ('35.03.06', 'Агроинженерия', 1, 1);
+
+INSERT INTO profiles (profile_id, specialty_code, education_type_id, profile_name) VALUES
+    (1, '35.03.06', 1, 'Технический сервис');
+
+INSERT INTO groups (group_id, profile_id) VALUES
+    ('2023-35.03.06-1', 1),
+    ('2023-35.03.06-2', 1);
+
+INSERT INTO students (student_id, group_id, last_name, first_name, middle_name) VALUES
+    (1, '2023-35.03.06-1', 'Алексеев', 'Иван', 'Иванович'),
+    (2, '2023-35.03.06-1', 'Борисов', 'Пётр', 'Петрович'),
+    (3, '2023-35.03.06-1', 'Васильев', 'Олег', 'Олегович'),
+    (4, '2023-35.03.06-2', 'Григорьев', 'Денис', 'Денисович');
+
+INSERT INTO student_group_history (student_id, group_id, valid_from, valid_to, reason) VALUES
+    (1, '2023-35.03.06-1', DATE '2024-09-01', NULL, 'enrollment'),
+    (2, '2023-35.03.06-1', DATE '2024-09-01', NULL, 'enrollment'),
+    (3, '2023-35.03.06-1', DATE '2024-09-01', NULL, 'enrollment'),
+    (4, '2023-35.03.06-1', DATE '2024-09-01', DATE '2024-09-15', 'enrollment'),
+    (4, '2023-35.03.06-2', DATE '2024-09-15', NULL, 'transfer');
+
+INSERT INTO headmans (headman_id, student_id, group_id, term_start) VALUES
+    (1, 1, '2023-35.03.06-1', DATE '2024-09-01');
+
+INSERT INTO users (user_id, username, password, user_role, headman_id, student_id, teacher_id) VALUES
+    ('a0000000-0000-4000-8000-000000000001', 'adminadmin', 'hash', 'Админ', NULL, NULL, NULL),
+    ('a0000000-0000-4000-8000-000000000002', 'studentuser', 'hash', 'Студент', NULL, 2, NULL),
+    ('a0000000-0000-4000-8000-000000000003', 'headmanuser', 'hash', 'Староста', 1, NULL, NULL),
+    ('a0000000-0000-4000-8000-000000000004', 'teacheruser', 'hash', 'Преподаватель', NULL, NULL, 1);
+
+INSERT INTO subgroups (subgroup_id, group_id, subgroup_name) VALUES (1, '2023-35.03.06-1', '1');
+
+INSERT INTO subgroup_students (subgroup_id, student_id) VALUES (1, 1), (1, 2);
+
+INSERT INTO lesson_slots (slot_id, university_id, slot_number, start_time, end_time, break_minutes) VALUES
+    (1, 1, 1, TIME '08:30', TIME '10:00', 10),
+    (2, 1, 2, TIME '10:10', TIME '11:40', 10);
+
+INSERT INTO academic_calendar (university_id, period_kind, title, semester, start_date, end_date) VALUES
+    (1, 'semester', 'Осенний семестр', 1, DATE '2024-09-01', DATE '2024-12-31'),
+    (1, 'holiday', 'День народного единства', NULL, DATE '2024-11-04', DATE '2024-11-04');
+
+-- schedule 2 is a laboratory work of subgroup 1, schedule 3 is archived
+INSERT INTO schedules (schedule_id, group_id, discipline_id, teacher_id, discipline_type_id, classroom_id, semester, begin_studies, week_type, day_of_week, start_time, slot_id, subgroup_id, is_actual) VALUES
+    (1, '2023-35.03.06-1', 1, 1, 1, 1, 1, DATE '2024-09-02', 'Верхняя', 'Понедельник', TIME '08:30', 1, NULL, TRUE),
+    (2, '2023-35.03.06-1', 2, 2, 2, 2, 1, DATE '2024-09-02', 'Верхняя', 'Понедельник', TIME '10:10', 2, 1, TRUE),
+    (3, '2023-35.03.06-1', 1, 1, 1, 1, 1, NULL, 'Нижняя', 'Вторник', TIME '08:30', 1, NULL, FALSE),
+    (4, '2023-35.03.06-2', 1, 1, 1, 1, 1, DATE '2024-09-02', 'Верхняя', 'Понедельник', TIME '08:30', 1, NULL, TRUE);
+
+INSERT INTO attendance (attendance_id, student_id, schedule_id, presence, late_arrival, respectfulness, reason, created) VALUES
+    (1, 1, 1, TRUE, FALSE, NULL, NULL, TIMESTAMP '2024-09-02 00:00:00'),
+    (2, 2, 1, FALSE, FALSE, TRUE, 'болезнь', TIMESTAMP '2024-09-02 00:00:00'),
+    (3, 3, 1, TRUE, TRUE, NULL, NULL, TIMESTAMP '2024-09-02 00:00:00'),
+    (4, 4, 1, TRUE, FALSE, NULL, NULL, TIMESTAMP '2024-09-02 00:00:00'),
+    (5, 1, 1, TRUE, FALSE, NULL, NULL, TIMESTAMP '2024-09-16 00:00:00'),
+    (6, 2, 1, TRUE, FALSE, NULL, NULL, TIMESTAMP '2024-09-16 00:00:00'),
+    (7, 4, 1, TRUE, FALSE, NULL, NULL, TIMESTAMP '2024-09-16 00:00:00'),
+    (8, 1, 1, TRUE, FALSE, NULL, NULL, TIMESTAMP '2024-11-04 00:00:00'),
+    (9, 1, 2, TRUE, FALSE, NULL, NULL, TIMESTAMP '2024-09-02 00:00:00'),
+    (10, 3, 2, TRUE, FALSE, NULL, NULL, TIMESTAMP '2024-09-02 00:00:00'),
+    (11, 4, 4, TRUE, FALSE, NULL, NULL, TIMESTAMP '2024-09-16 00:00:00');
+
+SELECT setval(pg_get_serial_sequence('university', 'university_id'), 1);
+SELECT setval(pg_get_serial_sequence('faculties', 'faculty_id'), 1);
+SELECT setval(pg_get_serial_sequence('departaments', 'departament_id'), 1);
+SELECT setval(pg_get_serial_sequence('teachers', 'teacher_id'), 2);
+SELECT setval(pg_get_serial_sequence('disciplines', 'discipline_id'), 2);
+SELECT setval(pg_get_serial_sequence('disciplinetypes', 'discipline_type_id'), 2);
+SELECT setval(pg_get_serial_sequence('classrooms', 'classroom_id'), 2);
+SELECT setval(pg_get_serial_sequence('educationlevels', 'education_level_id'), 1);
+SELECT setval(pg_get_serial_sequence('educationtypes', 'education_type_id'), 1);
+SELECT setval(pg_get_serial_sequence('profiles', 'profile_id'), 1);
+SELECT setval(pg_get_serial_sequence('students', 'student_id'), 4);
+SELECT setval(pg_get_serial_sequence('headmans', 'headman_id'), 1);
+SELECT setval(pg_get_serial_sequence('subgroups', 'subgroup_id'), 1);
+SELECT setval(pg_get_serial_sequence('lesson_slots', 'slot_id'), 2);
+SELECT setval(pg_get_serial_sequence('schedules', 'schedule_id'), 4);
+SELECT setval(pg_get_serial_sequence('attendance', 'attendance_id'), 11);
@@ -0,0 +1,98 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestTransactor(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+	errAbort := errors.New("abort")
+	student := domain.Student{GroupID: secondGroup, LastName: "Дмитриев", FirstName: "Антон", MiddleName: "Антонович"}
+
+	// createWithAccount creates the student and the account in the transaction of ctx
+	createWithAccount := func(ctx context.Context, username string) (int64, error) {
+		studentID, err := repos.Student.Create(ctx, student)
+		if err != nil {
+			return 0, err
+		}
+		return studentID, repos.User.Create(ctx, domain.User{Username: username, Password: "hash", Role: "Студент", StudentID: &studentID})
+	}
+
+	var committed int64
+	err := repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		committed, err = createWithAccount(ctx, "dmitrievanton")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WithinTransaction() error = %v", err)
+	}
+	if user, err := repos.User.GetByStudentID(ctx, committed); err != nil || user.User.Username != "dmitrievanton" {
+		t.Fatalf("account of the committed student = %+v, error = %v", user.User, err)
+	}
+
+	// the account breaks the unique username, the student goes with it
+	var rolledBack int64
+	err = repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		rolledBack, err = createWithAccount(ctx, "studentuser")
+		return err
+	})
+	if err == nil {
+		t.Fatal("WithinTransaction() with a taken username error = nil")
+	}
+	if rolledBack == 0 {
+		t.Fatal("the student wasn't created before the account")
+	}
+	if _, err := repos.Student.GetByID(ctx, rolledBack); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("rolled back student error = %v, want %v", err, pgx.ErrNoRows)
+	}
+
+	// a nested transaction is a savepoint: its failure keeps the outer changes
+	group := domain.Group{GroupID: "2024-35.03.06-1", ProfileID: 1}
+	err = repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := repos.Group.Create(ctx, group); err != nil {
+			return err
+		}
+		err := repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if _, err := repos.Student.Create(ctx, domain.Student{GroupID: group.GroupID, LastName: "Егоров", FirstName: "Максим", MiddleName: "Максимович"}); err != nil {
+				return err
+			}
+			return errAbort
+		})
+		if !errors.Is(err, errAbort) {
+			t.Errorf("nested WithinTransaction() error = %v, want %v", err, errAbort)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithinTransaction() error = %v", err)
+	}
+	if _, err := repos.Group.GetByID(ctx, group.GroupID); err != nil {
+		t.Errorf("group of the outer transaction error = %v", err)
+	}
+	if students, err := repos.Student.GetAllByGroupID(ctx, group.GroupID); err != nil || len(students) != 0 {
+		t.Errorf("students of the nested transaction = %d, error = %v, want none", len(students), err)
+	}
+}
+
+func TestScheduleRepoDeleteByGroupID(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	if err := repos.Schedule.DeleteByGroupID(ctx, firstGroup); err != nil {
+		t.Fatalf("DeleteByGroupID() error = %v", err)
+	}
+	if schedules, err := repos.Schedule.GetByGroupID(ctx, firstGroup); err != nil || len(schedules) != 0 {
+		t.Errorf("schedules of the group = %d, error = %v, want none", len(schedules), err)
+	}
+	if schedules, err := repos.Schedule.GetByGroupID(ctx, secondGroup); err != nil || len(schedules) != 1 {
+		t.Errorf("schedules of another group = %d, error = %v, want 1", len(schedules), err)
+	}
+}
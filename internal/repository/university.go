@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,14 +20,14 @@ func NewUniversityRepo(db *pgxpool.Pool) *UniversityRepo {
 func (r *UniversityRepo) Create(ctx context.Context, university domain.University) error {
 	query := `INSERT INTO university (university_name, head_last_name, head_first_name, head_middle_name, university_email)
               VALUES ($1, $2, $3, $4, $5)`
-	_, err := r.db.Exec(ctx, query, university.UniversityName, university.HeadLastName, university.HeadFirstName, university.HeadMiddleName, university.UniversityEmail)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, university.UniversityName, university.HeadLastName, university.HeadFirstName, university.HeadMiddleName, university.UniversityEmail)
 
 	return err
 }
 
 func (r *UniversityRepo) Put(ctx context.Context, university domain.University) error {
 	query := `UPDATE university SET university_name=$1, head_last_name=$2, head_first_name=$3, head_middle_name=$4, university_email=$5 WHERE university_id=$6`
-	_, err := r.db.Exec(ctx, query, university.UniversityName, university.HeadLastName, university.HeadFirstName, university.HeadMiddleName, university.UniversityEmail, university.UniversityID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, university.UniversityName, university.HeadLastName, university.HeadFirstName, university.HeadMiddleName, university.UniversityEmail, university.UniversityID)
 
 	return err
 }
@@ -45,14 +46,14 @@ func (r *UniversityRepo) Patch(ctx context.Context, universityID int64, updates
 	query = query[:len(query)-1]
 	query += " WHERE university_id = $" + strconv.Itoa(argsCounter)
 	args = append(args, universityID)
-	_, err := r.db.Exec(ctx, query, args...)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, args...)
 
 	return err
 }
 
 func (r *UniversityRepo) Delete(ctx context.Context, universityID int64) error {
 	query := `DELETE FROM university WHERE university_id = $1`
-	_, err := r.db.Exec(ctx, query, universityID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, universityID)
 
 	return err
 }
@@ -60,7 +61,7 @@ func (r *UniversityRepo) Delete(ctx context.Context, universityID int64) error {
 func (r *UniversityRepo) GetByID(ctx context.Context, universityID int64) (domain.University, error) {
 	query := `SELECT university_id, university_name, head_last_name, head_first_name, head_middle_name, university_email FROM university WHERE university_id = $1`
 	university := domain.University{}
-	err := r.db.QueryRow(ctx, query, universityID).Scan(&university.UniversityID, &university.UniversityName, &university.HeadLastName, &university.HeadFirstName, &university.HeadMiddleName, &university.UniversityEmail)
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, universityID).Scan(&university.UniversityID, &university.UniversityName, &university.HeadLastName, &university.HeadFirstName, &university.HeadMiddleName, &university.UniversityEmail)
 
 	return university, err
 }
@@ -68,7 +69,7 @@ func (r *UniversityRepo) GetByID(ctx context.Context, universityID int64) (domai
 func (r *UniversityRepo) GetByName(ctx context.Context, universityName string) (domain.University, error) {
 	query := `SELECT university_id, university_name, head_last_name, head_first_name, head_middle_name, university_email FROM university WHERE university_name = $1`
 	university := domain.University{}
-	err := r.db.QueryRow(ctx, query, universityName).Scan(&university.UniversityID, &university.UniversityName, &university.HeadLastName, &university.HeadFirstName, &university.HeadMiddleName, &university.UniversityEmail)
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, universityName).Scan(&university.UniversityID, &university.UniversityName, &university.HeadLastName, &university.HeadFirstName, &university.HeadMiddleName, &university.UniversityEmail)
 
 	return university, err
 }
@@ -76,14 +77,14 @@ func (r *UniversityRepo) GetByName(ctx context.Context, universityName string) (
 func (r *UniversityRepo) GetByEmail(ctx context.Context, universityName string) (domain.University, error) {
 	query := `SELECT university_id, university_name, head_last_name, head_first_name, head_middle_name, university_email FROM university WHERE university_name = $1`
 	university := domain.University{}
-	err := r.db.QueryRow(ctx, query, universityName).Scan(&university.UniversityID, &university.UniversityName, &university.HeadLastName, &university.HeadFirstName, &university.HeadMiddleName, &university.UniversityEmail)
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, universityName).Scan(&university.UniversityID, &university.UniversityName, &university.HeadLastName, &university.HeadFirstName, &university.HeadMiddleName, &university.UniversityEmail)
 
 	return university, err
 }
 
 func (r *UniversityRepo) GetAll(ctx context.Context) ([]domain.University, error) {
 	query := `SELECT university_id, university_name, head_last_name, head_first_name, head_middle_name, university_email FROM university`
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +110,7 @@ func (r *UniversityRepo) GetAll(ctx context.Context) ([]domain.University, error
 
 // func (r *UniversityRepo) getCountUniversities(ctx context.Context) (int64, error) {
 // 	query := `SELECT COUNT(*) FROM university;`
-// 	rows, err := r.db.Query(ctx, query)
+// 	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 // 	if err != nil {
 // 		return 0, err
 // 	}
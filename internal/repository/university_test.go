@@ -0,0 +1,61 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestUniversityRepo(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	university := domain.University{UniversityName: "Омский ГТУ", HeadLastName: "Лизунов", HeadFirstName: "Александр", HeadMiddleName: "Сергеевич", UniversityEmail: "info@omgtu.ru"}
+	if err := repos.University.Create(ctx, university); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repos.University.Create(ctx, university); !isViolation(err, "23505") {
+		t.Errorf("Create() of a duplicate name error = %v, want a unique violation", err)
+	}
+
+	created, err := repos.University.GetByName(ctx, university.UniversityName)
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if created.UniversityID != 2 || created.UniversityEmail != university.UniversityEmail {
+		t.Errorf("created university = %+v", created)
+	}
+
+	created.HeadLastName = "Попов"
+	if err := repos.University.Put(ctx, created); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := repos.University.Patch(ctx, created.UniversityID, map[string]interface{}{"university_email": "adm@omgtu.ru"}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	got, err := repos.University.GetByID(ctx, created.UniversityID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.HeadLastName != "Попов" || got.UniversityEmail != "adm@omgtu.ru" || got.UniversityName != university.UniversityName {
+		t.Errorf("university after Put() and Patch() = %+v", got)
+	}
+
+	all, err := repos.University.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("universities = %d, want 2", len(all))
+	}
+
+	if err := repos.University.Delete(ctx, created.UniversityID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.University.GetByID(ctx, created.UniversityID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted university error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -21,14 +22,14 @@ func NewUserRepo(db *pgxpool.Pool) *UserRepo {
 func (r *UserRepo) Create(ctx context.Context, user domain.User) error {
 	query := `INSERT INTO users (username, password, user_role, headman_id, student_id, teacher_id)
               VALUES ($1, $2, $3, $4, $5, $6)`
-	_, err := r.db.Exec(ctx, query, user.Username, user.Password, user.Role, user.HeadmanID, user.StudentID, user.TeacherID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, user.Username, user.Password, user.Role, user.HeadmanID, user.StudentID, user.TeacherID)
 
 	return err
 }
 
 func (r *UserRepo) Put(ctx context.Context, user domain.User) error {
 	query := `UPDATE users SET username=$1, password=$2, user_role=$3, headman_id=$4, student_id=$5, teacher_id=$6 WHERE user_id=$7`
-	_, err := r.db.Exec(ctx, query, user.Username, user.Password, user.Role, user.HeadmanID, user.StudentID, user.TeacherID, user.UserID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, user.Username, user.Password, user.Role, user.HeadmanID, user.StudentID, user.TeacherID, user.UserID)
 
 	return err
 }
@@ -47,14 +48,30 @@ func (r *UserRepo) Patch(ctx context.Context, userID uuid.UUID, updates map[stri
 	query = query[:len(query)-1]
 	query += " WHERE user_id = $" + strconv.Itoa(argsCounter)
 	args = append(args, userID)
-	_, err := r.db.Exec(ctx, query, args...)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, args...)
+
+	return err
+}
+
+// UpdatePassword sets a new password hash and invalidates all issued tokens
+func (r *UserRepo) UpdatePassword(ctx context.Context, userID uuid.UUID, password string) error {
+	query := `UPDATE users SET password = $1, token_version = token_version + 1 WHERE user_id = $2`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, password, userID)
+
+	return err
+}
+
+// RevokeTokens invalidates all tokens issued to the user
+func (r *UserRepo) RevokeTokens(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE users SET token_version = token_version + 1 WHERE user_id = $1`
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, userID)
 
 	return err
 }
 
 func (r *UserRepo) Delete(ctx context.Context, userID uuid.UUID) error {
 	query := `DELETE FROM users WHERE user_id = $1`
-	_, err := r.db.Exec(ctx, query, userID)
+	_, err := postgres.Conn(ctx, r.db).Exec(ctx, query, userID)
 
 	return err
 }
@@ -68,6 +85,7 @@ func (r *UserRepo) GetByID(ctx context.Context, userID uuid.UUID) (domain.UserIn
 			u.headman_id,
 			u.student_id,
 			u.teacher_id,
+			u.token_version,
 			s.last_name,
 			s.first_name,
 			s.middle_name,
@@ -96,7 +114,7 @@ func (r *UserRepo) GetByID(ctx context.Context, userID uuid.UUID) (domain.UserIn
 	var teacherLastName sql.NullString
 	var teacherFirstName sql.NullString
 	var teacherMiddleName sql.NullString
-	err := r.db.QueryRow(ctx, query, userID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, userID).Scan(
 		&user.User.UserID,
 		&user.User.Username,
 		&user.User.Password,
@@ -104,6 +122,7 @@ func (r *UserRepo) GetByID(ctx context.Context, userID uuid.UUID) (domain.UserIn
 		&user.User.HeadmanID,
 		&user.User.StudentID,
 		&user.User.TeacherID,
+		&user.User.TokenVersion,
 		&studentLastName,
 		&studentFirstName,
 		&studentMiddleName,
@@ -144,6 +163,7 @@ func (r *UserRepo) GetByName(ctx context.Context, username string) (domain.UserI
     u.headman_id,
     u.student_id,
     u.teacher_id,
+    u.token_version,
 	s.last_name,
 	s.first_name,
 	s.middle_name,
@@ -174,7 +194,7 @@ func (r *UserRepo) GetByName(ctx context.Context, username string) (domain.UserI
 	var teacherLastName sql.NullString
 	var teacherFirstName sql.NullString
 	var teacherMiddleName sql.NullString
-	err := r.db.QueryRow(ctx, query, username).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, username).Scan(
 		&user.User.UserID,
 		&user.User.Username,
 		&user.User.Password,
@@ -182,6 +202,7 @@ func (r *UserRepo) GetByName(ctx context.Context, username string) (domain.UserI
 		&user.User.HeadmanID,
 		&user.User.StudentID,
 		&user.User.TeacherID,
+		&user.User.TokenVersion,
 		&studentLastName,
 		&studentFirstName,
 		&studentMiddleName,
@@ -222,6 +243,7 @@ func (r *UserRepo) GetByStudentID(ctx context.Context, studentID int64) (domain.
 			  u.headman_id,
 			  u.student_id,
 			  u.teacher_id,
+			  u.token_version,
 			  s.last_name,
 			  s.first_name,
 			  s.middle_name,
@@ -251,7 +273,7 @@ func (r *UserRepo) GetByStudentID(ctx context.Context, studentID int64) (domain.
 	var teacherLastName sql.NullString
 	var teacherFirstName sql.NullString
 	var teacherMiddleName sql.NullString
-	err := r.db.QueryRow(ctx, query, studentID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, studentID).Scan(
 		&user.User.UserID,
 		&user.User.Username,
 		&user.User.Password,
@@ -259,6 +281,7 @@ func (r *UserRepo) GetByStudentID(ctx context.Context, studentID int64) (domain.
 		&user.User.HeadmanID,
 		&user.User.StudentID,
 		&user.User.TeacherID,
+		&user.User.TokenVersion,
 		&studentLastName,
 		&studentFirstName,
 		&studentMiddleName,
@@ -299,6 +322,7 @@ func (r *UserRepo) GetByTeacherID(ctx context.Context, teacherID int64) (domain.
 			  u.headman_id,
 			  u.student_id,
 			  u.teacher_id,
+			  u.token_version,
 			  s.last_name,
 			  s.first_name,
 			  s.middle_name,
@@ -328,7 +352,7 @@ func (r *UserRepo) GetByTeacherID(ctx context.Context, teacherID int64) (domain.
 	var teacherLastName sql.NullString
 	var teacherFirstName sql.NullString
 	var teacherMiddleName sql.NullString
-	err := r.db.QueryRow(ctx, query, teacherID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, teacherID).Scan(
 		&user.User.UserID,
 		&user.User.Username,
 		&user.User.Password,
@@ -336,6 +360,7 @@ func (r *UserRepo) GetByTeacherID(ctx context.Context, teacherID int64) (domain.
 		&user.User.HeadmanID,
 		&user.User.StudentID,
 		&user.User.TeacherID,
+		&user.User.TokenVersion,
 		&studentLastName,
 		&studentFirstName,
 		&studentMiddleName,
@@ -376,6 +401,7 @@ func (r *UserRepo) GetByHeadmanID(ctx context.Context, headmanID int64) (domain.
 			  u.headman_id,
 			  u.student_id,
 			  u.teacher_id,
+			  u.token_version,
 			  s.last_name,
 			  s.first_name,
 			  s.middle_name,
@@ -405,7 +431,7 @@ func (r *UserRepo) GetByHeadmanID(ctx context.Context, headmanID int64) (domain.
 	var teacherLastName sql.NullString
 	var teacherFirstName sql.NullString
 	var teacherMiddleName sql.NullString
-	err := r.db.QueryRow(ctx, query, headmanID).Scan(
+	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, headmanID).Scan(
 		&user.User.UserID,
 		&user.User.Username,
 		&user.User.Password,
@@ -413,6 +439,7 @@ func (r *UserRepo) GetByHeadmanID(ctx context.Context, headmanID int64) (domain.
 		&user.User.HeadmanID,
 		&user.User.StudentID,
 		&user.User.TeacherID,
+		&user.User.TokenVersion,
 		&studentLastName,
 		&studentFirstName,
 		&studentMiddleName,
@@ -453,6 +480,7 @@ func (r *UserRepo) GetAllByRole(ctx context.Context, role string) ([]domain.User
 	u.headman_id,
 	u.student_id,
 	u.teacher_id,
+	u.token_version,
 	s.last_name,
 	s.first_name,
 	s.middle_name,
@@ -469,7 +497,7 @@ func (r *UserRepo) GetAllByRole(ctx context.Context, role string) ([]domain.User
 	LEFT JOIN 
 		teachers t ON u.teacher_id = t.teacher_id
 		WHERE user_role = $1`
-	rows, err := r.db.Query(ctx, query, role)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, role)
 	if err != nil {
 		return nil, err
 	}
@@ -498,6 +526,7 @@ func (r *UserRepo) GetAllByRole(ctx context.Context, role string) ([]domain.User
 			&user.User.HeadmanID,
 			&user.User.StudentID,
 			&user.User.TeacherID,
+			&user.User.TokenVersion,
 			&studentLastName,
 			&studentFirstName,
 			&studentMiddleName,
@@ -542,6 +571,7 @@ func (r *UserRepo) GetAll(ctx context.Context) ([]domain.UserInfo, error) {
 	u.headman_id,
 	u.student_id,
 	u.teacher_id,
+	u.token_version,
 	s.last_name,
 	s.first_name,
 	s.middle_name,
@@ -557,7 +587,7 @@ func (r *UserRepo) GetAll(ctx context.Context) ([]domain.UserInfo, error) {
 		students s ON u.student_id = s.student_id OR h.student_id = s.student_id
 	LEFT JOIN 
 		teachers t ON u.teacher_id = t.teacher_id`
-	rows, err := r.db.Query(ctx, query)
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -586,6 +616,7 @@ func (r *UserRepo) GetAll(ctx context.Context) ([]domain.UserInfo, error) {
 			&user.User.HeadmanID,
 			&user.User.StudentID,
 			&user.User.TeacherID,
+			&user.User.TokenVersion,
 			&studentLastName,
 			&studentFirstName,
 			&studentMiddleName,
@@ -623,7 +654,7 @@ func (r *UserRepo) GetAll(ctx context.Context) ([]domain.UserInfo, error) {
 
 // func (r *UserRepo) getCountUsers(ctx context.Context) (int64, error) {
 // 	query := `SELECT COUNT(*) FROM users;`
-// 	rows, err := r.db.Query(ctx, query)
+// 	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query)
 // 	if err != nil {
 // 		return 0, err
 // 	}
@@ -688,7 +719,7 @@ func (r *UserRepo) GetAll(ctx context.Context) ([]domain.UserInfo, error) {
 // 		u.username = $1`
 
 // 	profile := domain.UserProfile{}
-// 	err := r.db.QueryRow(ctx, query, username).Scan(
+// 	err := postgres.Conn(ctx, r.db).QueryRow(ctx, query, username).Scan(
 // 		&profile.Username,
 // 		&profile.UserRole,
 // 		&profile.FullName,
@@ -0,0 +1,252 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	adminUserID   = uuid.MustParse("a0000000-0000-4000-8000-000000000001")
+	studentUserID = uuid.MustParse("a0000000-0000-4000-8000-000000000002")
+	headmanUserID = uuid.MustParse("a0000000-0000-4000-8000-000000000003")
+	teacherUserID = uuid.MustParse("a0000000-0000-4000-8000-000000000004")
+)
+
+func TestUserRepoGetters(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	// group is the group of the user info, the headman's one except for GetByID
+	tests := []struct {
+		name    string
+		get     func() (domain.UserInfo, error)
+		want    uuid.UUID
+		student string
+		teacher string
+		group   string
+	}{
+		{
+			name:    "headman by id",
+			get:     func() (domain.UserInfo, error) { return repos.User.GetByID(ctx, headmanUserID) },
+			want:    headmanUserID,
+			student: "Алексеев",
+			group:   firstGroup,
+		},
+		{
+			name:    "student by id",
+			get:     func() (domain.UserInfo, error) { return repos.User.GetByID(ctx, studentUserID) },
+			want:    studentUserID,
+			student: "Борисов",
+			group:   firstGroup,
+		},
+		{
+			name: "admin by id",
+			get:  func() (domain.UserInfo, error) { return repos.User.GetByID(ctx, adminUserID) },
+			want: adminUserID,
+		},
+		{
+			name:    "headman by name",
+			get:     func() (domain.UserInfo, error) { return repos.User.GetByName(ctx, "headmanuser") },
+			want:    headmanUserID,
+			student: "Алексеев",
+			group:   firstGroup,
+		},
+		{
+			name:    "student by name",
+			get:     func() (domain.UserInfo, error) { return repos.User.GetByName(ctx, "studentuser") },
+			want:    studentUserID,
+			student: "Борисов",
+		},
+		{
+			name:    "student",
+			get:     func() (domain.UserInfo, error) { return repos.User.GetByStudentID(ctx, 2) },
+			want:    studentUserID,
+			student: "Борисов",
+		},
+		{
+			name:    "headman by the student",
+			get:     func() (domain.UserInfo, error) { return repos.User.GetByStudentID(ctx, 1) },
+			want:    headmanUserID,
+			student: "Алексеев",
+			group:   firstGroup,
+		},
+		{
+			name:    "headman",
+			get:     func() (domain.UserInfo, error) { return repos.User.GetByHeadmanID(ctx, 1) },
+			want:    headmanUserID,
+			student: "Алексеев",
+			group:   firstGroup,
+		},
+		{
+			name:    "teacher",
+			get:     func() (domain.UserInfo, error) { return repos.User.GetByTeacherID(ctx, 1) },
+			want:    teacherUserID,
+			teacher: "Морозов",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := tt.get()
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if user.User.UserID != tt.want {
+				t.Fatalf("user = %s, want %s", user.User.UserID, tt.want)
+			}
+
+			student := ""
+			if user.UserSub.StudentFullName != nil {
+				student = user.UserSub.StudentFullName.LastName
+			}
+			teacher := ""
+			if user.UserSub.TeacherFullName != nil {
+				teacher = user.UserSub.TeacherFullName.LastName
+			}
+			group := ""
+			if user.UserSub.GroupID != nil {
+				group = *user.UserSub.GroupID
+			}
+			if student != tt.student || teacher != tt.teacher || group != tt.group {
+				t.Errorf("student = %q, teacher = %q, group = %q, want %q, %q, %q", student, teacher, group, tt.student, tt.teacher, tt.group)
+			}
+		})
+	}
+
+	missing := []struct {
+		name string
+		get  func() (domain.UserInfo, error)
+	}{
+		{name: "id", get: func() (domain.UserInfo, error) { return repos.User.GetByID(ctx, uuid.New()) }},
+		{name: "name", get: func() (domain.UserInfo, error) { return repos.User.GetByName(ctx, "nobodynobody") }},
+		{name: "student", get: func() (domain.UserInfo, error) { return repos.User.GetByStudentID(ctx, 3) }},
+		{name: "teacher", get: func() (domain.UserInfo, error) { return repos.User.GetByTeacherID(ctx, 2) }},
+	}
+	for _, tt := range missing {
+		t.Run("missing "+tt.name, func(t *testing.T) {
+			if _, err := tt.get(); !errors.Is(err, pgx.ErrNoRows) {
+				t.Fatalf("error = %v, want %v", err, pgx.ErrNoRows)
+			}
+		})
+	}
+}
+
+func TestUserRepoLists(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	all, err := repos.User.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != 4 {
+		t.Errorf("users = %d, want 4", len(all))
+	}
+
+	for role, want := range map[string]int{"Админ": 1, "Студент": 1, "Староста": 1, "Преподаватель": 1, "Декан": 0} {
+		users, err := repos.User.GetAllByRole(ctx, role)
+		if err != nil {
+			t.Fatalf("GetAllByRole(%s) error = %v", role, err)
+		}
+		if len(users) != want {
+			t.Errorf("users of %s = %d, want %d", role, len(users), want)
+		}
+	}
+}
+
+func TestUserRepoCreateUnique(t *testing.T) {
+	studentID, teacherID := int64(2), int64(1)
+
+	tests := []struct {
+		name       string
+		user       domain.User
+		constraint string
+	}{
+		{name: "username", user: domain.User{Username: "studentuser", Password: "hash", Role: "Админ"}, constraint: "U_users_username"},
+		{name: "student", user: domain.User{Username: "secondstudent", Password: "hash", Role: "Студент", StudentID: &studentID}, constraint: "U_users_student_id"},
+		{name: "teacher", user: domain.User{Username: "secondteacher", Password: "hash", Role: "Преподаватель", TeacherID: &teacherID}, constraint: "U_users_teacher_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos, _ := newRepos(t)
+
+			err := repos.User.Create(context.Background(), tt.user)
+			var pgErr *pgconn.PgError
+			if !errors.As(err, &pgErr) || pgErr.Code != "23505" || pgErr.ConstraintName != tt.constraint {
+				t.Fatalf("Create() error = %v, want a unique violation of %s", err, tt.constraint)
+			}
+		})
+	}
+}
+
+func TestUserRepoUpdate(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	teacherID := int64(2)
+	if err := repos.User.Create(ctx, domain.User{Username: "novikova", Password: "hash", Role: "Преподаватель", TeacherID: &teacherID}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	created, err := repos.User.GetByTeacherID(ctx, teacherID)
+	if err != nil {
+		t.Fatalf("GetByTeacherID() error = %v", err)
+	}
+	id := created.User.UserID
+	if created.User.TokenVersion != 0 || created.UserSub.TeacherFullName == nil || created.UserSub.TeacherFullName.LastName != "Новикова" {
+		t.Errorf("created user = %+v", created)
+	}
+
+	if err := repos.User.Patch(ctx, id, map[string]interface{}{"username": "novikovaelena"}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	if err := repos.User.UpdatePassword(ctx, id, "newhash"); err != nil {
+		t.Fatalf("UpdatePassword() error = %v", err)
+	}
+	if err := repos.User.RevokeTokens(ctx, id); err != nil {
+		t.Fatalf("RevokeTokens() error = %v", err)
+	}
+	updated, err := repos.User.GetByName(ctx, "novikovaelena")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if updated.User.UserID != id || updated.User.Password != "newhash" || updated.User.TokenVersion != 2 {
+		t.Errorf("updated user = %+v, want the new password and token version 2", updated.User)
+	}
+
+	// Put keeps the token version
+	user := updated.User
+	user.Username = "novikova"
+	if err := repos.User.Put(ctx, user); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	put, err := repos.User.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if put.User.Username != "novikova" || put.User.TokenVersion != 2 {
+		t.Errorf("user after Put() = %+v", put.User)
+	}
+
+	// the reset tokens of the user go with it
+	token := domain.PasswordResetToken{UserID: id, TokenHash: "token-hash", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := repos.PasswordReset.Create(ctx, token); err != nil {
+		t.Fatalf("create reset token: %v", err)
+	}
+	if err := repos.User.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.User.GetByID(ctx, id); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID() of the deleted user error = %v, want %v", err, pgx.ErrNoRows)
+	}
+	if _, err := repos.PasswordReset.Consume(ctx, token.TokenHash, time.Now()); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("Consume() of a token of the deleted user error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WorkloadRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewWorkloadRepo(db *pgxpool.Pool) *WorkloadRepo {
+	return &WorkloadRepo{db: db}
+}
+
+// CountDeliveredLessons returns the number of lessons with a marked attendance of the actual
+// schedules per teacher and schedule, the substitute teacher of an exception gets the lesson.
+// Semester 0 counts all semesters
+func (r *WorkloadRepo) CountDeliveredLessons(ctx context.Context, teacherIDs []int64, semester int) ([]domain.DeliveredLessons, error) {
+	query := `SELECT COALESCE(e.teacher_id, sc.teacher_id), sc.schedule_id, COUNT(DISTINCT a.created::date)
+	FROM attendance a
+	INNER JOIN schedules sc ON sc.schedule_id = a.schedule_id AND sc.is_actual
+	LEFT JOIN schedule_exceptions e ON e.schedule_id = sc.schedule_id AND NOT e.is_cancelled
+		AND COALESCE(e.moved_to_date, e.lesson_date) = a.created::date
+	WHERE COALESCE(e.teacher_id, sc.teacher_id) = ANY($1)
+	AND ($2 = 0 OR sc.semester = $2)
+	GROUP BY 1, 2`
+
+	rows, err := postgres.Conn(ctx, r.db).Query(ctx, query, teacherIDs, semester)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	delivered := make([]domain.DeliveredLessons, 0)
+	for rows.Next() {
+		var lessons domain.DeliveredLessons
+		if err := rows.Scan(&lessons.TeacherID, &lessons.ScheduleID, &lessons.Lessons); err != nil {
+			return nil, err
+		}
+		delivered = append(delivered, lessons)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return delivered, nil
+}
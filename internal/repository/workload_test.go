@@ -0,0 +1,72 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+func TestWorkloadRepoCountDeliveredLessons(t *testing.T) {
+	repos, _ := newRepos(t)
+	ctx := context.Background()
+
+	// a substitute gives the lesson of 2024-09-16, the cancelled lesson
+	// of 2024-11-04 stays with the teacher of the schedule
+	teacherID := int64(2)
+	exceptions := []domain.ScheduleException{
+		{ScheduleID: 1, LessonDate: date("2024-09-16"), TeacherID: &teacherID},
+		{ScheduleID: 1, LessonDate: date("2024-11-04"), IsCancelled: true},
+	}
+	for _, exception := range exceptions {
+		if _, err := repos.ScheduleException.Create(ctx, exception); err != nil {
+			t.Fatalf("create exception: %v", err)
+		}
+	}
+
+	// want maps the teacher and the schedule to the lessons
+	tests := []struct {
+		name     string
+		teachers []int64
+		semester int
+		want     map[[2]int64]int
+	}{
+		{
+			name:     "every teacher",
+			teachers: []int64{1, 2},
+			semester: 1,
+			want:     map[[2]int64]int{{1, 1}: 2, {2, 1}: 1, {2, 2}: 1, {1, 4}: 1},
+		},
+		{
+			name:     "substitute",
+			teachers: []int64{2},
+			want:     map[[2]int64]int{{2, 1}: 1, {2, 2}: 1},
+		},
+		{
+			name:     "another semester",
+			teachers: []int64{1, 2},
+			semester: 2,
+			want:     map[[2]int64]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delivered, err := repos.Workload.CountDeliveredLessons(ctx, tt.teachers, tt.semester)
+			if err != nil {
+				t.Fatalf("CountDeliveredLessons() error = %v", err)
+			}
+			got := make(map[[2]int64]int, len(delivered))
+			for _, lessons := range delivered {
+				got[[2]int64{lessons.TeacherID, lessons.ScheduleID}] = lessons.Lessons
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("delivered lessons = %v, want %v", got, tt.want)
+			}
+			for key, lessons := range tt.want {
+				if got[key] != lessons {
+					t.Errorf("lessons of the teacher %d in schedule %d = %d, want %d", key[0], key[1], got[key], lessons)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package seed
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+)
+
+// The dictionaries every installation needs before specialties, groups and schedules are added
+var (
+	EducationLevels = []string{"Бакалавриат", "Специалитет", "Магистратура", "Аспирантура"}
+	EducationTypes  = []string{"Очная", "Очно-заочная", "Заочная"}
+	DisciplineTypes = []string{"Лекция", "Практика", "Лабораторная работа", "Семинар"}
+)
+
+// Dictionaries adds the dictionary entries missing by name and returns the added ones,
+// running it twice changes nothing
+func Dictionaries(ctx context.Context, repos *repository.Repositories) ([]string, error) {
+	added := make([]string, 0)
+
+	levels, err := repos.EducationLevel.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool)
+	for _, level := range levels {
+		existing[level.EducationLevelName] = true
+	}
+	for _, name := range missing(EducationLevels, existing) {
+		if err := repos.EducationLevel.Create(ctx, domain.EducationLevel{EducationLevelName: name}); err != nil {
+			return added, err
+		}
+		added = append(added, "education level "+name)
+	}
+
+	types, err := repos.EducationType.GetAll(ctx)
+	if err != nil {
+		return added, err
+	}
+	existing = make(map[string]bool)
+	for _, educationType := range types {
+		existing[educationType.EducationTypeName] = true
+	}
+	for _, name := range missing(EducationTypes, existing) {
+		if err := repos.EducationType.Create(ctx, domain.EducationType{EducationTypeName: name}); err != nil {
+			return added, err
+		}
+		added = append(added, "education type "+name)
+	}
+
+	disciplineTypes, err := repos.DisciplineType.GetAll(ctx)
+	if err != nil {
+		return added, err
+	}
+	existing = make(map[string]bool)
+	for _, disciplineType := range disciplineTypes {
+		existing[disciplineType.DisciplineTypeName] = true
+	}
+	for _, name := range missing(DisciplineTypes, existing) {
+		if err := repos.DisciplineType.Create(ctx, domain.DisciplineType{DisciplineTypeName: name}); err != nil {
+			return added, err
+		}
+		added = append(added, "discipline type "+name)
+	}
+
+	return added, nil
+}
+
+func missing(names []string, existing map[string]bool) []string {
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		if !existing[name] {
+			result = append(result, name)
+		}
+	}
+	return result
+}
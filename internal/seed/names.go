@@ -0,0 +1,95 @@
+package seed
+
+// facultyTemplate is a faculty with its departaments, each departament teaches one specialty
+type facultyTemplate struct {
+	name         string
+	departaments []departamentTemplate
+}
+
+type departamentTemplate struct {
+	name          string
+	specialtyCode string
+	specialtyName string
+	profileName   string
+	disciplines   []string
+}
+
+var faculties = []facultyTemplate{
+	{
+		name: "Факультет информационных технологий",
+		departaments: []departamentTemplate{
+			{
+				name:          "Кафедра прикладной информатики",
+				specialtyCode: "09.03.03",
+				specialtyName: "Прикладная информатика",
+				profileName:   "Прикладная информатика в агропромышленном комплексе",
+				disciplines:   []string{"Программирование", "Базы данных", "Компьютерные сети", "Информационные системы", "Математический анализ"},
+			},
+			{
+				name:          "Кафедра программной инженерии",
+				specialtyCode: "09.03.04",
+				specialtyName: "Программная инженерия",
+				profileName:   "Разработка программно-информационных систем",
+				disciplines:   []string{"Алгоритмы и структуры данных", "Операционные системы", "Тестирование программного обеспечения", "Проектирование программных систем", "Дискретная математика"},
+			},
+		},
+	},
+	{
+		name: "Агротехнологический факультет",
+		departaments: []departamentTemplate{
+			{
+				name:          "Кафедра агрономии",
+				specialtyCode: "35.03.04",
+				specialtyName: "Агрономия",
+				profileName:   "Агрономия",
+				disciplines:   []string{"Земледелие", "Растениеводство", "Агрохимия", "Почвоведение", "Ботаника"},
+			},
+			{
+				name:          "Кафедра агроинженерии",
+				specialtyCode: "35.03.06",
+				specialtyName: "Агроинженерия",
+				profileName:   "Технические системы в агробизнесе",
+				disciplines:   []string{"Тракторы и автомобили", "Сельскохозяйственные машины", "Материаловедение", "Теоретическая механика", "Инженерная графика"},
+			},
+		},
+	},
+	{
+		name: "Экономический факультет",
+		departaments: []departamentTemplate{
+			{
+				name:          "Кафедра экономики",
+				specialtyCode: "38.03.01",
+				specialtyName: "Экономика",
+				profileName:   "Экономика предприятий и организаций",
+				disciplines:   []string{"Микроэкономика", "Макроэкономика", "Эконометрика", "Статистика", "Финансы"},
+			},
+			{
+				name:          "Кафедра менеджмента",
+				specialtyCode: "38.03.02",
+				specialtyName: "Менеджмент",
+				profileName:   "Производственный менеджмент",
+				disciplines:   []string{"Теория менеджмента", "Маркетинг", "Управление персоналом", "Стратегический менеджмент", "Бухгалтерский учёт"},
+			},
+		},
+	},
+}
+
+// person names are listed in the masculine and the feminine form
+var (
+	lastNames = [][2]string{
+		{"Иванов", "Иванова"}, {"Смирнов", "Смирнова"}, {"Кузнецов", "Кузнецова"}, {"Попов", "Попова"},
+		{"Васильев", "Васильева"}, {"Петров", "Петрова"}, {"Соколов", "Соколова"}, {"Михайлов", "Михайлова"},
+		{"Новиков", "Новикова"}, {"Фёдоров", "Фёдорова"}, {"Морозов", "Морозова"}, {"Волков", "Волкова"},
+		{"Алексеев", "Алексеева"}, {"Лебедев", "Лебедева"}, {"Семёнов", "Семёнова"}, {"Егоров", "Егорова"},
+		{"Павлов", "Павлова"}, {"Козлов", "Козлова"}, {"Степанов", "Степанова"}, {"Николаев", "Николаева"},
+	}
+	firstNames = [2][]string{
+		{"Александр", "Дмитрий", "Максим", "Сергей", "Андрей", "Алексей", "Артём", "Илья", "Кирилл", "Михаил", "Никита", "Егор"},
+		{"Анастасия", "Мария", "Дарья", "Анна", "Елизавета", "Полина", "Виктория", "Екатерина", "Софья", "Алина", "Ксения", "Ольга"},
+	}
+	middleNames = [2][]string{
+		{"Александрович", "Дмитриевич", "Сергеевич", "Андреевич", "Алексеевич", "Михайлович", "Игоревич", "Владимирович"},
+		{"Александровна", "Дмитриевна", "Сергеевна", "Андреевна", "Алексеевна", "Михайловна", "Игоревна", "Владимировна"},
+	}
+	absenceReasons = []string{"Болезнь", "Семейные обстоятельства", "Спортивные соревнования", "Донорский день"}
+)
@@ -0,0 +1,608 @@
+// Package seed fills a database with a deterministic demo dataset: a university with its faculties,
+// departaments, specialties, profiles and groups, teachers, students with headmen, an alternating-week
+// timetable and a few weeks of attendance. The same seed always produces the same dataset.
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/myhash"
+)
+
+// ErrSeeded is returned when the database already holds a demo dataset, the faculties, specialties
+// and groups of every seed share their names so only one dataset fits a database
+var ErrSeeded = errors.New("демонстрационные данные уже созданы")
+
+const universityPrefix = "Демонстрационный университет"
+
+const (
+	upperWeek = "Верхняя"
+	lowerWeek = "Нижняя"
+
+	educationLevel = "Бакалавриат"
+	educationType  = "Очная"
+	lecture        = "Лекция"
+	practice       = "Практика"
+	laboratory     = "Лабораторная работа"
+
+	// presenceRate is the share of lessons a student attends
+	presenceRate = 0.85
+	lateRate     = 0.05
+)
+
+var weekdays = []string{"Понедельник", "Вторник", "Среда", "Четверг", "Пятница"}
+
+// slots is the bell schedule of the demo university
+var slots = []struct {
+	hour, minute, breakMinutes int
+}{
+	{8, 30, 10}, {10, 10, 30}, {12, 10, 10}, {13, 50, 10}, {15, 30, 10}, {17, 10, 0},
+}
+
+const lessonMinutes = 90
+
+// Options sizes the dataset, zero fields take the defaults
+type Options struct {
+	Seed                   int64
+	Faculties              int
+	DepartamentsPerFaculty int
+	GroupsPerProfile       int
+	StudentsPerGroup       int
+	TeachersPerDepartament int
+	// LessonsPerDay is the most lessons a group has a day
+	LessonsPerDay int
+	// Weeks of attendance are generated from Start
+	Weeks int
+	// Start is the first day of the semester, it is moved back to Monday
+	Start    time.Time
+	Semester int
+	// Password is shared by every generated account
+	Password string
+}
+
+func (o Options) withDefaults() Options {
+	if o.Faculties <= 0 || o.Faculties > len(faculties) {
+		o.Faculties = len(faculties)
+	}
+	if o.DepartamentsPerFaculty <= 0 || o.DepartamentsPerFaculty > len(faculties[0].departaments) {
+		o.DepartamentsPerFaculty = len(faculties[0].departaments)
+	}
+	if o.GroupsPerProfile <= 0 {
+		o.GroupsPerProfile = 2
+	}
+	if o.StudentsPerGroup <= 0 {
+		o.StudentsPerGroup = 20
+	}
+	if o.TeachersPerDepartament <= 0 {
+		o.TeachersPerDepartament = 4
+	}
+	if o.LessonsPerDay <= 0 || o.LessonsPerDay > len(slots) {
+		o.LessonsPerDay = 3
+	}
+	if o.Weeks <= 0 {
+		o.Weeks = 4
+	}
+	if o.Start.IsZero() {
+		o.Start = time.Date(2024, time.September, 2, 0, 0, 0, 0, time.UTC)
+	}
+	o.Start = time.Date(o.Start.Year(), o.Start.Month(), o.Start.Day(), 0, 0, 0, 0, time.UTC)
+	for o.Start.Weekday() != time.Monday {
+		o.Start = o.Start.AddDate(0, 0, -1)
+	}
+	if o.Semester <= 0 {
+		o.Semester = 1
+	}
+	if o.Password == "" {
+		o.Password = "demo-password"
+	}
+	return o
+}
+
+// Result counts what Generate created
+type Result struct {
+	UniversityID int64
+	Admin        string
+	Password     string
+	Faculties    int
+	Departaments int
+	Groups       int
+	Teachers     int
+	Students     int
+	Schedules    int
+	Attendance   int
+}
+
+// generator carries the state shared by the generation steps
+type generator struct {
+	repos  *repository.Repositories
+	rand   *rand.Rand
+	opts   Options
+	prefix string
+	hash   string
+	result Result
+
+	universityID    int64
+	slots           []domain.LessonSlot
+	classrooms      []int64
+	disciplineTypes map[string]int64
+	// busy marks the teachers and classrooms taken at a week type, day and slot
+	busy map[string]bool
+}
+
+// Generate creates the demo dataset of opts.Seed, the dictionaries are added first when missing.
+// The dataset is created in one transaction, so a failed run leaves nothing behind
+func Generate(ctx context.Context, repos *repository.Repositories, hasher myhash.PasswordHasher, opts Options) (Result, error) {
+	opts = opts.withDefaults()
+	g := &generator{
+		repos:           repos,
+		rand:            rand.New(rand.NewSource(opts.Seed)),
+		opts:            opts,
+		prefix:          fmt.Sprintf("demo%d", opts.Seed),
+		disciplineTypes: make(map[string]int64),
+		busy:            make(map[string]bool),
+	}
+	g.result.Password = opts.Password
+
+	var err error
+	if g.hash, err = hasher.HashPassword(opts.Password); err != nil {
+		return Result{}, err
+	}
+	if err := repos.Transactor.WithinTransaction(ctx, g.generate); err != nil {
+		return Result{}, err
+	}
+	return g.result, nil
+}
+
+func (g *generator) generate(ctx context.Context) error {
+	universities, err := g.repos.University.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, university := range universities {
+		if strings.HasPrefix(university.UniversityName, universityPrefix) {
+			return ErrSeeded
+		}
+	}
+
+	if _, err := Dictionaries(ctx, g.repos); err != nil {
+		return err
+	}
+
+	if err := g.university(ctx, fmt.Sprintf("%s %d", universityPrefix, g.opts.Seed)); err != nil {
+		return fmt.Errorf("university: %w", err)
+	}
+	if err := g.admin(ctx); err != nil {
+		return fmt.Errorf("admin: %w", err)
+	}
+
+	levelID, typeID, err := g.dictionaryIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i, faculty := range faculties[:g.opts.Faculties] {
+		if err := g.faculty(ctx, i, faculty, levelID, typeID); err != nil {
+			return fmt.Errorf("%s: %w", faculty.name, err)
+		}
+	}
+
+	if _, _, err := g.repos.Headman.SyncRoles(ctx, g.opts.Start); err != nil {
+		return fmt.Errorf("headman roles: %w", err)
+	}
+	return nil
+}
+
+func (g *generator) university(ctx context.Context, name string) error {
+	head := g.person()
+	err := g.repos.University.Create(ctx, domain.University{
+		UniversityName:  name,
+		HeadLastName:    head.LastName,
+		HeadFirstName:   head.FirstName,
+		HeadMiddleName:  head.MiddleName,
+		UniversityEmail: g.prefix + "@demo.osau.ru",
+	})
+	if err != nil {
+		return err
+	}
+	university, err := g.repos.University.GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	g.universityID = university.UniversityID
+	g.result.UniversityID = university.UniversityID
+
+	for i, slot := range slots {
+		start := time.Date(0, 1, 1, slot.hour, slot.minute, 0, 0, time.UTC)
+		lessonSlot := domain.LessonSlot{
+			UniversityID: g.universityID,
+			SlotNumber:   i + 1,
+			StartTime:    start,
+			EndTime:      start.Add(lessonMinutes * time.Minute),
+			BreakMinutes: slot.breakMinutes,
+		}
+		if lessonSlot.SlotID, err = g.repos.LessonSlot.Create(ctx, lessonSlot); err != nil {
+			return err
+		}
+		g.slots = append(g.slots, lessonSlot)
+	}
+
+	semester := g.opts.Semester
+	_, err = g.repos.Calendar.Create(ctx, domain.CalendarPeriod{
+		UniversityID: g.universityID,
+		Kind:         domain.PeriodSemester,
+		Title:        fmt.Sprintf("Семестр %d", semester),
+		Semester:     &semester,
+		StartDate:    g.opts.Start,
+		EndDate:      g.opts.Start.AddDate(0, 0, 17*7-3),
+	})
+	return err
+}
+
+func (g *generator) admin(ctx context.Context) error {
+	g.result.Admin = g.prefix + ".admin"
+	return g.repos.User.Create(ctx, domain.User{Username: g.result.Admin, Password: g.hash, Role: "Админ"})
+}
+
+func (g *generator) dictionaryIDs(ctx context.Context) (levelID, typeID int64, err error) {
+	levels, err := g.repos.EducationLevel.GetAll(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, level := range levels {
+		if level.EducationLevelName == educationLevel {
+			levelID = level.EducationLevelID
+		}
+	}
+
+	fullTime, err := g.repos.EducationType.GetByName(ctx, educationType)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, name := range []string{lecture, practice, laboratory} {
+		disciplineType, err := g.repos.DisciplineType.GetByName(ctx, name)
+		if err != nil {
+			return 0, 0, err
+		}
+		g.disciplineTypes[name] = disciplineType.DisciplineTypeID
+	}
+	return levelID, fullTime.EducationTypeID, nil
+}
+
+func (g *generator) faculty(ctx context.Context, index int, template facultyTemplate, levelID, typeID int64) error {
+	dean := g.person()
+	err := g.repos.Faculty.Create(ctx, domain.Faculty{
+		UniversityID:   g.universityID,
+		FacultyName:    template.name,
+		HeadLastName:   dean.LastName,
+		HeadFirstName:  dean.FirstName,
+		HeadMiddleName: dean.MiddleName,
+		FacultyEmail:   fmt.Sprintf("%s.faculty%d@demo.osau.ru", g.prefix, index+1),
+	})
+	if err != nil {
+		return err
+	}
+	faculty, err := g.repos.Faculty.GetByName(ctx, template.name)
+	if err != nil {
+		return err
+	}
+	g.result.Faculties++
+
+	building := fmt.Sprint(index + 1)
+	for floor := 1; floor <= 2; floor++ {
+		for room := 1; room <= 4; room++ {
+			floor := floor
+			name := fmt.Sprintf("%s-%d%02d", building, floor, room)
+			features := []string{domain.FeatureProjector}
+			if room == 4 {
+				features = append(features, domain.FeatureComputerLab)
+			}
+			err := g.repos.Classroom.Create(ctx, domain.Classroom{
+				ClassroomName: name,
+				Capacity:      g.opts.StudentsPerGroup + 10,
+				Building:      building,
+				Floor:         &floor,
+				Features:      features,
+			})
+			if err != nil {
+				return err
+			}
+			classroom, err := g.repos.Classroom.GetByName(ctx, name)
+			if err != nil {
+				return err
+			}
+			g.classrooms = append(g.classrooms, classroom.ClassroomID)
+		}
+	}
+
+	for i, departament := range template.departaments[:g.opts.DepartamentsPerFaculty] {
+		if err := g.departament(ctx, faculty.Faculty.FacultyID, fmt.Sprintf("%d.%d", index+1, i+1), departament, levelID, typeID); err != nil {
+			return fmt.Errorf("%s: %w", departament.name, err)
+		}
+	}
+	return nil
+}
+
+// lessonKind is a discipline taught to a group in a form by a teacher
+type lessonKind struct {
+	disciplineID     int64
+	disciplineTypeID int64
+	teacherID        int64
+}
+
+func (g *generator) departament(ctx context.Context, facultyID int64, code string, template departamentTemplate, levelID, typeID int64) error {
+	head := g.person()
+	err := g.repos.Departament.Create(ctx, domain.Departament{
+		FacultyID:        facultyID,
+		DepartamentName:  template.name,
+		HeadLastName:     head.LastName,
+		HeadFirstName:    head.FirstName,
+		HeadMiddleName:   head.MiddleName,
+		DepartamentEmail: fmt.Sprintf("%s.departament%s@demo.osau.ru", g.prefix, code),
+	})
+	if err != nil {
+		return err
+	}
+	departament, err := g.repos.Departament.GetByName(ctx, template.name)
+	if err != nil {
+		return err
+	}
+	departamentID := departament.Departament.DepartamentID
+	g.result.Departaments++
+
+	teachers := make([]int64, 0, g.opts.TeachersPerDepartament)
+	for i := 0; i < g.opts.TeachersPerDepartament; i++ {
+		teacherID, err := g.teacher(ctx, departamentID, fmt.Sprintf("%s.%d", code, i+1))
+		if err != nil {
+			return err
+		}
+		teachers = append(teachers, teacherID)
+	}
+
+	// each discipline has a lecture and a practice or a laboratory work, read by different teachers
+	kinds := make([]lessonKind, 0, 2*len(template.disciplines))
+	for i, name := range template.disciplines {
+		if err := g.repos.Discipline.Create(ctx, domain.Discipline{DepartamentID: departamentID, DisciplineName: name}); err != nil {
+			return err
+		}
+		discipline, err := g.repos.Discipline.GetByName(ctx, name)
+		if err != nil {
+			return err
+		}
+		disciplineID := discipline.Discipline.DisciplineID
+
+		class := practice
+		if i%2 == 1 {
+			class = laboratory
+		}
+		kinds = append(kinds,
+			lessonKind{disciplineID: disciplineID, disciplineTypeID: g.disciplineTypes[lecture], teacherID: teachers[i%len(teachers)]},
+			lessonKind{disciplineID: disciplineID, disciplineTypeID: g.disciplineTypes[class], teacherID: teachers[(i+1)%len(teachers)]},
+		)
+	}
+
+	err = g.repos.Specialty.Create(ctx, domain.Specialty{
+		SpecialtyCode:    template.specialtyCode,
+		SpecialtyName:    template.specialtyName,
+		DepartamentID:    departamentID,
+		EducationLevelID: levelID,
+	})
+	if err != nil {
+		return err
+	}
+	err = g.repos.Profile.Create(ctx, domain.Profile{
+		SpecialtyCode:   template.specialtyCode,
+		EducationTypeID: typeID,
+		ProfileName:     template.profileName,
+	})
+	if err != nil {
+		return err
+	}
+	profile, err := g.repos.Profile.GetByName(ctx, template.profileName)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < g.opts.GroupsPerProfile; i++ {
+		groupID := fmt.Sprintf("%d-%s-%d", g.opts.Start.Year(), template.specialtyCode, i+1)
+		if err := g.group(ctx, groupID, profile.Profile.ProfileID, kinds); err != nil {
+			return fmt.Errorf("group %s: %w", groupID, err)
+		}
+	}
+	return nil
+}
+
+func (g *generator) teacher(ctx context.Context, departamentID int64, code string) (int64, error) {
+	person := g.person()
+	email := fmt.Sprintf("%s.teacher%s@demo.osau.ru", g.prefix, code)
+	err := g.repos.Teacher.Create(ctx, domain.Teacher{
+		DepartamentID: departamentID,
+		LastName:      person.LastName,
+		FirstName:     person.FirstName,
+		MiddleName:    person.MiddleName,
+		TeacherEmail:  email,
+	})
+	if err != nil {
+		return 0, err
+	}
+	teacher, err := g.repos.Teacher.GetByEmail(ctx, email)
+	if err != nil {
+		return 0, err
+	}
+	teacherID := teacher.Teacher.TeacherID
+
+	err = g.repos.User.Create(ctx, domain.User{
+		Username:  fmt.Sprintf("%s.teacher%s", g.prefix, code),
+		Password:  g.hash,
+		Role:      "Преподаватель",
+		TeacherID: &teacherID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	g.result.Teachers++
+	return teacherID, nil
+}
+
+func (g *generator) group(ctx context.Context, groupID string, profileID int64, kinds []lessonKind) error {
+	if err := g.repos.Group.Create(ctx, domain.Group{GroupID: groupID, ProfileID: profileID}); err != nil {
+		return err
+	}
+	g.result.Groups++
+
+	accounts := make([]domain.StudentAccount, g.opts.StudentsPerGroup)
+	for i := range accounts {
+		person := g.person()
+		accounts[i] = domain.StudentAccount{
+			Student: domain.Student{GroupID: groupID, LastName: person.LastName, FirstName: person.FirstName, MiddleName: person.MiddleName},
+			User:    &domain.User{Username: fmt.Sprintf("%s.%s.%02d", g.prefix, groupID, i+1), Password: g.hash, Role: "Студент"},
+		}
+	}
+	students, err := g.repos.Student.CreateWithAccounts(ctx, accounts)
+	if err != nil {
+		return err
+	}
+	g.result.Students += len(students)
+
+	if len(students) > 0 {
+		err := g.repos.Headman.Create(ctx, domain.Headman{StudentID: students[g.rand.Intn(len(students))], GroupID: groupID, TermStart: g.opts.Start})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := g.timetable(ctx, groupID, kinds); err != nil {
+		return err
+	}
+	return g.attendance(ctx, groupID, students)
+}
+
+// timetable fills both week types with up to LessonsPerDay lessons a day from the first slot on,
+// skipping a lesson whose teacher or every classroom is already taken
+func (g *generator) timetable(ctx context.Context, groupID string, kinds []lessonKind) error {
+	isActual := true
+	for _, weekType := range []string{upperWeek, lowerWeek} {
+		for _, day := range weekdays {
+			lessons := 1 + g.rand.Intn(g.opts.LessonsPerDay)
+			for _, slot := range g.slots[:lessons] {
+				kind := kinds[g.rand.Intn(len(kinds))]
+				at := fmt.Sprintf("%s/%s/%d", weekType, day, slot.SlotID)
+				if g.busy[fmt.Sprintf("teacher/%d/%s", kind.teacherID, at)] {
+					continue
+				}
+				classroomID, ok := g.freeClassroom(at)
+				if !ok {
+					continue
+				}
+
+				slotID := slot.SlotID
+				err := g.repos.Schedule.Create(ctx, domain.Schedule{
+					GroupID:          groupID,
+					DisciplineID:     kind.disciplineID,
+					TeacherID:        kind.teacherID,
+					DisciplineTypeID: kind.disciplineTypeID,
+					ClassroomID:      classroomID,
+					Semester:         g.opts.Semester,
+					BeginStudies:     g.opts.Start,
+					WeekType:         weekType,
+					DayOfWeek:        day,
+					StartTime:        slot.StartTime,
+					SlotID:           &slotID,
+					IsActual:         &isActual,
+				})
+				if err != nil {
+					return err
+				}
+				g.busy[fmt.Sprintf("teacher/%d/%s", kind.teacherID, at)] = true
+				g.busy[fmt.Sprintf("classroom/%d/%s", classroomID, at)] = true
+				g.result.Schedules++
+			}
+		}
+	}
+	return nil
+}
+
+func (g *generator) freeClassroom(at string) (int64, bool) {
+	offset := g.rand.Intn(len(g.classrooms))
+	for i := range g.classrooms {
+		classroomID := g.classrooms[(offset+i)%len(g.classrooms)]
+		if !g.busy[fmt.Sprintf("classroom/%d/%s", classroomID, at)] {
+			return classroomID, true
+		}
+	}
+	return 0, false
+}
+
+// attendance marks every student at every lesson held in the first Weeks of the semester,
+// the first week of studies is an upper one
+func (g *generator) attendance(ctx context.Context, groupID string, students []int64) error {
+	schedules, err := g.repos.Schedule.GetActualByGroupID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	for day := 0; day < g.opts.Weeks*7; day++ {
+		date := g.opts.Start.AddDate(0, 0, day)
+		weekday := int(date.Weekday()) - 1
+		if weekday < 0 || weekday >= len(weekdays) {
+			continue
+		}
+		weekType := upperWeek
+		if day/7%2 == 1 {
+			weekType = lowerWeek
+		}
+
+		for _, schedule := range schedules {
+			lesson := schedule.Schedule
+			if lesson.DayOfWeek != weekdays[weekday] || lesson.WeekType != weekType {
+				continue
+			}
+			created := date.Add(time.Duration(lesson.StartTime.Hour())*time.Hour + time.Duration(lesson.StartTime.Minute())*time.Minute)
+			for _, studentID := range students {
+				if err := g.repos.Attendance.Create(ctx, g.mark(studentID, lesson.ScheduleID, created)); err != nil {
+					return err
+				}
+				g.result.Attendance++
+			}
+		}
+	}
+	return nil
+}
+
+func (g *generator) mark(studentID, scheduleID int64, created time.Time) domain.Attendance {
+	presence := g.rand.Float64() < presenceRate
+	late := presence && g.rand.Float64() < lateRate
+	attendance := domain.Attendance{
+		StudentID:   studentID,
+		ScheduleID:  scheduleID,
+		Presence:    &presence,
+		LateArrival: &late,
+		Created:     created,
+	}
+	if !presence {
+		respectful := g.rand.Intn(2) == 0
+		attendance.Respectfulness = &respectful
+		if respectful {
+			reason := absenceReasons[g.rand.Intn(len(absenceReasons))]
+			attendance.Reason = &reason
+		}
+	}
+	return attendance
+}
+
+// person picks a full name of a random gender
+func (g *generator) person() domain.StudentFullName {
+	gender := g.rand.Intn(2)
+	return domain.StudentFullName{
+		LastName:   lastNames[g.rand.Intn(len(lastNames))][gender],
+		FirstName:  firstNames[gender][g.rand.Intn(len(firstNames[gender]))],
+		MiddleName: middleNames[gender][g.rand.Intn(len(middleNames[gender]))],
+	}
+}
@@ -0,0 +1,111 @@
+package seed_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository/memory"
+	"github.com/BeRebornBng/OsauAmsApi/internal/seed"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/myhash"
+	"github.com/google/uuid"
+)
+
+var testOptions = seed.Options{Seed: 7, Faculties: 1, DepartamentsPerFaculty: 1, GroupsPerProfile: 1, StudentsPerGroup: 5, TeachersPerDepartament: 3, Weeks: 1}
+
+// dataset holds the generated rows, the accounts without their ids and salted hashes
+type dataset struct {
+	Groups     []domain.GroupInfo
+	Teachers   []domain.TeacherInfo
+	Students   []domain.Student
+	Headmans   []domain.HeadmanInfo
+	Schedules  []domain.ScheduleInfo
+	Attendance []domain.AttendanceInfo
+	Accounts   []domain.User
+}
+
+func generated(t *testing.T, repos *repository.Repositories) dataset {
+	t.Helper()
+	ctx := context.Background()
+	var d dataset
+	var err error
+	check := func(what string) {
+		if err != nil {
+			t.Fatalf("%s: %v", what, err)
+		}
+	}
+
+	d.Groups, err = repos.Group.GetAll(ctx)
+	check("groups")
+	d.Teachers, err = repos.Teacher.GetAll(ctx)
+	check("teachers")
+	d.Students, err = repos.Student.GetAll(ctx)
+	check("students")
+	d.Headmans, err = repos.Headman.GetAll(ctx)
+	check("headmans")
+	d.Schedules, err = repos.Schedule.GetAll(ctx)
+	check("schedules")
+	d.Attendance, err = repos.Attendance.GetAll(ctx)
+	check("attendance")
+	users, err := repos.User.GetAll(ctx)
+	check("users")
+	for _, user := range users {
+		account := user.User
+		account.UserID, account.Password = uuid.Nil, ""
+		d.Accounts = append(d.Accounts, account)
+	}
+	return d
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	ctx := context.Background()
+	hasher := myhash.NewHasher("salt", 4)
+
+	first, second := memory.NewRepositories(), memory.NewRepositories()
+	firstResult, err := seed.Generate(ctx, first, hasher, testOptions)
+	if err != nil {
+		t.Fatalf("first Generate() error = %v", err)
+	}
+	secondResult, err := seed.Generate(ctx, second, hasher, testOptions)
+	if err != nil {
+		t.Fatalf("second Generate() error = %v", err)
+	}
+
+	if firstResult != secondResult {
+		t.Errorf("results differ: %+v and %+v", firstResult, secondResult)
+	}
+	if firstResult.Groups != 1 || firstResult.Students != 5 || firstResult.Schedules == 0 || firstResult.Attendance == 0 {
+		t.Errorf("result = %+v", firstResult)
+	}
+	firstRows, secondRows := generated(t, first), generated(t, second)
+	if len(firstRows.Students) != firstResult.Students || len(firstRows.Schedules) != firstResult.Schedules {
+		t.Errorf("rows don't match the result %+v", firstResult)
+	}
+	if !reflect.DeepEqual(firstRows, secondRows) {
+		t.Errorf("datasets of the same seed differ")
+	}
+}
+
+func TestGenerateSeededDatabase(t *testing.T) {
+	ctx := context.Background()
+	hasher := myhash.NewHasher("salt", 4)
+	repos := memory.NewRepositories()
+
+	if _, err := seed.Generate(ctx, repos, hasher, testOptions); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	before := generated(t, repos)
+
+	// another seed doesn't fit the database and adds nothing
+	options := testOptions
+	options.Seed = 8
+	if _, err := seed.Generate(ctx, repos, hasher, options); !errors.Is(err, seed.ErrSeeded) {
+		t.Fatalf("second Generate() error = %v, want %v", err, seed.ErrSeeded)
+	}
+	if after := generated(t, repos); !reflect.DeepEqual(before, after) {
+		t.Errorf("the failed run changed the dataset")
+	}
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"net/http"
 	"strconv"
 
@@ -10,20 +11,37 @@ import (
 
 type Server struct {
 	httpServer *http.Server
+	tls        config.TLSConfig
 }
 
 func NewServer(cfg *config.Config, handler http.Handler) *Server {
-	return &Server{
+	server := &Server{
 		httpServer: &http.Server{
-			Addr:         cfg.HTPP.Host + ":" + strconv.Itoa(int(cfg.HTPP.Port)),
-			Handler:      handler,
-			ReadTimeout:  cfg.HTPP.ReadTimeout,
-			WriteTimeout: cfg.HTPP.WriteTimeout,
+			Addr:              cfg.HTPP.Host + ":" + strconv.Itoa(cfg.HTPP.Port),
+			Handler:           handler,
+			ReadTimeout:       cfg.HTPP.ReadTimeout,
+			WriteTimeout:      cfg.HTPP.WriteTimeout,
+			IdleTimeout:       cfg.HTPP.IdleTimeout,
+			ReadHeaderTimeout: cfg.HTPP.ReadHeaderTimeout,
+			MaxHeaderBytes:    cfg.HTPP.MaxHeaderBytes,
 		},
+		tls: cfg.HTPP.TLS,
 	}
+
+	if cfg.HTPP.TLS.Enabled() {
+		// the version is checked by the config validation
+		minVersion, _ := cfg.HTPP.TLS.TLSVersion()
+		server.httpServer.TLSConfig = &tls.Config{MinVersion: minVersion}
+	}
+
+	return server
 }
 
+// Run serves HTTPS when the certificate and the key are configured and plain HTTP otherwise
 func (s *Server) Run() error {
+	if s.tls.Enabled() {
+		return s.httpServer.ListenAndServeTLS(s.tls.CertFile, s.tls.KeyFile)
+	}
 	return s.httpServer.ListenAndServe()
 }
 
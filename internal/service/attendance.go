@@ -1,74 +1,196 @@
-package service
-
-import (
-	"context"
-	"time"
-
-	"github.com/BeRebornBng/OsauAmsApi/domain"
-	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
-)
-
-type AttendanceService struct {
-	AttendanceRepo repository.IAttendance
-}
-
-func NewAttendanceService(attendanceRepo repository.IAttendance) *AttendanceService {
-	return &AttendanceService{AttendanceRepo: attendanceRepo}
-}
-
-func (s *AttendanceService) Create(ctx context.Context, attendance domain.Attendance) error {
-	return s.AttendanceRepo.Create(ctx, attendance)
-}
-
-func (s *AttendanceService) Put(ctx context.Context, attendance domain.Attendance) error {
-	return s.AttendanceRepo.Put(ctx, attendance)
-}
-
-func (s *AttendanceService) Patch(ctx context.Context, attendance domain.Attendance) error {
-	updates := make(map[string]interface{})
-	if attendance.StudentID != 0 {
-		updates["student_id"] = attendance.StudentID
-	}
-	if attendance.ScheduleID != 0 {
-		updates["schedule_id"] = attendance.ScheduleID
-	}
-	if attendance.Presence != nil {
-		updates["presence"] = attendance.Presence
-	}
-	if attendance.LateArrival != nil {
-		updates["late_arrival"] = attendance.LateArrival
-	}
-	if attendance.Respectfulness != nil {
-		updates["respectfulness"] = attendance.Respectfulness
-	}
-	if attendance.Reason != nil {
-		updates["reason"] = attendance.Reason
-	}
-	if !attendance.Created.IsZero() {
-		updates["created"] = attendance.Created
-	}
-	if len(updates) == 0 {
-		return ErrNoUpdates
-	}
-	return s.AttendanceRepo.Patch(ctx, attendance.AttendanceID, updates)
-}
-
-func (s *AttendanceService) Delete(ctx context.Context, attendanceID int64) error {
-	return s.AttendanceRepo.Delete(ctx, attendanceID)
-}
-
-func (s *AttendanceService) GetByID(ctx context.Context, attendanceID int64) (domain.AttendanceInfo, error) {
-	return s.AttendanceRepo.GetByID(ctx, attendanceID)
-}
-
-func (s *AttendanceService) GetByStudentID(ctx context.Context, studentID int64) ([]domain.AttendanceInfo, error) {
-	return s.AttendanceRepo.GetByStudentID(ctx, studentID)
-}
-
-func (s *AttendanceService) GetAll(ctx context.Context) ([]domain.AttendanceInfo, error) {
-	return s.AttendanceRepo.GetAll(ctx)
-}
-
-func (s *AttendanceService) GetAllByGroupIDAndCreated(ctx context.Context, groupID string, scheduleID int64, created time.Time) ([]domain.GroupAttendanceInfo, error) {
-	return s.AttendanceRepo.GetAllByGroupIDAndCreated(ctx, groupID, scheduleID, created)
-}
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+)
+
+type AttendanceService struct {
+	AttendanceRepo        repository.IAttendance
+	HeadmanRepo           repository.IHeadman
+	ScheduleRepo          repository.ISchedule
+	ScheduleExceptionRepo repository.IScheduleException
+	CalendarRepo          repository.ICalendar
+	SubgroupRepo          repository.ISubgroup
+	StudentRepo           repository.IStudent
+	MembershipRepo        repository.IMembership
+}
+
+func NewAttendanceService(attendanceRepo repository.IAttendance, headmanRepo repository.IHeadman, scheduleRepo repository.ISchedule, scheduleExceptionRepo repository.IScheduleException, calendarRepo repository.ICalendar, subgroupRepo repository.ISubgroup, studentRepo repository.IStudent, membershipRepo repository.IMembership) *AttendanceService {
+	return &AttendanceService{
+		AttendanceRepo:        attendanceRepo,
+		HeadmanRepo:           headmanRepo,
+		ScheduleRepo:          scheduleRepo,
+		ScheduleExceptionRepo: scheduleExceptionRepo,
+		CalendarRepo:          calendarRepo,
+		SubgroupRepo:          subgroupRepo,
+		StudentRepo:           studentRepo,
+		MembershipRepo:        membershipRepo,
+	}
+}
+
+// AuthorizeHeadman checks that the student behind the headman account was the
+// headman or the deputy of the schedule's group on the lesson date
+func (s *AttendanceService) AuthorizeHeadman(ctx context.Context, headmanID int64, scheduleID int64, date time.Time) error {
+	headman, err := s.HeadmanRepo.GetByID(ctx, headmanID)
+	if err != nil {
+		return err
+	}
+	schedule, err := s.ScheduleRepo.GetByID(ctx, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	active, err := s.HeadmanRepo.IsActive(ctx, headman.Headman.StudentID, schedule.Schedule.GroupID, date)
+	if err != nil {
+		return err
+	}
+	if !active {
+		return ErrNotHeadmanOnDate
+	}
+	return nil
+}
+
+// AuthorizeHeadmanUpdate checks the headman against the lesson of a stored attendance
+func (s *AttendanceService) AuthorizeHeadmanUpdate(ctx context.Context, headmanID int64, attendanceID int64) error {
+	attendance, err := s.AttendanceRepo.GetByID(ctx, attendanceID)
+	if err != nil {
+		return err
+	}
+	return s.AuthorizeHeadman(ctx, headmanID, attendance.Attendance.ScheduleID, attendance.Attendance.Created)
+}
+
+// AuthorizeTeacher checks that the teacher gives the lesson on the date,
+// the substitute teacher replaces the teacher of the timetable
+func (s *AttendanceService) AuthorizeTeacher(ctx context.Context, teacherID int64, scheduleID int64, date time.Time) error {
+	lesson, err := lessonOn(ctx, s.ScheduleRepo, s.ScheduleExceptionRepo, scheduleID, date)
+	if err != nil {
+		return err
+	}
+	if lesson.ScheduleInfo.Schedule.TeacherID != teacherID {
+		return ErrNotLessonTeacher
+	}
+	return nil
+}
+
+// AuthorizeTeacherUpdate checks the teacher against the lesson of a stored attendance
+func (s *AttendanceService) AuthorizeTeacherUpdate(ctx context.Context, teacherID int64, attendanceID int64) error {
+	attendance, err := s.AttendanceRepo.GetByID(ctx, attendanceID)
+	if err != nil {
+		return err
+	}
+	return s.AuthorizeTeacher(ctx, teacherID, attendance.Attendance.ScheduleID, attendance.Attendance.Created)
+}
+
+// Create marks the attendance of a lesson, lessons cancelled or moved away from the date
+// and days outside the teaching periods have no attendance. The student must be in the
+// group of the lesson on the date, a lab of a subgroup is marked only for the students of the subgroup
+func (s *AttendanceService) Create(ctx context.Context, attendance domain.Attendance) error {
+	lesson, err := lessonOn(ctx, s.ScheduleRepo, s.ScheduleExceptionRepo, attendance.ScheduleID, attendance.Created)
+	if err != nil {
+		return err
+	}
+	groupID, err := s.groupOn(ctx, attendance.StudentID, attendance.Created)
+	if err != nil {
+		return err
+	}
+	if groupID != lesson.ScheduleInfo.Schedule.GroupID {
+		return ErrStudentNotInGroup
+	}
+	teaching, err := s.CalendarRepo.IsTeachingDay(ctx, lesson.ScheduleInfo.Schedule.GroupID, attendance.Created)
+	if err != nil {
+		return err
+	}
+	if !teaching {
+		return ErrNotTeachingDay
+	}
+	if subgroupID := lesson.ScheduleInfo.Schedule.SubgroupID; subgroupID != nil {
+		inSubgroup, err := s.SubgroupRepo.HasStudent(ctx, *subgroupID, attendance.StudentID)
+		if err != nil {
+			return err
+		}
+		if !inSubgroup {
+			return ErrNotSubgroupStudent
+		}
+	}
+	return s.AttendanceRepo.Create(ctx, attendance)
+}
+
+// groupOn returns the group of the student on the date from the group history,
+// the history starts with the first group, and students without history are in their group
+func (s *AttendanceService) groupOn(ctx context.Context, studentID int64, date time.Time) (string, error) {
+	memberships, err := s.MembershipRepo.GetByStudentID(ctx, studentID)
+	if err != nil {
+		return "", err
+	}
+	if len(memberships) == 0 {
+		student, err := s.StudentRepo.GetByID(ctx, studentID)
+		if err != nil {
+			return "", err
+		}
+		return student.GroupID, nil
+	}
+
+	for _, membership := range memberships {
+		if !date.Before(membership.ValidFrom) && (membership.ValidTo == nil || date.Before(*membership.ValidTo)) {
+			return membership.GroupID, nil
+		}
+	}
+	return memberships[0].GroupID, nil
+}
+
+func (s *AttendanceService) Put(ctx context.Context, attendance domain.Attendance) error {
+	return s.AttendanceRepo.Put(ctx, attendance)
+}
+
+func (s *AttendanceService) Patch(ctx context.Context, attendance domain.Attendance) error {
+	updates := make(map[string]interface{})
+	if attendance.StudentID != 0 {
+		updates["student_id"] = attendance.StudentID
+	}
+	if attendance.ScheduleID != 0 {
+		updates["schedule_id"] = attendance.ScheduleID
+	}
+	if attendance.Presence != nil {
+		updates["presence"] = attendance.Presence
+	}
+	if attendance.LateArrival != nil {
+		updates["late_arrival"] = attendance.LateArrival
+	}
+	if attendance.Respectfulness != nil {
+		updates["respectfulness"] = attendance.Respectfulness
+	}
+	if attendance.Reason != nil {
+		updates["reason"] = attendance.Reason
+	}
+	if !attendance.Created.IsZero() {
+		updates["created"] = attendance.Created
+	}
+	if len(updates) == 0 {
+		return ErrNoUpdates
+	}
+	return s.AttendanceRepo.Patch(ctx, attendance.AttendanceID, updates)
+}
+
+func (s *AttendanceService) Delete(ctx context.Context, attendanceID int64) error {
+	return s.AttendanceRepo.Delete(ctx, attendanceID)
+}
+
+func (s *AttendanceService) GetByID(ctx context.Context, attendanceID int64) (domain.AttendanceInfo, error) {
+	return s.AttendanceRepo.GetByID(ctx, attendanceID)
+}
+
+func (s *AttendanceService) GetByStudentID(ctx context.Context, studentID int64) ([]domain.AttendanceInfo, error) {
+	return s.AttendanceRepo.GetByStudentID(ctx, studentID)
+}
+
+func (s *AttendanceService) GetAll(ctx context.Context) ([]domain.AttendanceInfo, error) {
+	return s.AttendanceRepo.GetAll(ctx)
+}
+
+func (s *AttendanceService) GetAllByGroupIDAndCreated(ctx context.Context, groupID string, scheduleID int64, created time.Time) ([]domain.GroupAttendanceInfo, error) {
+	return s.AttendanceRepo.GetAllByGroupIDAndCreated(ctx, groupID, scheduleID, created)
+}
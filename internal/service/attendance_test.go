@@ -0,0 +1,227 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/jackc/pgx/v5"
+)
+
+// createUniversity adds the university, the faculty, the departament, the specialty
+// and the profile 1 of the groups of newRepos
+func createUniversity(t *testing.T, repos *repository.Repositories) {
+	t.Helper()
+	ctx := context.Background()
+
+	steps := []func() error{
+		func() error { return repos.University.Create(ctx, domain.University{UniversityName: "ОГАУ"}) },
+		func() error {
+			return repos.Faculty.Create(ctx, domain.Faculty{UniversityID: 1, FacultyName: "Агрономический"})
+		},
+		func() error {
+			return repos.Departament.Create(ctx, domain.Departament{FacultyID: 1, DepartamentName: "Информатики"})
+		},
+		func() error {
+			return repos.Specialty.Create(ctx, domain.Specialty{SpecialtyCode: "35.03.06", SpecialtyName: "Агроинженерия", DepartamentID: 1})
+		},
+		func() error {
+			return repos.Profile.Create(ctx, domain.Profile{SpecialtyCode: "35.03.06", ProfileName: "Электрооборудование"})
+		},
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("create university: %v", err)
+		}
+	}
+}
+
+// newAttendanceService returns the service over the repositories of newRepos with the
+// university of the groups, the first semester with a holiday, a lecture of teacher 1
+// and a lab of teacher 2 for the subgroup of the first two students on upper Mondays,
+// the headman terms 1 and 2 of the first two students and student 4 of the other group
+func newAttendanceService(t *testing.T) (*service.AttendanceService, *repository.Repositories) {
+	t.Helper()
+	ctx := context.Background()
+	repos := newRepos(t)
+
+	createUniversity(t, repos)
+
+	semester := 1
+	periods := []domain.CalendarPeriod{
+		{UniversityID: 1, Kind: domain.PeriodSemester, Title: "Осенний семестр", Semester: &semester, StartDate: day("2024-09-01"), EndDate: day("2024-12-31")},
+		{UniversityID: 1, Kind: domain.PeriodHoliday, Title: "День народного единства", StartDate: day("2024-11-04"), EndDate: day("2024-11-04")},
+	}
+	for _, period := range periods {
+		if _, err := repos.Calendar.Create(ctx, period); err != nil {
+			t.Fatalf("create period: %v", err)
+		}
+	}
+
+	subgroupID, err := repos.Subgroup.Create(ctx, domain.Subgroup{GroupID: testGroup, Name: "1", StudentIDs: []int64{1, 2}})
+	if err != nil {
+		t.Fatalf("create subgroup: %v", err)
+	}
+	actual := true
+	schedules := []domain.Schedule{
+		{GroupID: testGroup, DisciplineID: 1, TeacherID: 1, DisciplineTypeID: 1, ClassroomID: 1, Semester: 1, BeginStudies: day("2024-09-02"),
+			WeekType: "Верхняя", DayOfWeek: "Понедельник", StartTime: clockAt("08:30"), IsActual: &actual},
+		{GroupID: testGroup, DisciplineID: 2, TeacherID: 2, DisciplineTypeID: 2, ClassroomID: 2, Semester: 1, BeginStudies: day("2024-09-02"),
+			WeekType: "Верхняя", DayOfWeek: "Понедельник", StartTime: clockAt("10:10"), SubgroupID: &subgroupID, IsActual: &actual},
+	}
+	for _, schedule := range schedules {
+		if err := repos.Schedule.Create(ctx, schedule); err != nil {
+			t.Fatalf("create schedule: %v", err)
+		}
+	}
+
+	substitute := int64(2)
+	exceptions := []domain.ScheduleException{
+		{ScheduleID: 1, LessonDate: day("2024-09-16"), IsCancelled: true},
+		{ScheduleID: 1, LessonDate: day("2024-09-30"), TeacherID: &substitute},
+		{ScheduleID: 1, LessonDate: day("2024-10-14"), MovedToDate: dayPtr("2024-10-15")},
+	}
+	for _, exception := range exceptions {
+		if _, err := repos.ScheduleException.Create(ctx, exception); err != nil {
+			t.Fatalf("create exception: %v", err)
+		}
+	}
+
+	headmen := []domain.Headman{
+		{StudentID: 1, GroupID: testGroup, TermStart: day("2024-09-01"), TermEnd: dayPtr("2024-12-31")},
+		{StudentID: 2, GroupID: testGroup, TermStart: day("2024-09-01"), TermEnd: dayPtr("2024-09-10"), IsDeputy: true},
+	}
+	for _, headman := range headmen {
+		if err := repos.Headman.Create(ctx, headman); err != nil {
+			t.Fatalf("create headman: %v", err)
+		}
+	}
+
+	if _, err := repos.Student.Create(ctx, domain.Student{GroupID: otherGroup, LastName: "Кузнецов", FirstName: "Пётр", MiddleName: "Сергеевич"}); err != nil {
+		t.Fatalf("create student: %v", err)
+	}
+
+	return service.NewAttendanceService(repos.Attendance, repos.Headman, repos.Schedule, repos.ScheduleException, repos.Calendar, repos.Subgroup, repos.Student, repos.Membership), repos
+}
+
+func TestAttendanceServiceCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		studentID  int64
+		scheduleID int64
+		created    string
+		wantErr    error
+	}{
+		{name: "lesson of the timetable", studentID: 1, scheduleID: 1, created: "2024-09-02"},
+		{name: "lesson with a substitute", studentID: 1, scheduleID: 1, created: "2024-09-30"},
+		{name: "cancelled lesson", studentID: 1, scheduleID: 1, created: "2024-09-16", wantErr: service.ErrLessonCancelled},
+		{name: "lesson moved away from the date", studentID: 1, scheduleID: 1, created: "2024-10-14", wantErr: service.ErrLessonMoved},
+		{name: "lesson moved to the date", studentID: 1, scheduleID: 1, created: "2024-10-15"},
+		{name: "holiday", studentID: 1, scheduleID: 1, created: "2024-11-04", wantErr: service.ErrNotTeachingDay},
+		{name: "outside the semester", studentID: 1, scheduleID: 1, created: "2025-01-13", wantErr: service.ErrNotTeachingDay},
+		{name: "student of the subgroup", studentID: 2, scheduleID: 2, created: "2024-09-02"},
+		{name: "student outside the subgroup", studentID: 3, scheduleID: 2, created: "2024-09-02", wantErr: service.ErrNotSubgroupStudent},
+		{name: "student of another group", studentID: 4, scheduleID: 1, created: "2024-09-02", wantErr: service.ErrStudentNotInGroup},
+		{name: "unknown student", studentID: 9, scheduleID: 1, created: "2024-09-02", wantErr: pgx.ErrNoRows},
+		{name: "unknown schedule", studentID: 1, scheduleID: 9, created: "2024-09-02", wantErr: pgx.ErrNoRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			attendances, repos := newAttendanceService(t)
+
+			present := true
+			attendance := domain.Attendance{StudentID: tt.studentID, ScheduleID: tt.scheduleID, Presence: &present, Created: day(tt.created)}
+			err := attendances.Create(ctx, attendance)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, err := repos.Attendance.GetByStudentID(ctx, tt.studentID)
+			if err != nil {
+				t.Fatalf("GetByStudentID() error = %v", err)
+			}
+			want := 1
+			if tt.wantErr != nil {
+				want = 0
+			}
+			if len(stored) != want {
+				t.Errorf("stored attendance = %d, want %d", len(stored), want)
+			}
+		})
+	}
+}
+
+func TestAttendanceServiceAuthorizeTeacher(t *testing.T) {
+	ctx := context.Background()
+	attendances, _ := newAttendanceService(t)
+
+	tests := []struct {
+		name       string
+		teacherID  int64
+		scheduleID int64
+		date       time.Time
+		wantErr    error
+	}{
+		{name: "teacher of the timetable", teacherID: 1, scheduleID: 1, date: day("2024-09-02")},
+		{name: "another teacher", teacherID: 2, scheduleID: 1, date: day("2024-09-02"), wantErr: service.ErrNotLessonTeacher},
+		{name: "substitute teacher", teacherID: 2, scheduleID: 1, date: day("2024-09-30")},
+		{name: "replaced teacher", teacherID: 1, scheduleID: 1, date: day("2024-09-30"), wantErr: service.ErrNotLessonTeacher},
+		{name: "cancelled lesson", teacherID: 1, scheduleID: 1, date: day("2024-09-16"), wantErr: service.ErrLessonCancelled},
+		{name: "teacher of the lab", teacherID: 2, scheduleID: 2, date: day("2024-09-02")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := attendances.AuthorizeTeacher(ctx, tt.teacherID, tt.scheduleID, tt.date); !errors.Is(err, tt.wantErr) {
+				t.Errorf("AuthorizeTeacher() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAttendanceServiceAuthorizeHeadman(t *testing.T) {
+	ctx := context.Background()
+	attendances, repos := newAttendanceService(t)
+
+	tests := []struct {
+		name      string
+		headmanID int64
+		date      time.Time
+		wantErr   error
+	}{
+		{name: "headman during the term", headmanID: 1, date: day("2024-09-16")},
+		{name: "deputy during the term", headmanID: 2, date: day("2024-09-02")},
+		{name: "deputy after the term", headmanID: 2, date: day("2024-09-16"), wantErr: service.ErrNotHeadmanOnDate},
+		{name: "headman before the term", headmanID: 1, date: day("2024-08-26"), wantErr: service.ErrNotHeadmanOnDate},
+		{name: "unknown headman", headmanID: 9, date: day("2024-09-02"), wantErr: pgx.ErrNoRows},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := attendances.AuthorizeHeadman(ctx, tt.headmanID, 1, tt.date); !errors.Is(err, tt.wantErr) {
+				t.Errorf("AuthorizeHeadman() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// an update is checked against the date of the stored attendance
+	present := true
+	if err := repos.Attendance.Create(ctx, domain.Attendance{StudentID: 3, ScheduleID: 1, Presence: &present, Created: day("2024-09-23")}); err != nil {
+		t.Fatalf("create attendance: %v", err)
+	}
+	stored, err := repos.Attendance.GetByStudentID(ctx, 3)
+	if err != nil || len(stored) != 1 {
+		t.Fatalf("GetByStudentID() = %v, %v", stored, err)
+	}
+	attendanceID := stored[0].Attendance.AttendanceID
+	if err := attendances.AuthorizeHeadmanUpdate(ctx, 1, attendanceID); err != nil {
+		t.Errorf("AuthorizeHeadmanUpdate() of the headman error = %v", err)
+	}
+	if err := attendances.AuthorizeHeadmanUpdate(ctx, 2, attendanceID); !errors.Is(err, service.ErrNotHeadmanOnDate) {
+		t.Errorf("AuthorizeHeadmanUpdate() of the former deputy error = %v, want %v", err, service.ErrNotHeadmanOnDate)
+	}
+}
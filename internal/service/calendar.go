@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+)
+
+type CalendarService struct {
+	CalendarRepo repository.ICalendar
+}
+
+func NewCalendarService(calendarRepo repository.ICalendar) *CalendarService {
+	return &CalendarService{CalendarRepo: calendarRepo}
+}
+
+func (s *CalendarService) Create(ctx context.Context, period domain.CalendarPeriod) (int64, error) {
+	if err := checkPeriod(period); err != nil {
+		return 0, err
+	}
+	return s.CalendarRepo.Create(ctx, period)
+}
+
+func (s *CalendarService) Put(ctx context.Context, period domain.CalendarPeriod) error {
+	if err := checkPeriod(period); err != nil {
+		return err
+	}
+	return s.CalendarRepo.Put(ctx, period)
+}
+
+func (s *CalendarService) Delete(ctx context.Context, periodID int64) error {
+	return s.CalendarRepo.Delete(ctx, periodID)
+}
+
+func (s *CalendarService) GetByID(ctx context.Context, periodID int64) (domain.CalendarPeriod, error) {
+	return s.CalendarRepo.GetByID(ctx, periodID)
+}
+
+func (s *CalendarService) GetByUniversityID(ctx context.Context, universityID int64, from, to time.Time) ([]domain.CalendarPeriod, error) {
+	return s.CalendarRepo.GetByUniversityID(ctx, universityID, from, to)
+}
+
+func (s *CalendarService) IsTeachingDay(ctx context.Context, groupID string, date time.Time) (bool, error) {
+	return s.CalendarRepo.IsTeachingDay(ctx, groupID, date)
+}
+
+func checkPeriod(period domain.CalendarPeriod) error {
+	if dateOnly(period.EndDate).Before(dateOnly(period.StartDate)) {
+		return ErrCalendarPeriodDates
+	}
+	if period.Semester != nil && period.Kind != domain.PeriodSemester {
+		return ErrCalendarSemester
+	}
+	return nil
+}
+
+// teachingDays caches whether the universities of the groups teach on the date
+type teachingDays struct {
+	calendarRepo repository.ICalendar
+	date         time.Time
+	groups       map[string]bool
+}
+
+func newTeachingDays(calendarRepo repository.ICalendar, date time.Time) *teachingDays {
+	return &teachingDays{calendarRepo: calendarRepo, date: date, groups: make(map[string]bool)}
+}
+
+func (t *teachingDays) of(ctx context.Context, groupID string) (bool, error) {
+	if teaching, ok := t.groups[groupID]; ok {
+		return teaching, nil
+	}
+	teaching, err := t.calendarRepo.IsTeachingDay(ctx, groupID, t.date)
+	if err != nil {
+		return false, err
+	}
+	t.groups[groupID] = teaching
+	return teaching, nil
+}
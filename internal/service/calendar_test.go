@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+)
+
+func TestCalendarServiceCreate(t *testing.T) {
+	semester := 2
+	tests := []struct {
+		name    string
+		period  domain.CalendarPeriod
+		wantErr error
+	}{
+		{name: "semester", period: domain.CalendarPeriod{Kind: domain.PeriodSemester, Semester: &semester, StartDate: day("2025-02-10"), EndDate: day("2025-06-30")}},
+		{name: "one day holiday", period: domain.CalendarPeriod{Kind: domain.PeriodHoliday, StartDate: day("2025-05-01"), EndDate: day("2025-05-01")}},
+		{name: "end before the start", period: domain.CalendarPeriod{Kind: domain.PeriodHoliday, StartDate: day("2025-05-02"), EndDate: day("2025-05-01")},
+			wantErr: service.ErrCalendarPeriodDates},
+		{name: "holiday with a semester", period: domain.CalendarPeriod{Kind: domain.PeriodHoliday, Semester: &semester, StartDate: day("2025-05-01"), EndDate: day("2025-05-01")},
+			wantErr: service.ErrCalendarSemester},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newRepos(t)
+			createUniversity(t, repos)
+			calendar := service.NewCalendarService(repos.Calendar)
+
+			tt.period.UniversityID, tt.period.Title = 1, tt.name
+			periodID, err := calendar.Create(ctx, tt.period)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			// a period is checked the same way when it is replaced
+			if tt.wantErr != nil {
+				if err := calendar.Put(ctx, tt.period); !errors.Is(err, tt.wantErr) {
+					t.Errorf("Put() error = %v, want %v", err, tt.wantErr)
+				}
+			}
+
+			periods, err := calendar.GetByUniversityID(ctx, 1, day("2025-01-01"), day("2025-12-31"))
+			if err != nil {
+				t.Fatalf("GetByUniversityID() error = %v", err)
+			}
+			if tt.wantErr == nil && (len(periods) != 1 || periods[0].PeriodID != periodID) {
+				t.Errorf("periods = %+v, want the created one", periods)
+			}
+			if tt.wantErr != nil && len(periods) != 0 {
+				t.Errorf("periods = %+v, want none", periods)
+			}
+		})
+	}
+}
+
+func TestCalendarServiceIsTeachingDay(t *testing.T) {
+	ctx := context.Background()
+	_, repos := newAttendanceService(t)
+	calendar := service.NewCalendarService(repos.Calendar)
+
+	tests := []struct {
+		name    string
+		groupID string
+		date    string
+		want    bool
+	}{
+		{name: "day of the semester", groupID: testGroup, date: "2024-09-02", want: true},
+		{name: "last day of the semester", groupID: testGroup, date: "2024-12-31", want: true},
+		{name: "holiday", groupID: testGroup, date: "2024-11-04"},
+		{name: "before the semester", groupID: testGroup, date: "2024-08-31"},
+		{name: "after the semester", groupID: testGroup, date: "2025-01-13"},
+		{name: "group of the same university", groupID: otherGroup, date: "2024-11-05", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teaching, err := calendar.IsTeachingDay(ctx, tt.groupID, day(tt.date))
+			if err != nil {
+				t.Fatalf("IsTeachingDay() error = %v", err)
+			}
+			if teaching != tt.want {
+				t.Errorf("IsTeachingDay(%s, %s) = %v, want %v", tt.groupID, tt.date, teaching, tt.want)
+			}
+		})
+	}
+}
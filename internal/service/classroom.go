@@ -9,10 +9,11 @@ import (
 
 type ClassroomService struct {
 	ClassroomRepo repository.IClassroom
+	StudentRepo   repository.IStudent
 }
 
-func NewClassroomService(classroomRepo repository.IClassroom) *ClassroomService {
-	return &ClassroomService{ClassroomRepo: classroomRepo}
+func NewClassroomService(classroomRepo repository.IClassroom, studentRepo repository.IStudent) *ClassroomService {
+	return &ClassroomService{ClassroomRepo: classroomRepo, StudentRepo: studentRepo}
 }
 
 func (s *ClassroomService) Create(ctx context.Context, classroom domain.Classroom) error {
@@ -28,6 +29,18 @@ func (s *ClassroomService) Patch(ctx context.Context, classroom domain.Classroom
 	if classroom.ClassroomName != "" {
 		updates["classroom_name"] = classroom.ClassroomName
 	}
+	if classroom.Capacity != 0 {
+		updates["capacity"] = classroom.Capacity
+	}
+	if classroom.Building != "" {
+		updates["building"] = classroom.Building
+	}
+	if classroom.Floor != nil {
+		updates["floor"] = classroom.Floor
+	}
+	if classroom.Features != nil {
+		updates["features"] = classroom.Features
+	}
 	if len(updates) == 0 {
 		return ErrNoUpdates
 	}
@@ -45,3 +58,18 @@ func (s *ClassroomService) GetByID(ctx context.Context, classroomID int64) (doma
 func (s *ClassroomService) GetAll(ctx context.Context) ([]domain.Classroom, error) {
 	return s.ClassroomRepo.GetAll(ctx)
 }
+
+// GetFree returns the classrooms free in the slot, a classroom for the group
+// must seat all students of the group
+func (s *ClassroomService) GetFree(ctx context.Context, filter domain.FreeClassroomFilter) ([]domain.Classroom, error) {
+	if filter.GroupID != "" {
+		students, err := s.StudentRepo.GetAllByGroupID(ctx, filter.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		if len(students) > filter.MinCapacity {
+			filter.MinCapacity = len(students)
+		}
+	}
+	return s.ClassroomRepo.GetFree(ctx, filter)
+}
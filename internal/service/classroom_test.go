@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+)
+
+func TestClassroomServiceGetFree(t *testing.T) {
+	ctx := context.Background()
+	repos := newRepos(t)
+	classrooms := service.NewClassroomService(repos.Classroom, repos.Student)
+
+	for _, classroom := range []domain.Classroom{
+		{ClassroomName: "101", Capacity: 2, Building: "1"},
+		{ClassroomName: "202", Capacity: 30, Building: "1", Features: []string{"projector"}},
+		{ClassroomName: "303", Capacity: 30, Building: "2"},
+	} {
+		if err := classrooms.Create(ctx, classroom); err != nil {
+			t.Fatalf("create classroom: %v", err)
+		}
+	}
+	actual, archived := true, false
+	for _, schedule := range []domain.Schedule{
+		{GroupID: otherGroup, ClassroomID: 2, Semester: 1, WeekType: "Верхняя", DayOfWeek: "Понедельник", StartTime: clockAt("08:30"), IsActual: &actual},
+		{GroupID: otherGroup, ClassroomID: 3, Semester: 1, WeekType: "Верхняя", DayOfWeek: "Понедельник", StartTime: clockAt("08:30"), IsActual: &archived},
+	} {
+		schedule.DisciplineID, schedule.TeacherID, schedule.DisciplineTypeID = 1, 1, 1
+		if err := repos.Schedule.Create(ctx, schedule); err != nil {
+			t.Fatalf("create schedule: %v", err)
+		}
+	}
+
+	upperMonday := domain.FreeClassroomFilter{Semester: 1, WeekType: "Верхняя", DayOfWeek: "Понедельник", StartTime: clockAt("08:30")}
+	tests := []struct {
+		name   string
+		filter func(domain.FreeClassroomFilter) domain.FreeClassroomFilter
+		want   []string
+	}{
+		{name: "busy classroom", want: []string{"101", "303"}},
+		{name: "another slot", filter: func(f domain.FreeClassroomFilter) domain.FreeClassroomFilter {
+			f.StartTime = clockAt("10:10")
+			return f
+		}, want: []string{"101", "202", "303"}},
+		{name: "another week type", filter: func(f domain.FreeClassroomFilter) domain.FreeClassroomFilter {
+			f.WeekType = "Нижняя"
+			return f
+		}, want: []string{"101", "202", "303"}},
+		{name: "another semester", filter: func(f domain.FreeClassroomFilter) domain.FreeClassroomFilter {
+			f.Semester = 2
+			return f
+		}, want: []string{"101", "202", "303"}},
+		{name: "minimum capacity", filter: func(f domain.FreeClassroomFilter) domain.FreeClassroomFilter {
+			f.MinCapacity = 10
+			return f
+		}, want: []string{"303"}},
+		{name: "group larger than the classroom", filter: func(f domain.FreeClassroomFilter) domain.FreeClassroomFilter {
+			f.GroupID = testGroup
+			return f
+		}, want: []string{"303"}},
+		{name: "group without students", filter: func(f domain.FreeClassroomFilter) domain.FreeClassroomFilter {
+			f.GroupID = otherGroup
+			return f
+		}, want: []string{"101", "303"}},
+		{name: "capacity above the group", filter: func(f domain.FreeClassroomFilter) domain.FreeClassroomFilter {
+			f.GroupID, f.MinCapacity = testGroup, 31
+			return f
+		}, want: []string{}},
+		{name: "features", filter: func(f domain.FreeClassroomFilter) domain.FreeClassroomFilter {
+			f.StartTime, f.Features = clockAt("10:10"), []string{"projector"}
+			return f
+		}, want: []string{"202"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := upperMonday
+			if tt.filter != nil {
+				filter = tt.filter(filter)
+			}
+			free, err := classrooms.GetFree(ctx, filter)
+			if err != nil {
+				t.Fatalf("GetFree() error = %v", err)
+			}
+			names := make([]string, 0, len(free))
+			for _, classroom := range free {
+				names = append(names, classroom.ClassroomName)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("free classrooms = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
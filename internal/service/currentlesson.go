@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+)
+
+const (
+	// defaultLessonDuration is the length of a lesson that starts outside the bell schedule
+	defaultLessonDuration = 90 * time.Minute
+	// nextLessonLookahead is how many days ahead the next lesson is searched
+	nextLessonLookahead = 14
+)
+
+type lessonsOfDay func(ctx context.Context, date time.Time) ([]domain.Lesson, error)
+
+// GetCurrentByGroup returns the lesson of the group going on at the moment, nil between lessons
+func (s *ScheduleExceptionService) GetCurrentByGroup(ctx context.Context, groupID string, now time.Time) (*domain.SlotLesson, error) {
+	return s.current(ctx, now, func(ctx context.Context, date time.Time) ([]domain.Lesson, error) {
+		return s.GetByGroupAndDate(ctx, groupID, date)
+	})
+}
+
+// GetNextByGroup returns the next lesson of the group within two weeks, nil without one
+func (s *ScheduleExceptionService) GetNextByGroup(ctx context.Context, groupID string, now time.Time) (*domain.SlotLesson, error) {
+	return s.next(ctx, now, func(ctx context.Context, date time.Time) ([]domain.Lesson, error) {
+		return s.GetByGroupAndDate(ctx, groupID, date)
+	})
+}
+
+// GetCurrentByTeacher returns the lesson the teacher gives at the moment, nil between lessons
+func (s *ScheduleExceptionService) GetCurrentByTeacher(ctx context.Context, teacherID int64, now time.Time) (*domain.SlotLesson, error) {
+	return s.current(ctx, now, func(ctx context.Context, date time.Time) ([]domain.Lesson, error) {
+		return s.GetByTeacherAndDate(ctx, teacherID, date)
+	})
+}
+
+// GetNextByTeacher returns the next lesson of the teacher within two weeks, nil without one
+func (s *ScheduleExceptionService) GetNextByTeacher(ctx context.Context, teacherID int64, now time.Time) (*domain.SlotLesson, error) {
+	return s.next(ctx, now, func(ctx context.Context, date time.Time) ([]domain.Lesson, error) {
+		return s.GetByTeacherAndDate(ctx, teacherID, date)
+	})
+}
+
+func (s *ScheduleExceptionService) current(ctx context.Context, now time.Time, day lessonsOfDay) (*domain.SlotLesson, error) {
+	lessons, err := s.slotLessons(ctx, now, day)
+	if err != nil {
+		return nil, err
+	}
+	for _, lesson := range lessons {
+		if !now.Before(lesson.StartsAt) && now.Before(lesson.EndsAt) {
+			return &lesson, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *ScheduleExceptionService) next(ctx context.Context, now time.Time, day lessonsOfDay) (*domain.SlotLesson, error) {
+	for i := 0; i <= nextLessonLookahead; i++ {
+		lessons, err := s.slotLessons(ctx, now.AddDate(0, 0, i), day)
+		if err != nil {
+			return nil, err
+		}
+		for _, lesson := range lessons {
+			if lesson.StartsAt.After(now) {
+				return &lesson, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// slotLessons places the held lessons of the day onto the bell schedule of their groups
+func (s *ScheduleExceptionService) slotLessons(ctx context.Context, moment time.Time, day lessonsOfDay) ([]domain.SlotLesson, error) {
+	lessons, err := day(ctx, dateOnly(moment))
+	if err != nil {
+		return nil, err
+	}
+
+	bells := make(map[string][]domain.LessonSlot)
+	slotLessons := make([]domain.SlotLesson, 0, len(lessons))
+	for _, lesson := range lessons {
+		if lesson.IsCancelled {
+			continue
+		}
+		schedule := lesson.ScheduleInfo.Schedule
+		slots, ok := bells[schedule.GroupID]
+		if !ok {
+			if slots, err = s.LessonSlotRepo.GetByGroupID(ctx, schedule.GroupID); err != nil {
+				return nil, err
+			}
+			bells[schedule.GroupID] = slots
+		}
+
+		slotLesson := domain.SlotLesson{Lesson: lesson, StartsAt: atClock(moment, schedule.StartTime)}
+		slotLesson.EndsAt = slotLesson.StartsAt.Add(defaultLessonDuration)
+		for i := range slots {
+			if clock(slots[i].StartTime) == clock(schedule.StartTime) {
+				slotLesson.Slot = &slots[i]
+				slotLesson.EndsAt = atClock(moment, slots[i].EndTime)
+				break
+			}
+		}
+		slotLessons = append(slotLessons, slotLesson)
+	}
+	return slotLessons, nil
+}
+
+// atClock returns the moment of the day at the time of the clock
+func atClock(day time.Time, clock time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, day.Location())
+}
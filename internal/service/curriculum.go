@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+)
+
+// lessonHours is the number of academic hours in one lesson ("пара")
+const lessonHours = 2
+
+type CurriculumService struct {
+	CurriculumRepo repository.ICurriculum
+	ScheduleRepo   repository.ISchedule
+	CalendarRepo   repository.ICalendar
+}
+
+func NewCurriculumService(curriculumRepo repository.ICurriculum, scheduleRepo repository.ISchedule, calendarRepo repository.ICalendar) *CurriculumService {
+	return &CurriculumService{CurriculumRepo: curriculumRepo, ScheduleRepo: scheduleRepo, CalendarRepo: calendarRepo}
+}
+
+func (s *CurriculumService) Create(ctx context.Context, item domain.CurriculumItem) (int64, error) {
+	return s.CurriculumRepo.Create(ctx, item)
+}
+
+func (s *CurriculumService) Put(ctx context.Context, item domain.CurriculumItem) error {
+	return s.CurriculumRepo.Put(ctx, item)
+}
+
+func (s *CurriculumService) Delete(ctx context.Context, curriculumID int64) error {
+	return s.CurriculumRepo.Delete(ctx, curriculumID)
+}
+
+func (s *CurriculumService) GetByID(ctx context.Context, curriculumID int64) (domain.CurriculumItemInfo, error) {
+	return s.CurriculumRepo.GetByID(ctx, curriculumID)
+}
+
+func (s *CurriculumService) GetByProfileID(ctx context.Context, profileID int64) ([]domain.CurriculumItemInfo, error) {
+	return s.CurriculumRepo.GetByProfileID(ctx, profileID)
+}
+
+// curriculumKey is a discipline type of a discipline
+type curriculumKey struct {
+	disciplineID     int64
+	disciplineTypeID int64
+}
+
+// subgroupHours sums the hours given to the whole group and to each of its subgroups,
+// a student attends the lessons of the whole group and of one subgroup
+type subgroupHours struct {
+	group     int
+	subgroups map[int64]int
+}
+
+func (h *subgroupHours) add(subgroupID *int64, hours int) {
+	if subgroupID == nil {
+		h.group += hours
+		return
+	}
+	if h.subgroups == nil {
+		h.subgroups = make(map[int64]int)
+	}
+	h.subgroups[*subgroupID] += hours
+}
+
+func (h subgroupHours) total() int {
+	most := 0
+	for _, hours := range h.subgroups {
+		if hours > most {
+			most = hours
+		}
+	}
+	return h.group + most
+}
+
+// GetHoursReport compares the planned hours of the group's curriculum in the semester
+// with the hours of the actual timetable on the teaching days of the semester and the
+// hours of the lessons with a marked attendance. Disciplines that are scheduled or held
+// but not planned are reported with no planned hours
+func (s *CurriculumService) GetHoursReport(ctx context.Context, groupID string, semester int) (domain.CurriculumReport, error) {
+	report := domain.CurriculumReport{GroupID: groupID, Semester: semester, Hours: make([]domain.CurriculumHours, 0)}
+
+	items, err := s.CurriculumRepo.GetByGroupAndSemester(ctx, groupID, semester)
+	if err != nil {
+		return report, err
+	}
+	schedules, err := s.ScheduleRepo.GetActualByGroupID(ctx, groupID)
+	if err != nil {
+		return report, err
+	}
+	days, err := s.CalendarRepo.GetTeachingDays(ctx, groupID, semester)
+	if err != nil {
+		return report, err
+	}
+	held, err := s.CurriculumRepo.CountHeldLessons(ctx, groupID, semester)
+	if err != nil {
+		return report, err
+	}
+
+	rows := make(map[curriculumKey]int)
+	row := func(hours domain.CurriculumHours) int {
+		key := curriculumKey{disciplineID: hours.DisciplineID, disciplineTypeID: hours.DisciplineTypeID}
+		if i, ok := rows[key]; ok {
+			return i
+		}
+		rows[key] = len(report.Hours)
+		report.Hours = append(report.Hours, hours)
+		return rows[key]
+	}
+
+	for _, item := range items {
+		i := row(domain.CurriculumHours{
+			DisciplineID:       item.CurriculumItem.DisciplineID,
+			DisciplineName:     item.CurriculumItemSub.DisciplineName,
+			DisciplineTypeID:   item.CurriculumItem.DisciplineTypeID,
+			DisciplineTypeName: item.CurriculumItemSub.DisciplineTypeName,
+		})
+		report.Hours[i].PlannedHours += item.CurriculumItem.PlannedHours
+	}
+
+	scheduled := make(map[int]*subgroupHours)
+	for _, scheduleInfo := range schedules {
+		schedule := scheduleInfo.Schedule
+		if schedule.Semester != semester {
+			continue
+		}
+		lessons := 0
+		for _, day := range days {
+			if heldByTimetable(schedule, day) {
+				lessons++
+			}
+		}
+		i := row(domain.CurriculumHours{
+			DisciplineID:       schedule.DisciplineID,
+			DisciplineName:     scheduleInfo.ScheduleSub.DisciplineName,
+			DisciplineTypeID:   schedule.DisciplineTypeID,
+			DisciplineTypeName: scheduleInfo.ScheduleSub.DisciplineTypeName,
+		})
+		if scheduled[i] == nil {
+			scheduled[i] = &subgroupHours{}
+		}
+		scheduled[i].add(schedule.SubgroupID, lessons*lessonHours)
+	}
+
+	heldHours := make(map[int]*subgroupHours)
+	for _, lessons := range held {
+		i := row(domain.CurriculumHours{
+			DisciplineID:       lessons.DisciplineID,
+			DisciplineName:     lessons.DisciplineName,
+			DisciplineTypeID:   lessons.DisciplineTypeID,
+			DisciplineTypeName: lessons.DisciplineTypeName,
+		})
+		if heldHours[i] == nil {
+			heldHours[i] = &subgroupHours{}
+		}
+		heldHours[i].add(lessons.SubgroupID, lessons.Lessons*lessonHours)
+	}
+
+	for i := range report.Hours {
+		if hours, ok := scheduled[i]; ok {
+			report.Hours[i].ScheduledHours = hours.total()
+		}
+		if hours, ok := heldHours[i]; ok {
+			report.Hours[i].HeldHours = hours.total()
+		}
+	}
+
+	return report, nil
+}
+
+// inCurriculum reports whether the curriculum allows the discipline type of the discipline,
+// a semester without a curriculum allows any discipline
+func inCurriculum(items []domain.CurriculumItemInfo, disciplineID, disciplineTypeID int64) bool {
+	if len(items) == 0 {
+		return true
+	}
+	for _, item := range items {
+		if item.CurriculumItem.DisciplineID == disciplineID && item.CurriculumItem.DisciplineTypeID == disciplineTypeID {
+			return true
+		}
+	}
+	return false
+}
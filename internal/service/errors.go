@@ -1,6 +1,10 @@
 package service
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
 	ErrNoUpdates                   = errors.New("there are few arguments, at least one is needed")
@@ -9,4 +13,98 @@ var (
 	ErrStudentIDExists       error = errors.New("such a student has already been registered")
 	ErrTeacherIDExists       error = errors.New("such a teacher has already been registered")
 	ErrHeadmanIDExists       error = errors.New("such a headman has already been registered")
+	ErrStudentExists         error = errors.New("such a student already exists in this group")
+)
+
+var (
+	ErrWrongPassword     = errors.New("the current password is wrong")
+	ErrInvalidResetToken = errors.New("the reset token is invalid or expired")
+)
+
+var (
+	ErrImportHasErrors      = errors.New("the import file contains errors, nothing was imported")
+	ErrImportEmpty          = errors.New("the import file has no rows")
+	ErrCredentialsNotFound  = errors.New("credentials not found or already downloaded")
+	errUsernameNotGenerated = errors.New("failed to generate a unique username")
+)
+
+var (
+	ErrSameSemester      = errors.New("the next semester must differ from the current one")
+	ErrNothingToRollover = errors.New("there are no actual schedules in the semester")
+)
+
+// MappingError is returned when a rollover maps to a classroom or a teacher that does not exist
+type MappingError struct {
+	Kind string
+	From int64
+	To   int64
+}
+
+func (e *MappingError) Error() string {
+	return fmt.Sprintf("%s %d is mapped to %d which does not exist", e.Kind, e.From, e.To)
+}
+
+var (
+	ErrSameGroup          = errors.New("the source and the target group are the same")
+	ErrGroupNotFound      = errors.New("group not found")
+	ErrTransferDate       = errors.New("the transfer date is earlier than the start of the current group membership")
+	ErrDuplicatePromotion = errors.New("a group can be promoted only once and receive students from only one group")
+	ErrGroupHasAttendance = errors.New("the group has marked attendance, archive its schedules instead of deleting it")
+)
+
+var (
+	ErrHeadmanTermDates   = errors.New("the term can't end before it starts")
+	ErrHeadmanTermOverlap = errors.New("the term overlaps another term of the group or the student")
+	ErrNotHeadmanOnDate   = errors.New("you are not the headman of this group on the lesson date")
+)
+
+var (
+	ErrExceptionNoChanges = errors.New("the exception must cancel the lesson or change its teacher, classroom, date or time")
+	ErrExceptionCancelled = errors.New("a cancelled lesson can't have a substitute teacher, classroom, date or time")
+	ErrLessonNotOnDate    = errors.New("the lesson is not held on this date by the timetable")
+	ErrLessonCancelled    = errors.New("the lesson is cancelled on this date")
+	ErrLessonMoved        = errors.New("the lesson is moved to another date")
+	ErrNotLessonTeacher   = errors.New("you are not the teacher of this lesson on this date")
+)
+
+var (
+	ErrCalendarPeriodDates = errors.New("the period can't end before it starts")
+	ErrCalendarSemester    = errors.New("only a semester period can have a semester number")
+	ErrNotTeachingDay      = errors.New("the date is outside the teaching periods of the academic calendar")
 )
+
+var (
+	ErrSlotNotFound = errors.New("the lesson slot is not in the bell schedule of the group's university")
+	ErrSlotTime     = errors.New("the lesson slot must end after it starts")
+	ErrSlotOverlap  = errors.New("the lesson slot overlaps another slot or its break")
+)
+
+var (
+	ErrSubgroupStudent    = errors.New("a student of the subgroup is not in its group")
+	ErrSubgroupGroup      = errors.New("the subgroup belongs to another group")
+	ErrNotSubgroupStudent = errors.New("the student is not in the subgroup of this lesson")
+)
+
+var ErrNotInCurriculum = errors.New("the discipline type of the discipline is not in the curriculum of the group for the semester")
+
+var (
+	ErrNotGroupTeacher   = errors.New("you don't teach this discipline to the group in the semester")
+	ErrStudentNotInGroup = errors.New("the student is not in the group")
+	ErrScoreAboveMax     = errors.New("the score is above the maximum score of the control point")
+	ErrGradeScale        = errors.New("the grade is not in the scale of the control type")
+)
+
+var ErrTooManyLoginAttempts = errors.New("too many failed sign-in attempts, try again later")
+
+// LoginLockedError is returned while a username or a client IP is locked out
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return ErrTooManyLoginAttempts.Error()
+}
+
+func (e *LoginLockedError) Is(target error) bool {
+	return target == ErrTooManyLoginAttempts
+}
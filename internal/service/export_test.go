@@ -0,0 +1,11 @@
+package service
+
+import "time"
+
+// WeekTypeOn exposes weekTypeOn to the tests of the package service_test
+var WeekTypeOn = weekTypeOn
+
+// SetLoginGuardClock replaces the clock of the guard
+func SetLoginGuardClock(g *LoginGuard, now func() time.Time) {
+	g.now = now
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+)
+
+// A student is admitted to the credit or the exam of a discipline when they attended
+// at least admissionAttendance percent of the lessons, have the average mark of at least
+// admissionAverageMark and have a score for every control point that is due
+const (
+	admissionAttendance  = 70.0
+	admissionAverageMark = 3.0
+)
+
+type GradebookService struct {
+	GradebookRepo         repository.IGradebook
+	ScheduleRepo          repository.ISchedule
+	ScheduleExceptionRepo repository.IScheduleException
+	StudentRepo           repository.IStudent
+	SubgroupRepo          repository.ISubgroup
+	DisciplineRepo        repository.IDiscipline
+}
+
+func NewGradebookService(gradebookRepo repository.IGradebook, scheduleRepo repository.ISchedule, scheduleExceptionRepo repository.IScheduleException, studentRepo repository.IStudent, subgroupRepo repository.ISubgroup, disciplineRepo repository.IDiscipline) *GradebookService {
+	return &GradebookService{
+		GradebookRepo:         gradebookRepo,
+		ScheduleRepo:          scheduleRepo,
+		ScheduleExceptionRepo: scheduleExceptionRepo,
+		StudentRepo:           studentRepo,
+		SubgroupRepo:          subgroupRepo,
+		DisciplineRepo:        disciplineRepo,
+	}
+}
+
+// authorizeTeacher checks that the teacher has an actual schedule of the discipline with the group in the semester
+func (s *GradebookService) authorizeTeacher(ctx context.Context, teacherID int64, groupID string, disciplineID int64, semester int) error {
+	schedules, err := s.ScheduleRepo.GetActualByTeacherID(ctx, teacherID)
+	if err != nil {
+		return err
+	}
+	for _, scheduleInfo := range schedules {
+		schedule := scheduleInfo.Schedule
+		if schedule.GroupID == groupID && schedule.DisciplineID == disciplineID && schedule.Semester == semester {
+			return nil
+		}
+	}
+	return ErrNotGroupTeacher
+}
+
+// checkStudent checks that the student studies in the group
+func (s *GradebookService) checkStudent(ctx context.Context, studentID int64, groupID string) error {
+	student, err := s.StudentRepo.GetByID(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	if student.GroupID != groupID {
+		return ErrStudentNotInGroup
+	}
+	return nil
+}
+
+// SetMark puts the mark of a student for a lesson, only the teacher of the lesson on the date
+// (the substitute teacher if there is one) can mark it
+func (s *GradebookService) SetMark(ctx context.Context, teacherID int64, mark domain.Mark) (int64, error) {
+	lesson, err := lessonOn(ctx, s.ScheduleRepo, s.ScheduleExceptionRepo, mark.ScheduleID, mark.LessonDate)
+	if err != nil {
+		return 0, err
+	}
+	schedule := lesson.ScheduleInfo.Schedule
+	if schedule.TeacherID != teacherID {
+		return 0, ErrNotLessonTeacher
+	}
+	if err := s.checkStudent(ctx, mark.StudentID, schedule.GroupID); err != nil {
+		return 0, err
+	}
+	if schedule.SubgroupID != nil {
+		inSubgroup, err := s.SubgroupRepo.HasStudent(ctx, *schedule.SubgroupID, mark.StudentID)
+		if err != nil {
+			return 0, err
+		}
+		if !inSubgroup {
+			return 0, ErrNotSubgroupStudent
+		}
+	}
+
+	mark.TeacherID = &teacherID
+	return s.GradebookRepo.SetMark(ctx, mark)
+}
+
+// DeleteMark removes a mark, any teacher of the discipline with the group can remove it
+func (s *GradebookService) DeleteMark(ctx context.Context, teacherID int64, markID int64) error {
+	mark, err := s.GradebookRepo.GetMarkByID(ctx, markID)
+	if err != nil {
+		return err
+	}
+	scheduleInfo, err := s.ScheduleRepo.GetByID(ctx, mark.ScheduleID)
+	if err != nil {
+		return err
+	}
+	schedule := scheduleInfo.Schedule
+	if err := s.authorizeTeacher(ctx, teacherID, schedule.GroupID, schedule.DisciplineID, schedule.Semester); err != nil {
+		return err
+	}
+	return s.GradebookRepo.DeleteMark(ctx, markID)
+}
+
+func (s *GradebookService) CreateControlPoint(ctx context.Context, teacherID int64, point domain.ControlPoint) (int64, error) {
+	if err := s.authorizeTeacher(ctx, teacherID, point.GroupID, point.DisciplineID, point.Semester); err != nil {
+		return 0, err
+	}
+	return s.GradebookRepo.CreateControlPoint(ctx, point)
+}
+
+func (s *GradebookService) DeleteControlPoint(ctx context.Context, teacherID int64, controlPointID int64) error {
+	point, err := s.GradebookRepo.GetControlPointByID(ctx, controlPointID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeTeacher(ctx, teacherID, point.GroupID, point.DisciplineID, point.Semester); err != nil {
+		return err
+	}
+	return s.GradebookRepo.DeleteControlPoint(ctx, controlPointID)
+}
+
+func (s *GradebookService) GetControlPoints(ctx context.Context, teacherID int64, groupID string, disciplineID int64, semester int) ([]domain.ControlPoint, error) {
+	if err := s.authorizeTeacher(ctx, teacherID, groupID, disciplineID, semester); err != nil {
+		return nil, err
+	}
+	return s.GradebookRepo.GetControlPoints(ctx, groupID, disciplineID, semester)
+}
+
+// SetControlPointResult puts the score of a student of the control point's group, the score can't exceed the maximum
+func (s *GradebookService) SetControlPointResult(ctx context.Context, teacherID int64, result domain.ControlPointResult) error {
+	point, err := s.GradebookRepo.GetControlPointByID(ctx, result.ControlPointID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeTeacher(ctx, teacherID, point.GroupID, point.DisciplineID, point.Semester); err != nil {
+		return err
+	}
+	if result.Score > point.MaxScore {
+		return ErrScoreAboveMax
+	}
+	if err := s.checkStudent(ctx, result.StudentID, point.GroupID); err != nil {
+		return err
+	}
+
+	result.TeacherID = &teacherID
+	return s.GradebookRepo.SetControlPointResult(ctx, result)
+}
+
+// SetFinalResult puts the credit or the exam grade of a student, the grade must belong to the scale of the control type
+func (s *GradebookService) SetFinalResult(ctx context.Context, teacherID int64, result domain.FinalResult) (int64, error) {
+	if !slices.Contains(domain.GradeScale[result.ControlType], result.Grade) {
+		return 0, ErrGradeScale
+	}
+	student, err := s.StudentRepo.GetByID(ctx, result.StudentID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.authorizeTeacher(ctx, teacherID, student.GroupID, result.DisciplineID, result.Semester); err != nil {
+		return 0, err
+	}
+
+	result.TeacherID = &teacherID
+	return s.GradebookRepo.SetFinalResult(ctx, result)
+}
+
+// GetGradebook collects the marks, the control points and the final results of the student
+// per discipline, semester 0 returns all semesters
+func (s *GradebookService) GetGradebook(ctx context.Context, studentID int64, semester int) (domain.Gradebook, error) {
+	gradebook := domain.Gradebook{StudentID: studentID, Semester: semester, Disciplines: make([]domain.DisciplineGrades, 0)}
+
+	marks, err := s.GradebookRepo.GetStudentMarks(ctx, studentID, semester)
+	if err != nil {
+		return gradebook, err
+	}
+	points, err := s.GradebookRepo.GetStudentControlPoints(ctx, studentID, semester)
+	if err != nil {
+		return gradebook, err
+	}
+	results, err := s.GradebookRepo.GetStudentFinalResults(ctx, studentID, semester)
+	if err != nil {
+		return gradebook, err
+	}
+
+	type disciplineKey struct {
+		disciplineID int64
+		semester     int
+	}
+	index := make(map[disciplineKey]int)
+	discipline := func(disciplineID int64, semester int) (int, error) {
+		key := disciplineKey{disciplineID: disciplineID, semester: semester}
+		if i, ok := index[key]; ok {
+			return i, nil
+		}
+		info, err := s.DisciplineRepo.GetByID(ctx, disciplineID)
+		if err != nil {
+			return 0, err
+		}
+		index[key] = len(gradebook.Disciplines)
+		gradebook.Disciplines = append(gradebook.Disciplines, domain.DisciplineGrades{
+			DisciplineID:   disciplineID,
+			DisciplineName: info.Discipline.DisciplineName,
+			Semester:       semester,
+			Marks:          make([]domain.Mark, 0),
+			ControlPoints:  make([]domain.ControlPointScore, 0),
+			FinalResults:   make([]domain.FinalResult, 0),
+		})
+		return index[key], nil
+	}
+
+	for _, mark := range marks {
+		i, err := discipline(mark.MarkSub.DisciplineID, mark.MarkSub.Semester)
+		if err != nil {
+			return gradebook, err
+		}
+		gradebook.Disciplines[i].Marks = append(gradebook.Disciplines[i].Marks, mark.Mark)
+	}
+	for _, point := range points {
+		i, err := discipline(point.ControlPoint.DisciplineID, point.ControlPoint.Semester)
+		if err != nil {
+			return gradebook, err
+		}
+		gradebook.Disciplines[i].ControlPoints = append(gradebook.Disciplines[i].ControlPoints, point)
+	}
+	for _, result := range results {
+		i, err := discipline(result.DisciplineID, result.Semester)
+		if err != nil {
+			return gradebook, err
+		}
+		gradebook.Disciplines[i].FinalResults = append(gradebook.Disciplines[i].FinalResults, result)
+	}
+
+	for i := range gradebook.Disciplines {
+		gradebook.Disciplines[i].AverageMark = averageMark(gradebook.Disciplines[i].Marks)
+	}
+
+	return gradebook, nil
+}
+
+// GetAdmission checks the admission of the student to the credit or the exam of the discipline
+// by the attendance percentage, the average mark and the scores of the control points that are due.
+// A discipline without marked attendance or marks is not held against the student
+func (s *GradebookService) GetAdmission(ctx context.Context, studentID int64, disciplineID int64, semester int) (domain.Admission, error) {
+	admission := domain.Admission{StudentID: studentID, DisciplineID: disciplineID, Semester: semester, Reasons: make([]string, 0)}
+
+	attendance, err := s.GradebookRepo.GetStudentAttendance(ctx, studentID, disciplineID, semester)
+	if err != nil {
+		return admission, err
+	}
+	gradebook, err := s.GetGradebook(ctx, studentID, semester)
+	if err != nil {
+		return admission, err
+	}
+
+	admission.AttendancePercentage = 100
+	if attendance.Total > 0 {
+		admission.AttendancePercentage = float64(attendance.Visits) * 100 / float64(attendance.Total)
+	}
+	if admission.AttendancePercentage < admissionAttendance {
+		admission.Reasons = append(admission.Reasons,
+			fmt.Sprintf("attendance %.1f%% is below %.0f%%", admission.AttendancePercentage, admissionAttendance))
+	}
+
+	today := dateOnly(time.Now())
+	for _, grades := range gradebook.Disciplines {
+		if grades.DisciplineID != disciplineID {
+			continue
+		}
+		admission.AverageMark = grades.AverageMark
+		if len(grades.Marks) > 0 && grades.AverageMark < admissionAverageMark {
+			admission.Reasons = append(admission.Reasons,
+				fmt.Sprintf("average mark %.2f is below %.1f", grades.AverageMark, admissionAverageMark))
+		}
+		for _, point := range grades.ControlPoints {
+			if point.Score == nil && point.ControlPoint.DueDate != nil && point.ControlPoint.DueDate.Before(today) {
+				admission.Reasons = append(admission.Reasons,
+					fmt.Sprintf("control point %q is not passed", point.ControlPoint.Name))
+			}
+		}
+	}
+
+	admission.Admitted = len(admission.Reasons) == 0
+	return admission, nil
+}
+
+func averageMark(marks []domain.Mark) float64 {
+	if len(marks) == 0 {
+		return 0
+	}
+	sum := 0
+	for _, mark := range marks {
+		sum += mark.Mark
+	}
+	return float64(sum) / float64(len(marks))
+}
@@ -0,0 +1,282 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/jackc/pgx/v5"
+)
+
+// newGradebookService returns the service over the timetable of newAttendanceService with
+// the disciplines Математика 1 and Физика 2
+func newGradebookService(t *testing.T) (*service.GradebookService, *repository.Repositories) {
+	t.Helper()
+	ctx := context.Background()
+	_, repos := newAttendanceService(t)
+
+	for _, name := range []string{"Математика", "Физика"} {
+		if err := repos.Discipline.Create(ctx, domain.Discipline{DepartamentID: 1, DisciplineName: name}); err != nil {
+			t.Fatalf("create discipline: %v", err)
+		}
+	}
+
+	return service.NewGradebookService(repos.Gradebook, repos.Schedule, repos.ScheduleException, repos.Student, repos.Subgroup, repos.Discipline), repos
+}
+
+func TestGradebookServiceSetMark(t *testing.T) {
+	tests := []struct {
+		name       string
+		teacherID  int64
+		studentID  int64
+		scheduleID int64
+		date       string
+		wantErr    error
+	}{
+		{name: "teacher of the timetable", teacherID: 1, studentID: 1, scheduleID: 1, date: "2024-09-02"},
+		{name: "another teacher", teacherID: 2, studentID: 1, scheduleID: 1, date: "2024-09-02", wantErr: service.ErrNotLessonTeacher},
+		{name: "substitute teacher", teacherID: 2, studentID: 1, scheduleID: 1, date: "2024-09-30"},
+		{name: "replaced teacher", teacherID: 1, studentID: 1, scheduleID: 1, date: "2024-09-30", wantErr: service.ErrNotLessonTeacher},
+		{name: "cancelled lesson", teacherID: 1, studentID: 1, scheduleID: 1, date: "2024-09-16", wantErr: service.ErrLessonCancelled},
+		{name: "lesson moved away from the date", teacherID: 1, studentID: 1, scheduleID: 1, date: "2024-10-14", wantErr: service.ErrLessonMoved},
+		{name: "lesson moved to the date", teacherID: 1, studentID: 1, scheduleID: 1, date: "2024-10-15"},
+		{name: "student of another group", teacherID: 1, studentID: 4, scheduleID: 1, date: "2024-09-02", wantErr: service.ErrStudentNotInGroup},
+		{name: "unknown student", teacherID: 1, studentID: 9, scheduleID: 1, date: "2024-09-02", wantErr: pgx.ErrNoRows},
+		{name: "student of the subgroup", teacherID: 2, studentID: 2, scheduleID: 2, date: "2024-09-02"},
+		{name: "student outside the subgroup", teacherID: 2, studentID: 3, scheduleID: 2, date: "2024-09-02", wantErr: service.ErrNotSubgroupStudent},
+		{name: "unknown schedule", teacherID: 1, studentID: 1, scheduleID: 9, date: "2024-09-02", wantErr: pgx.ErrNoRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			gradebooks, repos := newGradebookService(t)
+
+			mark := domain.Mark{ScheduleID: tt.scheduleID, StudentID: tt.studentID, LessonDate: day(tt.date), Mark: 5}
+			markID, err := gradebooks.SetMark(ctx, tt.teacherID, mark)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetMark() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			stored, err := repos.Gradebook.GetMarkByID(ctx, markID)
+			if err != nil {
+				t.Fatalf("GetMarkByID() error = %v", err)
+			}
+			if stored.TeacherID == nil || *stored.TeacherID != tt.teacherID {
+				t.Errorf("mark teacher = %v, want %d", stored.TeacherID, tt.teacherID)
+			}
+		})
+	}
+}
+
+func TestGradebookServiceAuthorizesTeacherOfGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		point   domain.ControlPoint
+		teacher int64
+		wantErr error
+	}{
+		{name: "teacher of the discipline", teacher: 1, point: domain.ControlPoint{GroupID: testGroup, DisciplineID: 1, Semester: 1}},
+		{name: "teacher of another discipline", teacher: 2, point: domain.ControlPoint{GroupID: testGroup, DisciplineID: 1, Semester: 1}, wantErr: service.ErrNotGroupTeacher},
+		{name: "another group", teacher: 1, point: domain.ControlPoint{GroupID: otherGroup, DisciplineID: 1, Semester: 1}, wantErr: service.ErrNotGroupTeacher},
+		{name: "another semester", teacher: 1, point: domain.ControlPoint{GroupID: testGroup, DisciplineID: 1, Semester: 2}, wantErr: service.ErrNotGroupTeacher},
+		{name: "teacher of the lab of a subgroup", teacher: 2, point: domain.ControlPoint{GroupID: testGroup, DisciplineID: 2, Semester: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			gradebooks, _ := newGradebookService(t)
+
+			tt.point.Name, tt.point.MaxScore = "Коллоквиум", 10
+			if _, err := gradebooks.CreateControlPoint(ctx, tt.teacher, tt.point); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateControlPoint() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, err := gradebooks.GetControlPoints(ctx, tt.teacher, tt.point.GroupID, tt.point.DisciplineID, tt.point.Semester); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetControlPoints() error = %v, want %v", err, tt.wantErr)
+			}
+			final := domain.FinalResult{StudentID: 1, DisciplineID: tt.point.DisciplineID, Semester: tt.point.Semester, ControlType: domain.ControlTypeExam, Grade: "отлично"}
+			if tt.point.GroupID != testGroup {
+				final.StudentID = 4
+			}
+			if _, err := gradebooks.SetFinalResult(ctx, tt.teacher, final); !errors.Is(err, tt.wantErr) {
+				t.Errorf("SetFinalResult() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGradebookServiceResults(t *testing.T) {
+	ctx := context.Background()
+	gradebooks, _ := newGradebookService(t)
+
+	pointID, err := gradebooks.CreateControlPoint(ctx, 1, domain.ControlPoint{GroupID: testGroup, DisciplineID: 1, Semester: 1, Name: "Коллоквиум", MaxScore: 10})
+	if err != nil {
+		t.Fatalf("CreateControlPoint() error = %v", err)
+	}
+
+	scores := []struct {
+		name    string
+		teacher int64
+		result  domain.ControlPointResult
+		wantErr error
+	}{
+		{name: "score of the group", teacher: 1, result: domain.ControlPointResult{ControlPointID: pointID, StudentID: 1, Score: 10}},
+		{name: "score above the maximum", teacher: 1, result: domain.ControlPointResult{ControlPointID: pointID, StudentID: 1, Score: 11}, wantErr: service.ErrScoreAboveMax},
+		{name: "student of another group", teacher: 1, result: domain.ControlPointResult{ControlPointID: pointID, StudentID: 4, Score: 5}, wantErr: service.ErrStudentNotInGroup},
+		{name: "another teacher", teacher: 2, result: domain.ControlPointResult{ControlPointID: pointID, StudentID: 1, Score: 5}, wantErr: service.ErrNotGroupTeacher},
+		{name: "unknown control point", teacher: 1, result: domain.ControlPointResult{ControlPointID: 9, StudentID: 1, Score: 5}, wantErr: pgx.ErrNoRows},
+	}
+	for _, tt := range scores {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := gradebooks.SetControlPointResult(ctx, tt.teacher, tt.result); !errors.Is(err, tt.wantErr) {
+				t.Errorf("SetControlPointResult() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	grades := []struct {
+		name    string
+		result  domain.FinalResult
+		wantErr error
+	}{
+		{name: "credit", result: domain.FinalResult{StudentID: 1, DisciplineID: 1, Semester: 1, ControlType: domain.ControlTypeCredit, Grade: "зачтено"}},
+		{name: "exam", result: domain.FinalResult{StudentID: 2, DisciplineID: 1, Semester: 1, ControlType: domain.ControlTypeExam, Grade: "хорошо"}},
+		{name: "grade of another scale", result: domain.FinalResult{StudentID: 1, DisciplineID: 1, Semester: 1, ControlType: domain.ControlTypeCredit, Grade: "отлично"}, wantErr: service.ErrGradeScale},
+		{name: "unknown control type", result: domain.FinalResult{StudentID: 1, DisciplineID: 1, Semester: 1, ControlType: "курсовая", Grade: "зачтено"}, wantErr: service.ErrGradeScale},
+		{name: "unknown student", result: domain.FinalResult{StudentID: 9, DisciplineID: 1, Semester: 1, ControlType: domain.ControlTypeExam, Grade: "хорошо"}, wantErr: pgx.ErrNoRows},
+	}
+	for _, tt := range grades {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := gradebooks.SetFinalResult(ctx, 1, tt.result); !errors.Is(err, tt.wantErr) {
+				t.Errorf("SetFinalResult() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGradebookServiceGetGradebook(t *testing.T) {
+	ctx := context.Background()
+	gradebooks, _ := newGradebookService(t)
+
+	for _, mark := range []domain.Mark{
+		{ScheduleID: 1, StudentID: 1, LessonDate: day("2024-09-02"), Mark: 5},
+		{ScheduleID: 1, StudentID: 1, LessonDate: day("2024-10-15"), Mark: 4},
+		{ScheduleID: 1, StudentID: 2, LessonDate: day("2024-09-02"), Mark: 2},
+	} {
+		if _, err := gradebooks.SetMark(ctx, 1, mark); err != nil {
+			t.Fatalf("SetMark() error = %v", err)
+		}
+	}
+	if _, err := gradebooks.SetMark(ctx, 2, domain.Mark{ScheduleID: 2, StudentID: 1, LessonDate: day("2024-09-02"), Mark: 3}); err != nil {
+		t.Fatalf("SetMark() error = %v", err)
+	}
+	// a second mark of the lesson replaces the first one
+	if _, err := gradebooks.SetMark(ctx, 2, domain.Mark{ScheduleID: 2, StudentID: 1, LessonDate: day("2024-09-02"), Mark: 4}); err != nil {
+		t.Fatalf("SetMark() error = %v", err)
+	}
+
+	gradebook, err := gradebooks.GetGradebook(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("GetGradebook() error = %v", err)
+	}
+	want := []struct {
+		name    string
+		marks   []int
+		average float64
+	}{
+		{name: "Математика", marks: []int{5, 4}, average: 4.5},
+		{name: "Физика", marks: []int{4}, average: 4},
+	}
+	if len(gradebook.Disciplines) != len(want) {
+		t.Fatalf("disciplines = %+v", gradebook.Disciplines)
+	}
+	for i, grades := range gradebook.Disciplines {
+		marks := make([]int, 0, len(grades.Marks))
+		for _, mark := range grades.Marks {
+			marks = append(marks, mark.Mark)
+		}
+		if grades.DisciplineName != want[i].name || !slices.Equal(marks, want[i].marks) || grades.AverageMark != want[i].average {
+			t.Errorf("discipline %d = %s %v average %v, want %+v", i, grades.DisciplineName, marks, grades.AverageMark, want[i])
+		}
+	}
+
+	empty, err := gradebooks.GetGradebook(ctx, 1, 2)
+	if err != nil {
+		t.Fatalf("GetGradebook() of the second semester error = %v", err)
+	}
+	if len(empty.Disciplines) != 0 {
+		t.Errorf("disciplines of the second semester = %+v, want none", empty.Disciplines)
+	}
+}
+
+func TestGradebookServiceGetAdmission(t *testing.T) {
+	tests := []struct {
+		name           string
+		presence       []bool
+		marks          []int
+		dueDate        string
+		score          *int
+		wantAdmitted   bool
+		wantAttendance float64
+		wantReasons    int
+	}{
+		{name: "nothing marked", wantAdmitted: true, wantAttendance: 100},
+		{name: "enough attendance and marks", presence: []bool{true, true, true, false}, marks: []int{3, 4}, wantAdmitted: true, wantAttendance: 75},
+		{name: "low attendance", presence: []bool{true, false, false}, wantAttendance: 100.0 / 3, wantReasons: 1},
+		{name: "low average mark", marks: []int{2, 3}, wantAttendance: 100, wantReasons: 1},
+		{name: "control point due without a score", dueDate: "2000-01-01", wantAttendance: 100, wantReasons: 1},
+		{name: "control point due with a score", dueDate: "2000-01-01", score: new(int), wantAdmitted: true, wantAttendance: 100},
+		{name: "control point not due yet", dueDate: "2999-01-01", wantAdmitted: true, wantAttendance: 100},
+		{name: "every rule broken", presence: []bool{false}, marks: []int{2}, dueDate: "2000-01-01", wantReasons: 3},
+	}
+	lessons := []string{"2024-09-02", "2024-09-30", "2024-10-15", "2024-10-28"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			gradebooks, repos := newGradebookService(t)
+
+			for i, present := range tt.presence {
+				if err := repos.Attendance.Create(ctx, domain.Attendance{StudentID: 1, ScheduleID: 1, Presence: &present, Created: day(lessons[i])}); err != nil {
+					t.Fatalf("create attendance: %v", err)
+				}
+			}
+			for i, mark := range tt.marks {
+				if _, err := repos.Gradebook.SetMark(ctx, domain.Mark{ScheduleID: 1, StudentID: 1, LessonDate: day(lessons[i]), Mark: mark}); err != nil {
+					t.Fatalf("set mark: %v", err)
+				}
+			}
+			if tt.dueDate != "" {
+				pointID, err := repos.Gradebook.CreateControlPoint(ctx, domain.ControlPoint{GroupID: testGroup, DisciplineID: 1, Semester: 1,
+					Name: "Коллоквиум", MaxScore: 10, DueDate: dayPtr(tt.dueDate)})
+				if err != nil {
+					t.Fatalf("create control point: %v", err)
+				}
+				if tt.score != nil {
+					if err := repos.Gradebook.SetControlPointResult(ctx, domain.ControlPointResult{ControlPointID: pointID, StudentID: 1, Score: *tt.score}); err != nil {
+						t.Fatalf("set score: %v", err)
+					}
+				}
+			}
+
+			admission, err := gradebooks.GetAdmission(ctx, 1, 1, 1)
+			if err != nil {
+				t.Fatalf("GetAdmission() error = %v", err)
+			}
+			if admission.Admitted != tt.wantAdmitted || len(admission.Reasons) != tt.wantReasons {
+				t.Errorf("admission = %+v, want admitted %v with %d reasons", admission, tt.wantAdmitted, tt.wantReasons)
+			}
+			if tt.wantAttendance != 0 && admission.AttendancePercentage != tt.wantAttendance {
+				t.Errorf("attendance = %v, want %v", admission.AttendancePercentage, tt.wantAttendance)
+			}
+		})
+	}
+}
@@ -8,11 +8,19 @@ import (
 )
 
 type GroupService struct {
-	GroupRepo repository.IGroup
+	Transactor     repository.ITransactor
+	GroupRepo      repository.IGroup
+	ScheduleRepo   repository.ISchedule
+	AttendanceRepo repository.IAttendance
 }
 
-func NewGroupService(groupRepo repository.IGroup) *GroupService {
-	return &GroupService{GroupRepo: groupRepo}
+func NewGroupService(transactor repository.ITransactor, groupRepo repository.IGroup, scheduleRepo repository.ISchedule, attendanceRepo repository.IAttendance) *GroupService {
+	return &GroupService{
+		Transactor:     transactor,
+		GroupRepo:      groupRepo,
+		ScheduleRepo:   scheduleRepo,
+		AttendanceRepo: attendanceRepo,
+	}
 }
 
 func (s *GroupService) Create(ctx context.Context, group domain.Group) error {
@@ -34,8 +42,22 @@ func (s *GroupService) Patch(ctx context.Context, group domain.Group) error {
 	return s.GroupRepo.Patch(ctx, group.GroupID, updates)
 }
 
+// Delete removes the group with its schedules in one transaction. Deleting the schedules
+// would take their attendance with them, so a group with marked attendance is refused
 func (s *GroupService) Delete(ctx context.Context, groupID string) error {
-	return s.GroupRepo.Delete(ctx, groupID)
+	return s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		hasAttendance, err := s.AttendanceRepo.ExistsByGroupID(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		if hasAttendance {
+			return ErrGroupHasAttendance
+		}
+		if err := s.ScheduleRepo.DeleteByGroupID(ctx, groupID); err != nil {
+			return err
+		}
+		return s.GroupRepo.Delete(ctx, groupID)
+	})
 }
 
 func (s *GroupService) GetByID(ctx context.Context, groupID string) (domain.GroupInfo, error) {
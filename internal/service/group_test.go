@@ -0,0 +1,75 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestGroupServiceDelete(t *testing.T) {
+	repos := newRepos(t)
+	ctx := context.Background()
+	groups := service.NewGroupService(repos.Transactor, repos.Group, repos.Schedule, repos.Attendance)
+
+	actual := true
+	for _, groupID := range []string{testGroup, otherGroup} {
+		schedule := domain.Schedule{GroupID: groupID, DisciplineID: 1, TeacherID: 1, DisciplineTypeID: 1, ClassroomID: 1, Semester: 1,
+			WeekType: "Верхняя", DayOfWeek: "Понедельник", StartTime: time.Date(2000, 1, 1, 8, 30, 0, 0, time.UTC), IsActual: &actual}
+		if err := repos.Schedule.Create(ctx, schedule); err != nil {
+			t.Fatalf("create schedule: %v", err)
+		}
+	}
+
+	// a failing outer transaction takes the deletion back
+	errAbort := errors.New("abort")
+	err := repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := groups.Delete(ctx, testGroup); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("WithinTransaction() error = %v, want %v", err, errAbort)
+	}
+	if schedules, _ := repos.Schedule.GetByGroupID(ctx, testGroup); len(schedules) != 1 {
+		t.Fatalf("schedules after the rollback = %d, want 1", len(schedules))
+	}
+
+	if err := groups.Delete(ctx, testGroup); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repos.Group.GetByID(ctx, testGroup); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("deleted group error = %v, want %v", err, pgx.ErrNoRows)
+	}
+	if schedules, _ := repos.Schedule.GetByGroupID(ctx, testGroup); len(schedules) != 0 {
+		t.Errorf("schedules of the deleted group = %d, want none", len(schedules))
+	}
+	if schedules, _ := repos.Schedule.GetByGroupID(ctx, otherGroup); len(schedules) != 1 {
+		t.Errorf("schedules of another group = %d, want 1", len(schedules))
+	}
+
+	// deleting the schedules would wipe the marked attendance
+	schedules, err := repos.Schedule.GetByGroupID(ctx, otherGroup)
+	if err != nil {
+		t.Fatalf("GetByGroupID() error = %v", err)
+	}
+	present := true
+	attendance := domain.Attendance{StudentID: 1, ScheduleID: schedules[0].Schedule.ScheduleID, Presence: &present, Created: day("2024-09-02")}
+	if err := repos.Attendance.Create(ctx, attendance); err != nil {
+		t.Fatalf("create attendance: %v", err)
+	}
+	if err := groups.Delete(ctx, otherGroup); !errors.Is(err, service.ErrGroupHasAttendance) {
+		t.Fatalf("Delete() of a group with attendance error = %v, want %v", err, service.ErrGroupHasAttendance)
+	}
+	if _, err := repos.Group.GetByID(ctx, otherGroup); err != nil {
+		t.Errorf("group with attendance error = %v", err)
+	}
+	if schedules, _ := repos.Schedule.GetByGroupID(ctx, otherGroup); len(schedules) != 1 {
+		t.Errorf("schedules of the group with attendance = %d, want 1", len(schedules))
+	}
+}
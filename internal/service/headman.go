@@ -2,25 +2,54 @@ package service
 
 import (
 	"context"
+	"log/slog"
+	"time"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
 	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
 )
 
+const defaultRoleSyncInterval = 15 * time.Minute
+
 type HeadmanService struct {
+	Transactor  repository.ITransactor
 	HeadmanRepo repository.IHeadman
 }
 
-func NewHeadmanService(headmanRepo repository.IHeadman) *HeadmanService {
-	return &HeadmanService{HeadmanRepo: headmanRepo}
+func NewHeadmanService(transactor repository.ITransactor, headmanRepo repository.IHeadman) *HeadmanService {
+	return &HeadmanService{Transactor: transactor, HeadmanRepo: headmanRepo}
 }
 
+// Create stores the term and switches the user roles in one transaction, so the term
+// isn't stored when the role of the student can't be switched
 func (s *HeadmanService) Create(ctx context.Context, headman domain.Headman) error {
-	return s.HeadmanRepo.Create(ctx, headman)
+	if headman.TermStart.IsZero() {
+		headman.TermStart = today()
+	}
+	return s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkTerm(ctx, headman); err != nil {
+			return err
+		}
+		if err := s.HeadmanRepo.Create(ctx, headman); err != nil {
+			return err
+		}
+		return s.syncRoles(ctx)
+	})
 }
 
 func (s *HeadmanService) Put(ctx context.Context, headman domain.Headman) error {
-	return s.HeadmanRepo.Put(ctx, headman)
+	if headman.TermStart.IsZero() {
+		headman.TermStart = today()
+	}
+	return s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkTerm(ctx, headman); err != nil {
+			return err
+		}
+		if err := s.HeadmanRepo.Put(ctx, headman); err != nil {
+			return err
+		}
+		return s.syncRoles(ctx)
+	})
 }
 
 func (s *HeadmanService) Patch(ctx context.Context, headman domain.Headman) error {
@@ -31,14 +60,129 @@ func (s *HeadmanService) Patch(ctx context.Context, headman domain.Headman) erro
 	if headman.GroupID != "" {
 		updates["group_id"] = headman.GroupID
 	}
+	if !headman.TermStart.IsZero() {
+		updates["term_start"] = headman.TermStart
+	}
+	if headman.TermEnd != nil {
+		updates["term_end"] = headman.TermEnd
+	}
 	if len(updates) == 0 {
 		return ErrNoUpdates
 	}
-	return s.HeadmanRepo.Patch(ctx, headman.HeadmanID, updates)
+
+	return s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.HeadmanRepo.GetByID(ctx, headman.HeadmanID)
+		if err != nil {
+			return err
+		}
+		patched := current.Headman
+		if headman.StudentID != 0 {
+			patched.StudentID = headman.StudentID
+		}
+		if headman.GroupID != "" {
+			patched.GroupID = headman.GroupID
+		}
+		if !headman.TermStart.IsZero() {
+			patched.TermStart = headman.TermStart
+		}
+		if headman.TermEnd != nil {
+			patched.TermEnd = headman.TermEnd
+		}
+		if err := s.checkTerm(ctx, patched); err != nil {
+			return err
+		}
+
+		if err := s.HeadmanRepo.Patch(ctx, headman.HeadmanID, updates); err != nil {
+			return err
+		}
+		return s.syncRoles(ctx)
+	})
+}
+
+// GetByGroupID returns the terms of the headmen and the deputies of a group
+func (s *HeadmanService) GetByGroupID(ctx context.Context, groupID string) ([]domain.HeadmanInfo, error) {
+	return s.HeadmanRepo.GetAllByGroupID(ctx, groupID)
+}
+
+// RunRoleSync switches user roles when terms start and end until the context is done
+func (s *HeadmanService) RunRoleSync(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultRoleSyncInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.syncRoles(ctx); err != nil {
+			slog.ErrorContext(ctx, "failed to sync headman roles", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *HeadmanService) syncRoles(ctx context.Context) error {
+	demoted, promoted, err := s.HeadmanRepo.SyncRoles(ctx, today())
+	if err != nil {
+		return err
+	}
+	if demoted > 0 || promoted > 0 {
+		slog.InfoContext(ctx, "headman roles synced", slog.Int64("demoted", demoted), slog.Int64("promoted", promoted))
+	}
+	return nil
+}
+
+// checkTerm allows one headman and one deputy per group at a time and one term
+// per student at a time
+func (s *HeadmanService) checkTerm(ctx context.Context, headman domain.Headman) error {
+	if headman.TermEnd != nil && headman.TermEnd.Before(headman.TermStart) {
+		return ErrHeadmanTermDates
+	}
+
+	groupTerms, err := s.HeadmanRepo.GetAllByGroupID(ctx, headman.GroupID)
+	if err != nil {
+		return err
+	}
+	for _, term := range groupTerms {
+		if term.Headman.HeadmanID == headman.HeadmanID || term.Headman.IsDeputy != headman.IsDeputy {
+			continue
+		}
+		if term.Headman.Overlaps(headman) {
+			return ErrHeadmanTermOverlap
+		}
+	}
+
+	studentTerms, err := s.HeadmanRepo.GetAllByStudentID(ctx, headman.StudentID)
+	if err != nil {
+		return err
+	}
+	for _, term := range studentTerms {
+		if term.Headman.HeadmanID != headman.HeadmanID && term.Headman.Overlaps(headman) {
+			return ErrHeadmanTermOverlap
+		}
+	}
+
+	return nil
+}
+
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 }
 
+// Delete switches the account of the headman back to the student role with the term,
+// otherwise the account would keep the headman role once the term is gone
 func (s *HeadmanService) Delete(ctx context.Context, headmanID int64) error {
-	return s.HeadmanRepo.Delete(ctx, headmanID)
+	return s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.HeadmanRepo.Demote(ctx, headmanID); err != nil {
+			return err
+		}
+		return s.HeadmanRepo.Delete(ctx, headmanID)
+	})
 }
 
 func (s *HeadmanService) GetByID(ctx context.Context, headmanID int64) (domain.HeadmanInfo, error) {
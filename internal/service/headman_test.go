@@ -0,0 +1,215 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository/memory"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+)
+
+const (
+	testGroup  = "2023-35.03.06-1"
+	otherGroup = "2023-35.03.06-2"
+)
+
+// newRepos returns the in-memory repositories with two groups and three students
+// of the first group
+func newRepos(t *testing.T) *repository.Repositories {
+	t.Helper()
+	ctx := context.Background()
+	repos := memory.NewRepositories()
+
+	for _, groupID := range []string{testGroup, otherGroup} {
+		if err := repos.Group.Create(ctx, domain.Group{GroupID: groupID, ProfileID: 1}); err != nil {
+			t.Fatalf("create group: %v", err)
+		}
+	}
+	for _, lastName := range []string{"Иванов", "Петров", "Сидоров"} {
+		student := domain.Student{GroupID: testGroup, LastName: lastName, FirstName: "Иван", MiddleName: "Иванович"}
+		if _, err := repos.Student.Create(ctx, student); err != nil {
+			t.Fatalf("create student: %v", err)
+		}
+	}
+	return repos
+}
+
+func day(value string) time.Time {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return date
+}
+
+func dayPtr(value string) *time.Time {
+	date := day(value)
+	return &date
+}
+
+func TestHeadmanServiceCreate(t *testing.T) {
+	existing := []domain.Headman{
+		{StudentID: 1, GroupID: testGroup, TermStart: day("2023-09-01"), TermEnd: dayPtr("2024-06-30")},
+		{StudentID: 2, GroupID: testGroup, TermStart: day("2023-09-01"), TermEnd: dayPtr("2024-06-30"), IsDeputy: true},
+	}
+
+	tests := []struct {
+		name    string
+		headman domain.Headman
+		wantErr error
+	}{
+		{
+			name:    "term ends before it starts",
+			headman: domain.Headman{StudentID: 3, GroupID: testGroup, TermStart: day("2024-09-01"), TermEnd: dayPtr("2024-08-31")},
+			wantErr: service.ErrHeadmanTermDates,
+		},
+		{
+			name:    "second headman of the group",
+			headman: domain.Headman{StudentID: 3, GroupID: testGroup, TermStart: day("2024-01-01")},
+			wantErr: service.ErrHeadmanTermOverlap,
+		},
+		{
+			name:    "second deputy of the group",
+			headman: domain.Headman{StudentID: 3, GroupID: testGroup, TermStart: day("2024-06-30"), IsDeputy: true},
+			wantErr: service.ErrHeadmanTermOverlap,
+		},
+		{
+			name:    "student already holds a term",
+			headman: domain.Headman{StudentID: 1, GroupID: otherGroup, TermStart: day("2024-01-01"), IsDeputy: true},
+			wantErr: service.ErrHeadmanTermOverlap,
+		},
+		{
+			name:    "next term of the group",
+			headman: domain.Headman{StudentID: 3, GroupID: testGroup, TermStart: day("2024-07-01")},
+		},
+		{
+			name:    "headman of another group",
+			headman: domain.Headman{StudentID: 3, GroupID: otherGroup, TermStart: day("2023-09-01")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newRepos(t)
+			headmen := service.NewHeadmanService(repos.Transactor, repos.Headman)
+			for _, headman := range existing {
+				if err := headmen.Create(ctx, headman); err != nil {
+					t.Fatalf("create existing term: %v", err)
+				}
+			}
+
+			err := headmen.Create(ctx, tt.headman)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHeadmanServicePatchChecksTerm(t *testing.T) {
+	ctx := context.Background()
+	repos := newRepos(t)
+	headmen := service.NewHeadmanService(repos.Transactor, repos.Headman)
+
+	if err := headmen.Create(ctx, domain.Headman{StudentID: 1, GroupID: testGroup, TermStart: day("2023-09-01"), TermEnd: dayPtr("2024-06-30")}); err != nil {
+		t.Fatalf("create term: %v", err)
+	}
+	if err := headmen.Create(ctx, domain.Headman{StudentID: 2, GroupID: testGroup, TermStart: day("2024-07-01")}); err != nil {
+		t.Fatalf("create term: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		patch   domain.Headman
+		wantErr error
+	}{
+		{name: "no updates", patch: domain.Headman{HeadmanID: 1}, wantErr: service.ErrNoUpdates},
+		{name: "end before start", patch: domain.Headman{HeadmanID: 1, TermEnd: dayPtr("2023-08-01")}, wantErr: service.ErrHeadmanTermDates},
+		{name: "extended into the next term", patch: domain.Headman{HeadmanID: 1, TermEnd: dayPtr("2024-12-31")}, wantErr: service.ErrHeadmanTermOverlap},
+		{name: "shortened", patch: domain.Headman{HeadmanID: 1, TermEnd: dayPtr("2024-01-31")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := headmen.Patch(ctx, tt.patch)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Patch() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHeadmanServiceSyncsRoles(t *testing.T) {
+	ctx := context.Background()
+	repos := newRepos(t)
+	headmen := service.NewHeadmanService(repos.Transactor, repos.Headman)
+
+	studentID := int64(1)
+	user := domain.User{Username: "ivanovivan", Password: "hash", Role: "Студент", StudentID: &studentID}
+	if err := repos.User.Create(ctx, user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	start := time.Now().AddDate(0, 0, -1)
+	if err := headmen.Create(ctx, domain.Headman{StudentID: studentID, GroupID: testGroup, TermStart: start}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	headman, err := repos.User.GetByName(ctx, user.Username)
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	if headman.User.Role != "Староста" || headman.User.HeadmanID == nil || headman.User.StudentID != nil {
+		t.Fatalf("user after the term started = %+v, want the headman role", headman.User)
+	}
+
+	if err := headmen.Patch(ctx, domain.Headman{HeadmanID: *headman.User.HeadmanID, TermEnd: &start}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	// the term ended yesterday, the role goes back today
+	student, err := repos.User.GetByName(ctx, user.Username)
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	if student.User.Role != "Студент" || student.User.StudentID == nil || *student.User.StudentID != studentID {
+		t.Fatalf("user after the term ended = %+v, want the student role", student.User)
+	}
+}
+
+func TestHeadmanServiceDeleteDemotes(t *testing.T) {
+	ctx := context.Background()
+	repos := newRepos(t)
+	headmen := service.NewHeadmanService(repos.Transactor, repos.Headman)
+
+	studentID := int64(1)
+	user := domain.User{Username: "ivanovivan", Password: "hash", Role: "Студент", StudentID: &studentID}
+	if err := repos.User.Create(ctx, user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if err := headmen.Create(ctx, domain.Headman{StudentID: studentID, GroupID: testGroup, TermStart: time.Now().AddDate(0, 0, -1)}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	headman, err := repos.User.GetByName(ctx, user.Username)
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	if headman.User.HeadmanID == nil {
+		t.Fatalf("user after the term started = %+v, want the headman role", headman.User)
+	}
+
+	if err := headmen.Delete(ctx, *headman.User.HeadmanID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	student, err := repos.User.GetByName(ctx, user.Username)
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	if student.User.Role != "Студент" || student.User.HeadmanID != nil || student.User.StudentID == nil || *student.User.StudentID != studentID {
+		t.Fatalf("user after the term was deleted = %+v, want the student role", student.User)
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+)
+
+type LessonSlotService struct {
+	LessonSlotRepo repository.ILessonSlot
+}
+
+func NewLessonSlotService(lessonSlotRepo repository.ILessonSlot) *LessonSlotService {
+	return &LessonSlotService{LessonSlotRepo: lessonSlotRepo}
+}
+
+func (s *LessonSlotService) Create(ctx context.Context, slot domain.LessonSlot) (int64, error) {
+	if err := s.check(ctx, slot); err != nil {
+		return 0, err
+	}
+	return s.LessonSlotRepo.Create(ctx, slot)
+}
+
+// Put updates the slot, the actual schedules of the slot move to its new start time
+func (s *LessonSlotService) Put(ctx context.Context, slot domain.LessonSlot) error {
+	if err := s.check(ctx, slot); err != nil {
+		return err
+	}
+	return s.LessonSlotRepo.Put(ctx, slot)
+}
+
+func (s *LessonSlotService) Delete(ctx context.Context, slotID int64) error {
+	return s.LessonSlotRepo.Delete(ctx, slotID)
+}
+
+func (s *LessonSlotService) GetByID(ctx context.Context, slotID int64) (domain.LessonSlot, error) {
+	return s.LessonSlotRepo.GetByID(ctx, slotID)
+}
+
+func (s *LessonSlotService) GetByUniversityID(ctx context.Context, universityID int64) ([]domain.LessonSlot, error) {
+	return s.LessonSlotRepo.GetByUniversityID(ctx, universityID)
+}
+
+// check keeps the bell schedule ordered: a slot with a greater number starts
+// after the previous slot and its break
+func (s *LessonSlotService) check(ctx context.Context, slot domain.LessonSlot) error {
+	if clock(slot.EndTime) <= clock(slot.StartTime) {
+		return ErrSlotTime
+	}
+
+	slots, err := s.LessonSlotRepo.GetByUniversityID(ctx, slot.UniversityID)
+	if err != nil {
+		return err
+	}
+	bell := make([]domain.LessonSlot, 0, len(slots)+1)
+	for _, other := range slots {
+		if other.SlotID != slot.SlotID {
+			bell = append(bell, other)
+		}
+	}
+	bell = append(bell, slot)
+	sort.Slice(bell, func(i, j int) bool { return bell[i].SlotNumber < bell[j].SlotNumber })
+
+	for i := 1; i < len(bell); i++ {
+		previous := bell[i-1]
+		free := previous.EndTime.Add(time.Duration(previous.BreakMinutes) * time.Minute)
+		if clock(bell[i].StartTime) < clock(free) {
+			return ErrSlotOverlap
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository/memory"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+)
+
+func clockAt(value string) time.Time {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		panic(err)
+	}
+	return clock
+}
+
+func TestLessonSlotServiceCreate(t *testing.T) {
+	bell := []domain.LessonSlot{
+		{UniversityID: 1, SlotNumber: 1, StartTime: clockAt("08:30"), EndTime: clockAt("10:00"), BreakMinutes: 10},
+		{UniversityID: 1, SlotNumber: 3, StartTime: clockAt("12:00"), EndTime: clockAt("13:30"), BreakMinutes: 10},
+	}
+
+	tests := []struct {
+		name    string
+		slot    domain.LessonSlot
+		wantErr error
+	}{
+		{
+			name:    "ends before it starts",
+			slot:    domain.LessonSlot{UniversityID: 1, SlotNumber: 2, StartTime: clockAt("11:40"), EndTime: clockAt("10:10")},
+			wantErr: service.ErrSlotTime,
+		},
+		{
+			name:    "starts during the break",
+			slot:    domain.LessonSlot{UniversityID: 1, SlotNumber: 2, StartTime: clockAt("10:05"), EndTime: clockAt("11:35")},
+			wantErr: service.ErrSlotOverlap,
+		},
+		{
+			name:    "runs into the next slot",
+			slot:    domain.LessonSlot{UniversityID: 1, SlotNumber: 2, StartTime: clockAt("10:10"), EndTime: clockAt("11:55"), BreakMinutes: 10},
+			wantErr: service.ErrSlotOverlap,
+		},
+		{
+			name: "between the slots",
+			slot: domain.LessonSlot{UniversityID: 1, SlotNumber: 2, StartTime: clockAt("10:10"), EndTime: clockAt("11:40"), BreakMinutes: 20},
+		},
+		{
+			name: "another university",
+			slot: domain.LessonSlot{UniversityID: 2, SlotNumber: 2, StartTime: clockAt("09:00"), EndTime: clockAt("10:30")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			slots := service.NewLessonSlotService(memory.NewRepositories().LessonSlot)
+			for _, slot := range bell {
+				if _, err := slots.Create(ctx, slot); err != nil {
+					t.Fatalf("create bell slot: %v", err)
+				}
+			}
+
+			_, err := slots.Create(ctx, tt.slot)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/pkg/ratelimit"
+)
+
+const (
+	usernameAttemptsPrefix = "login:user:"
+	ipAttemptsPrefix       = "login:ip:"
+)
+
+// LoginPolicy configures when failed sign-ins lock an account or a client IP
+type LoginPolicy struct {
+	MaxAttempts    int
+	MaxIPAttempts  int
+	BaseLockout    time.Duration
+	MaxLockout     time.Duration
+	AttemptsWindow time.Duration
+}
+
+// LoginGuard tracks failed sign-ins per username and per IP and applies
+// exponential lockouts once the allowed number of attempts is exceeded
+type LoginGuard struct {
+	store  ratelimit.AttemptStore
+	policy LoginPolicy
+	now    func() time.Time
+}
+
+func NewLoginGuard(store ratelimit.AttemptStore, policy LoginPolicy) *LoginGuard {
+	return &LoginGuard{store: store, policy: policy, now: time.Now}
+}
+
+// Check returns a LoginLockedError if the username or the IP is locked. Otherwise it counts
+// the attempt of the username before the password is checked, so parallel sign-ins can't
+// try more passwords than the limit allows; Success drops the count
+func (g *LoginGuard) Check(ctx context.Context, username, clientIP string) error {
+	now := g.now()
+	for _, key := range g.keys(username, clientIP) {
+		attempts, err := g.store.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if attempts.LockedUntil.After(now) {
+			return &LoginLockedError{RetryAfter: attempts.LockedUntil.Sub(now)}
+		}
+	}
+	if g.policy.MaxAttempts <= 0 {
+		return nil
+	}
+
+	attempts, err := g.store.Incr(ctx, usernameAttemptsPrefix+username, g.policy.AttemptsWindow)
+	if err != nil {
+		return err
+	}
+	if attempts.LockedUntil.After(now) {
+		return &LoginLockedError{RetryAfter: attempts.LockedUntil.Sub(now)}
+	}
+	// the attempts in flight already use up the limit
+	if attempts.Failures > max(g.policy.MaxAttempts, attempts.Allowed) {
+		return &LoginLockedError{RetryAfter: g.policy.BaseLockout}
+	}
+	return nil
+}
+
+// Fail records a failed sign-in and locks the key when the limit is reached.
+// The attempt of the username is counted by Check
+func (g *LoginGuard) Fail(ctx context.Context, username, clientIP string) error {
+	if g.policy.MaxAttempts > 0 {
+		attempts, err := g.store.Get(ctx, usernameAttemptsPrefix+username)
+		if err != nil {
+			return err
+		}
+		if err := g.lock(ctx, usernameAttemptsPrefix+username, attempts.Failures, g.policy.MaxAttempts); err != nil {
+			return err
+		}
+	}
+	if clientIP == "" {
+		return nil
+	}
+	return g.fail(ctx, ipAttemptsPrefix+clientIP, g.policy.MaxIPAttempts)
+}
+
+// Success clears failed attempts of the username
+func (g *LoginGuard) Success(ctx context.Context, username string) error {
+	return g.store.Reset(ctx, usernameAttemptsPrefix+username)
+}
+
+// fail counts a failure of the key, the IP counts failures only as its sign-ins
+// are shared by many users
+func (g *LoginGuard) fail(ctx context.Context, key string, maxAttempts int) error {
+	if maxAttempts <= 0 {
+		return nil
+	}
+
+	attempts, err := g.store.Incr(ctx, key, g.policy.AttemptsWindow)
+	if err != nil {
+		return err
+	}
+	return g.lock(ctx, key, attempts.Failures, maxAttempts)
+}
+
+// lock locks the key once the failures reach the limit
+func (g *LoginGuard) lock(ctx context.Context, key string, failures, maxAttempts int) error {
+	if failures < maxAttempts {
+		return nil
+	}
+
+	lockout := g.lockout(failures - maxAttempts)
+	return g.store.Lock(ctx, key, g.now().Add(lockout), lockout+g.policy.AttemptsWindow)
+}
+
+// lockout doubles the base lockout for every failure over the limit,
+// up to MaxLockout when it is set
+func (g *LoginGuard) lockout(over int) time.Duration {
+	lockout := g.policy.BaseLockout
+	for i := 0; i < over && lockout > 0 && lockout <= math.MaxInt64/2; i++ {
+		if g.policy.MaxLockout > 0 && lockout >= g.policy.MaxLockout {
+			break
+		}
+		lockout *= 2
+	}
+	if g.policy.MaxLockout > 0 && lockout > g.policy.MaxLockout {
+		lockout = g.policy.MaxLockout
+	}
+	return lockout
+}
+
+func (g *LoginGuard) keys(username, clientIP string) []string {
+	keys := []string{usernameAttemptsPrefix + username}
+	if clientIP != "" {
+		keys = append(keys, ipAttemptsPrefix+clientIP)
+	}
+	return keys
+}
@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/ratelimit"
+)
+
+var testLoginPolicy = service.LoginPolicy{
+	MaxAttempts:    3,
+	MaxIPAttempts:  10,
+	BaseLockout:    time.Minute,
+	MaxLockout:     time.Hour,
+	AttemptsWindow: 15 * time.Minute,
+}
+
+func TestLoginGuardLocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		clientIP string
+		locked   bool
+	}{
+		{name: "under the limit", failures: 2, clientIP: "10.0.0.1"},
+		{name: "at the limit", failures: 3, clientIP: "10.0.0.1", locked: true},
+		{name: "without the ip", failures: 3, locked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			guard := service.NewLoginGuard(ratelimit.NewMemoryStore(), testLoginPolicy)
+
+			for i := 0; i < tt.failures; i++ {
+				failSignIn(t, guard, "studentuser", tt.clientIP)
+			}
+
+			err := guard.Check(ctx, "studentuser", "10.0.0.2")
+			var locked *service.LoginLockedError
+			if errors.As(err, &locked) != tt.locked {
+				t.Fatalf("Check() error = %v, want locked %v", err, tt.locked)
+			}
+			if tt.locked && (locked.RetryAfter <= 0 || locked.RetryAfter > testLoginPolicy.BaseLockout) {
+				t.Errorf("retry after = %v, want up to %v", locked.RetryAfter, testLoginPolicy.BaseLockout)
+			}
+		})
+	}
+}
+
+func TestLoginGuardSuccessResets(t *testing.T) {
+	ctx := context.Background()
+	guard := service.NewLoginGuard(ratelimit.NewMemoryStore(), testLoginPolicy)
+
+	for i := 0; i < testLoginPolicy.MaxAttempts-1; i++ {
+		failSignIn(t, guard, "studentuser", "")
+	}
+	if err := guard.Check(ctx, "studentuser", ""); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if err := guard.Success(ctx, "studentuser"); err != nil {
+		t.Fatalf("Success() error = %v", err)
+	}
+	failSignIn(t, guard, "studentuser", "")
+	if err := guard.Check(ctx, "studentuser", ""); err != nil {
+		t.Errorf("Check() after a success error = %v, want nil", err)
+	}
+}
+
+// parallel sign-ins check no more passwords than the limit allows
+func TestLoginGuardParallelSignIns(t *testing.T) {
+	ctx := context.Background()
+	guard := service.NewLoginGuard(ratelimit.NewMemoryStore(), testLoginPolicy)
+
+	const signIns = 50
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		checked int
+	)
+	start := make(chan struct{})
+	for i := 0; i < signIns; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			var locked *service.LoginLockedError
+			if err := guard.Check(ctx, "studentuser", "10.0.0.1"); errors.As(err, &locked) {
+				return
+			} else if err != nil {
+				t.Errorf("Check() error = %v", err)
+				return
+			}
+			mu.Lock()
+			checked++
+			mu.Unlock()
+			if err := guard.Fail(ctx, "studentuser", "10.0.0.1"); err != nil {
+				t.Errorf("Fail() error = %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if checked != testLoginPolicy.MaxAttempts {
+		t.Errorf("checked passwords = %d, want %d", checked, testLoginPolicy.MaxAttempts)
+	}
+	if err := guard.Check(ctx, "studentuser", ""); err == nil {
+		t.Error("Check() error = nil, want the username locked")
+	}
+}
+
+func TestLoginGuardLockoutDoubles(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxLockout time.Duration
+		want       []time.Duration
+	}{
+		{name: "capped", maxLockout: 3 * time.Minute, want: []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute}},
+		{name: "without a cap", want: []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			policy := testLoginPolicy
+			policy.MaxLockout = tt.maxLockout
+			guard := service.NewLoginGuard(ratelimit.NewMemoryStore(), policy)
+			now := time.Date(2024, 9, 2, 8, 30, 0, 0, time.UTC)
+			service.SetLoginGuardClock(guard, func() time.Time { return now })
+
+			for i := 0; i < policy.MaxAttempts-1; i++ {
+				failSignIn(t, guard, "studentuser", "")
+			}
+			for _, want := range tt.want {
+				failSignIn(t, guard, "studentuser", "")
+				var locked *service.LoginLockedError
+				if err := guard.Check(ctx, "studentuser", ""); !errors.As(err, &locked) || locked.RetryAfter != want {
+					t.Fatalf("Check() error = %v, want a lockout of %v", err, want)
+				}
+				// a single attempt follows the lockout
+				now = now.Add(want)
+			}
+		})
+	}
+}
+
+// failSignIn checks the sign-in and records its failure as SignIn does
+func failSignIn(t *testing.T, guard *service.LoginGuard, username, clientIP string) {
+	t.Helper()
+	ctx := context.Background()
+	if err := guard.Check(ctx, username, clientIP); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if err := guard.Fail(ctx, username, clientIP); err != nil {
+		t.Fatalf("Fail() error = %v", err)
+	}
+}
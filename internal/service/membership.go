@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+type MembershipService struct {
+	MembershipRepo repository.IMembership
+	StudentRepo    repository.IStudent
+	GroupRepo      repository.IGroup
+}
+
+func NewMembershipService(MembershipRepo repository.IMembership, StudentRepo repository.IStudent, GroupRepo repository.IGroup) *MembershipService {
+	return &MembershipService{
+		MembershipRepo: MembershipRepo,
+		StudentRepo:    StudentRepo,
+		GroupRepo:      GroupRepo,
+	}
+}
+
+func (s *MembershipService) GetByStudentID(ctx context.Context, studentID int64) ([]domain.GroupMembership, error) {
+	if _, err := s.StudentRepo.GetByID(ctx, studentID); err != nil {
+		return nil, err
+	}
+	return s.MembershipRepo.GetByStudentID(ctx, studentID)
+}
+
+// Transfer moves the student to the group from the transfer date, the date can't
+// be earlier than the start of the current membership
+func (s *MembershipService) Transfer(ctx context.Context, transfer domain.StudentTransfer) error {
+	student, err := s.StudentRepo.GetByID(ctx, transfer.StudentID)
+	if err != nil {
+		return err
+	}
+	if student.GroupID == transfer.GroupID {
+		return ErrSameGroup
+	}
+	if _, err := s.GroupRepo.GetByID(ctx, transfer.GroupID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrGroupNotFound
+		}
+		return err
+	}
+
+	if err := s.checkMoveDate(ctx, transfer.StudentID, transfer.Date); err != nil {
+		return err
+	}
+
+	return s.MembershipRepo.Transfer(ctx, transfer)
+}
+
+// checkMoveDate returns ErrTransferDate when the student can't leave the current group
+// at the date because the membership in it starts later
+func (s *MembershipService) checkMoveDate(ctx context.Context, studentID int64, date time.Time) error {
+	memberships, err := s.MembershipRepo.GetByStudentID(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	for _, membership := range memberships {
+		if membership.ValidTo == nil && date.Before(membership.ValidFrom) {
+			return ErrTransferDate
+		}
+	}
+	return nil
+}
+
+// Promote moves the students of every source group to its target group. Missing
+// target groups are created with the profile of the source group. As for Transfer,
+// the date can't be earlier than the start of the current membership of any student
+func (s *MembershipService) Promote(ctx context.Context, promotions []domain.GroupPromotion, date time.Time, dryRun bool) (domain.PromotionResult, error) {
+	result := domain.PromotionResult{DryRun: dryRun, Date: date}
+
+	sources := make(map[string]struct{}, len(promotions))
+	targets := make(map[string]struct{}, len(promotions))
+	for _, promotion := range promotions {
+		if promotion.FromGroupID == promotion.ToGroupID {
+			return result, ErrSameGroup
+		}
+		if _, ok := sources[promotion.FromGroupID]; ok {
+			return result, ErrDuplicatePromotion
+		}
+		if _, ok := targets[promotion.ToGroupID]; ok {
+			return result, ErrDuplicatePromotion
+		}
+		sources[promotion.FromGroupID] = struct{}{}
+		targets[promotion.ToGroupID] = struct{}{}
+	}
+
+	var groups []domain.Group
+	for _, promotion := range promotions {
+		source, err := s.GroupRepo.GetByID(ctx, promotion.FromGroupID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return result, ErrGroupNotFound
+			}
+			return result, err
+		}
+
+		_, err = s.GroupRepo.GetByID(ctx, promotion.ToGroupID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return result, err
+		}
+		if err != nil {
+			groups = append(groups, domain.Group{GroupID: promotion.ToGroupID, ProfileID: source.Group.ProfileID})
+			result.CreatedGroups = append(result.CreatedGroups, promotion.ToGroupID)
+		}
+
+		students, err := s.StudentRepo.GetAllByGroupID(ctx, promotion.FromGroupID)
+		if err != nil {
+			return result, err
+		}
+		for _, student := range students {
+			if err := s.checkMoveDate(ctx, student.StudentID, date); err != nil {
+				return result, err
+			}
+		}
+		promotion.Students = len(students)
+		result.Promotions = append(result.Promotions, promotion)
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	if err := s.MembershipRepo.Promote(ctx, result.Promotions, groups, date); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,172 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+)
+
+func TestMembershipServiceTransfer(t *testing.T) {
+	tests := []struct {
+		name     string
+		transfer domain.StudentTransfer
+		wantErr  error
+	}{
+		{
+			name:     "same group",
+			transfer: domain.StudentTransfer{StudentID: 1, GroupID: testGroup, Date: day("2030-09-01")},
+			wantErr:  service.ErrSameGroup,
+		},
+		{
+			name:     "missing group",
+			transfer: domain.StudentTransfer{StudentID: 1, GroupID: "2023-35.03.06-9", Date: day("2030-09-01")},
+			wantErr:  service.ErrGroupNotFound,
+		},
+		{
+			name:     "before the current membership",
+			transfer: domain.StudentTransfer{StudentID: 1, GroupID: otherGroup, Date: day("2000-09-01")},
+			wantErr:  service.ErrTransferDate,
+		},
+		{
+			name:     "transfer",
+			transfer: domain.StudentTransfer{StudentID: 1, GroupID: otherGroup, Date: day("2030-09-01")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newRepos(t)
+			memberships := service.NewMembershipService(repos.Membership, repos.Student, repos.Group)
+
+			err := memberships.Transfer(ctx, tt.transfer)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Transfer() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			student, err := repos.Student.GetByID(ctx, tt.transfer.StudentID)
+			if err != nil {
+				t.Fatalf("get student: %v", err)
+			}
+			if student.GroupID != tt.transfer.GroupID {
+				t.Fatalf("student group = %s, want %s", student.GroupID, tt.transfer.GroupID)
+			}
+			history, err := memberships.GetByStudentID(ctx, tt.transfer.StudentID)
+			if err != nil {
+				t.Fatalf("GetByStudentID() error = %v", err)
+			}
+			if len(history) != 2 || history[0].ValidTo == nil || history[1].ValidTo != nil || history[1].GroupID != tt.transfer.GroupID {
+				t.Fatalf("history = %+v, want the closed and the new membership", history)
+			}
+		})
+	}
+}
+
+func TestMembershipServicePromote(t *testing.T) {
+	tests := []struct {
+		name        string
+		promotions  []domain.GroupPromotion
+		date        string
+		dryRun      bool
+		wantErr     error
+		wantCreated []string
+		wantMoved   bool
+	}{
+		{
+			name:       "same group",
+			promotions: []domain.GroupPromotion{{FromGroupID: testGroup, ToGroupID: testGroup}},
+			wantErr:    service.ErrSameGroup,
+		},
+		{
+			name: "duplicate source",
+			promotions: []domain.GroupPromotion{
+				{FromGroupID: testGroup, ToGroupID: "2024-35.03.06-1"},
+				{FromGroupID: testGroup, ToGroupID: "2024-35.03.06-2"},
+			},
+			wantErr: service.ErrDuplicatePromotion,
+		},
+		{
+			name: "duplicate target",
+			promotions: []domain.GroupPromotion{
+				{FromGroupID: testGroup, ToGroupID: "2024-35.03.06-1"},
+				{FromGroupID: otherGroup, ToGroupID: "2024-35.03.06-1"},
+			},
+			wantErr: service.ErrDuplicatePromotion,
+		},
+		{
+			name:       "missing source",
+			promotions: []domain.GroupPromotion{{FromGroupID: "2023-35.03.06-9", ToGroupID: "2024-35.03.06-1"}},
+			wantErr:    service.ErrGroupNotFound,
+		},
+		{
+			name:       "before the current membership",
+			promotions: []domain.GroupPromotion{{FromGroupID: testGroup, ToGroupID: "2024-35.03.06-1"}},
+			date:       "2000-09-01",
+			wantErr:    service.ErrTransferDate,
+		},
+		{
+			name:        "dry run",
+			promotions:  []domain.GroupPromotion{{FromGroupID: testGroup, ToGroupID: "2024-35.03.06-1"}},
+			dryRun:      true,
+			wantCreated: []string{"2024-35.03.06-1"},
+		},
+		{
+			name:        "promotion",
+			promotions:  []domain.GroupPromotion{{FromGroupID: testGroup, ToGroupID: "2024-35.03.06-1"}},
+			wantCreated: []string{"2024-35.03.06-1"},
+			wantMoved:   true,
+		},
+		{
+			name:       "into an existing group",
+			promotions: []domain.GroupPromotion{{FromGroupID: testGroup, ToGroupID: otherGroup}},
+			wantMoved:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newRepos(t)
+			memberships := service.NewMembershipService(repos.Membership, repos.Student, repos.Group)
+
+			date := "2030-09-01"
+			if tt.date != "" {
+				date = tt.date
+			}
+			result, err := memberships.Promote(ctx, tt.promotions, day(date), tt.dryRun)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Promote() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if len(result.CreatedGroups) != len(tt.wantCreated) {
+				t.Fatalf("created groups = %v, want %v", result.CreatedGroups, tt.wantCreated)
+			}
+			for i, groupID := range tt.wantCreated {
+				if result.CreatedGroups[i] != groupID {
+					t.Fatalf("created groups = %v, want %v", result.CreatedGroups, tt.wantCreated)
+				}
+			}
+			if result.Promotions[0].Students != 3 {
+				t.Fatalf("promoted students = %d, want 3", result.Promotions[0].Students)
+			}
+
+			target := tt.promotions[0].ToGroupID
+			moved, err := repos.Student.GetAllByGroupID(ctx, target)
+			if err != nil {
+				t.Fatalf("get students: %v", err)
+			}
+			if tt.wantMoved != (len(moved) == 3) {
+				t.Fatalf("students of %s = %d, moved %v", target, len(moved), tt.wantMoved)
+			}
+		})
+	}
+}
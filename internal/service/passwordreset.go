@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/mailer"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/myhash"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	resetTokenBytes      = 32
+	defaultResetTokenTTL = time.Hour
+	resetMailSubject     = "Восстановление пароля OSAU AMS"
+)
+
+// ResetToken is the plain reset token, it is shown only once
+type ResetToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type PasswordResetService struct {
+	Transactor  repository.ITransactor
+	Hasher      myhash.PasswordHasher
+	UserRepo    repository.IUser
+	TeacherRepo repository.ITeacher
+	ResetRepo   repository.IPasswordReset
+	Mailer      mailer.Sender
+	TokenTTL    time.Duration
+	now         func() time.Time
+}
+
+func NewPasswordResetService(
+	Transactor repository.ITransactor,
+	Hasher myhash.PasswordHasher,
+	UserRepo repository.IUser,
+	TeacherRepo repository.ITeacher,
+	ResetRepo repository.IPasswordReset,
+	Mailer mailer.Sender,
+	TokenTTL time.Duration,
+) *PasswordResetService {
+	if TokenTTL <= 0 {
+		TokenTTL = defaultResetTokenTTL
+	}
+	return &PasswordResetService{
+		Transactor:  Transactor,
+		Hasher:      Hasher,
+		UserRepo:    UserRepo,
+		TeacherRepo: TeacherRepo,
+		ResetRepo:   ResetRepo,
+		Mailer:      Mailer,
+		TokenTTL:    TokenTTL,
+		now:         time.Now,
+	}
+}
+
+// CreateForUser issues a reset token for an admin to hand over to the user
+func (s *PasswordResetService) CreateForUser(ctx context.Context, userID uuid.UUID) (ResetToken, error) {
+	if _, err := s.UserRepo.GetByID(ctx, userID); err != nil {
+		return ResetToken{}, err
+	}
+	return s.issue(ctx, userID)
+}
+
+// Request emails a reset token when the user has a known email address.
+// It does not reveal whether the username exists
+func (s *PasswordResetService) Request(ctx context.Context, username string) error {
+	user, err := s.UserRepo.GetByName(ctx, username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	email, err := s.userEmail(ctx, user.User)
+	if err != nil {
+		return err
+	}
+	if email == "" {
+		slog.InfoContext(ctx, "password reset requested for user without email", slog.String("username", username))
+		return nil
+	}
+
+	token, err := s.issue(ctx, user.User.UserID)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Код для восстановления пароля: %s\nКод действителен до %s.",
+		token.Token, token.ExpiresAt.Format("2006-01-02 15:04"))
+	return s.Mailer.Send(ctx, email, resetMailSubject, body)
+}
+
+// Reset sets a new password using a single-use token and revokes issued access tokens.
+// The token is consumed in the transaction of the new password, so a failed reset keeps it
+func (s *PasswordResetService) Reset(ctx context.Context, token, newPassword string) error {
+	return s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		userID, err := s.ResetRepo.Consume(ctx, hashResetToken(token), s.now())
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrInvalidResetToken
+			}
+			return err
+		}
+
+		hashpassword, err := s.Hasher.HashPassword(newPassword)
+		if err != nil {
+			return err
+		}
+		if err := s.UserRepo.UpdatePassword(ctx, userID, hashpassword); err != nil {
+			return err
+		}
+		return s.ResetRepo.DeleteByUserID(ctx, userID)
+	})
+}
+
+func (s *PasswordResetService) issue(ctx context.Context, userID uuid.UUID) (ResetToken, error) {
+	raw := make([]byte, resetTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return ResetToken{}, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	resetToken := domain.PasswordResetToken{
+		UserID:    userID,
+		TokenHash: hashResetToken(token),
+		ExpiresAt: s.now().Add(s.TokenTTL),
+	}
+	if err := s.ResetRepo.Create(ctx, resetToken); err != nil {
+		return ResetToken{}, err
+	}
+
+	return ResetToken{Token: token, ExpiresAt: resetToken.ExpiresAt}, nil
+}
+
+func (s *PasswordResetService) userEmail(ctx context.Context, user domain.User) (string, error) {
+	if user.TeacherID == nil {
+		return "", nil
+	}
+	teacher, err := s.TeacherRepo.GetByID(ctx, *user.TeacherID)
+	if err != nil {
+		return "", err
+	}
+	return teacher.Teacher.TeacherEmail, nil
+}
+
+// reset tokens are stored as sha256 so a database leak does not expose them
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
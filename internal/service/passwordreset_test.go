@@ -0,0 +1,198 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/myhash"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// failingHasher fails to hash while err is set
+type failingHasher struct {
+	myhash.PasswordHasher
+	err error
+}
+
+func (h *failingHasher) HashPassword(password string) (string, error) {
+	if h.err != nil {
+		return "", h.err
+	}
+	return h.PasswordHasher.HashPassword(password)
+}
+
+func TestPasswordResetServiceResetKeepsTokenOnFailure(t *testing.T) {
+	repos := newRepos(t)
+	ctx := context.Background()
+
+	if err := repos.User.Create(ctx, domain.User{Username: "studentuser", Password: "old", Role: "Студент"}); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	created, err := repos.User.GetByName(ctx, "studentuser")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	userID := created.User.UserID
+	hasher := &failingHasher{PasswordHasher: myhash.NewHasher("salt", 4), err: errors.New("hash failed")}
+	resets := service.NewPasswordResetService(repos.Transactor, hasher, repos.User, repos.Teacher, repos.PasswordReset, nil, 0)
+	token, err := resets.CreateForUser(ctx, userID)
+	if err != nil {
+		t.Fatalf("CreateForUser() error = %v", err)
+	}
+
+	// the token is consumed in the transaction of the new password
+	if err := resets.Reset(ctx, token.Token, "new-password"); !errors.Is(err, hasher.err) {
+		t.Fatalf("Reset() error = %v, want %v", err, hasher.err)
+	}
+	hasher.err = nil
+	if err := resets.Reset(ctx, token.Token, "new-password"); err != nil {
+		t.Fatalf("Reset() after a failed reset error = %v", err)
+	}
+	user, err := repos.User.GetByID(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !hasher.ComparePassword(user.User.Password, "new-password") {
+		t.Errorf("password isn't the new one")
+	}
+	if err := resets.Reset(ctx, token.Token, "another-password"); !errors.Is(err, service.ErrInvalidResetToken) {
+		t.Errorf("Reset() with a used token error = %v, want %v", err, service.ErrInvalidResetToken)
+	}
+}
+
+// sentMail records the emails instead of sending them
+type sentMail struct {
+	to   []string
+	body []string
+}
+
+func (m *sentMail) Send(ctx context.Context, to, subject, body string) error {
+	m.to = append(m.to, to)
+	m.body = append(m.body, body)
+	return nil
+}
+
+func TestPasswordResetServiceRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		wantTo   string
+	}{
+		{name: "teacher with an email", username: "teacheruser", wantTo: "morozov@omgau.org"},
+		{name: "student without an email", username: "studentuser"},
+		{name: "unknown user", username: "nobody"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newRepos(t)
+			ctx := context.Background()
+			if err := repos.Teacher.Create(ctx, domain.Teacher{DepartamentID: 1, LastName: "Морозов", FirstName: "Павел", MiddleName: "Андреевич", TeacherEmail: "morozov@omgau.org"}); err != nil {
+				t.Fatalf("create teacher: %v", err)
+			}
+			teacherID, studentID := int64(1), int64(1)
+			for _, user := range []domain.User{
+				{Username: "teacheruser", Password: "hash", Role: "Преподаватель", TeacherID: &teacherID},
+				{Username: "studentuser", Password: "hash", Role: "Студент", StudentID: &studentID},
+			} {
+				if err := repos.User.Create(ctx, user); err != nil {
+					t.Fatalf("create user: %v", err)
+				}
+			}
+			mail := &sentMail{}
+			hasher := myhash.NewHasher("salt", 4)
+			resets := service.NewPasswordResetService(repos.Transactor, hasher, repos.User, repos.Teacher, repos.PasswordReset, mail, 0)
+
+			if err := resets.Request(ctx, tt.username); err != nil {
+				t.Fatalf("Request() error = %v", err)
+			}
+			if tt.wantTo == "" {
+				if len(mail.to) != 0 {
+					t.Errorf("emails = %v, want none", mail.to)
+				}
+				return
+			}
+			if len(mail.to) != 1 || mail.to[0] != tt.wantTo {
+				t.Fatalf("emails = %v, want one to %s", mail.to, tt.wantTo)
+			}
+
+			// the emailed code resets the password
+			code := strings.TrimPrefix(strings.SplitN(mail.body[0], "\n", 2)[0], "Код для восстановления пароля: ")
+			if err := resets.Reset(ctx, code, "new-password"); err != nil {
+				t.Fatalf("Reset() with the emailed code error = %v", err)
+			}
+			user, err := repos.User.GetByName(ctx, tt.username)
+			if err != nil {
+				t.Fatalf("GetByName() error = %v", err)
+			}
+			if !hasher.ComparePassword(user.User.Password, "new-password") {
+				t.Errorf("password isn't the new one")
+			}
+		})
+	}
+}
+
+func TestPasswordResetServiceReset(t *testing.T) {
+	repos := newRepos(t)
+	ctx := context.Background()
+
+	if err := repos.User.Create(ctx, domain.User{Username: "studentuser", Password: "old", Role: "Студент"}); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	user, err := repos.User.GetByName(ctx, "studentuser")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	userID := user.User.UserID
+
+	const expired = "expired-token"
+	hash := sha256.Sum256([]byte(expired))
+	if err := repos.PasswordReset.Create(ctx, domain.PasswordResetToken{UserID: userID, TokenHash: hex.EncodeToString(hash[:]), ExpiresAt: time.Now().Add(-time.Minute)}); err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+
+	resets := service.NewPasswordResetService(repos.Transactor, myhash.NewHasher("salt", 4), repos.User, repos.Teacher, repos.PasswordReset, nil, time.Hour)
+	first, err := resets.CreateForUser(ctx, userID)
+	if err != nil {
+		t.Fatalf("CreateForUser() error = %v", err)
+	}
+	second, err := resets.CreateForUser(ctx, userID)
+	if err != nil {
+		t.Fatalf("CreateForUser() error = %v", err)
+	}
+	if !first.ExpiresAt.After(time.Now().Add(59 * time.Minute)) {
+		t.Errorf("token expires at %v, want in an hour", first.ExpiresAt)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "unknown token", token: "unknown", wantErr: service.ErrInvalidResetToken},
+		{name: "expired token", token: expired, wantErr: service.ErrInvalidResetToken},
+		{name: "valid token", token: first.Token},
+		{name: "used token", token: first.Token, wantErr: service.ErrInvalidResetToken},
+		// a reset drops the other tokens of the user
+		{name: "another token of the user", token: second.Token, wantErr: service.ErrInvalidResetToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := resets.Reset(ctx, tt.token, "new-password"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Reset() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := resets.CreateForUser(ctx, uuid.New()); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("CreateForUser() of an unknown user error = %v, want %v", err, pgx.ErrNoRows)
+	}
+}
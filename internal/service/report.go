@@ -6,6 +6,9 @@ import (
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
 	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ReportService struct {
@@ -17,5 +20,9 @@ func NewReportService(reportRepo repository.IReport) *ReportService {
 }
 
 func (s *ReportService) GetActualReportByGroupIDCreated(ctx context.Context, groupID string, startRange time.Time, endRange time.Time) (*domain.AttendanceReport, error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "ReportService.GetActualReportByGroupIDCreated",
+		trace.WithAttributes(attribute.String("group_id", groupID)))
+	defer span.End()
+
 	return s.ReportRepo.GetActualReportByGroupIDCreated(ctx, groupID, startRange, endRange)
 }
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+type RolloverService struct {
+	ScheduleRepo  repository.ISchedule
+	ClassroomRepo repository.IClassroom
+	TeacherRepo   repository.ITeacher
+}
+
+func NewRolloverService(ScheduleRepo repository.ISchedule, ClassroomRepo repository.IClassroom, TeacherRepo repository.ITeacher) *RolloverService {
+	return &RolloverService{
+		ScheduleRepo:  ScheduleRepo,
+		ClassroomRepo: ClassroomRepo,
+		TeacherRepo:   TeacherRepo,
+	}
+}
+
+// Rollover archives the actual schedules of the semester and clones the schedules
+// of the selected groups into the next semester. With dryRun only the diff is built
+func (s *RolloverService) Rollover(ctx context.Context, rollover domain.SemesterRollover, dryRun bool) (domain.RolloverDiff, error) {
+	diff := domain.RolloverDiff{DryRun: dryRun}
+
+	if rollover.NextSemester == rollover.Semester {
+		return diff, ErrSameSemester
+	}
+	if err := s.checkMappings(ctx, rollover); err != nil {
+		return diff, err
+	}
+
+	schedules, err := s.ScheduleRepo.GetAll(ctx)
+	if err != nil {
+		return diff, err
+	}
+
+	selected := make(map[string]int, len(rollover.GroupIDs))
+	for _, groupID := range rollover.GroupIDs {
+		selected[groupID] = 0
+	}
+
+	clones := make([]domain.Schedule, 0)
+	for _, scheduleInfo := range schedules {
+		schedule := scheduleInfo.Schedule
+		if schedule.Semester != rollover.Semester || schedule.IsActual == nil || !*schedule.IsActual {
+			continue
+		}
+		diff.Archived = append(diff.Archived, scheduleInfo)
+
+		if _, ok := selected[schedule.GroupID]; !ok {
+			continue
+		}
+		selected[schedule.GroupID]++
+
+		clone := cloneSchedule(schedule, rollover)
+		clones = append(clones, clone.Schedule)
+		diff.Created = append(diff.Created, clone)
+	}
+
+	for _, groupID := range rollover.GroupIDs {
+		if selected[groupID] == 0 {
+			diff.Warnings = append(diff.Warnings, "group "+groupID+" has no actual schedules in semester "+strconv.Itoa(rollover.Semester))
+		}
+	}
+
+	if dryRun {
+		return diff, nil
+	}
+	if len(diff.Archived) == 0 {
+		return diff, ErrNothingToRollover
+	}
+
+	if err := s.ScheduleRepo.Rollover(ctx, rollover.Semester, clones); err != nil {
+		return diff, err
+	}
+
+	return diff, nil
+}
+
+func (s *RolloverService) checkMappings(ctx context.Context, rollover domain.SemesterRollover) error {
+	for from, to := range rollover.ClassroomMap {
+		if _, err := s.ClassroomRepo.GetByID(ctx, to); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return &MappingError{Kind: "classroom", From: from, To: to}
+			}
+			return err
+		}
+	}
+	for from, to := range rollover.TeacherMap {
+		if _, err := s.TeacherRepo.GetByID(ctx, to); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return &MappingError{Kind: "teacher", From: from, To: to}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func cloneSchedule(schedule domain.Schedule, rollover domain.SemesterRollover) domain.ScheduleClone {
+	isActual := true
+	clone := domain.ScheduleClone{SourceScheduleID: schedule.ScheduleID}
+
+	schedule.ScheduleID = 0
+	schedule.Semester = rollover.NextSemester
+	schedule.BeginStudies = rollover.BeginStudies
+	schedule.IsActual = &isActual
+
+	if classroomID, ok := rollover.ClassroomMap[schedule.ClassroomID]; ok && classroomID != schedule.ClassroomID {
+		schedule.ClassroomID = classroomID
+		clone.ClassroomChanged = true
+	}
+	if teacherID, ok := rollover.TeacherMap[schedule.TeacherID]; ok && teacherID != schedule.TeacherID {
+		schedule.TeacherID = teacherID
+		clone.TeacherChanged = true
+	}
+
+	clone.Schedule = schedule
+	return clone
+}
@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+)
+
+// newRolloverService returns the service over two classrooms, two teachers, the actual
+// schedules 1 and 2 of the first group and 3 of the second group in the first semester,
+// the archived schedule 4 of the first semester and the actual schedule 5 of the second one
+func newRolloverService(t *testing.T) (*service.RolloverService, *repository.Repositories) {
+	t.Helper()
+	ctx := context.Background()
+	repos := newRepos(t)
+
+	for _, name := range []string{"101", "202"} {
+		if err := repos.Classroom.Create(ctx, domain.Classroom{ClassroomName: name, Capacity: 30, Building: "1"}); err != nil {
+			t.Fatalf("create classroom: %v", err)
+		}
+	}
+	for _, lastName := range []string{"Кузнецов", "Новикова"} {
+		if err := repos.Teacher.Create(ctx, domain.Teacher{DepartamentID: 1, LastName: lastName, FirstName: "Анна", MiddleName: "Игоревна", TeacherEmail: lastName + "@example.com"}); err != nil {
+			t.Fatalf("create teacher: %v", err)
+		}
+	}
+
+	actual, archived := true, false
+	schedules := []domain.Schedule{
+		{GroupID: testGroup, TeacherID: 1, ClassroomID: 1, Semester: 1, DayOfWeek: "Понедельник", IsActual: &actual},
+		{GroupID: testGroup, TeacherID: 2, ClassroomID: 2, Semester: 1, DayOfWeek: "Вторник", IsActual: &actual},
+		{GroupID: otherGroup, TeacherID: 1, ClassroomID: 1, Semester: 1, DayOfWeek: "Вторник", IsActual: &actual},
+		{GroupID: testGroup, TeacherID: 1, ClassroomID: 1, Semester: 1, DayOfWeek: "Среда", IsActual: &archived},
+		{GroupID: otherGroup, TeacherID: 2, ClassroomID: 2, Semester: 2, DayOfWeek: "Среда", IsActual: &actual},
+	}
+	for _, schedule := range schedules {
+		schedule.DisciplineID, schedule.DisciplineTypeID, schedule.WeekType, schedule.StartTime = 1, 1, "Верхняя", clockAt("08:30")
+		if err := repos.Schedule.Create(ctx, schedule); err != nil {
+			t.Fatalf("create schedule: %v", err)
+		}
+	}
+
+	return service.NewRolloverService(repos.Schedule, repos.Classroom, repos.Teacher), repos
+}
+
+// actualSchedules returns the ids of the actual schedules of the semester
+func actualSchedules(t *testing.T, repos *repository.Repositories, semester int) []int64 {
+	t.Helper()
+	schedules, err := repos.Schedule.GetAll(context.Background())
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	ids := make([]int64, 0)
+	for _, info := range schedules {
+		schedule := info.Schedule
+		if schedule.Semester == semester && schedule.IsActual != nil && *schedule.IsActual {
+			ids = append(ids, schedule.ScheduleID)
+		}
+	}
+	return ids
+}
+
+func TestRolloverServiceRolloverErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		rollover    domain.SemesterRollover
+		dryRun      bool
+		wantErr     error
+		wantMapping *service.MappingError
+	}{
+		{
+			name:     "same semester",
+			rollover: domain.SemesterRollover{Semester: 1, NextSemester: 1, GroupIDs: []string{testGroup}},
+			wantErr:  service.ErrSameSemester,
+		},
+		{
+			name:        "unknown classroom",
+			rollover:    domain.SemesterRollover{Semester: 1, NextSemester: 2, GroupIDs: []string{testGroup}, ClassroomMap: map[int64]int64{1: 9}},
+			dryRun:      true,
+			wantMapping: &service.MappingError{Kind: "classroom", From: 1, To: 9},
+		},
+		{
+			name:        "unknown teacher",
+			rollover:    domain.SemesterRollover{Semester: 1, NextSemester: 2, GroupIDs: []string{testGroup}, TeacherMap: map[int64]int64{2: 7}},
+			wantMapping: &service.MappingError{Kind: "teacher", From: 2, To: 7},
+		},
+		{
+			name:     "semester without actual schedules",
+			rollover: domain.SemesterRollover{Semester: 3, NextSemester: 4, GroupIDs: []string{testGroup}},
+			wantErr:  service.ErrNothingToRollover,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rollovers, repos := newRolloverService(t)
+
+			_, err := rollovers.Rollover(context.Background(), tt.rollover, tt.dryRun)
+			if tt.wantMapping != nil {
+				var mappingErr *service.MappingError
+				if !errors.As(err, &mappingErr) || *mappingErr != *tt.wantMapping {
+					t.Fatalf("Rollover() error = %v, want %v", err, tt.wantMapping)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Rollover() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := actualSchedules(t, repos, 1); !slices.Equal(got, []int64{1, 2, 3}) {
+				t.Errorf("actual schedules of the first semester = %v, want untouched", got)
+			}
+		})
+	}
+}
+
+func TestRolloverServiceRollover(t *testing.T) {
+	ctx := context.Background()
+	rollovers, repos := newRolloverService(t)
+
+	const emptyGroup = "2023-35.03.06-9"
+	rollover := domain.SemesterRollover{
+		Semester:     1,
+		NextSemester: 2,
+		BeginStudies: day("2025-02-10"),
+		GroupIDs:     []string{testGroup, emptyGroup},
+		ClassroomMap: map[int64]int64{1: 2},
+		TeacherMap:   map[int64]int64{1: 1},
+	}
+
+	preview, err := rollovers.Rollover(ctx, rollover, true)
+	if err != nil {
+		t.Fatalf("Rollover() of the preview error = %v", err)
+	}
+	if !preview.DryRun || len(preview.Archived) != 3 || len(preview.Created) != 2 || len(preview.Warnings) != 1 {
+		t.Fatalf("preview = %+v, want 3 archived, 2 created and a warning about %s", preview, emptyGroup)
+	}
+	if got := actualSchedules(t, repos, 2); !slices.Equal(got, []int64{5}) {
+		t.Fatalf("actual schedules of the second semester after the preview = %v, want [5]", got)
+	}
+
+	diff, err := rollovers.Rollover(ctx, rollover, false)
+	if err != nil {
+		t.Fatalf("Rollover() error = %v", err)
+	}
+	for _, clone := range diff.Created {
+		schedule := clone.Schedule
+		if schedule.GroupID != testGroup || schedule.Semester != 2 || !schedule.BeginStudies.Equal(rollover.BeginStudies) {
+			t.Errorf("clone of %d = %+v", clone.SourceScheduleID, schedule)
+		}
+		// the teacher mapped onto itself is not a change
+		if clone.TeacherChanged {
+			t.Errorf("clone of %d has a changed teacher", clone.SourceScheduleID)
+		}
+		if wantChanged := clone.SourceScheduleID == 1; clone.ClassroomChanged != wantChanged || schedule.ClassroomID != 2 {
+			t.Errorf("clone of %d classroom = %d changed %v", clone.SourceScheduleID, schedule.ClassroomID, clone.ClassroomChanged)
+		}
+	}
+
+	if got := actualSchedules(t, repos, 1); len(got) != 0 {
+		t.Errorf("actual schedules of the first semester = %v, want all archived", got)
+	}
+	if got := actualSchedules(t, repos, 2); len(got) != 3 {
+		t.Errorf("actual schedules of the second semester = %v, want the old one and two clones", got)
+	}
+}
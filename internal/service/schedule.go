@@ -8,22 +8,141 @@ import (
 )
 
 type ScheduleService struct {
-	ScheduleRepo repository.ISchedule
+	ScheduleRepo   repository.ISchedule
+	LessonSlotRepo repository.ILessonSlot
+	SubgroupRepo   repository.ISubgroup
+	CurriculumRepo repository.ICurriculum
 }
 
-func NewScheduleService(scheduleRepo repository.ISchedule) *ScheduleService {
-	return &ScheduleService{ScheduleRepo: scheduleRepo}
+func NewScheduleService(scheduleRepo repository.ISchedule, lessonSlotRepo repository.ILessonSlot, subgroupRepo repository.ISubgroup, curriculumRepo repository.ICurriculum) *ScheduleService {
+	return &ScheduleService{ScheduleRepo: scheduleRepo, LessonSlotRepo: lessonSlotRepo, SubgroupRepo: subgroupRepo, CurriculumRepo: curriculumRepo}
 }
 
+// Create adds a schedule, the lesson starts at the time of its slot
 func (s *ScheduleService) Create(ctx context.Context, schedule domain.Schedule) error {
+	schedule, err := s.withSlot(ctx, schedule)
+	if err != nil {
+		return err
+	}
+	if err := s.checkSubgroup(ctx, schedule.GroupID, schedule.SubgroupID); err != nil {
+		return err
+	}
+	if err := s.checkCurriculum(ctx, schedule); err != nil {
+		return err
+	}
 	return s.ScheduleRepo.Create(ctx, schedule)
 }
 
 func (s *ScheduleService) Put(ctx context.Context, schedule domain.Schedule) error {
+	schedule, err := s.withSlot(ctx, schedule)
+	if err != nil {
+		return err
+	}
+	if err := s.checkSubgroup(ctx, schedule.GroupID, schedule.SubgroupID); err != nil {
+		return err
+	}
+	if err := s.checkCurriculum(ctx, schedule); err != nil {
+		return err
+	}
 	return s.ScheduleRepo.Put(ctx, schedule)
 }
 
+// checkSubgroup allows only a subgroup of the schedule's group, a schedule
+// without a subgroup is for the whole group
+func (s *ScheduleService) checkSubgroup(ctx context.Context, groupID string, subgroupID *int64) error {
+	if subgroupID == nil {
+		return nil
+	}
+	subgroup, err := s.SubgroupRepo.GetByID(ctx, *subgroupID)
+	if err != nil {
+		return err
+	}
+	if subgroup.GroupID != groupID {
+		return ErrSubgroupGroup
+	}
+	return nil
+}
+
+// checkCurriculum allows only the discipline types planned for the group in the semester
+func (s *ScheduleService) checkCurriculum(ctx context.Context, schedule domain.Schedule) error {
+	items, err := s.CurriculumRepo.GetByGroupAndSemester(ctx, schedule.GroupID, schedule.Semester)
+	if err != nil {
+		return err
+	}
+	if !inCurriculum(items, schedule.DisciplineID, schedule.DisciplineTypeID) {
+		return ErrNotInCurriculum
+	}
+	return nil
+}
+
+// withSlot sets the start time of the schedule from its slot in the bell schedule
+// of the group's university
+func (s *ScheduleService) withSlot(ctx context.Context, schedule domain.Schedule) (domain.Schedule, error) {
+	if schedule.SlotID == nil {
+		return schedule, ErrSlotNotFound
+	}
+	slots, err := s.LessonSlotRepo.GetByGroupID(ctx, schedule.GroupID)
+	if err != nil {
+		return schedule, err
+	}
+	for _, slot := range slots {
+		if slot.SlotID == *schedule.SlotID {
+			schedule.StartTime = slot.StartTime
+			return schedule, nil
+		}
+	}
+	return schedule, ErrSlotNotFound
+}
+
+// Patch partially updates a schedule, the slot is checked against the bell schedule
+// of the group's university, the subgroup against the group and the discipline against
+// the curriculum when any of them changes
 func (s *ScheduleService) Patch(ctx context.Context, schedule domain.Schedule) error {
+	if schedule.SlotID != nil || schedule.SubgroupID != nil || schedule.GroupID != "" ||
+		schedule.DisciplineID != 0 || schedule.DisciplineTypeID != 0 || schedule.Semester != 0 {
+		current, err := s.ScheduleRepo.GetByID(ctx, schedule.ScheduleID)
+		if err != nil {
+			return err
+		}
+		groupID := schedule.GroupID
+		if groupID == "" {
+			groupID = current.Schedule.GroupID
+		}
+		slotID := schedule.SlotID
+		if slotID == nil {
+			slotID = current.Schedule.SlotID
+		}
+		if slotID != nil {
+			checked, err := s.withSlot(ctx, domain.Schedule{GroupID: groupID, SlotID: slotID})
+			if err != nil {
+				return err
+			}
+			schedule.SlotID = checked.SlotID
+			schedule.StartTime = checked.StartTime
+		}
+		subgroupID := schedule.SubgroupID
+		if subgroupID == nil {
+			subgroupID = current.Schedule.SubgroupID
+		}
+		if err := s.checkSubgroup(ctx, groupID, subgroupID); err != nil {
+			return err
+		}
+		planned := current.Schedule
+		planned.GroupID = groupID
+		if schedule.DisciplineID != 0 {
+			planned.DisciplineID = schedule.DisciplineID
+		}
+		if schedule.DisciplineTypeID != 0 {
+			planned.DisciplineTypeID = schedule.DisciplineTypeID
+		}
+		if schedule.Semester != 0 {
+			planned.Semester = schedule.Semester
+		}
+		if err := s.checkCurriculum(ctx, planned); err != nil {
+			return err
+		}
+	}
+
 	updates := make(map[string]interface{})
 	if schedule.GroupID != "" {
 		updates["group_id"] = schedule.GroupID
@@ -52,9 +171,13 @@ func (s *ScheduleService) Patch(ctx context.Context, schedule domain.Schedule) e
 	if schedule.DayOfWeek != "" {
 		updates["day_of_week"] = schedule.DayOfWeek
 	}
-	if !schedule.StartTime.IsZero() {
+	if schedule.SlotID != nil {
+		updates["slot_id"] = schedule.SlotID
 		updates["start_time"] = schedule.StartTime
 	}
+	if schedule.SubgroupID != nil {
+		updates["subgroup_id"] = schedule.SubgroupID
+	}
 	if schedule.IsActual != nil {
 		updates["is_actual"] = schedule.IsActual
 	}
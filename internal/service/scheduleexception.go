@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+)
+
+const (
+	upperWeek = "Верхняя"
+	lowerWeek = "Нижняя"
+)
+
+var weekdays = map[time.Weekday]string{
+	time.Monday:    "Понедельник",
+	time.Tuesday:   "Вторник",
+	time.Wednesday: "Среда",
+	time.Thursday:  "Четверг",
+	time.Friday:    "Пятница",
+	time.Saturday:  "Суббота",
+	time.Sunday:    "Воскресенье",
+}
+
+type ScheduleExceptionService struct {
+	ScheduleExceptionRepo repository.IScheduleException
+	ScheduleRepo          repository.ISchedule
+	CalendarRepo          repository.ICalendar
+	LessonSlotRepo        repository.ILessonSlot
+}
+
+func NewScheduleExceptionService(scheduleExceptionRepo repository.IScheduleException, scheduleRepo repository.ISchedule, calendarRepo repository.ICalendar, lessonSlotRepo repository.ILessonSlot) *ScheduleExceptionService {
+	return &ScheduleExceptionService{
+		ScheduleExceptionRepo: scheduleExceptionRepo,
+		ScheduleRepo:          scheduleRepo,
+		CalendarRepo:          calendarRepo,
+		LessonSlotRepo:        lessonSlotRepo,
+	}
+}
+
+func (s *ScheduleExceptionService) Create(ctx context.Context, exception domain.ScheduleException) (int64, error) {
+	exception, err := s.check(ctx, exception)
+	if err != nil {
+		return 0, err
+	}
+	return s.ScheduleExceptionRepo.Create(ctx, exception)
+}
+
+func (s *ScheduleExceptionService) Put(ctx context.Context, exception domain.ScheduleException) error {
+	exception, err := s.check(ctx, exception)
+	if err != nil {
+		return err
+	}
+	return s.ScheduleExceptionRepo.Put(ctx, exception)
+}
+
+func (s *ScheduleExceptionService) Delete(ctx context.Context, exceptionID int64) error {
+	return s.ScheduleExceptionRepo.Delete(ctx, exceptionID)
+}
+
+func (s *ScheduleExceptionService) GetByID(ctx context.Context, exceptionID int64) (domain.ScheduleExceptionInfo, error) {
+	return s.ScheduleExceptionRepo.GetByID(ctx, exceptionID)
+}
+
+func (s *ScheduleExceptionService) GetByScheduleID(ctx context.Context, scheduleID int64) ([]domain.ScheduleExceptionInfo, error) {
+	return s.ScheduleExceptionRepo.GetByScheduleID(ctx, scheduleID)
+}
+
+// GetByGroupAndDate resolves the timetable of the group on the date, cancelled lessons
+// stay in the timetable and lessons moved to another date are left out.
+// There are no lessons on the days off of the academic calendar
+func (s *ScheduleExceptionService) GetByGroupAndDate(ctx context.Context, groupID string, date time.Time) ([]domain.Lesson, error) {
+	teaching, err := s.CalendarRepo.IsTeachingDay(ctx, groupID, date)
+	if err != nil {
+		return nil, err
+	}
+	if !teaching {
+		return []domain.Lesson{}, nil
+	}
+
+	schedules, err := s.ScheduleRepo.GetActualByGroupAndDay(ctx, groupID, weekdays[date.Weekday()])
+	if err != nil {
+		return nil, err
+	}
+	return s.resolve(ctx, schedules, date, func(lesson domain.Lesson) bool {
+		return lesson.ScheduleInfo.Schedule.GroupID == groupID
+	})
+}
+
+// GetByTeacherAndDate resolves the timetable of the teacher on the date including
+// the lessons the teacher substitutes for and excluding the lessons given to a substitute
+func (s *ScheduleExceptionService) GetByTeacherAndDate(ctx context.Context, teacherID int64, date time.Time) ([]domain.Lesson, error) {
+	schedules, err := s.ScheduleRepo.GetActualByTeacherAndDay(ctx, teacherID, weekdays[date.Weekday()])
+	if err != nil {
+		return nil, err
+	}
+	return s.resolve(ctx, schedules, date, func(lesson domain.Lesson) bool {
+		return lesson.ScheduleInfo.Schedule.TeacherID == teacherID
+	})
+}
+
+func (s *ScheduleExceptionService) resolve(ctx context.Context, schedules []domain.ScheduleInfo, date time.Time, keep func(domain.Lesson) bool) ([]domain.Lesson, error) {
+	exceptions, err := s.ScheduleExceptionRepo.GetByDate(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	teachingDays := newTeachingDays(s.CalendarRepo, date)
+	byLesson := make(map[int64]domain.ScheduleExceptionInfo)
+	for _, exception := range exceptions {
+		if sameDay(exception.ScheduleException.LessonDate, date) {
+			byLesson[exception.ScheduleException.ScheduleID] = exception
+		}
+	}
+
+	lessons := make([]domain.Lesson, 0, len(schedules))
+	for _, schedule := range schedules {
+		if !heldByTimetable(schedule.Schedule, date) {
+			continue
+		}
+		lesson := domain.Lesson{Date: date, ScheduleInfo: schedule}
+		if exception, ok := byLesson[schedule.Schedule.ScheduleID]; ok {
+			if !sameDay(exception.ScheduleException.HeldOn(), date) {
+				continue
+			}
+			lesson = lesson.Apply(exception)
+		}
+		if keep(lesson) {
+			lessons = append(lessons, lesson)
+		}
+	}
+
+	// the lessons of other schedules may come to the date by a substitution or a move
+	for _, exception := range exceptions {
+		e := exception.ScheduleException
+		movedIn := !sameDay(e.LessonDate, date)
+		if !movedIn && (e.TeacherID == nil || e.MovedToDate != nil) {
+			continue
+		}
+		if !movedIn && containsSchedule(lessons, e.ScheduleID) {
+			continue
+		}
+		schedule, err := s.ScheduleRepo.GetByID(ctx, e.ScheduleID)
+		if err != nil {
+			return nil, err
+		}
+		if !movedIn && !heldByTimetable(schedule.Schedule, date) {
+			continue
+		}
+		lesson := domain.Lesson{Date: date, ScheduleInfo: schedule}.Apply(exception)
+		if keep(lesson) {
+			lessons = append(lessons, lesson)
+		}
+	}
+
+	held := lessons[:0]
+	for _, lesson := range lessons {
+		teaching, err := teachingDays.of(ctx, lesson.ScheduleInfo.Schedule.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		if teaching {
+			held = append(held, lesson)
+		}
+	}
+	lessons = held
+
+	sort.SliceStable(lessons, func(i, j int) bool {
+		return clock(lessons[i].ScheduleInfo.Schedule.StartTime) < clock(lessons[j].ScheduleInfo.Schedule.StartTime)
+	})
+	return lessons, nil
+}
+
+func (s *ScheduleExceptionService) check(ctx context.Context, exception domain.ScheduleException) (domain.ScheduleException, error) {
+	if exception.MovedToDate != nil && sameDay(*exception.MovedToDate, exception.LessonDate) {
+		exception.MovedToDate = nil
+	}
+	changed := exception.TeacherID != nil || exception.ClassroomID != nil || exception.MovedToDate != nil || exception.StartTime != nil
+	if exception.IsCancelled && changed {
+		return exception, ErrExceptionCancelled
+	}
+	if !exception.IsCancelled && !changed {
+		return exception, ErrExceptionNoChanges
+	}
+
+	schedule, err := s.ScheduleRepo.GetByID(ctx, exception.ScheduleID)
+	if err != nil {
+		return exception, err
+	}
+	if !heldByTimetable(schedule.Schedule, exception.LessonDate) {
+		return exception, ErrLessonNotOnDate
+	}
+	if exception.MovedToDate != nil {
+		teaching, err := s.CalendarRepo.IsTeachingDay(ctx, schedule.Schedule.GroupID, *exception.MovedToDate)
+		if err != nil {
+			return exception, err
+		}
+		if !teaching {
+			return exception, ErrNotTeachingDay
+		}
+	}
+	return exception, nil
+}
+
+// lessonOn resolves the lesson of the schedule on the date, it fails when the lesson
+// is cancelled or moved away from the date
+func lessonOn(ctx context.Context, scheduleRepo repository.ISchedule, exceptionRepo repository.IScheduleException, scheduleID int64, date time.Time) (domain.Lesson, error) {
+	schedule, err := scheduleRepo.GetByID(ctx, scheduleID)
+	if err != nil {
+		return domain.Lesson{}, err
+	}
+	exceptions, err := exceptionRepo.GetByScheduleAndDate(ctx, scheduleID, date)
+	if err != nil {
+		return domain.Lesson{}, err
+	}
+
+	lesson := domain.Lesson{Date: date, ScheduleInfo: schedule}
+	for _, exception := range exceptions {
+		if !sameDay(exception.ScheduleException.LessonDate, date) {
+			return lesson.Apply(exception), nil
+		}
+	}
+	for _, exception := range exceptions {
+		switch {
+		case exception.ScheduleException.IsCancelled:
+			return lesson, ErrLessonCancelled
+		case !sameDay(exception.ScheduleException.HeldOn(), date):
+			return lesson, ErrLessonMoved
+		default:
+			return lesson.Apply(exception), nil
+		}
+	}
+	return lesson, nil
+}
+
+// heldByTimetable reports whether the schedule has a lesson on the date by its day and week type
+func heldByTimetable(schedule domain.Schedule, date time.Time) bool {
+	if !schedule.BeginStudies.IsZero() && dateOnly(date).Before(dateOnly(schedule.BeginStudies)) {
+		return false
+	}
+	return schedule.DayOfWeek == weekdays[date.Weekday()] && schedule.WeekType == weekTypeOn(date, schedule.BeginStudies)
+}
+
+// weekTypeOn returns the week type of the date, the first week of studies is the upper one.
+// Schedules without the start of studies count the weeks from the 1st of September
+func weekTypeOn(date, beginStudies time.Time) string {
+	start := dateOnly(beginStudies)
+	if beginStudies.IsZero() {
+		year := date.Year()
+		if date.Month() < time.September {
+			year--
+		}
+		start = time.Date(year, time.September, 1, 0, 0, 0, 0, time.UTC)
+	}
+	start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+
+	days := int(dateOnly(date).Sub(start).Hours() / 24)
+	weeks := days / 7
+	if days < 0 {
+		weeks = (days - 6) / 7
+	}
+	if weeks%2 == 0 {
+		return upperWeek
+	}
+	return lowerWeek
+}
+
+func dateOnly(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func sameDay(a, b time.Time) bool {
+	return dateOnly(a).Equal(dateOnly(b))
+}
+
+// clock returns the time of the day as a comparable string
+func clock(t time.Time) string {
+	return t.Format("15:04:05")
+}
+
+func containsSchedule(lessons []domain.Lesson, scheduleID int64) bool {
+	for _, lesson := range lessons {
+		if lesson.ScheduleInfo.Schedule.ScheduleID == scheduleID {
+			return true
+		}
+	}
+	return false
+}
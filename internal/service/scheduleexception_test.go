@@ -0,0 +1,168 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/jackc/pgx/v5"
+)
+
+// newScheduleExceptionService returns the service over the timetable of newAttendanceService
+func newScheduleExceptionService(t *testing.T) (*service.ScheduleExceptionService, *repository.Repositories) {
+	t.Helper()
+	_, repos := newAttendanceService(t)
+	return service.NewScheduleExceptionService(repos.ScheduleException, repos.Schedule, repos.Calendar, repos.LessonSlot), repos
+}
+
+// lessonIDs returns the schedules of the lessons with the cancelled ones marked by a minus
+func lessonIDs(lessons []domain.Lesson) []int64 {
+	ids := make([]int64, 0, len(lessons))
+	for _, lesson := range lessons {
+		id := lesson.ScheduleInfo.Schedule.ScheduleID
+		if lesson.IsCancelled {
+			id = -id
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestWeekTypeOn(t *testing.T) {
+	const upper, lower = "Верхняя", "Нижняя"
+	tests := []struct {
+		name         string
+		date         string
+		beginStudies string
+		want         string
+	}{
+		{name: "first day of studies", date: "2024-09-02", beginStudies: "2024-09-02", want: upper},
+		{name: "sunday of the first week", date: "2024-09-08", beginStudies: "2024-09-02", want: upper},
+		{name: "second week", date: "2024-09-09", beginStudies: "2024-09-02", want: lower},
+		{name: "third week", date: "2024-09-20", beginStudies: "2024-09-02", want: upper},
+		{name: "studies from the middle of the week", date: "2024-09-02", beginStudies: "2024-09-04", want: upper},
+		{name: "week before the studies", date: "2024-08-26", beginStudies: "2024-09-02", want: lower},
+		{name: "two weeks before the studies", date: "2024-08-25", beginStudies: "2024-09-02", want: upper},
+		{name: "no start, week of the 1st of September", date: "2024-08-26", want: upper},
+		{name: "no start, autumn", date: "2024-10-01", want: lower},
+		{name: "no start, spring counts from the last September", date: "2025-02-10", want: upper},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var beginStudies time.Time
+			if tt.beginStudies != "" {
+				beginStudies = day(tt.beginStudies)
+			}
+			if got := service.WeekTypeOn(day(tt.date), beginStudies); got != tt.want {
+				t.Errorf("WeekTypeOn(%s, %s) = %s, want %s", tt.date, tt.beginStudies, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleExceptionServiceCreate(t *testing.T) {
+	substitute, classroom := int64(2), int64(2)
+	tests := []struct {
+		name      string
+		exception domain.ScheduleException
+		wantErr   error
+	}{
+		{name: "cancelled lesson", exception: domain.ScheduleException{ScheduleID: 1, LessonDate: day("2024-10-28"), IsCancelled: true}},
+		{name: "substitute teacher", exception: domain.ScheduleException{ScheduleID: 1, LessonDate: day("2024-10-28"), TeacherID: &substitute}},
+		{name: "lesson moved to a teaching day", exception: domain.ScheduleException{ScheduleID: 1, LessonDate: day("2024-10-28"), MovedToDate: dayPtr("2024-10-29")}},
+		{name: "cancelled lesson with changes", exception: domain.ScheduleException{ScheduleID: 1, LessonDate: day("2024-10-28"), IsCancelled: true, ClassroomID: &classroom},
+			wantErr: service.ErrExceptionCancelled},
+		{name: "no changes", exception: domain.ScheduleException{ScheduleID: 1, LessonDate: day("2024-10-28")}, wantErr: service.ErrExceptionNoChanges},
+		{name: "moved to the same date", exception: domain.ScheduleException{ScheduleID: 1, LessonDate: day("2024-10-28"), MovedToDate: dayPtr("2024-10-28")},
+			wantErr: service.ErrExceptionNoChanges},
+		{name: "lower week", exception: domain.ScheduleException{ScheduleID: 1, LessonDate: day("2024-10-21"), IsCancelled: true}, wantErr: service.ErrLessonNotOnDate},
+		{name: "another day of the week", exception: domain.ScheduleException{ScheduleID: 1, LessonDate: day("2024-10-29"), IsCancelled: true}, wantErr: service.ErrLessonNotOnDate},
+		{name: "before the studies", exception: domain.ScheduleException{ScheduleID: 1, LessonDate: day("2024-08-19"), IsCancelled: true}, wantErr: service.ErrLessonNotOnDate},
+		{name: "moved to a holiday", exception: domain.ScheduleException{ScheduleID: 1, LessonDate: day("2024-10-28"), MovedToDate: dayPtr("2024-11-04")},
+			wantErr: service.ErrNotTeachingDay},
+		{name: "unknown schedule", exception: domain.ScheduleException{ScheduleID: 9, LessonDate: day("2024-10-28"), IsCancelled: true}, wantErr: pgx.ErrNoRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exceptions, _ := newScheduleExceptionService(t)
+			if _, err := exceptions.Create(context.Background(), tt.exception); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScheduleExceptionServiceGetByGroupAndDate(t *testing.T) {
+	ctx := context.Background()
+	exceptions, _ := newScheduleExceptionService(t)
+
+	tests := []struct {
+		name    string
+		groupID string
+		date    string
+		want    []int64
+	}{
+		{name: "upper monday", groupID: testGroup, date: "2024-09-02", want: []int64{1, 2}},
+		{name: "lower monday", groupID: testGroup, date: "2024-09-09", want: []int64{}},
+		{name: "cancelled lesson stays", groupID: testGroup, date: "2024-09-16", want: []int64{-1, 2}},
+		{name: "lesson with a substitute", groupID: testGroup, date: "2024-09-30", want: []int64{1, 2}},
+		{name: "lesson moved away", groupID: testGroup, date: "2024-10-14", want: []int64{2}},
+		{name: "lesson moved in", groupID: testGroup, date: "2024-10-15", want: []int64{1}},
+		{name: "outside the semester", groupID: testGroup, date: "2025-01-20", want: []int64{}},
+		{name: "another group", groupID: otherGroup, date: "2024-09-02", want: []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lessons, err := exceptions.GetByGroupAndDate(ctx, tt.groupID, day(tt.date))
+			if err != nil {
+				t.Fatalf("GetByGroupAndDate() error = %v", err)
+			}
+			if got := lessonIDs(lessons); !slices.Equal(got, tt.want) {
+				t.Errorf("lessons = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleExceptionServiceGetByTeacherAndDate(t *testing.T) {
+	ctx := context.Background()
+	exceptions, repos := newScheduleExceptionService(t)
+
+	// a holiday on an upper monday leaves the teachers without lessons
+	if _, err := repos.Calendar.Create(ctx, domain.CalendarPeriod{UniversityID: 1, Kind: domain.PeriodHoliday, Title: "Выходной",
+		StartDate: day("2024-10-28"), EndDate: day("2024-10-28")}); err != nil {
+		t.Fatalf("create holiday: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		teacherID int64
+		date      string
+		want      []int64
+	}{
+		{name: "lecture", teacherID: 1, date: "2024-09-02", want: []int64{1}},
+		{name: "lab", teacherID: 2, date: "2024-09-02", want: []int64{2}},
+		{name: "replaced teacher", teacherID: 1, date: "2024-09-30", want: []int64{}},
+		{name: "substitute teacher", teacherID: 2, date: "2024-09-30", want: []int64{1, 2}},
+		{name: "cancelled lesson", teacherID: 1, date: "2024-09-16", want: []int64{-1}},
+		{name: "lesson moved in", teacherID: 1, date: "2024-10-15", want: []int64{1}},
+		{name: "holiday", teacherID: 1, date: "2024-10-28", want: []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lessons, err := exceptions.GetByTeacherAndDate(ctx, tt.teacherID, day(tt.date))
+			if err != nil {
+				t.Fatalf("GetByTeacherAndDate() error = %v", err)
+			}
+			if got := lessonIDs(lessons); !slices.Equal(got, tt.want) {
+				t.Errorf("lessons = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,411 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+type ScheduleImportOptions struct {
+	DryRun   bool
+	Semester int
+}
+
+type ScheduleImportService struct {
+	ScheduleRepo       repository.ISchedule
+	GroupRepo          repository.IGroup
+	DisciplineRepo     repository.IDiscipline
+	DisciplineTypeRepo repository.IDisciplineType
+	TeacherRepo        repository.ITeacher
+	ClassroomRepo      repository.IClassroom
+	LessonSlotRepo     repository.ILessonSlot
+	CurriculumRepo     repository.ICurriculum
+}
+
+func NewScheduleImportService(
+	ScheduleRepo repository.ISchedule,
+	GroupRepo repository.IGroup,
+	DisciplineRepo repository.IDiscipline,
+	DisciplineTypeRepo repository.IDisciplineType,
+	TeacherRepo repository.ITeacher,
+	ClassroomRepo repository.IClassroom,
+	LessonSlotRepo repository.ILessonSlot,
+	CurriculumRepo repository.ICurriculum,
+) *ScheduleImportService {
+	return &ScheduleImportService{
+		ScheduleRepo:       ScheduleRepo,
+		GroupRepo:          GroupRepo,
+		DisciplineRepo:     DisciplineRepo,
+		DisciplineTypeRepo: DisciplineTypeRepo,
+		TeacherRepo:        TeacherRepo,
+		ClassroomRepo:      ClassroomRepo,
+		LessonSlotRepo:     LessonSlotRepo,
+		CurriculumRepo:     CurriculumRepo,
+	}
+}
+
+// Import resolves the names of every row, checks the rows for conflicts with each
+// other and with the actual timetable and, unless it is a dry run, inserts all
+// schedules in one transaction
+func (s *ScheduleImportService) Import(ctx context.Context, batch domain.ScheduleImportBatch, opts ScheduleImportOptions) (domain.ScheduleImportResult, error) {
+	result := domain.ScheduleImportResult{
+		DryRun: opts.DryRun,
+		Total:  batch.Total,
+		Errors: append([]domain.ImportError{}, batch.Errors...),
+	}
+
+	resolver := newScheduleResolver(s)
+	schedules := make([]domain.Schedule, 0, len(batch.Rows))
+	rows := make([]int, 0, len(batch.Rows))
+	for _, row := range batch.Rows {
+		schedule, rowErrors, err := resolver.resolve(ctx, row, opts.Semester)
+		if err != nil {
+			return result, err
+		}
+		if len(rowErrors) > 0 {
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+		schedules = append(schedules, schedule)
+		rows = append(rows, row.Row)
+	}
+
+	conflicts, err := s.findConflicts(ctx, schedules, rows)
+	if err != nil {
+		return result, err
+	}
+	result.Errors = append(result.Errors, conflicts...)
+	result.Valid = batch.Total - countRows(result.Errors)
+	result.Schedules = schedules
+
+	if opts.DryRun {
+		return result, nil
+	}
+	if len(result.Errors) > 0 {
+		return result, ErrImportHasErrors
+	}
+	if len(schedules) == 0 {
+		return result, ErrImportEmpty
+	}
+
+	if err := s.ScheduleRepo.CreateMany(ctx, schedules); err != nil {
+		return result, err
+	}
+	result.Created = len(schedules)
+
+	return result, nil
+}
+
+// findConflicts reports rows that put a group, a teacher or a classroom into two
+// lessons at the same time, inside the file or against the actual timetable
+func (s *ScheduleImportService) findConflicts(ctx context.Context, schedules []domain.Schedule, rows []int) ([]domain.ImportError, error) {
+	existing, err := s.ScheduleRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	busy := make(map[string]string)
+	for _, scheduleInfo := range existing {
+		schedule := scheduleInfo.Schedule
+		if schedule.IsActual == nil || !*schedule.IsActual {
+			continue
+		}
+		for _, slot := range scheduleSlots(schedule) {
+			busy[slot.key] = "schedule " + strconv.FormatInt(schedule.ScheduleID, 10)
+		}
+	}
+
+	var conflicts []domain.ImportError
+	for i, schedule := range schedules {
+		for _, slot := range scheduleSlots(schedule) {
+			if owner, ok := busy[slot.key]; ok {
+				conflicts = append(conflicts, domain.ImportError{Row: rows[i], Field: slot.field, Message: "conflicts with " + owner})
+				continue
+			}
+			busy[slot.key] = "row " + strconv.Itoa(rows[i])
+		}
+	}
+
+	return conflicts, nil
+}
+
+// scheduleSlot is a group, a teacher or a classroom taken at a lesson time
+type scheduleSlot struct {
+	field string
+	key   string
+}
+
+func scheduleSlots(schedule domain.Schedule) []scheduleSlot {
+	lesson := schedule.WeekType + "|" + schedule.DayOfWeek + "|" + schedule.StartTime.Format("15:04") + "|"
+	return []scheduleSlot{
+		{field: "group_id", key: lesson + "group|" + schedule.GroupID},
+		{field: "teacher", key: lesson + "teacher|" + strconv.FormatInt(schedule.TeacherID, 10)},
+		{field: "classroom", key: lesson + "classroom|" + strconv.FormatInt(schedule.ClassroomID, 10)},
+	}
+}
+
+// scheduleResolver caches the names already looked up during one import
+type scheduleResolver struct {
+	service         *ScheduleImportService
+	groups          map[string]bool
+	disciplines     map[string]int64
+	disciplineTypes map[string]int64
+	classrooms      map[string]int64
+	teachers        map[string]teacherMatch
+	bells           map[string][]domain.LessonSlot
+	curricula       map[string][]domain.CurriculumItemInfo
+}
+
+type teacherMatch struct {
+	teacherID int64
+	message   string
+}
+
+func newScheduleResolver(service *ScheduleImportService) *scheduleResolver {
+	return &scheduleResolver{
+		service:         service,
+		groups:          make(map[string]bool),
+		disciplines:     make(map[string]int64),
+		disciplineTypes: make(map[string]int64),
+		classrooms:      make(map[string]int64),
+		teachers:        make(map[string]teacherMatch),
+		bells:           make(map[string][]domain.LessonSlot),
+		curricula:       make(map[string][]domain.CurriculumItemInfo),
+	}
+}
+
+func (r *scheduleResolver) resolve(ctx context.Context, row domain.ScheduleImportRow, semester int) (domain.Schedule, []domain.ImportError, error) {
+	var rowErrors []domain.ImportError
+	notFound := func(field, value string) {
+		rowErrors = append(rowErrors, domain.ImportError{Row: row.Row, Field: field, Message: value + " not found"})
+	}
+
+	isActual := true
+	schedule := domain.Schedule{
+		GroupID:   row.GroupID,
+		Semester:  semester,
+		WeekType:  row.WeekType,
+		DayOfWeek: row.DayOfWeek,
+		StartTime: row.StartTime,
+		IsActual:  &isActual,
+	}
+
+	groupExists, err := r.group(ctx, row.GroupID)
+	if err != nil {
+		return schedule, nil, err
+	}
+	if !groupExists {
+		notFound("group_id", "group "+row.GroupID)
+	} else {
+		slot, err := r.slot(ctx, row.GroupID, row.StartTime)
+		if err != nil {
+			return schedule, nil, err
+		}
+		if slot == nil {
+			rowErrors = append(rowErrors, domain.ImportError{Row: row.Row, Field: "start_time", Message: "no lesson slot starts at " + row.StartTime.Format("15:04")})
+		} else if slot.SlotID != 0 {
+			schedule.SlotID = &slot.SlotID
+		}
+	}
+
+	if schedule.DisciplineID, err = r.discipline(ctx, row.Discipline); err != nil {
+		return schedule, nil, err
+	}
+	if schedule.DisciplineID == 0 {
+		notFound("discipline", "discipline "+row.Discipline)
+	}
+
+	if schedule.DisciplineTypeID, err = r.disciplineType(ctx, row.DisciplineType); err != nil {
+		return schedule, nil, err
+	}
+	if schedule.DisciplineTypeID == 0 {
+		notFound("discipline_type", "discipline type "+row.DisciplineType)
+	}
+
+	if groupExists && schedule.DisciplineID != 0 && schedule.DisciplineTypeID != 0 {
+		planned, err := r.planned(ctx, schedule)
+		if err != nil {
+			return schedule, nil, err
+		}
+		if !planned {
+			rowErrors = append(rowErrors, domain.ImportError{Row: row.Row, Field: "discipline", Message: row.Discipline + " (" + row.DisciplineType + ") is not in the curriculum of the group for semester " + strconv.Itoa(semester)})
+		}
+	}
+
+	if schedule.ClassroomID, err = r.classroom(ctx, row.Classroom); err != nil {
+		return schedule, nil, err
+	}
+	if schedule.ClassroomID == 0 {
+		notFound("classroom", "classroom "+row.Classroom)
+	}
+
+	teacher, err := r.teacher(ctx, row.Teacher)
+	if err != nil {
+		return schedule, nil, err
+	}
+	if teacher.message != "" {
+		rowErrors = append(rowErrors, domain.ImportError{Row: row.Row, Field: "teacher", Message: teacher.message})
+	}
+	schedule.TeacherID = teacher.teacherID
+
+	return schedule, rowErrors, nil
+}
+
+// slot finds the slot of the bell schedule of the group's university by the start time,
+// any time fits a university without a bell schedule and gets an empty slot
+func (r *scheduleResolver) slot(ctx context.Context, groupID string, startTime time.Time) (*domain.LessonSlot, error) {
+	slots, ok := r.bells[groupID]
+	if !ok {
+		var err error
+		if slots, err = r.service.LessonSlotRepo.GetByGroupID(ctx, groupID); err != nil {
+			return nil, err
+		}
+		r.bells[groupID] = slots
+	}
+	if len(slots) == 0 {
+		return &domain.LessonSlot{}, nil
+	}
+	for i := range slots {
+		if clock(slots[i].StartTime) == clock(startTime) {
+			return &slots[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// planned checks the discipline against the curriculum of the group in the semester of the import
+func (r *scheduleResolver) planned(ctx context.Context, schedule domain.Schedule) (bool, error) {
+	items, ok := r.curricula[schedule.GroupID]
+	if !ok {
+		var err error
+		if items, err = r.service.CurriculumRepo.GetByGroupAndSemester(ctx, schedule.GroupID, schedule.Semester); err != nil {
+			return false, err
+		}
+		r.curricula[schedule.GroupID] = items
+	}
+	return inCurriculum(items, schedule.DisciplineID, schedule.DisciplineTypeID), nil
+}
+
+func (r *scheduleResolver) group(ctx context.Context, groupID string) (bool, error) {
+	if exists, ok := r.groups[groupID]; ok {
+		return exists, nil
+	}
+	_, err := r.service.GroupRepo.GetByID(ctx, groupID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return false, err
+	}
+	r.groups[groupID] = err == nil
+	return err == nil, nil
+}
+
+func (r *scheduleResolver) discipline(ctx context.Context, name string) (int64, error) {
+	if id, ok := r.disciplines[name]; ok {
+		return id, nil
+	}
+	disciplineInfo, err := r.service.DisciplineRepo.GetByName(ctx, name)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return 0, err
+	}
+	r.disciplines[name] = disciplineInfo.Discipline.DisciplineID
+	return disciplineInfo.Discipline.DisciplineID, nil
+}
+
+func (r *scheduleResolver) disciplineType(ctx context.Context, name string) (int64, error) {
+	if id, ok := r.disciplineTypes[name]; ok {
+		return id, nil
+	}
+	disciplineType, err := r.service.DisciplineTypeRepo.GetByName(ctx, name)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return 0, err
+	}
+	r.disciplineTypes[name] = disciplineType.DisciplineTypeID
+	return disciplineType.DisciplineTypeID, nil
+}
+
+func (r *scheduleResolver) classroom(ctx context.Context, name string) (int64, error) {
+	if id, ok := r.classrooms[name]; ok {
+		return id, nil
+	}
+	classroom, err := r.service.ClassroomRepo.GetByName(ctx, name)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return 0, err
+	}
+	r.classrooms[name] = classroom.ClassroomID
+	return classroom.ClassroomID, nil
+}
+
+// teacher accepts an email or a name like "Иванов И.И." or "Иванов Иван Иванович"
+func (r *scheduleResolver) teacher(ctx context.Context, value string) (teacherMatch, error) {
+	if match, ok := r.teachers[value]; ok {
+		return match, nil
+	}
+
+	var match teacherMatch
+	if strings.Contains(value, "@") {
+		teacherInfo, err := r.service.TeacherRepo.GetByEmail(ctx, value)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return match, err
+		}
+		if err != nil {
+			match.message = "teacher " + value + " not found"
+		}
+		match.teacherID = teacherInfo.Teacher.TeacherID
+		r.teachers[value] = match
+		return match, nil
+	}
+
+	parts := strings.FieldsFunc(value, func(r rune) bool { return r == ' ' || r == '.' })
+	if len(parts) == 0 {
+		match.message = "teacher is empty"
+		return match, nil
+	}
+
+	candidates, err := r.service.TeacherRepo.GetAllByLastName(ctx, parts[0])
+	if err != nil {
+		return match, err
+	}
+
+	var found []domain.Teacher
+	for _, candidate := range candidates {
+		teacher := candidate.Teacher
+		if len(parts) > 1 && !nameMatches(teacher.FirstName, parts[1]) {
+			continue
+		}
+		if len(parts) > 2 && !nameMatches(teacher.MiddleName, parts[2]) {
+			continue
+		}
+		found = append(found, teacher)
+	}
+
+	switch len(found) {
+	case 0:
+		match.message = "teacher " + value + " not found"
+	case 1:
+		match.teacherID = found[0].TeacherID
+	default:
+		emails := make([]string, 0, len(found))
+		for _, teacher := range found {
+			emails = append(emails, teacher.TeacherEmail)
+		}
+		match.message = "teacher " + value + " is ambiguous, use one of the emails: " + strings.Join(emails, ", ")
+	}
+
+	r.teachers[value] = match
+	return match, nil
+}
+
+// nameMatches compares a name with a full name or with an initial
+func nameMatches(name, value string) bool {
+	if utf8.RuneCountInString(value) == 1 {
+		first, _ := utf8.DecodeRuneInString(name)
+		return strings.EqualFold(string(first), value)
+	}
+	return strings.EqualFold(name, value)
+}
@@ -0,0 +1,199 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+)
+
+// newScheduleImportService returns the service over the university of the groups with
+// the slots at 08:30 and 10:10, the lectures on Математика and the labs on Физика in the
+// curriculum of the first semester, the namesake teachers Новикова 2 and 3, and the
+// actual schedule 1 of the second group with teacher 2 in classroom 202 on upper Mondays at 08:30
+func newScheduleImportService(t *testing.T) (*service.ScheduleImportService, *repository.Repositories) {
+	t.Helper()
+	ctx := context.Background()
+	repos := newRepos(t)
+	createUniversity(t, repos)
+
+	for i, start := range []string{"08:30", "10:10"} {
+		slot := domain.LessonSlot{UniversityID: 1, SlotNumber: i + 1, StartTime: clockAt(start), EndTime: clockAt(start).Add(90 * time.Minute)}
+		if _, err := repos.LessonSlot.Create(ctx, slot); err != nil {
+			t.Fatalf("create slot: %v", err)
+		}
+	}
+	for _, name := range []string{"Математика", "Физика"} {
+		if err := repos.Discipline.Create(ctx, domain.Discipline{DepartamentID: 1, DisciplineName: name}); err != nil {
+			t.Fatalf("create discipline: %v", err)
+		}
+	}
+	for _, name := range []string{"Лекция", "Лабораторная работа"} {
+		if err := repos.DisciplineType.Create(ctx, domain.DisciplineType{DisciplineTypeName: name}); err != nil {
+			t.Fatalf("create discipline type: %v", err)
+		}
+	}
+	for _, item := range []domain.CurriculumItem{
+		{ProfileID: 1, Semester: 1, DisciplineID: 1, DisciplineTypeID: 1, PlannedHours: 36},
+		{ProfileID: 1, Semester: 1, DisciplineID: 2, DisciplineTypeID: 2, PlannedHours: 18},
+	} {
+		if _, err := repos.Curriculum.Create(ctx, item); err != nil {
+			t.Fatalf("create curriculum item: %v", err)
+		}
+	}
+	for _, name := range []string{"101", "202"} {
+		if err := repos.Classroom.Create(ctx, domain.Classroom{ClassroomName: name, Capacity: 30, Building: "1"}); err != nil {
+			t.Fatalf("create classroom: %v", err)
+		}
+	}
+	for _, teacher := range []domain.Teacher{
+		{DepartamentID: 1, LastName: "Кузнецов", FirstName: "Иван", MiddleName: "Петрович", TeacherEmail: "kuznetsov@example.com"},
+		{DepartamentID: 1, LastName: "Новикова", FirstName: "Анна", MiddleName: "Игоревна", TeacherEmail: "novikova.a@example.com"},
+		{DepartamentID: 1, LastName: "Новикова", FirstName: "Алла", MiddleName: "Сергеевна", TeacherEmail: "novikova.s@example.com"},
+	} {
+		if err := repos.Teacher.Create(ctx, teacher); err != nil {
+			t.Fatalf("create teacher: %v", err)
+		}
+	}
+
+	actual := true
+	schedule := domain.Schedule{GroupID: otherGroup, DisciplineID: 1, TeacherID: 2, DisciplineTypeID: 1, ClassroomID: 2, Semester: 1,
+		WeekType: "Верхняя", DayOfWeek: "Понедельник", StartTime: clockAt("08:30"), IsActual: &actual}
+	if err := repos.Schedule.Create(ctx, schedule); err != nil {
+		t.Fatalf("create schedule: %v", err)
+	}
+
+	return service.NewScheduleImportService(repos.Schedule, repos.Group, repos.Discipline, repos.DisciplineType,
+		repos.Teacher, repos.Classroom, repos.LessonSlot, repos.Curriculum), repos
+}
+
+// lessonRow is a row of an upper Monday
+func lessonRow(row int, groupID, start, discipline, disciplineType, teacher, classroom string) domain.ScheduleImportRow {
+	return domain.ScheduleImportRow{Row: row, GroupID: groupID, DayOfWeek: "Понедельник", WeekType: "Верхняя", StartTime: clockAt(start),
+		Discipline: discipline, DisciplineType: disciplineType, Teacher: teacher, Classroom: classroom}
+}
+
+func TestScheduleImportServiceImport(t *testing.T) {
+	tests := []struct {
+		name        string
+		rows        []domain.ScheduleImportRow
+		dryRun      bool
+		wantErr     error
+		wantErrors  []domain.ImportError
+		wantCreated int
+	}{
+		{
+			name: "teacher by initials and by email",
+			rows: []domain.ScheduleImportRow{
+				lessonRow(2, testGroup, "08:30", "Математика", "Лекция", "Кузнецов И.П.", "101"),
+				lessonRow(3, testGroup, "10:10", "Физика", "Лабораторная работа", "novikova.s@example.com", "202"),
+			},
+			wantCreated: 2,
+		},
+		{
+			name:       "unknown group",
+			rows:       []domain.ScheduleImportRow{lessonRow(2, "2023-35.03.06-9", "10:10", "Математика", "Лекция", "Кузнецов И.П.", "101")},
+			wantErr:    service.ErrImportHasErrors,
+			wantErrors: []domain.ImportError{{Row: 2, Field: "group_id", Message: "group 2023-35.03.06-9 not found"}},
+		},
+		{
+			name:       "time outside the bell schedule",
+			rows:       []domain.ScheduleImportRow{lessonRow(2, testGroup, "09:00", "Математика", "Лекция", "Кузнецов И.П.", "101")},
+			wantErr:    service.ErrImportHasErrors,
+			wantErrors: []domain.ImportError{{Row: 2, Field: "start_time", Message: "no lesson slot starts at 09:00"}},
+		},
+		{
+			name: "unknown names",
+			rows: []domain.ScheduleImportRow{lessonRow(2, testGroup, "10:10", "Химия", "Семинар", "Смирнов", "303")},
+			wantErrors: []domain.ImportError{
+				{Row: 2, Field: "discipline", Message: "discipline Химия not found"},
+				{Row: 2, Field: "discipline_type", Message: "discipline type Семинар not found"},
+				{Row: 2, Field: "classroom", Message: "classroom 303 not found"},
+				{Row: 2, Field: "teacher", Message: "teacher Смирнов not found"},
+			},
+			wantErr: service.ErrImportHasErrors,
+		},
+		{
+			name:       "discipline outside the curriculum",
+			rows:       []domain.ScheduleImportRow{lessonRow(2, testGroup, "10:10", "Физика", "Лекция", "Кузнецов И.П.", "101")},
+			wantErr:    service.ErrImportHasErrors,
+			wantErrors: []domain.ImportError{{Row: 2, Field: "discipline", Message: "Физика (Лекция) is not in the curriculum of the group for semester 1"}},
+		},
+		{
+			name:    "ambiguous teacher",
+			rows:    []domain.ScheduleImportRow{lessonRow(2, testGroup, "10:10", "Математика", "Лекция", "Новикова А.", "101")},
+			wantErr: service.ErrImportHasErrors,
+			wantErrors: []domain.ImportError{{Row: 2, Field: "teacher",
+				Message: "teacher Новикова А. is ambiguous, use one of the emails: novikova.a@example.com, novikova.s@example.com"}},
+		},
+		{
+			name:       "teacher busy in the timetable",
+			rows:       []domain.ScheduleImportRow{lessonRow(2, testGroup, "08:30", "Математика", "Лекция", "Новикова Анна Игоревна", "101")},
+			wantErr:    service.ErrImportHasErrors,
+			wantErrors: []domain.ImportError{{Row: 2, Field: "teacher", Message: "conflicts with schedule 1"}},
+		},
+		{
+			name: "group and classroom busy in the file",
+			rows: []domain.ScheduleImportRow{
+				lessonRow(2, testGroup, "10:10", "Математика", "Лекция", "Кузнецов И.П.", "101"),
+				lessonRow(3, testGroup, "10:10", "Физика", "Лабораторная работа", "novikova.s@example.com", "101"),
+			},
+			dryRun: true,
+			wantErrors: []domain.ImportError{
+				{Row: 3, Field: "group_id", Message: "conflicts with row 2"},
+				{Row: 3, Field: "classroom", Message: "conflicts with row 2"},
+			},
+		},
+		{
+			name:    "empty file",
+			wantErr: service.ErrImportEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			imports, repos := newScheduleImportService(t)
+
+			batch := domain.ScheduleImportBatch{Total: len(tt.rows), Rows: tt.rows}
+			result, err := imports.Import(ctx, batch, service.ScheduleImportOptions{DryRun: tt.dryRun, Semester: 1})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Import() error = %v, want %v", err, tt.wantErr)
+			}
+			if result.Created != tt.wantCreated {
+				t.Errorf("created = %d, want %d", result.Created, tt.wantCreated)
+			}
+			if len(result.Errors) != len(tt.wantErrors) {
+				t.Fatalf("errors = %+v, want %+v", result.Errors, tt.wantErrors)
+			}
+			for i := range tt.wantErrors {
+				if result.Errors[i] != tt.wantErrors[i] {
+					t.Errorf("error %d = %+v, want %+v", i, result.Errors[i], tt.wantErrors[i])
+				}
+			}
+
+			schedules, err := repos.Schedule.GetByGroupID(ctx, testGroup)
+			if err != nil {
+				t.Fatalf("GetByGroupID() error = %v", err)
+			}
+			if len(schedules) != tt.wantCreated {
+				t.Fatalf("schedules of the group = %d, want %d", len(schedules), tt.wantCreated)
+			}
+			// the imported rows take the slot of their start time
+			for _, info := range schedules {
+				stored, err := repos.Schedule.GetByID(ctx, info.Schedule.ScheduleID)
+				if err != nil {
+					t.Fatalf("GetByID() error = %v", err)
+				}
+				schedule := stored.Schedule
+				if schedule.SlotID == nil || schedule.Semester != 1 || schedule.IsActual == nil || !*schedule.IsActual {
+					t.Errorf("imported schedule = %+v", schedule)
+				}
+			}
+		})
+	}
+}
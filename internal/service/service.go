@@ -5,16 +5,22 @@ import (
 
 	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
 	"github.com/BeRebornBng/OsauAmsApi/pkg/auth"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/mailer"
 	"github.com/BeRebornBng/OsauAmsApi/pkg/myhash"
 )
 
+const tracerName = "github.com/BeRebornBng/OsauAmsApi/internal/service"
+
 // support structs
 
 type Support struct {
 	Repos          *repository.Repositories
 	Hasher         myhash.PasswordHasher
 	TokenManager   auth.TokenManager
+	LoginGuard     *LoginGuard
+	Mailer         mailer.Sender
 	AccessTokenTTL time.Duration
+	ResetTokenTTL  time.Duration
 }
 
 type Tokens struct {
@@ -22,64 +28,100 @@ type Tokens struct {
 }
 
 type Services struct {
-	ReportService         *ReportService
-	HeadmanService        *HeadmanService
-	StudentService        *StudentService
-	ScheduleService       *ScheduleService
-	AttendanceService     *AttendanceService
-	UserService           *UserService
-	UniversityService     *UniversityService
-	FacultyService        *FacultyService
-	DepartamentService    *DepartamentService
-	TeacherService        *TeacherService
-	DisciplineService     *DisciplineService
-	DisciplineTypeService *DisciplineTypeService
-	ClassroomService      *ClassroomService
-	EducationLevelService *EducationLevelService
-	SpecialtyService      *SpecialtyService
-	ProfileService        *ProfileService
-	GroupService          *GroupService
-	EducationTypeService  *EducationTypeService
+	ReportService            *ReportService
+	HeadmanService           *HeadmanService
+	StudentService           *StudentService
+	ScheduleService          *ScheduleService
+	AttendanceService        *AttendanceService
+	ScheduleExceptionService *ScheduleExceptionService
+	CalendarService          *CalendarService
+	LessonSlotService        *LessonSlotService
+	SubgroupService          *SubgroupService
+	CurriculumService        *CurriculumService
+	WorkloadService          *WorkloadService
+	GradebookService         *GradebookService
+	UserService              *UserService
+	UniversityService        *UniversityService
+	FacultyService           *FacultyService
+	DepartamentService       *DepartamentService
+	TeacherService           *TeacherService
+	DisciplineService        *DisciplineService
+	DisciplineTypeService    *DisciplineTypeService
+	ClassroomService         *ClassroomService
+	EducationLevelService    *EducationLevelService
+	SpecialtyService         *SpecialtyService
+	ProfileService           *ProfileService
+	GroupService             *GroupService
+	EducationTypeService     *EducationTypeService
+	PasswordResetService     *PasswordResetService
+	StudentImportService     *StudentImportService
+	ScheduleImportService    *ScheduleImportService
+	RolloverService          *RolloverService
+	MembershipService        *MembershipService
 }
 
 func NewServices(support Support) *Services {
 	reportService := NewReportService(support.Repos.Report)
-	headmanService := NewHeadmanService(support.Repos.Headman)
-	studentService := NewStudentService(support.Repos.Student)
-	scheduleService := NewScheduleService(support.Repos.Schedule)
-	attendanceService := NewAttendanceService(support.Repos.Attendance)
-	userService := NewUserService(support.TokenManager, support.Hasher, support.Repos.User, support.AccessTokenTTL)
+	headmanService := NewHeadmanService(support.Repos.Transactor, support.Repos.Headman)
+	studentService := NewStudentService(support.Repos.Transactor, support.Hasher, support.Repos.Student, support.Repos.User)
+	scheduleService := NewScheduleService(support.Repos.Schedule, support.Repos.LessonSlot, support.Repos.Subgroup, support.Repos.Curriculum)
+	attendanceService := NewAttendanceService(support.Repos.Attendance, support.Repos.Headman, support.Repos.Schedule, support.Repos.ScheduleException, support.Repos.Calendar, support.Repos.Subgroup, support.Repos.Student, support.Repos.Membership)
+	scheduleExceptionService := NewScheduleExceptionService(support.Repos.ScheduleException, support.Repos.Schedule, support.Repos.Calendar, support.Repos.LessonSlot)
+	lessonSlotService := NewLessonSlotService(support.Repos.LessonSlot)
+	subgroupService := NewSubgroupService(support.Repos.Subgroup, support.Repos.Student)
+	curriculumService := NewCurriculumService(support.Repos.Curriculum, support.Repos.Schedule, support.Repos.Calendar)
+	workloadService := NewWorkloadService(support.Repos.Schedule, support.Repos.Teacher, support.Repos.Calendar, support.Repos.Workload)
+	gradebookService := NewGradebookService(support.Repos.Gradebook, support.Repos.Schedule, support.Repos.ScheduleException, support.Repos.Student, support.Repos.Subgroup, support.Repos.Discipline)
+	calendarService := NewCalendarService(support.Repos.Calendar)
+	userService := NewUserService(support.TokenManager, support.Hasher, support.Repos.User, support.LoginGuard, support.AccessTokenTTL)
 	universityService := NewUniversityService(support.Repos.University)
 	facultyService := NewFacultyService(support.Repos.Faculty)
 	departamentService := NewDepartamentService(support.Repos.Departament)
 	teacherService := NewTeacherService(support.Repos.Teacher)
 	disciplineService := NewDisciplineService(support.Repos.Discipline)
 	disciplineTypeService := NewDisciplineTypeService(support.Repos.DisciplineType)
-	classroomService := NewClassroomService(support.Repos.Classroom)
+	classroomService := NewClassroomService(support.Repos.Classroom, support.Repos.Student)
 	educationLevelService := NewEducationLevelService(support.Repos.EducationLevel)
 	specialtyService := NewSpecialtyService(support.Repos.Specialty)
 	profileService := NewProfileService(support.Repos.Profile)
-	groupService := NewGroupService(support.Repos.Group)
+	groupService := NewGroupService(support.Repos.Transactor, support.Repos.Group, support.Repos.Schedule, support.Repos.Attendance)
 	educationTypeService := NewEducationTypeService(support.Repos.EducationType)
+	passwordResetService := NewPasswordResetService(support.Repos.Transactor, support.Hasher, support.Repos.User, support.Repos.Teacher, support.Repos.PasswordReset, support.Mailer, support.ResetTokenTTL)
+	studentImportService := NewStudentImportService(support.Hasher, support.Repos.Student, support.Repos.Group, support.Repos.User)
+	scheduleImportService := NewScheduleImportService(support.Repos.Schedule, support.Repos.Group, support.Repos.Discipline, support.Repos.DisciplineType, support.Repos.Teacher, support.Repos.Classroom, support.Repos.LessonSlot, support.Repos.Curriculum)
+	rolloverService := NewRolloverService(support.Repos.Schedule, support.Repos.Classroom, support.Repos.Teacher)
+	membershipService := NewMembershipService(support.Repos.Membership, support.Repos.Student, support.Repos.Group)
 
 	return &Services{
-		ReportService:         reportService,
-		HeadmanService:        headmanService,
-		StudentService:        studentService,
-		ScheduleService:       scheduleService,
-		AttendanceService:     attendanceService,
-		UserService:           userService,
-		UniversityService:     universityService,
-		FacultyService:        facultyService,
-		DepartamentService:    departamentService,
-		TeacherService:        teacherService,
-		DisciplineService:     disciplineService,
-		DisciplineTypeService: disciplineTypeService,
-		ClassroomService:      classroomService,
-		EducationLevelService: educationLevelService,
-		SpecialtyService:      specialtyService,
-		ProfileService:        profileService,
-		GroupService:          groupService,
-		EducationTypeService:  educationTypeService,
+		ReportService:            reportService,
+		HeadmanService:           headmanService,
+		StudentService:           studentService,
+		ScheduleService:          scheduleService,
+		AttendanceService:        attendanceService,
+		ScheduleExceptionService: scheduleExceptionService,
+		CalendarService:          calendarService,
+		LessonSlotService:        lessonSlotService,
+		SubgroupService:          subgroupService,
+		CurriculumService:        curriculumService,
+		WorkloadService:          workloadService,
+		GradebookService:         gradebookService,
+		UserService:              userService,
+		UniversityService:        universityService,
+		FacultyService:           facultyService,
+		DepartamentService:       departamentService,
+		TeacherService:           teacherService,
+		DisciplineService:        disciplineService,
+		DisciplineTypeService:    disciplineTypeService,
+		ClassroomService:         classroomService,
+		EducationLevelService:    educationLevelService,
+		SpecialtyService:         specialtyService,
+		ProfileService:           profileService,
+		GroupService:             groupService,
+		EducationTypeService:     educationTypeService,
+		PasswordResetService:     passwordResetService,
+		StudentImportService:     studentImportService,
+		ScheduleImportService:    scheduleImportService,
+		RolloverService:          rolloverService,
+		MembershipService:        membershipService,
 	}
 }
@@ -2,21 +2,66 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
 	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/myhash"
+	"github.com/jackc/pgx/v5"
 )
 
 type StudentService struct {
+	Transactor  repository.ITransactor
+	Hasher      myhash.PasswordHasher
 	StudentRepo repository.IStudent
+	UserRepo    repository.IUser
 }
 
-func NewStudentService(studentRepo repository.IStudent) *StudentService {
-	return &StudentService{StudentRepo: studentRepo}
+func NewStudentService(transactor repository.ITransactor, hasher myhash.PasswordHasher, studentRepo repository.IStudent, userRepo repository.IUser) *StudentService {
+	return &StudentService{
+		Transactor:  transactor,
+		Hasher:      hasher,
+		StudentRepo: studentRepo,
+		UserRepo:    userRepo,
+	}
 }
 
 func (s *StudentService) Create(ctx context.Context, student domain.Student) error {
-	return s.StudentRepo.Create(ctx, student)
+	_, err := s.StudentRepo.Create(ctx, student)
+	return err
+}
+
+// CreateWithAccount creates the student and the user account of the student in one
+// transaction, the student isn't stored when the username is taken
+func (s *StudentService) CreateWithAccount(ctx context.Context, student domain.Student, username, password string) (int64, error) {
+	hashpassword, err := s.Hasher.HashPassword(password)
+	if err != nil {
+		return 0, err
+	}
+
+	var studentID int64
+	err = s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.UserRepo.GetByName(ctx, username); err == nil {
+			return ErrUserNameExists
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		studentID, err = s.StudentRepo.Create(ctx, student)
+		if err != nil {
+			return err
+		}
+		return s.UserRepo.Create(ctx, domain.User{
+			Username:  username,
+			Password:  hashpassword,
+			Role:      studentRole,
+			StudentID: &studentID,
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return studentID, nil
 }
 
 func (s *StudentService) Put(ctx context.Context, student domain.Student) error {
@@ -0,0 +1,42 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/myhash"
+)
+
+func TestStudentServiceCreateWithAccount(t *testing.T) {
+	repos := newRepos(t)
+	ctx := context.Background()
+	students := service.NewStudentService(repos.Transactor, myhash.NewHasher("salt", 4), repos.Student, repos.User)
+	student := domain.Student{GroupID: otherGroup, LastName: "Кузнецов", FirstName: "Иван", MiddleName: "Иванович"}
+
+	studentID, err := students.CreateWithAccount(ctx, student, "kuznetsov", "Password1!")
+	if err != nil {
+		t.Fatalf("CreateWithAccount() error = %v", err)
+	}
+	user, err := repos.User.GetByStudentID(ctx, studentID)
+	if err != nil {
+		t.Fatalf("account of the student: %v", err)
+	}
+	if user.User.Username != "kuznetsov" || user.User.Role != "Студент" || user.User.Password == "Password1!" {
+		t.Errorf("account = %+v", user.User)
+	}
+
+	// the student isn't stored without the account
+	if _, err := students.CreateWithAccount(ctx, student, "kuznetsov", "Password1!"); !errors.Is(err, service.ErrUserNameExists) {
+		t.Fatalf("CreateWithAccount() with a taken username error = %v, want %v", err, service.ErrUserNameExists)
+	}
+	groupStudents, err := repos.Student.GetAllByGroupID(ctx, otherGroup)
+	if err != nil {
+		t.Fatalf("GetAllByGroupID() error = %v", err)
+	}
+	if len(groupStudents) != 1 {
+		t.Errorf("students of the group = %d, want 1", len(groupStudents))
+	}
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/myhash"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	studentRole            = "Студент"
+	generatedPasswordChars = "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz23456789"
+	generatedPasswordLen   = 12
+	minUsernameLen         = 8
+	maxUsernameLen         = 40
+	credentialsTTL         = 24 * time.Hour
+)
+
+type StudentImportOptions struct {
+	DryRun         bool
+	CreateAccounts bool
+}
+
+type StudentImportService struct {
+	Hasher      myhash.PasswordHasher
+	StudentRepo repository.IStudent
+	GroupRepo   repository.IGroup
+	UserRepo    repository.IUser
+	credentials *credentialStore
+}
+
+func NewStudentImportService(
+	Hasher myhash.PasswordHasher,
+	StudentRepo repository.IStudent,
+	GroupRepo repository.IGroup,
+	UserRepo repository.IUser,
+) *StudentImportService {
+	return &StudentImportService{
+		Hasher:      Hasher,
+		StudentRepo: StudentRepo,
+		GroupRepo:   GroupRepo,
+		UserRepo:    UserRepo,
+		credentials: newCredentialStore(credentialsTTL),
+	}
+}
+
+// Import checks every row against the database and, unless it is a dry run,
+// creates all students in one transaction. Rows are only written when the whole
+// file is valid
+func (s *StudentImportService) Import(ctx context.Context, batch domain.StudentImportBatch, opts StudentImportOptions) (domain.StudentImportResult, error) {
+	result := domain.StudentImportResult{
+		DryRun: opts.DryRun,
+		Total:  batch.Total,
+		Errors: append([]domain.ImportError{}, batch.Errors...),
+	}
+
+	rowErrors, err := s.checkRows(ctx, batch.Rows)
+	if err != nil {
+		return result, err
+	}
+	result.Errors = append(result.Errors, rowErrors...)
+	result.Valid = batch.Total - countRows(result.Errors)
+
+	if opts.DryRun {
+		return result, nil
+	}
+	if len(result.Errors) > 0 {
+		return result, ErrImportHasErrors
+	}
+	if len(batch.Rows) == 0 {
+		return result, ErrImportEmpty
+	}
+
+	accounts := make([]domain.StudentAccount, 0, len(batch.Rows))
+	credentials := make([]domain.StudentCredential, 0, len(batch.Rows))
+	usernames := make(map[string]struct{}, len(batch.Rows))
+	for _, row := range batch.Rows {
+		account := domain.StudentAccount{Student: row.Student}
+		if opts.CreateAccounts {
+			credential, user, err := s.newAccount(ctx, row.Student, usernames)
+			if err != nil {
+				return result, err
+			}
+			account.User = &user
+			credentials = append(credentials, credential)
+		}
+		accounts = append(accounts, account)
+	}
+
+	studentIDs, err := s.StudentRepo.CreateWithAccounts(ctx, accounts)
+	if err != nil {
+		return result, err
+	}
+	result.Created = len(studentIDs)
+
+	if opts.CreateAccounts {
+		for i := range credentials {
+			credentials[i].StudentID = studentIDs[i]
+		}
+		result.CredentialsID, err = s.credentials.put(credentials)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// TakeCredentials returns the generated logins of an import and forgets them
+func (s *StudentImportService) TakeCredentials(credentialsID string) ([]domain.StudentCredential, error) {
+	credentials, ok := s.credentials.take(credentialsID)
+	if !ok {
+		return nil, ErrCredentialsNotFound
+	}
+	return credentials, nil
+}
+
+func (s *StudentImportService) checkRows(ctx context.Context, rows []domain.StudentImportRow) ([]domain.ImportError, error) {
+	var rowErrors []domain.ImportError
+	groups := make(map[string]bool)
+	seen := make(map[string]int, len(rows))
+
+	for _, row := range rows {
+		student := row.Student
+
+		exists, ok := groups[student.GroupID]
+		if !ok {
+			_, err := s.GroupRepo.GetByID(ctx, student.GroupID)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return nil, err
+			}
+			exists = err == nil
+			groups[student.GroupID] = exists
+		}
+		if !exists {
+			rowErrors = append(rowErrors, domain.ImportError{Row: row.Row, Field: "group_id", Message: "group " + student.GroupID + " does not exist"})
+			continue
+		}
+
+		key := strings.ToLower(student.GroupID + "|" + student.LastName + "|" + student.FirstName + "|" + student.MiddleName)
+		if first, ok := seen[key]; ok {
+			rowErrors = append(rowErrors, domain.ImportError{Row: row.Row, Message: "duplicates row " + strconv.Itoa(first)})
+			continue
+		}
+		seen[key] = row.Row
+
+		existing, err := s.StudentRepo.GetByName(ctx, student.LastName, student.FirstName, student.MiddleName)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		if err == nil && existing.GroupID == student.GroupID {
+			rowErrors = append(rowErrors, domain.ImportError{Row: row.Row, Message: ErrStudentExists.Error()})
+		}
+	}
+
+	return rowErrors, nil
+}
+
+func (s *StudentImportService) newAccount(ctx context.Context, student domain.Student, taken map[string]struct{}) (domain.StudentCredential, domain.User, error) {
+	username, err := s.uniqueUsername(ctx, student, taken)
+	if err != nil {
+		return domain.StudentCredential{}, domain.User{}, err
+	}
+
+	password, err := generatePassword()
+	if err != nil {
+		return domain.StudentCredential{}, domain.User{}, err
+	}
+	hashpassword, err := s.Hasher.HashPassword(password)
+	if err != nil {
+		return domain.StudentCredential{}, domain.User{}, err
+	}
+
+	credential := domain.StudentCredential{
+		GroupID:    student.GroupID,
+		LastName:   student.LastName,
+		FirstName:  student.FirstName,
+		MiddleName: student.MiddleName,
+		Username:   username,
+		Password:   password,
+	}
+	user := domain.User{
+		Username: username,
+		Password: hashpassword,
+		Role:     studentRole,
+	}
+	return credential, user, nil
+}
+
+// uniqueUsername builds a login like "ivanovaa" from the full name and adds a
+// number when the login is already used in the database or in this import
+func (s *StudentImportService) uniqueUsername(ctx context.Context, student domain.Student, taken map[string]struct{}) (string, error) {
+	base := transliterate(student.LastName) + firstLetter(transliterate(student.FirstName)) + firstLetter(transliterate(student.MiddleName))
+	if base == "" {
+		base = "student"
+	}
+	if len(base) > maxUsernameLen-4 {
+		base = base[:maxUsernameLen-4]
+	}
+
+	const maxAttempts = 1000
+	for i := 0; i < maxAttempts; i++ {
+		username := base
+		if i > 0 || len(username) < minUsernameLen {
+			suffix := strconv.Itoa(i + 1)
+			for len(username)+len(suffix) < minUsernameLen {
+				username += "0"
+			}
+			username += suffix
+		}
+
+		if _, ok := taken[username]; ok {
+			continue
+		}
+		_, err := s.UserRepo.GetByName(ctx, username)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return "", err
+		}
+
+		taken[username] = struct{}{}
+		return username, nil
+	}
+
+	return "", errUsernameNotGenerated
+}
+
+func generatePassword() (string, error) {
+	password := make([]byte, generatedPasswordLen)
+	max := big.NewInt(int64(len(generatedPasswordChars)))
+	for i := range password {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		password[i] = generatedPasswordChars[n.Int64()]
+	}
+	return string(password), nil
+}
+
+var translitTable = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+}
+
+// transliterate converts a russian name to lower case latin letters and digits
+func transliterate(value string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(value) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			sb.WriteRune(r)
+		default:
+			sb.WriteString(translitTable[r])
+		}
+	}
+	return sb.String()
+}
+
+func firstLetter(value string) string {
+	if value == "" {
+		return ""
+	}
+	return value[:1]
+}
+
+func countRows(rowErrors []domain.ImportError) int {
+	rows := make(map[int]struct{}, len(rowErrors))
+	for _, rowError := range rowErrors {
+		rows[rowError.Row] = struct{}{}
+	}
+	return len(rows)
+}
+
+// credentialStore keeps generated logins in memory until they are downloaded once
+type credentialStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]credentialEntry
+}
+
+type credentialEntry struct {
+	credentials []domain.StudentCredential
+	expiresAt   time.Time
+}
+
+func newCredentialStore(ttl time.Duration) *credentialStore {
+	return &credentialStore{ttl: ttl, entries: make(map[string]credentialEntry)}
+}
+
+func (s *credentialStore) put(credentials []domain.StudentCredential) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, entry := range s.entries {
+		if now.After(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+	s.entries[id] = credentialEntry{credentials: credentials, expiresAt: now.Add(s.ttl)}
+
+	return id, nil
+}
+
+func (s *credentialStore) take(id string) ([]domain.StudentCredential, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[id]
+	if !ok {
+		return nil, false
+	}
+	delete(s.entries, id)
+
+	if time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.credentials, true
+}
@@ -0,0 +1,167 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/myhash"
+)
+
+func importRow(row int, groupID, lastName, firstName, middleName string) domain.StudentImportRow {
+	return domain.StudentImportRow{Row: row, Student: domain.Student{GroupID: groupID, LastName: lastName, FirstName: firstName, MiddleName: middleName}}
+}
+
+func TestStudentImportServiceImportChecksRows(t *testing.T) {
+	tests := []struct {
+		name       string
+		batch      domain.StudentImportBatch
+		dryRun     bool
+		wantErr    error
+		wantValid  int
+		wantErrors []domain.ImportError
+	}{
+		{
+			name: "unknown group",
+			batch: domain.StudentImportBatch{Total: 2, Rows: []domain.StudentImportRow{
+				importRow(2, otherGroup, "Кузнецов", "Пётр", "Сергеевич"),
+				importRow(3, "2023-35.03.06-9", "Смирнов", "Олег", "Петрович"),
+			}},
+			wantErr:    service.ErrImportHasErrors,
+			wantValid:  1,
+			wantErrors: []domain.ImportError{{Row: 3, Field: "group_id", Message: "group 2023-35.03.06-9 does not exist"}},
+		},
+		{
+			name: "duplicate row of the file",
+			batch: domain.StudentImportBatch{Total: 2, Rows: []domain.StudentImportRow{
+				importRow(2, otherGroup, "Кузнецов", "Пётр", "Сергеевич"),
+				importRow(3, otherGroup, "КУЗНЕЦОВ", "Пётр", "Сергеевич"),
+			}},
+			wantErr:    service.ErrImportHasErrors,
+			wantValid:  1,
+			wantErrors: []domain.ImportError{{Row: 3, Message: "duplicates row 2"}},
+		},
+		{
+			name:       "student of the group exists",
+			batch:      domain.StudentImportBatch{Total: 1, Rows: []domain.StudentImportRow{importRow(2, testGroup, "Иванов", "Иван", "Иванович")}},
+			wantErr:    service.ErrImportHasErrors,
+			wantErrors: []domain.ImportError{{Row: 2, Message: service.ErrStudentExists.Error()}},
+		},
+		{
+			name:      "namesake in another group",
+			batch:     domain.StudentImportBatch{Total: 1, Rows: []domain.StudentImportRow{importRow(2, otherGroup, "Иванов", "Иван", "Иванович")}},
+			dryRun:    true,
+			wantValid: 1,
+		},
+		{
+			name: "rows rejected while parsing",
+			batch: domain.StudentImportBatch{Total: 2, Rows: []domain.StudentImportRow{importRow(2, otherGroup, "Кузнецов", "Пётр", "Сергеевич")},
+				Errors: []domain.ImportError{{Row: 3, Field: "last_name", Message: "is required"}}},
+			wantErr:    service.ErrImportHasErrors,
+			wantValid:  1,
+			wantErrors: []domain.ImportError{{Row: 3, Field: "last_name", Message: "is required"}},
+		},
+		{
+			name: "dry run with errors",
+			batch: domain.StudentImportBatch{Total: 2, Rows: []domain.StudentImportRow{
+				importRow(2, otherGroup, "Кузнецов", "Пётр", "Сергеевич"),
+				importRow(3, testGroup, "Петров", "Иван", "Иванович"),
+			}},
+			dryRun:     true,
+			wantValid:  1,
+			wantErrors: []domain.ImportError{{Row: 3, Message: service.ErrStudentExists.Error()}},
+		},
+		{
+			name:    "empty file",
+			batch:   domain.StudentImportBatch{},
+			wantErr: service.ErrImportEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newRepos(t)
+			imports := service.NewStudentImportService(myhash.NewHasher("salt", 4), repos.Student, repos.Group, repos.User)
+
+			result, err := imports.Import(ctx, tt.batch, service.StudentImportOptions{DryRun: tt.dryRun, CreateAccounts: true})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Import() error = %v, want %v", err, tt.wantErr)
+			}
+			if result.DryRun != tt.dryRun || result.Total != tt.batch.Total || result.Valid != tt.wantValid || result.Created != 0 {
+				t.Errorf("result = %+v, want %d valid rows and nothing created", result, tt.wantValid)
+			}
+			if len(result.Errors) != len(tt.wantErrors) {
+				t.Fatalf("errors = %+v, want %+v", result.Errors, tt.wantErrors)
+			}
+			for i := range tt.wantErrors {
+				if result.Errors[i] != tt.wantErrors[i] {
+					t.Errorf("error %d = %+v, want %+v", i, result.Errors[i], tt.wantErrors[i])
+				}
+			}
+
+			students, err := repos.Student.GetAll(ctx)
+			if err != nil {
+				t.Fatalf("GetAll() error = %v", err)
+			}
+			if len(students) != 3 {
+				t.Errorf("students = %d, want the 3 of newRepos", len(students))
+			}
+		})
+	}
+}
+
+func TestStudentImportServiceImportCreatesAccounts(t *testing.T) {
+	ctx := context.Background()
+	repos := newRepos(t)
+	hasher := myhash.NewHasher("salt", 4)
+	imports := service.NewStudentImportService(hasher, repos.Student, repos.Group, repos.User)
+
+	if err := repos.User.Create(ctx, domain.User{Username: "kuznetsovps", Password: "hash", Role: "Студент"}); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	batch := domain.StudentImportBatch{Total: 4, Rows: []domain.StudentImportRow{
+		importRow(2, otherGroup, "Кузнецов", "Пётр", "Сергеевич"),
+		importRow(3, otherGroup, "Кузнецова", "Полина", "Сергеевна"),
+		importRow(4, otherGroup, "Кузнецов", "Павел", "Семёнович"),
+		importRow(5, otherGroup, "Ли", "Ан", "Бо"),
+	}}
+	result, err := imports.Import(ctx, batch, service.StudentImportOptions{CreateAccounts: true})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if result.Created != 4 || result.Valid != 4 || result.CredentialsID == "" {
+		t.Fatalf("result = %+v", result)
+	}
+
+	credentials, err := imports.TakeCredentials(result.CredentialsID)
+	if err != nil {
+		t.Fatalf("TakeCredentials() error = %v", err)
+	}
+	// a login taken in the database or earlier in the file gets the next number,
+	// a short login is padded to the minimum length
+	wantUsernames := []string{"kuznetsovps2", "kuznetsovaps", "kuznetsovps3", "liab0001"}
+	if len(credentials) != len(wantUsernames) {
+		t.Fatalf("credentials = %+v", credentials)
+	}
+	for i, credential := range credentials {
+		if credential.Username != wantUsernames[i] {
+			t.Errorf("username of row %d = %s, want %s", i+2, credential.Username, wantUsernames[i])
+		}
+		user, err := repos.User.GetByStudentID(ctx, credential.StudentID)
+		if err != nil {
+			t.Fatalf("account of student %d: %v", credential.StudentID, err)
+		}
+		if user.User.Username != credential.Username || user.User.Role != "Студент" || !hasher.ComparePassword(user.User.Password, credential.Password) {
+			t.Errorf("account of %s = %+v", credential.Username, user.User)
+		}
+	}
+
+	// the credentials are given out once
+	if _, err := imports.TakeCredentials(result.CredentialsID); !errors.Is(err, service.ErrCredentialsNotFound) {
+		t.Errorf("second TakeCredentials() error = %v, want %v", err, service.ErrCredentialsNotFound)
+	}
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+)
+
+type SubgroupService struct {
+	SubgroupRepo repository.ISubgroup
+	StudentRepo  repository.IStudent
+}
+
+func NewSubgroupService(subgroupRepo repository.ISubgroup, studentRepo repository.IStudent) *SubgroupService {
+	return &SubgroupService{SubgroupRepo: subgroupRepo, StudentRepo: studentRepo}
+}
+
+func (s *SubgroupService) Create(ctx context.Context, subgroup domain.Subgroup) (int64, error) {
+	if err := s.check(ctx, subgroup); err != nil {
+		return 0, err
+	}
+	return s.SubgroupRepo.Create(ctx, subgroup)
+}
+
+// Put updates the subgroup and replaces its students
+func (s *SubgroupService) Put(ctx context.Context, subgroup domain.Subgroup) error {
+	if err := s.check(ctx, subgroup); err != nil {
+		return err
+	}
+	return s.SubgroupRepo.Put(ctx, subgroup)
+}
+
+func (s *SubgroupService) Delete(ctx context.Context, subgroupID int64) error {
+	return s.SubgroupRepo.Delete(ctx, subgroupID)
+}
+
+func (s *SubgroupService) GetByID(ctx context.Context, subgroupID int64) (domain.Subgroup, error) {
+	return s.SubgroupRepo.GetByID(ctx, subgroupID)
+}
+
+func (s *SubgroupService) GetByGroupID(ctx context.Context, groupID string) ([]domain.Subgroup, error) {
+	return s.SubgroupRepo.GetByGroupID(ctx, groupID)
+}
+
+// check allows only the students of the group in its subgroups
+func (s *SubgroupService) check(ctx context.Context, subgroup domain.Subgroup) error {
+	students, err := s.StudentRepo.GetAllByGroupID(ctx, subgroup.GroupID)
+	if err != nil {
+		return err
+	}
+	inGroup := make(map[int64]bool, len(students))
+	for _, student := range students {
+		inGroup[student.StudentID] = true
+	}
+	for _, studentID := range subgroup.StudentIDs {
+		if !inGroup[studentID] {
+			return ErrSubgroupStudent
+		}
+	}
+	return nil
+}
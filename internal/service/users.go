@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/BeRebornBng/OsauAmsApi/domain"
@@ -10,7 +11,7 @@ import (
 	"github.com/BeRebornBng/OsauAmsApi/pkg/auth"
 	"github.com/BeRebornBng/OsauAmsApi/pkg/myhash"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx"
+	"github.com/jackc/pgx/v5"
 )
 
 type UserService struct {
@@ -18,6 +19,7 @@ type UserService struct {
 	Hasher         myhash.PasswordHasher
 	UserRepo       repository.IUser
 	StudentRepo    repository.IStudent
+	LoginGuard     *LoginGuard
 	AccessTokenTTL time.Duration
 }
 
@@ -25,30 +27,92 @@ func NewUserService(
 	TokenManager auth.TokenManager,
 	Hasher myhash.PasswordHasher,
 	UserRepo repository.IUser,
+	LoginGuard *LoginGuard,
 	AccessTokenTTL time.Duration,
 ) *UserService {
 	return &UserService{
 		TokenManager:   TokenManager,
 		Hasher:         Hasher,
 		UserRepo:       UserRepo,
+		LoginGuard:     LoginGuard,
 		AccessTokenTTL: AccessTokenTTL,
 	}
 }
 
-func (s *UserService) SignIn(ctx context.Context, username, password string) (Tokens, error) {
+func (s *UserService) SignIn(ctx context.Context, username, password, clientIP string) (Tokens, error) {
+	if s.LoginGuard != nil {
+		if err := s.LoginGuard.Check(ctx, username, clientIP); err != nil {
+			return Tokens{}, err
+		}
+	}
+
 	user, err := s.UserRepo.GetByName(ctx, username)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return Tokens{}, ErrUserNamePassNotExists
+			return Tokens{}, s.failSignIn(ctx, username, clientIP)
 		}
 		return Tokens{}, err
 	}
 
 	if !(s.Hasher.ComparePassword(user.User.Password, password)) {
-		return Tokens{}, ErrUserNamePassNotExists
+		return Tokens{}, s.failSignIn(ctx, username, clientIP)
+	}
+
+	if s.LoginGuard != nil {
+		if err := s.LoginGuard.Success(ctx, username); err != nil {
+			return Tokens{}, err
+		}
+	}
+	s.rehashPassword(ctx, user.User, password)
+
+	return s.newTokens(user.User)
+}
+
+// ChangePassword replaces the password of the user and returns a new token,
+// tokens issued before the change stop working
+func (s *UserService) ChangePassword(ctx context.Context, userID uuid.UUID, oldPassword, newPassword string) (Tokens, error) {
+	user, err := s.UserRepo.GetByID(ctx, userID)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	if !s.Hasher.ComparePassword(user.User.Password, oldPassword) {
+		return Tokens{}, ErrWrongPassword
+	}
+
+	hashpassword, err := s.Hasher.HashPassword(newPassword)
+	if err != nil {
+		return Tokens{}, err
+	}
+	if err := s.UserRepo.UpdatePassword(ctx, userID, hashpassword); err != nil {
+		return Tokens{}, err
+	}
+
+	user.User.TokenVersion++
+	return s.newTokens(user.User)
+}
+
+// ResetPassword sets a new password of the user without the old one, tokens issued before stop working
+func (s *UserService) ResetPassword(ctx context.Context, username, password string) error {
+	user, err := s.UserRepo.GetByName(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	hashpassword, err := s.Hasher.HashPassword(password)
+	if err != nil {
+		return err
 	}
+	return s.UserRepo.UpdatePassword(ctx, user.User.UserID, hashpassword)
+}
 
-	accessToken, err := s.TokenManager.NewJWT(user.User.UserID.String(), user.User.Role, s.AccessTokenTTL)
+// RevokeSessions signs the user out everywhere by invalidating all issued tokens
+func (s *UserService) RevokeSessions(ctx context.Context, userID uuid.UUID) error {
+	return s.UserRepo.RevokeTokens(ctx, userID)
+}
+
+func (s *UserService) newTokens(user domain.User) (Tokens, error) {
+	accessToken, err := s.TokenManager.NewJWT(user.UserID.String(), user.Role, user.TokenVersion, s.AccessTokenTTL)
 	if err != nil {
 		return Tokens{}, err
 	}
@@ -56,6 +120,33 @@ func (s *UserService) SignIn(ctx context.Context, username, password string) (To
 	return Tokens{AccessToken: accessToken}, nil
 }
 
+// rehashPassword upgrades legacy or outdated password hashes after a successful sign in
+func (s *UserService) rehashPassword(ctx context.Context, user domain.User, password string) {
+	rehasher, ok := s.Hasher.(myhash.Rehasher)
+	if !ok || !rehasher.NeedsRehash(user.Password) {
+		return
+	}
+
+	hashpassword, err := s.Hasher.HashPassword(password)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to rehash password", slog.String("error", err.Error()))
+		return
+	}
+	if err := s.UserRepo.Patch(ctx, user.UserID, map[string]interface{}{"password": hashpassword}); err != nil {
+		slog.WarnContext(ctx, "failed to store rehashed password", slog.String("error", err.Error()))
+	}
+}
+
+func (s *UserService) failSignIn(ctx context.Context, username, clientIP string) error {
+	slog.WarnContext(ctx, "failed sign in", slog.String("username", username), slog.String("client_ip", clientIP))
+	if s.LoginGuard != nil {
+		if err := s.LoginGuard.Fail(ctx, username, clientIP); err != nil {
+			return err
+		}
+	}
+	return ErrUserNamePassNotExists
+}
+
 func (s *UserService) Create(ctx context.Context, user domain.User) error {
 	_, err := s.UserRepo.GetByName(ctx, user.Username)
 	if err != nil {
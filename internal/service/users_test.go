@@ -0,0 +1,175 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/auth"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/myhash"
+	"github.com/BeRebornBng/OsauAmsApi/pkg/ratelimit"
+)
+
+// cheap argon2id parameters keep the tests fast
+var testArgon2Params = myhash.Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1}
+
+// newUserService returns the service with an argon2id user "argonuser" and a user
+// "legacyuser" with a legacy bcrypt hash, both with the password "password1"
+func newUserService(t *testing.T) (*service.UserService, *repository.Repositories, *auth.Manager) {
+	t.Helper()
+	ctx := context.Background()
+	repos := newRepos(t)
+	legacy := myhash.NewHasher("salt", 4)
+	hasher := myhash.NewArgon2Hasher(testArgon2Params, "pepper", legacy)
+	manager := auth.NewManager("secret")
+
+	argonHash, err := hasher.HashPassword("password1")
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	legacyHash, err := legacy.HashPassword("password1")
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	for _, user := range []domain.User{
+		{Username: "argonuser", Password: argonHash, Role: "Админ"},
+		{Username: "legacyuser", Password: legacyHash, Role: "Преподаватель"},
+	} {
+		if err := repos.User.Create(ctx, user); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+
+	guard := service.NewLoginGuard(ratelimit.NewMemoryStore(), testLoginPolicy)
+	return service.NewUserService(manager, hasher, repos.User, guard, time.Hour), repos, manager
+}
+
+func TestUserServiceSignIn(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		password string
+		wantErr  error
+		wantRole string
+	}{
+		{name: "argon2id hash", username: "argonuser", password: "password1", wantRole: "Админ"},
+		{name: "legacy hash", username: "legacyuser", password: "password1", wantRole: "Преподаватель"},
+		{name: "wrong password", username: "argonuser", password: "password2", wantErr: service.ErrUserNamePassNotExists},
+		{name: "wrong password of a legacy hash", username: "legacyuser", password: "password2", wantErr: service.ErrUserNamePassNotExists},
+		{name: "unknown user", username: "nobody", password: "password1", wantErr: service.ErrUserNamePassNotExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, _, manager := newUserService(t)
+
+			tokens, err := users.SignIn(context.Background(), tt.username, tt.password, "10.0.0.1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SignIn() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			claims, err := manager.ParseClaims(tokens.AccessToken)
+			if err != nil {
+				t.Fatalf("ParseClaims() error = %v", err)
+			}
+			if claims.UserRole != tt.wantRole || claims.TokenVersion != 0 {
+				t.Errorf("claims = %+v, want the role %s and version 0", claims, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestUserServiceSignInRehashesLegacyHash(t *testing.T) {
+	users, repos, _ := newUserService(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		password string
+		argon2id bool
+	}{
+		{name: "a failed sign in keeps the hash", password: "password2"},
+		{name: "a sign in upgrades the hash", password: "password1", argon2id: true},
+		// the upgraded hash still accepts the password
+		{name: "a sign in with the new hash", password: "password1", argon2id: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := users.SignIn(ctx, "legacyuser", tt.password, "")
+			if (err == nil) != tt.argon2id {
+				t.Fatalf("SignIn() error = %v", err)
+			}
+			user, err := repos.User.GetByName(ctx, "legacyuser")
+			if err != nil {
+				t.Fatalf("GetByName() error = %v", err)
+			}
+			if got := strings.HasPrefix(user.User.Password, "$argon2id$"); got != tt.argon2id {
+				t.Errorf("argon2id hash = %v, want %v: %s", got, tt.argon2id, user.User.Password)
+			}
+		})
+	}
+}
+
+func TestUserServiceSignInLockout(t *testing.T) {
+	users, _, _ := newUserService(t)
+	ctx := context.Background()
+
+	for i := 1; i <= testLoginPolicy.MaxAttempts; i++ {
+		if _, err := users.SignIn(ctx, "argonuser", "password2", "10.0.0.1"); !errors.Is(err, service.ErrUserNamePassNotExists) {
+			t.Fatalf("SignIn() attempt %d error = %v, want %v", i, err, service.ErrUserNamePassNotExists)
+		}
+	}
+
+	// the right password doesn't help while the username is locked, from any address
+	_, err := users.SignIn(ctx, "argonuser", "password1", "10.0.0.2")
+	var locked *service.LoginLockedError
+	if !errors.As(err, &locked) || !errors.Is(err, service.ErrTooManyLoginAttempts) {
+		t.Fatalf("SignIn() of a locked user error = %v, want %v", err, service.ErrTooManyLoginAttempts)
+	}
+	if locked.RetryAfter <= 0 || locked.RetryAfter > testLoginPolicy.BaseLockout {
+		t.Errorf("retry after = %v, want up to %v", locked.RetryAfter, testLoginPolicy.BaseLockout)
+	}
+	if _, err := users.SignIn(ctx, "legacyuser", "password1", "10.0.0.1"); err != nil {
+		t.Errorf("SignIn() of another user error = %v", err)
+	}
+}
+
+func TestUserServiceChangePassword(t *testing.T) {
+	users, repos, manager := newUserService(t)
+	ctx := context.Background()
+
+	user, err := repos.User.GetByName(ctx, "argonuser")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	userID := user.User.UserID
+
+	if _, err := users.ChangePassword(ctx, userID, "password2", "password3"); !errors.Is(err, service.ErrWrongPassword) {
+		t.Fatalf("ChangePassword() with a wrong password error = %v, want %v", err, service.ErrWrongPassword)
+	}
+	tokens, err := users.ChangePassword(ctx, userID, "password1", "password3")
+	if err != nil {
+		t.Fatalf("ChangePassword() error = %v", err)
+	}
+	// the tokens issued before the change stop working
+	claims, err := manager.ParseClaims(tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("ParseClaims() error = %v", err)
+	}
+	if claims.TokenVersion != 1 {
+		t.Errorf("token version = %d, want 1", claims.TokenVersion)
+	}
+	if _, err := users.SignIn(ctx, "argonuser", "password1", ""); !errors.Is(err, service.ErrUserNamePassNotExists) {
+		t.Errorf("SignIn() with the old password error = %v, want %v", err, service.ErrUserNamePassNotExists)
+	}
+	if _, err := users.SignIn(ctx, "argonuser", "password3", ""); err != nil {
+		t.Errorf("SignIn() with the new password error = %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/repository"
+)
+
+type WorkloadService struct {
+	ScheduleRepo repository.ISchedule
+	TeacherRepo  repository.ITeacher
+	CalendarRepo repository.ICalendar
+	WorkloadRepo repository.IWorkload
+}
+
+func NewWorkloadService(scheduleRepo repository.ISchedule, teacherRepo repository.ITeacher, calendarRepo repository.ICalendar, workloadRepo repository.IWorkload) *WorkloadService {
+	return &WorkloadService{ScheduleRepo: scheduleRepo, TeacherRepo: teacherRepo, CalendarRepo: calendarRepo, WorkloadRepo: workloadRepo}
+}
+
+// workloadKey is a discipline type of a discipline taught to a group in a semester
+type workloadKey struct {
+	disciplineID     int64
+	disciplineTypeID int64
+	groupID          string
+	semester         int
+}
+
+// teachingDaysKey is a semester of a group
+type teachingDaysKey struct {
+	groupID  string
+	semester int
+}
+
+// GetByTeacher returns the planned and delivered hours of the teacher, semester 0 counts all semesters
+func (s *WorkloadService) GetByTeacher(ctx context.Context, teacherID int64, semester int) (domain.TeacherWorkload, error) {
+	teacher, err := s.TeacherRepo.GetByID(ctx, teacherID)
+	if err != nil {
+		return domain.TeacherWorkload{}, err
+	}
+
+	workloads, err := s.workloads(ctx, []domain.TeacherInfo{teacher}, semester)
+	if err != nil {
+		return domain.TeacherWorkload{}, err
+	}
+	return workloads[0], nil
+}
+
+// GetByDepartament returns the workload of every teacher of the departament with the departament totals,
+// semester 0 counts all semesters
+func (s *WorkloadService) GetByDepartament(ctx context.Context, departamentID int64, semester int) (domain.DepartamentWorkload, error) {
+	workload := domain.DepartamentWorkload{DepartamentID: departamentID, Teachers: make([]domain.TeacherWorkload, 0)}
+
+	teachers, err := s.TeacherRepo.GetAllByDepartamentID(ctx, departamentID)
+	if err != nil {
+		return workload, err
+	}
+	if len(teachers) == 0 {
+		return workload, nil
+	}
+
+	workload.Teachers, err = s.workloads(ctx, teachers, semester)
+	if err != nil {
+		return workload, err
+	}
+	for _, teacher := range workload.Teachers {
+		workload.PlannedHours += teacher.PlannedHours
+		workload.DeliveredHours += teacher.DeliveredHours
+	}
+
+	return workload, nil
+}
+
+// workloads counts the planned hours of the actual timetable of the teachers on the teaching days
+// and the delivered hours of the lessons with a marked attendance, a substitute teacher gets the
+// delivered hours of the lessons they held
+func (s *WorkloadService) workloads(ctx context.Context, teachers []domain.TeacherInfo, semester int) ([]domain.TeacherWorkload, error) {
+	days := make(map[teachingDaysKey][]time.Time)
+	teachingDays := func(groupID string, semester int) ([]time.Time, error) {
+		key := teachingDaysKey{groupID: groupID, semester: semester}
+		if cached, ok := days[key]; ok {
+			return cached, nil
+		}
+		found, err := s.CalendarRepo.GetTeachingDays(ctx, groupID, semester)
+		if err != nil {
+			return nil, err
+		}
+		days[key] = found
+		return found, nil
+	}
+
+	schedules := make(map[int64]domain.ScheduleInfo)
+	workloads := make([]domain.TeacherWorkload, 0, len(teachers))
+	rows := make([]map[workloadKey]int, 0, len(teachers))
+	index := make(map[int64]int, len(teachers))
+	teacherIDs := make([]int64, 0, len(teachers))
+
+	row := func(i int, scheduleInfo domain.ScheduleInfo) int {
+		schedule := scheduleInfo.Schedule
+		key := workloadKey{
+			disciplineID:     schedule.DisciplineID,
+			disciplineTypeID: schedule.DisciplineTypeID,
+			groupID:          schedule.GroupID,
+			semester:         schedule.Semester,
+		}
+		if j, ok := rows[i][key]; ok {
+			return j
+		}
+		rows[i][key] = len(workloads[i].Rows)
+		workloads[i].Rows = append(workloads[i].Rows, domain.WorkloadRow{
+			DisciplineID:       schedule.DisciplineID,
+			DisciplineName:     scheduleInfo.ScheduleSub.DisciplineName,
+			DisciplineTypeID:   schedule.DisciplineTypeID,
+			DisciplineTypeName: scheduleInfo.ScheduleSub.DisciplineTypeName,
+			GroupID:            schedule.GroupID,
+			Semester:           schedule.Semester,
+		})
+		return rows[i][key]
+	}
+
+	for i, teacher := range teachers {
+		workloads = append(workloads, domain.TeacherWorkload{
+			TeacherID: teacher.Teacher.TeacherID,
+			TeacherFullName: domain.TeacherFullName{
+				LastName:   teacher.Teacher.LastName,
+				FirstName:  teacher.Teacher.FirstName,
+				MiddleName: teacher.Teacher.MiddleName,
+			},
+			DepartamentID: teacher.Teacher.DepartamentID,
+			Rows:          make([]domain.WorkloadRow, 0),
+		})
+		rows = append(rows, make(map[workloadKey]int))
+		index[teacher.Teacher.TeacherID] = i
+		teacherIDs = append(teacherIDs, teacher.Teacher.TeacherID)
+
+		actual, err := s.ScheduleRepo.GetActualByTeacherID(ctx, teacher.Teacher.TeacherID)
+		if err != nil {
+			return nil, err
+		}
+		for _, scheduleInfo := range actual {
+			schedule := scheduleInfo.Schedule
+			if semester != 0 && schedule.Semester != semester {
+				continue
+			}
+			schedules[schedule.ScheduleID] = scheduleInfo
+
+			dates, err := teachingDays(schedule.GroupID, schedule.Semester)
+			if err != nil {
+				return nil, err
+			}
+			lessons := 0
+			for _, day := range dates {
+				if heldByTimetable(schedule, day) {
+					lessons++
+				}
+			}
+			workloads[i].Rows[row(i, scheduleInfo)].PlannedHours += lessons * lessonHours
+		}
+	}
+
+	delivered, err := s.WorkloadRepo.CountDeliveredLessons(ctx, teacherIDs, semester)
+	if err != nil {
+		return nil, err
+	}
+	for _, lessons := range delivered {
+		i, ok := index[lessons.TeacherID]
+		if !ok {
+			continue
+		}
+		scheduleInfo, ok := schedules[lessons.ScheduleID]
+		if !ok {
+			// the teacher substituted in a schedule of another teacher
+			scheduleInfo, err = s.ScheduleRepo.GetByID(ctx, lessons.ScheduleID)
+			if err != nil {
+				return nil, err
+			}
+			schedules[lessons.ScheduleID] = scheduleInfo
+		}
+		workloads[i].Rows[row(i, scheduleInfo)].DeliveredHours += lessons.Lessons * lessonHours
+	}
+
+	for i := range workloads {
+		for _, r := range workloads[i].Rows {
+			workloads[i].PlannedHours += r.PlannedHours
+			workloads[i].DeliveredHours += r.DeliveredHours
+		}
+	}
+
+	return workloads, nil
+}
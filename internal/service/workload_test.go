@@ -0,0 +1,118 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BeRebornBng/OsauAmsApi/domain"
+	"github.com/BeRebornBng/OsauAmsApi/internal/service"
+	"github.com/jackc/pgx/v5"
+)
+
+// newWorkloadService returns the service over the timetable of newAttendanceService with the
+// teachers 1 and 2 of its lessons and teacher 3 without lessons, the lecture was held on
+// 2024-09-02 and by the substitute on 2024-09-30, the lab was held on 2024-09-02
+func newWorkloadService(t *testing.T) *service.WorkloadService {
+	t.Helper()
+	ctx := context.Background()
+	_, repos := newAttendanceService(t)
+
+	for _, lastName := range []string{"Кузнецов", "Новикова", "Смирнов"} {
+		if err := repos.Teacher.Create(ctx, domain.Teacher{DepartamentID: 1, LastName: lastName, FirstName: "Анна", MiddleName: "Игоревна", TeacherEmail: lastName + "@example.com"}); err != nil {
+			t.Fatalf("create teacher: %v", err)
+		}
+	}
+
+	present := true
+	for _, attendance := range []domain.Attendance{
+		{StudentID: 1, ScheduleID: 1, Created: day("2024-09-02")},
+		{StudentID: 2, ScheduleID: 1, Created: day("2024-09-02")},
+		{StudentID: 1, ScheduleID: 1, Created: day("2024-09-30")},
+		{StudentID: 1, ScheduleID: 2, Created: day("2024-09-02")},
+	} {
+		attendance.Presence = &present
+		if err := repos.Attendance.Create(ctx, attendance); err != nil {
+			t.Fatalf("create attendance: %v", err)
+		}
+	}
+
+	return service.NewWorkloadService(repos.Schedule, repos.Teacher, repos.Calendar, repos.Workload)
+}
+
+func TestWorkloadServiceGetByTeacher(t *testing.T) {
+	ctx := context.Background()
+	workloads := newWorkloadService(t)
+
+	// the upper mondays of the semester without the holiday give 9 lessons of 2 hours
+	tests := []struct {
+		name          string
+		teacherID     int64
+		semester      int
+		wantPlanned   int
+		wantDelivered int
+		wantRows      []domain.WorkloadRow
+		wantErr       error
+	}{
+		{name: "teacher of the lecture", teacherID: 1, semester: 1, wantPlanned: 18, wantDelivered: 2,
+			wantRows: []domain.WorkloadRow{{DisciplineID: 1, DisciplineTypeID: 1, GroupID: testGroup, Semester: 1, PlannedHours: 18, DeliveredHours: 2}}},
+		{name: "substitute teacher", teacherID: 2, semester: 1, wantPlanned: 18, wantDelivered: 4,
+			wantRows: []domain.WorkloadRow{
+				{DisciplineID: 2, DisciplineTypeID: 2, GroupID: testGroup, Semester: 1, PlannedHours: 18, DeliveredHours: 2},
+				{DisciplineID: 1, DisciplineTypeID: 1, GroupID: testGroup, Semester: 1, DeliveredHours: 2},
+			}},
+		{name: "all semesters", teacherID: 1, wantPlanned: 18, wantDelivered: 2,
+			wantRows: []domain.WorkloadRow{{DisciplineID: 1, DisciplineTypeID: 1, GroupID: testGroup, Semester: 1, PlannedHours: 18, DeliveredHours: 2}}},
+		{name: "semester without lessons", teacherID: 1, semester: 2},
+		{name: "teacher without lessons", teacherID: 3, semester: 1},
+		{name: "unknown teacher", teacherID: 9, semester: 1, wantErr: pgx.ErrNoRows},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workload, err := workloads.GetByTeacher(ctx, tt.teacherID, tt.semester)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetByTeacher() error = %v, want %v", err, tt.wantErr)
+			}
+			if workload.PlannedHours != tt.wantPlanned || workload.DeliveredHours != tt.wantDelivered {
+				t.Errorf("hours = %d planned, %d delivered, want %d and %d", workload.PlannedHours, workload.DeliveredHours, tt.wantPlanned, tt.wantDelivered)
+			}
+			if len(workload.Rows) != len(tt.wantRows) {
+				t.Fatalf("rows = %+v, want %+v", workload.Rows, tt.wantRows)
+			}
+			for i, row := range workload.Rows {
+				row.DisciplineName, row.DisciplineTypeName = "", ""
+				if row != tt.wantRows[i] {
+					t.Errorf("row %d = %+v, want %+v", i, row, tt.wantRows[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWorkloadServiceGetByDepartament(t *testing.T) {
+	ctx := context.Background()
+	workloads := newWorkloadService(t)
+
+	tests := []struct {
+		name          string
+		departamentID int64
+		wantTeachers  int
+		wantPlanned   int
+		wantDelivered int
+	}{
+		{name: "departament of the teachers", departamentID: 1, wantTeachers: 3, wantPlanned: 36, wantDelivered: 6},
+		{name: "departament without teachers", departamentID: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workload, err := workloads.GetByDepartament(ctx, tt.departamentID, 1)
+			if err != nil {
+				t.Fatalf("GetByDepartament() error = %v", err)
+			}
+			if len(workload.Teachers) != tt.wantTeachers || workload.PlannedHours != tt.wantPlanned || workload.DeliveredHours != tt.wantDelivered {
+				t.Errorf("workload = %d teachers, %d planned, %d delivered, want %d, %d and %d", len(workload.Teachers),
+					workload.PlannedHours, workload.DeliveredHours, tt.wantTeachers, tt.wantPlanned, tt.wantDelivered)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS attendance;
+DROP TABLE IF EXISTS schedules;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS headmans;
+DROP TABLE IF EXISTS students;
+DROP TABLE IF EXISTS groups;
+DROP TABLE IF EXISTS profiles;
+DROP TABLE IF EXISTS specialties;
+DROP TABLE IF EXISTS educationTypes;
+DROP TABLE IF EXISTS educationLevels;
+DROP TABLE IF EXISTS classrooms;
+DROP TABLE IF EXISTS disciplineTypes;
+DROP TABLE IF EXISTS disciplines;
+DROP TABLE IF EXISTS teachers;
+DROP TABLE IF EXISTS departaments;
+DROP TABLE IF EXISTS faculties;
+DROP TABLE IF EXISTS university;
@@ -0,0 +1,158 @@
+-- The tables the later migrations alter. The database was created by hand before
+-- the migrations, so the tables and the columns follow the queries of the baseline
+-- repositories and every table is created only when it is missing: a database made
+-- by hand keeps its tables and its data. Only the constraints the baseline handlers
+-- check by name (U_users_*) are named, the others get the names Postgres generates.
+
+CREATE TABLE IF NOT EXISTS university (
+    university_id    BIGSERIAL PRIMARY KEY,
+    university_name  TEXT NOT NULL,
+    head_last_name   TEXT NOT NULL,
+    head_first_name  TEXT NOT NULL,
+    head_middle_name TEXT NOT NULL,
+    university_email TEXT NOT NULL,
+    UNIQUE (university_name)
+);
+
+CREATE TABLE IF NOT EXISTS faculties (
+    faculty_id       BIGSERIAL PRIMARY KEY,
+    university_id    BIGINT NOT NULL REFERENCES university (university_id) ON DELETE CASCADE,
+    faculty_name     TEXT NOT NULL,
+    head_last_name   TEXT NOT NULL,
+    head_first_name  TEXT NOT NULL,
+    head_middle_name TEXT NOT NULL,
+    faculty_email    TEXT NOT NULL,
+    UNIQUE (faculty_name)
+);
+
+CREATE TABLE IF NOT EXISTS departaments (
+    departament_id    BIGSERIAL PRIMARY KEY,
+    faculty_id        BIGINT NOT NULL REFERENCES faculties (faculty_id) ON DELETE CASCADE,
+    departament_name  TEXT NOT NULL,
+    head_last_name    TEXT NOT NULL,
+    head_first_name   TEXT NOT NULL,
+    head_middle_name  TEXT NOT NULL,
+    departament_email TEXT NOT NULL,
+    UNIQUE (departament_name)
+);
+
+CREATE TABLE IF NOT EXISTS teachers (
+    teacher_id     BIGSERIAL PRIMARY KEY,
+    departament_id BIGINT NOT NULL REFERENCES departaments (departament_id) ON DELETE CASCADE,
+    last_name      TEXT NOT NULL,
+    first_name     TEXT NOT NULL,
+    middle_name    TEXT NOT NULL,
+    teacher_email  TEXT NOT NULL,
+    UNIQUE (teacher_email)
+);
+
+CREATE TABLE IF NOT EXISTS disciplines (
+    discipline_id   BIGSERIAL PRIMARY KEY,
+    departament_id  BIGINT NOT NULL REFERENCES departaments (departament_id) ON DELETE CASCADE,
+    discipline_name TEXT NOT NULL,
+    UNIQUE (discipline_name)
+);
+
+CREATE TABLE IF NOT EXISTS disciplineTypes (
+    discipline_type_id   BIGSERIAL PRIMARY KEY,
+    discipline_type_name TEXT NOT NULL,
+    UNIQUE (discipline_type_name)
+);
+
+CREATE TABLE IF NOT EXISTS classrooms (
+    classroom_id   BIGSERIAL PRIMARY KEY,
+    classroom_name TEXT NOT NULL,
+    UNIQUE (classroom_name)
+);
+
+CREATE TABLE IF NOT EXISTS educationLevels (
+    education_level_id   BIGSERIAL PRIMARY KEY,
+    education_level_name TEXT NOT NULL,
+    UNIQUE (education_level_name)
+);
+
+CREATE TABLE IF NOT EXISTS educationTypes (
+    education_type_id   BIGSERIAL PRIMARY KEY,
+    education_type_name TEXT NOT NULL,
+    UNIQUE (education_type_name)
+);
+
+CREATE TABLE IF NOT EXISTS specialties (
+    specialty_code     TEXT NOT NULL,
+    specialty_name     TEXT NOT NULL,
+    departament_id     BIGINT NOT NULL REFERENCES departaments (departament_id) ON DELETE CASCADE,
+    education_level_id BIGINT NOT NULL REFERENCES educationLevels (education_level_id),
+    PRIMARY KEY (specialty_code)
+);
+
+CREATE TABLE IF NOT EXISTS profiles (
+    profile_id        BIGSERIAL PRIMARY KEY,
+    specialty_code    TEXT NOT NULL REFERENCES specialties (specialty_code) ON UPDATE CASCADE ON DELETE CASCADE,
+    education_type_id BIGINT NOT NULL REFERENCES educationTypes (education_type_id),
+    profile_name      TEXT NOT NULL,
+    UNIQUE (profile_name)
+);
+
+-- the baseline reads group_name for the headmen but never writes it, the group
+-- code is its name
+CREATE TABLE IF NOT EXISTS groups (
+    group_id   TEXT NOT NULL,
+    profile_id BIGINT NOT NULL REFERENCES profiles (profile_id),
+    group_name TEXT GENERATED ALWAYS AS (group_id) STORED,
+    PRIMARY KEY (group_id)
+);
+
+CREATE TABLE IF NOT EXISTS students (
+    student_id  BIGSERIAL PRIMARY KEY,
+    group_id    TEXT NOT NULL REFERENCES groups (group_id) ON UPDATE CASCADE,
+    last_name   TEXT NOT NULL,
+    first_name  TEXT NOT NULL,
+    middle_name TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS headmans (
+    headman_id BIGSERIAL PRIMARY KEY,
+    student_id BIGINT NOT NULL REFERENCES students (student_id) ON DELETE CASCADE,
+    group_id   TEXT NOT NULL REFERENCES groups (group_id) ON UPDATE CASCADE,
+    UNIQUE (group_id),
+    UNIQUE (student_id)
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    user_id    UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    username   TEXT NOT NULL,
+    password   TEXT NOT NULL,
+    user_role  TEXT NOT NULL,
+    headman_id BIGINT REFERENCES headmans (headman_id) ON DELETE SET NULL,
+    student_id BIGINT REFERENCES students (student_id) ON DELETE SET NULL,
+    teacher_id BIGINT REFERENCES teachers (teacher_id) ON DELETE SET NULL,
+    CONSTRAINT "U_users_username" UNIQUE (username),
+    CONSTRAINT "U_users_headman_id" UNIQUE (headman_id),
+    CONSTRAINT "U_users_student_id" UNIQUE (student_id),
+    CONSTRAINT "U_users_teacher_id" UNIQUE (teacher_id)
+);
+
+CREATE TABLE IF NOT EXISTS schedules (
+    schedule_id        BIGSERIAL PRIMARY KEY,
+    group_id           TEXT NOT NULL REFERENCES groups (group_id) ON UPDATE CASCADE,
+    discipline_id      BIGINT NOT NULL REFERENCES disciplines (discipline_id),
+    teacher_id         BIGINT NOT NULL REFERENCES teachers (teacher_id),
+    discipline_type_id BIGINT NOT NULL REFERENCES disciplineTypes (discipline_type_id),
+    classroom_id       BIGINT NOT NULL REFERENCES classrooms (classroom_id),
+    semester           INT NOT NULL,
+    week_type          TEXT NOT NULL,
+    day_of_week        TEXT NOT NULL,
+    start_time         TIME NOT NULL,
+    is_actual          BOOLEAN DEFAULT TRUE
+);
+
+CREATE TABLE IF NOT EXISTS attendance (
+    attendance_id  BIGSERIAL PRIMARY KEY,
+    student_id     BIGINT NOT NULL REFERENCES students (student_id) ON DELETE CASCADE,
+    schedule_id    BIGINT NOT NULL REFERENCES schedules (schedule_id) ON DELETE CASCADE,
+    presence       BOOLEAN,
+    late_arrival   BOOLEAN,
+    respectfulness BOOLEAN,
+    reason         TEXT,
+    created        TIMESTAMP NOT NULL DEFAULT now()
+);
@@ -0,0 +1,13 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS token_version BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    token_id   BIGSERIAL PRIMARY KEY,
+    user_id    UUID NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT U_password_reset_tokens_token_hash UNIQUE (token_hash)
+);
+
+CREATE INDEX IF NOT EXISTS I_password_reset_tokens_user_id ON password_reset_tokens (user_id);
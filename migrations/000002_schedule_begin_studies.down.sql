@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS I_schedules_semester_is_actual;
+
+ALTER TABLE schedules DROP COLUMN IF EXISTS begin_studies;
@@ -0,0 +1,3 @@
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS begin_studies DATE;
+
+CREATE INDEX IF NOT EXISTS I_schedules_semester_is_actual ON schedules (semester, is_actual);
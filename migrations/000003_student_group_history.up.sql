@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS student_group_history (
+    history_id BIGSERIAL PRIMARY KEY,
+    student_id BIGINT NOT NULL REFERENCES students (student_id) ON DELETE CASCADE,
+    group_id   TEXT NOT NULL REFERENCES groups (group_id) ON UPDATE CASCADE,
+    valid_from DATE NOT NULL,
+    valid_to   DATE,
+    reason     TEXT,
+    CONSTRAINT C_student_group_history_period CHECK (valid_to IS NULL OR valid_to >= valid_from)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS U_student_group_history_open ON student_group_history (student_id) WHERE valid_to IS NULL;
+CREATE INDEX IF NOT EXISTS I_student_group_history_group_id ON student_group_history (group_id, valid_from);
+
+-- the date of enrollment is unknown for existing students, the membership starts at the epoch
+INSERT INTO student_group_history (student_id, group_id, valid_from)
+SELECT s.student_id, s.group_id, DATE '1970-01-01'
+FROM students s
+WHERE NOT EXISTS (SELECT 1 FROM student_group_history h WHERE h.student_id = s.student_id);
@@ -0,0 +1,27 @@
+DROP INDEX IF EXISTS I_headmans_student_id_term;
+DROP INDEX IF EXISTS I_headmans_group_id_term;
+
+ALTER TABLE headmans DROP CONSTRAINT IF EXISTS C_headmans_term;
+
+-- a group and a student have a single headman row again, so only the latest term of the
+-- headman of each group is kept: the deputies and the earlier terms are lost
+DELETE FROM headmans WHERE is_deputy;
+DELETE FROM headmans h
+WHERE EXISTS (
+    SELECT 1 FROM headmans later
+    WHERE later.group_id = h.group_id
+    AND (later.term_start, later.headman_id) > (h.term_start, h.headman_id)
+);
+DELETE FROM headmans h
+WHERE EXISTS (
+    SELECT 1 FROM headmans later
+    WHERE later.student_id = h.student_id
+    AND (later.term_start, later.headman_id) > (h.term_start, h.headman_id)
+);
+
+ALTER TABLE headmans DROP COLUMN IF EXISTS is_deputy;
+ALTER TABLE headmans DROP COLUMN IF EXISTS term_end;
+ALTER TABLE headmans DROP COLUMN IF EXISTS term_start;
+
+ALTER TABLE headmans ADD CONSTRAINT headmans_group_id_key UNIQUE (group_id);
+ALTER TABLE headmans ADD CONSTRAINT headmans_student_id_key UNIQUE (student_id);
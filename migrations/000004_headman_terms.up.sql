@@ -0,0 +1,28 @@
+ALTER TABLE headmans ADD COLUMN IF NOT EXISTS term_start DATE NOT NULL DEFAULT DATE '1970-01-01';
+ALTER TABLE headmans ADD COLUMN IF NOT EXISTS term_end DATE;
+ALTER TABLE headmans ADD COLUMN IF NOT EXISTS is_deputy BOOLEAN NOT NULL DEFAULT false;
+
+-- a group and a student can have several terms now, the unique constraints on the columns
+-- are looked up because their names depend on how the table was created
+DO $$
+DECLARE
+    unique_name TEXT;
+BEGIN
+    FOR unique_name IN
+        SELECT c.conname
+        FROM pg_constraint c
+        WHERE c.conrelid = 'headmans'::regclass AND c.contype = 'u'
+        AND c.conkey IN (
+            ARRAY[(SELECT attnum FROM pg_attribute WHERE attrelid = 'headmans'::regclass AND attname = 'group_id')],
+            ARRAY[(SELECT attnum FROM pg_attribute WHERE attrelid = 'headmans'::regclass AND attname = 'student_id')]
+        )
+    LOOP
+        EXECUTE format('ALTER TABLE headmans DROP CONSTRAINT %I', unique_name);
+    END LOOP;
+END
+$$;
+
+ALTER TABLE headmans ADD CONSTRAINT C_headmans_term CHECK (term_end IS NULL OR term_end >= term_start);
+
+CREATE INDEX IF NOT EXISTS I_headmans_group_id_term ON headmans (group_id, term_start);
+CREATE INDEX IF NOT EXISTS I_headmans_student_id_term ON headmans (student_id, term_start);
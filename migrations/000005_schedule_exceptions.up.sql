@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS schedule_exceptions (
+    exception_id   BIGSERIAL PRIMARY KEY,
+    schedule_id    BIGINT NOT NULL REFERENCES schedules (schedule_id) ON DELETE CASCADE,
+    lesson_date    DATE NOT NULL,
+    is_cancelled   BOOLEAN NOT NULL DEFAULT FALSE,
+    teacher_id     BIGINT REFERENCES teachers (teacher_id) ON DELETE SET NULL,
+    classroom_id   BIGINT REFERENCES classrooms (classroom_id) ON DELETE SET NULL,
+    moved_to_date  DATE,
+    start_time     TIME,
+    reason         TEXT,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT U_schedule_exceptions_lesson UNIQUE (schedule_id, lesson_date),
+    CONSTRAINT C_schedule_exceptions_cancel CHECK (
+        NOT is_cancelled OR (teacher_id IS NULL AND classroom_id IS NULL AND moved_to_date IS NULL AND start_time IS NULL)
+    )
+);
+
+CREATE INDEX IF NOT EXISTS I_schedule_exceptions_lesson_date ON schedule_exceptions (lesson_date);
+CREATE INDEX IF NOT EXISTS I_schedule_exceptions_moved_to_date ON schedule_exceptions (moved_to_date) WHERE moved_to_date IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP FUNCTION IF EXISTS is_teaching_day(BIGINT, DATE);
+DROP FUNCTION IF EXISTS group_university_id(TEXT);
+DROP TABLE IF EXISTS academic_calendar;
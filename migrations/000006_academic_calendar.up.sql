@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS academic_calendar (
+    period_id     BIGSERIAL PRIMARY KEY,
+    university_id BIGINT NOT NULL REFERENCES university (university_id) ON DELETE CASCADE,
+    period_kind   TEXT NOT NULL,
+    title         TEXT NOT NULL DEFAULT '',
+    semester      INT,
+    start_date    DATE NOT NULL,
+    end_date      DATE NOT NULL,
+    CONSTRAINT C_academic_calendar_kind CHECK (period_kind IN ('semester', 'holiday', 'exam_session', 'practice')),
+    CONSTRAINT C_academic_calendar_period CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS I_academic_calendar_university_dates ON academic_calendar (university_id, start_date, end_date);
+
+CREATE OR REPLACE FUNCTION group_university_id(p_group_id TEXT) RETURNS BIGINT
+LANGUAGE sql STABLE AS $$
+    SELECT f.university_id
+    FROM groups g
+    INNER JOIN profiles p ON p.profile_id = g.profile_id
+    INNER JOIN specialties s ON s.specialty_code = p.specialty_code
+    INNER JOIN departaments d ON d.departament_id = s.departament_id
+    INNER JOIN faculties f ON f.faculty_id = d.faculty_id
+    WHERE g.group_id = p_group_id
+$$;
+
+-- a day is a teaching day when a semester covers it and no holiday, exam session or practice does,
+-- universities without semesters in the calendar teach every day
+CREATE OR REPLACE FUNCTION is_teaching_day(p_university_id BIGINT, p_date DATE) RETURNS BOOLEAN
+LANGUAGE sql STABLE AS $$
+    SELECT (
+        NOT EXISTS (
+            SELECT 1 FROM academic_calendar c
+            WHERE c.university_id = p_university_id AND c.period_kind = 'semester'
+        )
+        OR EXISTS (
+            SELECT 1 FROM academic_calendar c
+            WHERE c.university_id = p_university_id AND c.period_kind = 'semester'
+            AND p_date BETWEEN c.start_date AND c.end_date
+        )
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM academic_calendar c
+        WHERE c.university_id = p_university_id AND c.period_kind <> 'semester'
+        AND p_date BETWEEN c.start_date AND c.end_date
+    )
+$$;
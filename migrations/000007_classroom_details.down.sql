@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS I_schedules_classroom_slot;
+DROP INDEX IF EXISTS I_classrooms_features;
+
+ALTER TABLE classrooms DROP CONSTRAINT IF EXISTS C_classrooms_capacity;
+ALTER TABLE classrooms DROP COLUMN IF EXISTS features;
+ALTER TABLE classrooms DROP COLUMN IF EXISTS floor;
+ALTER TABLE classrooms DROP COLUMN IF EXISTS building;
+ALTER TABLE classrooms DROP COLUMN IF EXISTS capacity;
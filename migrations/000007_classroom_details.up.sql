@@ -0,0 +1,10 @@
+ALTER TABLE classrooms ADD COLUMN IF NOT EXISTS capacity INT NOT NULL DEFAULT 0;
+ALTER TABLE classrooms ADD COLUMN IF NOT EXISTS building TEXT NOT NULL DEFAULT '';
+ALTER TABLE classrooms ADD COLUMN IF NOT EXISTS floor INT;
+ALTER TABLE classrooms ADD COLUMN IF NOT EXISTS features TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE classrooms DROP CONSTRAINT IF EXISTS C_classrooms_capacity;
+ALTER TABLE classrooms ADD CONSTRAINT C_classrooms_capacity CHECK (capacity >= 0);
+
+CREATE INDEX IF NOT EXISTS I_classrooms_features ON classrooms USING GIN (features);
+CREATE INDEX IF NOT EXISTS I_schedules_classroom_slot ON schedules (classroom_id, semester, week_type, day_of_week, start_time) WHERE is_actual = TRUE;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS I_schedules_slot_id;
+
+ALTER TABLE schedules DROP COLUMN IF EXISTS slot_id;
+
+DROP TABLE IF EXISTS lesson_slots;
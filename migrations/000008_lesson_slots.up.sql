@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS lesson_slots (
+    slot_id       BIGSERIAL PRIMARY KEY,
+    university_id BIGINT NOT NULL REFERENCES university (university_id) ON DELETE CASCADE,
+    slot_number   INT NOT NULL,
+    start_time    TIME NOT NULL,
+    end_time      TIME NOT NULL,
+    break_minutes INT NOT NULL DEFAULT 0,
+    CONSTRAINT U_lesson_slots_number UNIQUE (university_id, slot_number),
+    CONSTRAINT U_lesson_slots_start_time UNIQUE (university_id, start_time),
+    CONSTRAINT C_lesson_slots_time CHECK (end_time > start_time),
+    CONSTRAINT C_lesson_slots_number CHECK (slot_number > 0),
+    CONSTRAINT C_lesson_slots_break CHECK (break_minutes >= 0)
+);
+
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS slot_id BIGINT REFERENCES lesson_slots (slot_id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS I_schedules_slot_id ON schedules (slot_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS I_schedules_subgroup_id;
+
+ALTER TABLE schedules DROP COLUMN IF EXISTS subgroup_id;
+
+DROP TABLE IF EXISTS subgroup_students;
+
+DROP TABLE IF EXISTS subgroups;
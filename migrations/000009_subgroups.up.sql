@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS subgroups (
+    subgroup_id   BIGSERIAL PRIMARY KEY,
+    group_id      TEXT NOT NULL REFERENCES groups (group_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    subgroup_name TEXT NOT NULL,
+    CONSTRAINT U_subgroups_name UNIQUE (group_id, subgroup_name)
+);
+
+CREATE TABLE IF NOT EXISTS subgroup_students (
+    subgroup_id BIGINT NOT NULL REFERENCES subgroups (subgroup_id) ON DELETE CASCADE,
+    student_id  BIGINT NOT NULL REFERENCES students (student_id) ON DELETE CASCADE,
+    PRIMARY KEY (subgroup_id, student_id)
+);
+
+CREATE INDEX IF NOT EXISTS I_subgroup_students_student_id ON subgroup_students (student_id);
+
+-- a schedule without a subgroup is attended by the whole group
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS subgroup_id BIGINT REFERENCES subgroups (subgroup_id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS I_schedules_subgroup_id ON schedules (subgroup_id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS curriculum (
+    curriculum_id      BIGSERIAL PRIMARY KEY,
+    profile_id         BIGINT NOT NULL REFERENCES profiles (profile_id) ON DELETE CASCADE,
+    semester           INT NOT NULL,
+    discipline_id      BIGINT NOT NULL REFERENCES disciplines (discipline_id) ON DELETE CASCADE,
+    discipline_type_id BIGINT NOT NULL REFERENCES disciplineTypes (discipline_type_id) ON DELETE CASCADE,
+    planned_hours      INT NOT NULL,
+    CONSTRAINT U_curriculum_item UNIQUE (profile_id, semester, discipline_id, discipline_type_id),
+    CONSTRAINT C_curriculum_semester CHECK (semester BETWEEN 1 AND 12),
+    CONSTRAINT C_curriculum_planned_hours CHECK (planned_hours > 0)
+);
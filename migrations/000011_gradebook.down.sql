@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS final_results;
+DROP TABLE IF EXISTS control_point_results;
+DROP TABLE IF EXISTS control_points;
+DROP TABLE IF EXISTS marks;
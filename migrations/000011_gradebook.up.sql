@@ -0,0 +1,58 @@
+-- marks of the students for the lessons of a schedule
+CREATE TABLE IF NOT EXISTS marks (
+    mark_id     BIGSERIAL PRIMARY KEY,
+    schedule_id BIGINT NOT NULL REFERENCES schedules (schedule_id) ON DELETE CASCADE,
+    student_id  BIGINT NOT NULL REFERENCES students (student_id) ON DELETE CASCADE,
+    lesson_date DATE NOT NULL,
+    mark        SMALLINT NOT NULL,
+    comment     TEXT,
+    teacher_id  BIGINT REFERENCES teachers (teacher_id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT U_marks_lesson UNIQUE (schedule_id, student_id, lesson_date),
+    CONSTRAINT C_marks_mark CHECK (mark BETWEEN 2 AND 5)
+);
+
+CREATE INDEX IF NOT EXISTS I_marks_student_id ON marks (student_id);
+
+-- control points (tests, colloquiums, course works) of a discipline for a group in a semester
+CREATE TABLE IF NOT EXISTS control_points (
+    control_point_id   BIGSERIAL PRIMARY KEY,
+    group_id           TEXT NOT NULL REFERENCES groups (group_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    discipline_id      BIGINT NOT NULL REFERENCES disciplines (discipline_id) ON DELETE CASCADE,
+    semester           INT NOT NULL,
+    control_point_name TEXT NOT NULL,
+    max_score          INT NOT NULL,
+    due_date           DATE,
+    CONSTRAINT U_control_points_name UNIQUE (group_id, discipline_id, semester, control_point_name),
+    CONSTRAINT C_control_points_semester CHECK (semester BETWEEN 1 AND 12),
+    CONSTRAINT C_control_points_max_score CHECK (max_score > 0)
+);
+
+CREATE TABLE IF NOT EXISTS control_point_results (
+    control_point_id BIGINT NOT NULL REFERENCES control_points (control_point_id) ON DELETE CASCADE,
+    student_id       BIGINT NOT NULL REFERENCES students (student_id) ON DELETE CASCADE,
+    score            INT NOT NULL,
+    teacher_id       BIGINT REFERENCES teachers (teacher_id) ON DELETE SET NULL,
+    graded_at        TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (control_point_id, student_id),
+    CONSTRAINT C_control_point_results_score CHECK (score >= 0)
+);
+
+-- final results of the exam session: a credit (зачёт) or an exam (экзамен) with its grade
+CREATE TABLE IF NOT EXISTS final_results (
+    final_result_id BIGSERIAL PRIMARY KEY,
+    student_id      BIGINT NOT NULL REFERENCES students (student_id) ON DELETE CASCADE,
+    discipline_id   BIGINT NOT NULL REFERENCES disciplines (discipline_id) ON DELETE CASCADE,
+    semester        INT NOT NULL,
+    control_type    TEXT NOT NULL,
+    grade           TEXT NOT NULL,
+    teacher_id      BIGINT REFERENCES teachers (teacher_id) ON DELETE SET NULL,
+    graded_on       DATE NOT NULL DEFAULT CURRENT_DATE,
+    CONSTRAINT U_final_results_discipline UNIQUE (student_id, discipline_id, semester, control_type),
+    CONSTRAINT C_final_results_semester CHECK (semester BETWEEN 1 AND 12),
+    CONSTRAINT C_final_results_control_type CHECK (control_type IN ('зачёт', 'экзамен')),
+    CONSTRAINT C_final_results_grade CHECK (
+        (control_type = 'зачёт' AND grade IN ('зачтено', 'не зачтено'))
+        OR (control_type = 'экзамен' AND grade IN ('отлично', 'хорошо', 'удовлетворительно', 'неудовлетворительно'))
+    )
+);
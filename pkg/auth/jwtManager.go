@@ -8,8 +8,9 @@ import (
 )
 
 type TokenManager interface {
-	NewJWT(userId string, userRole string, ttl time.Duration) (string, error)
+	NewJWT(userId string, userRole string, tokenVersion int64, ttl time.Duration) (string, error)
 	Parse(accessToken string) (string, error)
+	ParseClaims(accessToken string) (*CustomClaims, error)
 }
 
 type Manager struct {
@@ -22,16 +23,18 @@ func NewManager(signingKey string) *Manager {
 
 type CustomClaims struct {
 	jwt.StandardClaims
-	UserRole string `json:"userRole"`
+	UserRole     string `json:"userRole"`
+	TokenVersion int64  `json:"ver"`
 }
 
-func (m *Manager) NewJWT(userId string, userRole string, ttl time.Duration) (string, error) {
+func (m *Manager) NewJWT(userId string, userRole string, tokenVersion int64, ttl time.Duration) (string, error) {
 	claims := CustomClaims{
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: time.Now().Add(ttl).Unix(),
 			Subject:   userId,
 		},
-		UserRole: userRole,
+		UserRole:     userRole,
+		TokenVersion: tokenVersion,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -58,3 +61,20 @@ func (m *Manager) Parse(accessToken string) (string, error) {
 
 	return claims["sub"].(string), nil
 }
+
+// ParseClaims validates the token and returns all custom claims including the token version
+func (m *Manager) ParseClaims(accessToken string) (*CustomClaims, error) {
+	claims := &CustomClaims{}
+	_, err := jwt.ParseWithClaims(accessToken, claims, func(token *jwt.Token) (i interface{}, err error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return []byte(m.signingKey), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
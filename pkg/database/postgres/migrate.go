@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Migration is an up migration file named NNNNNN_name.up.sql
+type Migration struct {
+	Version int64
+	Name    string
+}
+
+// Migrate applies the up migrations of fsys newer than the version stored in schema_migrations,
+// each one in its own transaction. The table has the layout of golang-migrate, so a database
+// migrated by its CLI continues from the same version
+func Migrate(ctx context.Context, db *pgxpool.Pool, fsys fs.FS) ([]Migration, error) {
+	migrations, err := upMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT PRIMARY KEY, dirty BOOLEAN NOT NULL)`
+	if _, err := db.Exec(ctx, query); err != nil {
+		return nil, err
+	}
+
+	// a database without a version has no migrations applied, not even the baseline 0
+	current := int64(-1)
+	var dirty bool
+	query = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+	if err := db.QueryRow(ctx, query).Scan(&current, &dirty); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	if dirty {
+		return nil, fmt.Errorf("database is dirty at version %d, fix it and reset the version manually", current)
+	}
+
+	applied := make([]Migration, 0)
+	for _, migration := range migrations {
+		if migration.Version <= current {
+			continue
+		}
+		if err := apply(ctx, db, fsys, migration); err != nil {
+			return applied, fmt.Errorf("migration %s: %w", migration.Name, err)
+		}
+		applied = append(applied, migration)
+	}
+
+	return applied, nil
+}
+
+func apply(ctx context.Context, db *pgxpool.Pool, fsys fs.FS, migration Migration) error {
+	sql, err := fs.ReadFile(fsys, migration.Name)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, string(sql)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return err
+	}
+	query := `INSERT INTO schema_migrations (version, dirty) VALUES ($1, FALSE)`
+	if _, err := tx.Exec(ctx, query, migration.Version); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// upMigrations lists the up migrations ordered by version
+func upMigrations(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]Migration, 0, len(names))
+	for _, name := range names {
+		prefix, _, ok := strings.Cut(name, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s has no version prefix", name)
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has an invalid version: %w", name, err)
+		}
+		migrations = append(migrations, Migration{Version: version, Name: name})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
@@ -18,6 +18,7 @@ func New(url string) (*pgxpool.Pool, error) {
 	if err != nil {
 		return nil, err
 	}
+	config.ConnConfig.Tracer = NewTracer()
 
 	conn, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/BeRebornBng/OsauAmsApi/pkg/database/postgres"
+
+// Tracer creates a span for every query executed through the pool
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+func NewTracer() *Tracer {
+	return &Tracer{tracer: otel.Tracer(tracerName)}
+}
+
+func (t *Tracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = t.tracer.Start(ctx, statementName(data.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBQueryText(data.SQL),
+			attribute.Int("db.args.count", len(data.Args)),
+		),
+	)
+	return ctx
+}
+
+func (t *Tracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+		return
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+}
+
+// statementName builds a short span name like "SELECT schedules" from the query text
+func statementName(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "query"
+	}
+
+	operation := strings.ToUpper(fields[0])
+	if operation == "WITH" {
+		return "WITH"
+	}
+
+	var keyword string
+	switch operation {
+	case "SELECT", "DELETE":
+		keyword = "FROM"
+	case "INSERT":
+		keyword = "INTO"
+	case "UPDATE":
+		return operation + " " + strings.Trim(fieldAt(fields, 1), "(;")
+	default:
+		return operation
+	}
+
+	for i, field := range fields {
+		if strings.ToUpper(field) == keyword {
+			return operation + " " + strings.Trim(fieldAt(fields, i+1), "(;")
+		}
+	}
+	return operation
+}
+
+func fieldAt(fields []string, i int) string {
+	if i < len(fields) {
+		return fields[i]
+	}
+	return ""
+}
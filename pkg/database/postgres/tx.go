@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Querier is the part of *pgxpool.Pool and pgx.Tx the repositories run their queries on
+type Querier interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+type txKey struct{}
+
+// TxManager runs functions in a transaction passed to the repositories through the context
+type TxManager struct {
+	db *pgxpool.Pool
+}
+
+func NewTxManager(db *pgxpool.Pool) *TxManager {
+	return &TxManager{db: db}
+}
+
+// WithinTransaction runs fn in a transaction, commits it when fn succeeds and rolls it back
+// otherwise. Inside the transaction of another call fn runs in a savepoint of it.
+// A transaction is one connection, so fn must not run queries concurrently
+func (m *TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := Conn(ctx, m.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Conn returns the transaction of the context or the pool when there is none.
+// A transaction begun on the result of Conn nests into the one of the context as a savepoint
+func Conn(ctx context.Context, db *pgxpool.Pool) Querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,85 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sender delivers plain text emails
+type Sender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// SMTPSender sends emails through an SMTP server with PLAIN auth
+type SMTPSender struct {
+	addr   string
+	auth   smtp.Auth
+	from   string
+	domain string
+}
+
+func NewSMTPSender(host string, port int, username, password, from string) *SMTPSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPSender{
+		addr:   host + ":" + strconv.Itoa(port),
+		auth:   auth,
+		from:   from,
+		domain: messageDomain(from, host),
+	}
+}
+
+// messageDomain returns the domain of the sender address for the Message-ID header
+func messageDomain(from, host string) string {
+	if at := strings.LastIndex(from, "@"); at >= 0 {
+		if domain := strings.Trim(from[at+1:], "> "); domain != "" {
+			return domain
+		}
+	}
+	return host
+}
+
+func (s *SMTPSender) Send(ctx context.Context, to, subject, body string) error {
+	msg := strings.Join([]string{
+		"From: " + s.from,
+		"To: " + to,
+		// the subject is in russian and headers must be 7-bit, so it is encoded as RFC 2047 words
+		"Subject: " + mime.QEncoding.Encode("utf-8", subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"Message-ID: <" + uuid.NewString() + "@" + s.domain + ">",
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"Content-Transfer-Encoding: 8bit",
+		"",
+		body,
+	}, "\r\n")
+
+	if err := smtp.SendMail(s.addr, s.auth, s.from, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+	return nil
+}
+
+// LogSender only logs emails and is used when SMTP is not configured
+type LogSender struct {
+	logger *slog.Logger
+}
+
+func NewLogSender(logger *slog.Logger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+func (s *LogSender) Send(ctx context.Context, to, subject, body string) error {
+	s.logger.InfoContext(ctx, "email is not sent, smtp is not configured", slog.String("to", to), slog.String("subject", subject))
+	return nil
+}
@@ -0,0 +1,156 @@
+package myhash
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2Prefix = "$argon2id$"
+
+var ErrInvalidHash = errors.New("invalid argon2id hash")
+
+// Argon2Params are the argon2id cost parameters
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params follows the OWASP recommendation for argon2id
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Argon2Hasher hashes passwords with argon2id and stores the parameters in the
+// hash string. Legacy bcrypt hashes are still verified with the legacy hasher
+type Argon2Hasher struct {
+	params Argon2Params
+	pepper string
+	legacy *Hasher
+}
+
+func NewArgon2Hasher(params Argon2Params, pepper string, legacy *Hasher) *Argon2Hasher {
+	if params.Memory == 0 {
+		params.Memory = DefaultArgon2Params.Memory
+	}
+	if params.Iterations == 0 {
+		params.Iterations = DefaultArgon2Params.Iterations
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = DefaultArgon2Params.Parallelism
+	}
+	if params.SaltLength == 0 {
+		params.SaltLength = DefaultArgon2Params.SaltLength
+	}
+	if params.KeyLength == 0 {
+		params.KeyLength = DefaultArgon2Params.KeyLength
+	}
+	return &Argon2Hasher{
+		params: params,
+		pepper: pepper,
+		legacy: legacy,
+	}
+}
+
+// HashPassword returns $argon2id$v=19$m=...,t=...,p=...$salt$key
+func (h *Argon2Hasher) HashPassword(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey(h.peppered(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2Prefix,
+		argon2.Version,
+		h.params.Memory, h.params.Iterations, h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *Argon2Hasher) ComparePassword(hashPass, password string) bool {
+	if !strings.HasPrefix(hashPass, argon2Prefix) {
+		return h.legacy != nil && h.legacy.ComparePassword(hashPass, password)
+	}
+
+	params, salt, key, err := decodeArgon2(hashPass)
+	if err != nil {
+		return false
+	}
+
+	other := argon2.IDKey(h.peppered(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
+
+// NeedsRehash is true for legacy hashes and argon2id hashes with outdated parameters
+func (h *Argon2Hasher) NeedsRehash(hashPass string) bool {
+	if !strings.HasPrefix(hashPass, argon2Prefix) {
+		return true
+	}
+
+	params, salt, _, err := decodeArgon2(hashPass)
+	if err != nil {
+		return true
+	}
+	return params.Memory != h.params.Memory ||
+		params.Iterations != h.params.Iterations ||
+		params.Parallelism != h.params.Parallelism ||
+		params.KeyLength != h.params.KeyLength ||
+		uint32(len(salt)) != h.params.SaltLength
+}
+
+// peppered mixes the server-side pepper into the password before hashing
+func (h *Argon2Hasher) peppered(password string) []byte {
+	if h.pepper == "" {
+		return []byte(password)
+	}
+	mac := hmac.New(sha256.New, []byte(h.pepper))
+	mac.Write([]byte(password))
+	return mac.Sum(nil)
+}
+
+func decodeArgon2(hashPass string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	parts := strings.Split(hashPass, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrInvalidHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
@@ -10,13 +10,24 @@ type PasswordHasher interface {
 	ComparePassword(hashPass, password string) bool
 }
 
+// Rehasher reports whether a stored hash should be replaced after a successful login
+type Rehasher interface {
+	NeedsRehash(hashPass string) bool
+}
+
+// Hasher is the legacy bcrypt hasher with a static salt appended to the password
 type Hasher struct {
 	salt string
+	cost int
 }
 
-func NewHasher(salt string) *Hasher {
+func NewHasher(salt string, cost int) *Hasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
 	return &Hasher{
 		salt: salt,
+		cost: cost,
 	}
 }
 
@@ -24,7 +35,7 @@ func NewHasher(salt string) *Hasher {
 func (h *Hasher) HashPassword(password string) (string, error) {
 
 	passWithSalt := password + h.salt
-	b, err := bcrypt.GenerateFromPassword([]byte(passWithSalt), bcrypt.MinCost)
+	b, err := bcrypt.GenerateFromPassword([]byte(passWithSalt), h.cost)
 	if err != nil {
 		return "", err
 	}
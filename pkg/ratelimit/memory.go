@@ -0,0 +1,141 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	cleanupInterval = time.Minute
+	bucketIdleTTL   = 10 * time.Minute
+)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type attemptEntry struct {
+	attempts Attempts
+	expires  time.Time
+}
+
+// MemoryStore is an in-process implementation of Store and AttemptStore
+type MemoryStore struct {
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	attempts    map[string]attemptEntry
+	lastCleanup time.Time
+	now         func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:  make(map[string]*bucket),
+		attempts: make(map[string]attemptEntry),
+		now:      time.Now,
+	}
+}
+
+func (s *MemoryStore) Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.cleanup(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		s.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.last).Seconds()
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+		return false, wait, nil
+	}
+	b.tokens--
+	return true, 0, nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) (Attempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.attempts[key]
+	if !ok || s.now().After(entry.expires) {
+		return Attempts{}, nil
+	}
+	return entry.attempts, nil
+}
+
+func (s *MemoryStore) Incr(ctx context.Context, key string, ttl time.Duration) (Attempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := s.liveAttempts(key, ttl)
+	entry.attempts.Failures++
+	s.attempts[key] = entry
+	return entry.attempts, nil
+}
+
+func (s *MemoryStore) Lock(ctx context.Context, key string, until time.Time, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := s.liveAttempts(key, ttl)
+	if until.After(entry.attempts.LockedUntil) {
+		entry.attempts.LockedUntil = until
+	}
+	if allowed := entry.attempts.Failures + 1; allowed > entry.attempts.Allowed {
+		entry.attempts.Allowed = allowed
+	}
+	s.attempts[key] = entry
+	return nil
+}
+
+// liveAttempts returns the unexpired entry of the key, or an empty one, kept for at least ttl
+func (s *MemoryStore) liveAttempts(key string, ttl time.Duration) attemptEntry {
+	now := s.now()
+	entry, ok := s.attempts[key]
+	if !ok || now.After(entry.expires) {
+		entry = attemptEntry{}
+	}
+	if expires := now.Add(ttl); expires.After(entry.expires) {
+		entry.expires = expires
+	}
+	return entry
+}
+
+func (s *MemoryStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
+
+// cleanup drops full buckets and expired attempts so the maps do not grow forever
+func (s *MemoryStore) cleanup(now time.Time) {
+	if now.Sub(s.lastCleanup) < cleanupInterval {
+		return
+	}
+	s.lastCleanup = now
+
+	for key, b := range s.buckets {
+		if now.Sub(b.last) > bucketIdleTTL {
+			delete(s.buckets, key)
+		}
+	}
+	for key, entry := range s.attempts {
+		if now.After(entry.expires) {
+			delete(s.attempts, key)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit describes a token bucket: Rate tokens are added per second up to Burst
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Enabled reports whether the limit should be enforced
+func (l Limit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+// Store keeps token buckets by key. The in-memory store is used by default,
+// a shared backend can implement the same interface
+type Store interface {
+	Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error)
+}
+
+// Attempts holds login attempts for a key. Allowed is the count of attempts let through
+// after the lockouts so far, every lock lets one attempt more than the counted ones
+type Attempts struct {
+	Failures    int
+	Allowed     int
+	LockedUntil time.Time
+}
+
+// AttemptStore keeps login attempts by key. Incr and Lock change the attempts
+// atomically, so attempts of parallel sign-ins are all counted
+type AttemptStore interface {
+	Get(ctx context.Context, key string) (Attempts, error)
+	// Incr counts an attempt and keeps the key for at least ttl, it returns the updated attempts
+	Incr(ctx context.Context, key string, ttl time.Duration) (Attempts, error)
+	// Lock locks the key until the time unless it is locked longer, allows one attempt
+	// more than the counted ones after the lock and keeps the key for at least ttl
+	Lock(ctx context.Context, key string, until time.Time, ttl time.Duration) error
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,46 @@
+package requestid
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Header is the HTTP header used to receive and return the request ID
+const Header = "X-Request-ID"
+
+type ctxKey struct{}
+
+// WithID returns a copy of ctx carrying the request ID
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, if any
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(ctxKey{}).(string)
+	return id, ok && id != ""
+}
+
+// LogHandler adds request_id to every record logged with a request context
+type LogHandler struct {
+	slog.Handler
+}
+
+func NewLogHandler(next slog.Handler) *LogHandler {
+	return &LogHandler{Handler: next}
+}
+
+func (h *LogHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id, ok := FromContext(ctx); ok {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &LogHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,238 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported file format, expected csv or xlsx")
+
+// Table is a parsed sheet: the header row and the non-empty data rows
+type Table struct {
+	Header []string
+	Rows   []Row
+}
+
+// Row keeps the line number of the file to point users at the broken row
+type Row struct {
+	Line  int
+	Cells []string
+}
+
+// FormatFromFilename detects the format by the file extension
+func FormatFromFilename(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// Read parses the first sheet of an xlsx file or a csv file separated by "," or ";"
+func Read(r io.Reader, format string) (*Table, error) {
+	var records []Row
+	var err error
+
+	switch format {
+	case FormatCSV:
+		records, err = readCSV(r)
+	case FormatXLSX:
+		records, err = readXLSX(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("the file is empty")
+	}
+
+	table := &Table{Header: normalizeRow(records[0].Cells)}
+	for _, record := range records[1:] {
+		cells := normalizeRow(record.Cells)
+		if isEmptyRow(cells) {
+			continue
+		}
+		table.Rows = append(table.Rows, Row{Line: record.Line, Cells: cells})
+	}
+	return table, nil
+}
+
+// ContentType returns the MIME type of the format
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Write writes the header and the rows as a csv file or as the first sheet of an xlsx file
+func Write(w io.Writer, format string, header []string, rows [][]string) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, header, rows)
+	case FormatXLSX:
+		return writeXLSX(w, header, rows)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
+
+// Columns maps every header alias to its column index. Aliases are compared
+// case-insensitively, unknown headers are ignored
+func (t *Table) Columns(aliases map[string][]string) (map[string]int, error) {
+	columns := make(map[string]int, len(aliases))
+	for i, title := range t.Header {
+		title = strings.ToLower(title)
+		for column, names := range aliases {
+			for _, name := range names {
+				if title == strings.ToLower(name) {
+					columns[column] = i
+				}
+			}
+		}
+	}
+
+	for column, names := range aliases {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("column %q is missing", names[0])
+		}
+	}
+	return columns, nil
+}
+
+// Cell returns the trimmed value of a column or an empty string
+func (r Row) Cell(index int) string {
+	if index < 0 || index >= len(r.Cells) {
+		return ""
+	}
+	return r.Cells[index]
+}
+
+func readCSV(r io.Reader) ([]Row, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimPrefix(string(data), "\uFEFF")
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.FieldsPerRecord = -1
+	reader.Comma = detectDelimiter(text)
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, Row{Line: line, Cells: record})
+	}
+	return rows, nil
+}
+
+func readXLSX(r io.Reader) ([]Row, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("the workbook has no sheets")
+	}
+	records, err := file.GetRows(sheets[0])
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]Row, 0, len(records))
+	for i, record := range records {
+		rows = append(rows, Row{Line: i + 1, Cells: record})
+	}
+	return rows, nil
+}
+
+func writeCSV(w io.Writer, header []string, rows [][]string) error {
+	// BOM lets Excel open the cyrillic names correctly
+	if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func writeXLSX(w io.Writer, header []string, rows [][]string) error {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	sheet := file.GetSheetName(0)
+	records := append([][]string{header}, rows...)
+	for i, record := range records {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return err
+		}
+		values := make([]interface{}, len(record))
+		for j, value := range record {
+			values[j] = value
+		}
+		if err := file.SetSheetRow(sheet, cell, &values); err != nil {
+			return err
+		}
+	}
+	return file.Write(w)
+}
+
+// detectDelimiter picks ";" for files exported by Excel with a russian locale
+func detectDelimiter(text string) rune {
+	firstLine, _, _ := strings.Cut(text, "\n")
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		return ';'
+	}
+	return ','
+}
+
+func normalizeRow(row []string) []string {
+	normalized := make([]string, len(row))
+	for i, cell := range row {
+		normalized[i] = strings.TrimSpace(cell)
+	}
+	return normalized
+}
+
+func isEmptyRow(row []string) bool {
+	for _, cell := range row {
+		if cell != "" {
+			return false
+		}
+	}
+	return true
+}